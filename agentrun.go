@@ -0,0 +1,260 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ─── Agent Runs ──────────────────────────────────────────────────────────────
+//
+// With primary_mode "background", c starts the coding agent without handing
+// it the terminal: its output goes to a log in the data directory
+// (runs/<plan>-<hash>.log) and planc stays usable. L shows the selected
+// plan's log and follows new output while open. A background agent must not
+// need a terminal, e.g. "primary": ["claude", "-p"]. Runs outlive planc; a
+// plan's log is replaced by its next run.
+
+const (
+	agentTailBytes      = 64 << 10 // how much of the log L reads
+	agentOutputInterval = time.Second
+)
+
+// agentRun is a background agent started this session.
+type agentRun struct {
+	started time.Time
+	ended   time.Time // zero while running
+	err     error
+}
+
+// agentLogPath returns where a plan's background agent output is written.
+// The hash keeps plans with the same file name in different projects apart.
+func agentLogPath(planPath string) (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(planPath))
+	name := strings.TrimSuffix(filepath.Base(planPath), ".md") + "-" + hex.EncodeToString(sum[:4]) + ".log"
+	return filepath.Join(dir, "runs", name), nil
+}
+
+// startAgentRun launches args detached, writing its output to the plan's log.
+func startAgentRun(args []string, planPath string) tea.Cmd {
+	return func() tea.Msg {
+		logPath, err := agentLogPath(planPath)
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(logPath), 0o755)
+		}
+		if err != nil {
+			return errMsg{fmt.Errorf("agent: %w", err)}
+		}
+		f, err := os.Create(logPath)
+		if err != nil {
+			return errMsg{fmt.Errorf("agent: %w", err)}
+		}
+		c := shellCommand(args...)
+		c.Stdout, c.Stderr = f, f
+		detach(c)
+		if err := c.Start(); err != nil {
+			f.Close()
+			return errMsg{fmt.Errorf("agent start: %w", err)}
+		}
+		wait := func() error {
+			defer f.Close()
+			return c.Wait()
+		}
+		return agentStartedMsg{path: planPath, run: agentRun{started: time.Now()}, wait: wait}
+	}
+}
+
+// waitAgentRun reports when a started agent exits.
+func waitAgentRun(path string, wait func() error) tea.Cmd {
+	return func() tea.Msg {
+		return agentExitedMsg{path: path, err: wait()}
+	}
+}
+
+// readTail returns the last lines of a log that fit in limit bytes, with
+// terminal escapes removed and carriage-return progress lines collapsed to
+// their final state.
+func readTail(path string, limit int64) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-limit, 0)
+	data := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(data, offset); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	text := string(data)
+	if offset > 0 {
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[i+1:] // drop the partial first line
+		}
+	}
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		if j := strings.LastIndexByte(l, '\r'); j >= 0 {
+			l = l[j+1:]
+		}
+		lines[i] = strings.ReplaceAll(ansi.Strip(l), "\t", "    ")
+	}
+	return lines, nil
+}
+
+// loadAgentOutput reads a plan's log for the L modal.
+func loadAgentOutput(path string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		logPath, err := agentLogPath(path)
+		if err != nil {
+			return agentOutputMsg{path: path, seq: seq, err: err}
+		}
+		var modified time.Time
+		if info, err := os.Stat(logPath); err == nil {
+			modified = info.ModTime()
+		}
+		lines, err := readTail(logPath, agentTailBytes)
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return agentOutputMsg{path: path, seq: seq, lines: lines, modified: modified, err: err}
+	}
+}
+
+func agentOutputTick(seq int) tea.Cmd {
+	return tea.Tick(agentOutputInterval, func(time.Time) tea.Msg {
+		return agentOutputTickMsg{seq: seq}
+	})
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+type agentOutputState struct {
+	active   bool
+	loading  bool
+	seq      int // ties refresh ticks to one opening of the modal
+	path     string
+	title    string
+	lines    []string
+	modified time.Time // log's modification time, zero if there is none
+	err      error
+	scroll   int // lines above the bottom; 0 follows new output
+}
+
+func (m *model) openAgentOutput(p plan) tea.Cmd {
+	m.agentOutput = agentOutputState{active: true, loading: true, seq: m.agentOutput.seq + 1, path: p.path(), title: p.title}
+	return loadAgentOutput(p.path(), m.agentOutput.seq)
+}
+
+// agentOutputHeight is how many log lines the modal shows.
+func (m model) agentOutputHeight() int {
+	return max(m.height-12, 3)
+}
+
+func (m model) handleAgentOutputKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	maxScroll := max(len(m.agentOutput.lines)-m.agentOutputHeight(), 0)
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.AgentOutput), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.agentOutput.active = false
+	case msg.String() == "k" || msg.String() == "up":
+		m.agentOutput.scroll = min(m.agentOutput.scroll+1, maxScroll)
+	case msg.String() == "j" || msg.String() == "down":
+		m.agentOutput.scroll = max(m.agentOutput.scroll-1, 0)
+	case key.Matches(msg, m.keys.ScrollUp):
+		m.agentOutput.scroll = min(m.agentOutput.scroll+m.agentOutputHeight()/2, maxScroll)
+	case key.Matches(msg, m.keys.ScrollDown):
+		m.agentOutput.scroll = max(m.agentOutput.scroll-m.agentOutputHeight()/2, 0)
+	case msg.String() == "g":
+		m.agentOutput.scroll = maxScroll
+	case msg.String() == "G":
+		m.agentOutput.scroll = 0
+	}
+	return m, nil, true
+}
+
+// agentRunStatus describes a plan's run for the modal header.
+func agentRunStatus(run agentRun, ok bool, modified time.Time, now time.Time) string {
+	switch {
+	case ok && run.ended.IsZero():
+		return trf("running for %s", now.Sub(run.started).Truncate(time.Second))
+	case ok && run.err != nil:
+		return trf("failed at %s: %v", run.ended.Format("15:04"), run.err)
+	case ok:
+		return trf("finished at %s", run.ended.Format("15:04"))
+	case !modified.IsZero():
+		return trf("log from %s", modified.Format("Jan 2 15:04"))
+	}
+	return ""
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderAgentOutputModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	s := m.agentOutput
+
+	modalW := min(m.width-4, 120)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(trf("Agent output: %s", truncateForWidth(s.title, contentW-14))) + "\n")
+	run, ok := m.agentRuns[s.path]
+	if status := agentRunStatus(run, ok, s.modified, time.Now()); status != "" {
+		b.WriteString(dimStyle.Render(status) + "\n")
+	}
+	b.WriteString("\n")
+
+	height := m.agentOutputHeight()
+	switch {
+	case s.loading:
+		b.WriteString(dimStyle.Render(tr("Loading...")) + "\n")
+	case s.err != nil:
+		b.WriteString(dimStyle.Render(trf("Error: %v", s.err)) + "\n")
+	case len(s.lines) == 0 && s.modified.IsZero():
+		b.WriteString(dimStyle.Render(tr("No agent output for this plan. With primary_mode set to background, c runs the agent here.")) + "\n")
+	case len(s.lines) == 0:
+		b.WriteString(dimStyle.Render(tr("No output yet.")) + "\n")
+	default:
+		end := len(s.lines) - min(s.scroll, len(s.lines))
+		start := max(end-height, 0)
+		for _, l := range s.lines[start:end] {
+			b.WriteString(truncateForWidth(l, contentW) + "\n")
+		}
+	}
+
+	hint := tr("j/k scroll · g/G top/bottom · esc close")
+	if s.scroll > 0 {
+		hint = trf("%d lines below · ", s.scroll) + hint
+	}
+	b.WriteString("\n" + dimStyle.Render(hint))
+
+	overlay := helpBoxStyle.Width(modalW - 2).Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestReadTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	log := "first line that gets cut\nsecond\n\x1b[32mgreen\x1b[0m\r\nprogress 10%\rprogress 100%\n\tindented\n"
+	os.WriteFile(path, []byte(log), 0o644)
+
+	lines, err := readTail(path, 1<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"first line that gets cut", "second", "green", "progress 100%", "    indented"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("lines = %q", lines)
+	}
+
+	lines, _ = readTail(path, int64(len(log)-10))
+	if lines[0] != "second" {
+		t.Errorf("a partial first line should be dropped: %q", lines)
+	}
+}
+
+func TestAgentLogPathSeparatesProjects(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	a, _ := agentLogPath("/code/api/plans/auth.md")
+	b, _ := agentLogPath("/code/web/plans/auth.md")
+	if a == b || !strings.HasPrefix(filepath.Base(a), "auth-") || filepath.Base(filepath.Dir(a)) != "runs" {
+		t.Errorf("log paths = %s, %s", a, b)
+	}
+}
+
+func TestBackgroundPrimaryWritesLog(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	commandShell.Store("sh")
+	t.Cleanup(func() { commandShell.Store("") })
+
+	dir := t.TempDir()
+	ps := testPlans()
+	for i := range ps {
+		ps[i].dir = dir
+	}
+	cfg := newDefaultConfig()
+	cfg.PrimaryMode = "background"
+	cfg.Primary = []string{"echo", "working on"}
+	m := newModel(ps, dir, cfg, nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = m2.(model)
+	target := m.list.SelectedItem().(plan)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	started, ok := cmd().(agentStartedMsg)
+	if !ok {
+		t.Fatal("c should start the agent in the background")
+	}
+	m2, cmd = m.Update(started)
+	m = m2.(model)
+	if _, ok := m.agentRuns[target.path()]; !ok {
+		t.Fatal("the run should be tracked")
+	}
+	// The batch holds the wait command and the notification.
+	for _, c := range cmd().(tea.BatchMsg) {
+		if exited, ok := c().(agentExitedMsg); ok {
+			if exited.err != nil {
+				t.Fatalf("agent failed: %v", exited.err)
+			}
+			m2, _ = m.Update(exited)
+			m = m2.(model)
+		}
+	}
+	if m.agentRuns[target.path()].ended.IsZero() {
+		t.Fatal("the run should be marked finished")
+	}
+
+	m2, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = m2.(model)
+	if !m.agentOutput.active {
+		t.Fatal("L should open the output modal")
+	}
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	view := m.View()
+	if !strings.Contains(view, "working on") || !strings.Contains(view, "finished at") {
+		t.Errorf("modal should show the agent's output:\n%s", view)
+	}
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = m2.(model)
+	if m.agentOutput.active {
+		t.Error("esc should close the modal")
+	}
+	if _, cmd := m.Update(agentOutputTickMsg{seq: m.agentOutput.seq}); cmd != nil {
+		t.Error("ticks should stop once the modal is closed")
+	}
+}
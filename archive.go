@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── planc archive ───────────────────────────────────────────────────────────
+//
+// Done plans pile up. `planc archive` moves done plans nobody has touched in
+// archive_after_days (default 30) into the archive/ subdirectory next to
+// them, which scans skip; --days overrides the setting and --dry-run lists
+// the plans without moving them. Z does the same from the TUI after a y/n
+// confirmation. `planc --archived` browses the archives in a session of
+// their own.
+
+const defaultArchiveAfterDays = 30
+
+// archiveAfter returns how long a done plan may go unmodified before it's
+// archived.
+func archiveAfter(cfg config) time.Duration {
+	days := cfg.ArchiveAfterDays
+	if days <= 0 {
+		days = defaultArchiveAfterDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// archiveCandidates returns the done plans unmodified for longer than after.
+func archiveCandidates(plans []plan, after time.Duration, now time.Time) []plan {
+	var out []plan
+	for _, p := range plans {
+		if isDone(p.status) && now.Sub(p.modified) > after {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// archivePlanFiles archives each plan in turn, stopping at the first error.
+// It returns an event for every plan it moved.
+func archivePlanFiles(plans []plan) ([]planEvent, error) {
+	var events []planEvent
+	for _, p := range plans {
+		path, err := archivePlanFile(p)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, planEvent{kind: eventPlanArchived, path: path, from: p.path(), to: path})
+	}
+	return events, nil
+}
+
+// archiveDonePlans archives plans and rescans.
+func archiveDonePlans(agentDir, projectGlob string, plans []plan) tea.Cmd {
+	return func() tea.Msg {
+		events, err := archivePlanFiles(plans)
+		if err != nil && len(events) == 0 {
+			return errMsg{fmt.Errorf("archive: %w", err)}
+		}
+		all, scanErr := scanAllPlans(agentDir, projectGlob)
+		if scanErr != nil {
+			return errMsg{scanErr}
+		}
+		if err != nil {
+			logger.Error("archive failed", "err", err)
+		}
+		return reloadMsg{plans: all, events: events}
+	}
+}
+
+func runArchive(cfg config, args []string) int {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	days := fs.Int("days", 0, fmt.Sprintf("archive done plans untouched for this many days (default: archive_after_days, or %d)", defaultArchiveAfterDays))
+	dryRun := fs.Bool("dry-run", false, "list the plans without moving them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc archive [--days N] [--dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	if *days < 0 {
+		return cliError("archive: --days must not be negative")
+	}
+	if *days > 0 {
+		cfg.ArchiveAfterDays = *days
+	}
+
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("archive: %v", err)
+	}
+	sortPlans(all)
+	candidates := archiveCandidates(all, archiveAfter(cfg), time.Now())
+	if *dryRun {
+		for _, p := range candidates {
+			fmt.Println(contractHome(p.path()))
+		}
+		fmt.Fprintf(os.Stderr, "%d plans to archive\n", len(candidates))
+		return 0
+	}
+	if len(candidates) == 0 {
+		fmt.Fprintln(os.Stderr, "no plans to archive")
+		return 0
+	}
+	events, err := archivePlanFiles(candidates)
+	auditPath, perr := auditLogPath()
+	for _, ev := range events {
+		ev.at = time.Now()
+		fmt.Printf("%s → %s\n", contractHome(ev.from), contractHome(ev.to))
+		if perr == nil {
+			auditLog{auditPath}.record(ev)
+		}
+	}
+	if err != nil {
+		return cliError("archive: %v", err)
+	}
+	return 0
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// openArchivePrompt asks before archiving the done plans due for it. It
+// reports false when there are none.
+func (m *model) openArchivePrompt() bool {
+	after := archiveAfter(m.cfg)
+	plans := archiveCandidates(m.allPlans, after, time.Now())
+	if len(plans) == 0 {
+		return false
+	}
+	m.archivePlans = plans
+	m.notification = trf("Archive %d done plans untouched for %d days? (y/n)", len(plans), int(after.Hours()/24))
+	return true
+}
+
+func (m model) handleArchiveConfirm(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	plans := m.archivePlans
+	m.archivePlans = nil
+	m.notification = ""
+	switch {
+	case msg.String() == "y":
+		return m, tea.Batch(
+			archiveDonePlans(m.dir, m.cfg.ProjectPlanGlob, plans),
+			m.setNotification(trf("Archived %d plans", len(plans)), statusTimeout),
+		), true
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	}
+	return m, nil, true
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestArchiveCandidates(t *testing.T) {
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	plans := []plan{
+		{file: "old-done.md", status: "done", modified: now.AddDate(0, 0, -40)},
+		{file: "new-done.md", status: "done", modified: now.AddDate(0, 0, -5)},
+		{file: "old-active.md", status: "active", modified: now.AddDate(0, 0, -40)},
+	}
+	got := archiveCandidates(plans, archiveAfter(config{}), now)
+	if len(got) != 1 || got[0].file != "old-done.md" {
+		t.Errorf("candidates = %v", got)
+	}
+	if got := archiveCandidates(plans, archiveAfter(config{ArchiveAfterDays: 3}), now); len(got) != 2 {
+		t.Errorf("with 3 days, %d candidates", len(got))
+	}
+}
+
+// writeAgedPlan writes a plan and backdates it by days.
+func writeAgedPlan(t *testing.T, dir, name, status string, days int) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	writeFile(t, path, "---\nstatus: "+status+"\n---\n# "+name+"\n")
+	old := time.Now().AddDate(0, 0, -days)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunArchive(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	writeAgedPlan(t, cfg.PlansDir, "old.md", "done", 40)
+	writeAgedPlan(t, cfg.PlansDir, "recent.md", "done", 10)
+	writeAgedPlan(t, cfg.PlansDir, "stale.md", "active", 40)
+
+	if code := runArchive(cfg, []string{"--dry-run"}); code != 0 {
+		t.Fatalf("dry run exit code %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.PlansDir, "old.md")); err != nil {
+		t.Fatal("--dry-run moved a plan")
+	}
+
+	if code := runArchive(cfg, nil); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	for name, archived := range map[string]bool{"old.md": true, "recent.md": false, "stale.md": false} {
+		_, err := os.Stat(filepath.Join(cfg.PlansDir, archiveDirName, name))
+		if (err == nil) != archived {
+			t.Errorf("%s archived = %v, want %v", name, err == nil, archived)
+		}
+	}
+	path, _ := auditLogPath()
+	entries, err := readAuditLog(path)
+	if err != nil || len(entries) != 1 || entries[0].Event != string(eventPlanArchived) {
+		t.Errorf("audit log = %+v, %v", entries, err)
+	}
+	plans, _ := scanAllPlans(cfg.PlansDir, "")
+	if len(plans) != 2 {
+		t.Errorf("scan found %d plans, want the 2 unarchived", len(plans))
+	}
+
+	if code := runArchive(cfg, []string{"--days", "5"}); code != 0 {
+		t.Fatalf("--days exit code %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.PlansDir, archiveDirName, "recent.md")); err != nil {
+		t.Error("--days 5 didn't archive recent.md")
+	}
+}
+
+func TestArchiveKey(t *testing.T) {
+	dir := t.TempDir()
+	writeAgedPlan(t, dir, "old.md", "done", 40)
+	writeAgedPlan(t, dir, "open.md", "active", 40)
+	plans, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	zKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}}
+
+	m2, _ := m.Update(zKey)
+	m = m2.(model)
+	if len(m.archivePlans) != 1 || !strings.Contains(m.notification, "Archive 1 done plans") {
+		t.Fatalf("prompt = %q, plans %v", m.notification, m.archivePlans)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = m2.(model)
+	if m.archivePlans != nil || m.notification != "" {
+		t.Fatal("n should cancel")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.md")); err != nil {
+		t.Fatal("cancel moved the plan")
+	}
+
+	msg := archiveDonePlans(dir, "", archiveCandidates(plans, archiveAfter(m.cfg), time.Now()))()
+	reload, ok := msg.(reloadMsg)
+	if !ok || len(reload.plans) != 1 || len(reload.events) != 1 || reload.events[0].kind != eventPlanArchived {
+		t.Errorf("archiveDonePlans = %+v", msg)
+	}
+}
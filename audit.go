@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Audit Log ───────────────────────────────────────────────────────────────
+//
+// Every plan event (see events.go) is appended as a JSON line to audit.jsonl
+// in the data directory, so "when did this plan get marked done?" has an
+// answer after the session ends. `planc log` prints the log and A opens it in
+// the TUI. Events from demo mode are not recorded.
+
+const auditFileName = "audit.jsonl"
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	File  string    `json:"file"`
+	Field string    `json:"field,omitempty"`
+	From  string    `json:"from,omitempty"`
+	To    string    `json:"to,omitempty"`
+}
+
+// auditFields maps event kinds to the frontmatter field they change.
+var auditFields = map[eventKind]string{
+	eventStatusChanged: "status",
+	eventLabelsChanged: "labels",
+	eventTitleChanged:  "title",
+}
+
+func newAuditEntry(ev planEvent) auditEntry {
+	field := ev.field
+	if field == "" {
+		field = auditFields[ev.kind]
+	}
+	return auditEntry{Time: ev.at, Event: string(ev.kind), File: ev.path, Field: field, From: ev.from, To: ev.to}
+}
+
+// describe renders the change, e.g. "status: reviewed → done" or
+// "comment added: needs a rollback step".
+func (e auditEntry) describe() string {
+	orNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+	if e.Field != "" {
+		return fmt.Sprintf("%s: %s → %s", e.Field, orNone(e.From), orNone(e.To))
+	}
+	what := strings.TrimPrefix(strings.ReplaceAll(e.Event, "_", " "), "plan ")
+	switch {
+	case e.From != "" && e.To != "":
+		return fmt.Sprintf("%s: %s → %s", what, e.From, e.To)
+	case e.To != "":
+		return what + ": " + e.To
+	case e.From != "":
+		return what + ": " + e.From
+	}
+	return what
+}
+
+// dataDir returns planc's data directory: $XDG_DATA_HOME/planc, else
+// ~/.local/share/planc on Unix and the config directory on macOS and Windows.
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "planc"), nil
+	}
+	if runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "share", "planc"), nil
+		}
+	}
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(path), nil
+}
+
+func auditLogPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, auditFileName), nil
+}
+
+// auditLog appends events to the audit file. record runs on the event bus's
+// dispatcher goroutine, which delivers one event at a time.
+type auditLog struct {
+	path string
+}
+
+// record appends ev to the log. Demo events are skipped; write failures are
+// logged rather than interrupting the user.
+func (a auditLog) record(ev planEvent) {
+	if ev.demo {
+		return
+	}
+	line, err := json.Marshal(newAuditEntry(ev))
+	if err == nil {
+		err = appendLine(a.path, line)
+	}
+	if err != nil {
+		logger.Error("audit write failed", "path", a.path, "err", err)
+	}
+}
+
+func appendLine(path string, line []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readAuditLog returns the entries in the log at path, oldest first. A
+// missing log is empty; malformed lines are skipped.
+func readAuditLog(path string) ([]auditEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []auditEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e auditEntry
+		if json.Unmarshal(sc.Bytes(), &e) == nil && e.File != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries, sc.Err()
+}
+
+// filterAudit returns the entries whose file (or, for renames and moves, old
+// path) contains query, case-insensitively.
+func filterAudit(entries []auditEntry, query string) []auditEntry {
+	query = strings.ToLower(query)
+	var out []auditEntry
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.File), query) ||
+			(e.Event == string(eventPlanRenamed) || e.Event == string(eventPlanMoved)) && strings.Contains(strings.ToLower(e.From), query) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// runLog implements `planc log [plan]`.
+func runLog(_ config, args []string) int {
+	if len(args) > 1 {
+		return cliError("usage: planc log [plan]")
+	}
+	path, err := auditLogPath()
+	if err != nil {
+		return cliError("%v", err)
+	}
+	entries, err := readAuditLog(path)
+	if err != nil {
+		return cliError("%v", err)
+	}
+	if len(args) == 1 {
+		entries = filterAudit(entries, args[0])
+	}
+	for _, e := range entries {
+		fmt.Printf("%s  %s  %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), filepath.Base(e.File), e.describe())
+	}
+	return 0
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// activityLimit caps how many entries the activity view loads.
+const activityLimit = 500
+
+type activityState struct {
+	active  bool
+	loading bool
+	entries []auditEntry // newest first
+	err     error
+	cursor  int
+}
+
+func loadActivity(path string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := readAuditLog(path)
+		if len(entries) > activityLimit {
+			entries = entries[len(entries)-activityLimit:]
+		}
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+		return activityLoadedMsg{entries: entries, err: err}
+	}
+}
+
+func (m *model) openActivity() tea.Cmd {
+	m.activity = activityState{active: true}
+	if m.demo.active || m.auditPath == "" {
+		return nil
+	}
+	m.activity.loading = true
+	return loadActivity(m.auditPath)
+}
+
+func (m model) handleActivityKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Activity), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.activity.active = false
+	case msg.Type == tea.KeyEnter:
+		if m.activity.cursor < len(m.activity.entries) {
+			m.activity.active = false
+			m.revealPlan(m.activity.entries[m.activity.cursor].File)
+			return m, m.renderWindow(), true
+		}
+	case msg.String() == "j" || msg.String() == "down":
+		if m.activity.cursor < len(m.activity.entries)-1 {
+			m.activity.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.activity.cursor > 0 {
+			m.activity.cursor--
+		}
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderActivityModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := m.width - 4
+	if modalW > 100 {
+		modalW = 100
+	}
+	contentW := modalW - 8 // helpBoxStyle borders + padding
+	if contentW < 20 {
+		contentW = 20
+	}
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Activity")) + "\n")
+
+	entries := m.activity.entries
+	switch {
+	case m.demo.active:
+		b.WriteString(dimStyle.Render("Activity isn't recorded in demo mode.") + "\n")
+	case m.auditPath == "":
+		b.WriteString(dimStyle.Render("No data directory; activity isn't recorded.") + "\n")
+	case m.activity.loading:
+		b.WriteString(dimStyle.Render("Loading...") + "\n")
+	case m.activity.err != nil:
+		b.WriteString(dimStyle.Render("Error: "+m.activity.err.Error()) + "\n")
+	case len(entries) == 0:
+		b.WriteString(dimStyle.Render("No changes recorded yet.") + "\n")
+	default:
+		maxVisible := m.height - 12
+		if maxVisible < 3 {
+			maxVisible = 3
+		}
+		scrollOff := 0
+		if len(entries) > maxVisible {
+			scrollOff = min(max(m.activity.cursor-maxVisible/2, 0), len(entries)-maxVisible)
+		}
+		end := min(scrollOff+maxVisible, len(entries))
+		if scrollOff > 0 {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    ↑ %d more", scrollOff)) + "\n")
+		}
+		for i, e := range entries[scrollOff:end] {
+			when := e.Time.Local().Format("Jan 02 15:04")
+			textW := contentW - 2 - len(when) - 2
+			text := truncateForWidth(filepath.Base(e.File)+"  "+e.describe(), textW)
+			if scrollOff+i == m.activity.cursor {
+				b.WriteString(accentStyle.Render("> "+text) + "  " + dimStyle.Render(when) + "\n")
+			} else {
+				b.WriteString("  " + text + "  " + dimStyle.Render(when) + "\n")
+			}
+		}
+		if end < len(entries) {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    ↓ %d more", len(entries)-end)) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + dimStyle.Render(tr("j/k navigate · enter show plan · esc close")))
+
+	overlay := helpBoxStyle.Width(modalW - 2).Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAuditLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", auditFileName)
+	a := auditLog{path}
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	a.record(planEvent{kind: eventStatusChanged, path: "/p/a.md", from: "reviewed", to: "done", at: at})
+	a.record(planEvent{kind: eventStatusChanged, path: "/p/demo.md", to: "done", at: at, demo: true})
+	a.record(planEvent{kind: eventPlanRenamed, path: "/p/b.md", from: "/p/a.md", to: "/p/b.md", at: at})
+	a.record(planEvent{kind: eventFieldChanged, path: "/p/b.md", field: "sprint", to: "12", at: at})
+
+	entries, err := readAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3 (demo skipped): %+v", len(entries), entries)
+	}
+	want := []string{"status: reviewed → done", "renamed: /p/a.md → /p/b.md", "sprint: (none) → 12"}
+	for i, e := range entries {
+		if got := e.describe(); got != want[i] {
+			t.Errorf("entry %d: describe() = %q, want %q", i, got, want[i])
+		}
+	}
+	if !entries[0].Time.Equal(at) {
+		t.Errorf("time = %v, want %v", entries[0].Time, at)
+	}
+
+	// Filtering by the old name still finds the rename.
+	if got := filterAudit(entries, "a.md"); len(got) != 2 {
+		t.Errorf("filterAudit(a.md) = %d entries, want 2", len(got))
+	}
+}
+
+func TestReadAuditLogMissing(t *testing.T) {
+	entries, err := readAuditLog(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || entries != nil {
+		t.Errorf("missing log: %v, %v", entries, err)
+	}
+}
+
+func TestBatchSetFieldReportsEvents(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md")
+	writeFile(t, a, "---\nsprint: 11\n---\n# A\n")
+	writeFile(t, b, "---\nsprint: 12\n---\n# B\n")
+
+	msg := batchSetField(dir, "", []string{a, b}, "sprint", "12")().(batchDoneMsg)
+	events := msg.planEvents(nil)
+	if len(events) != 1 || events[0].path != a || events[0].field != "sprint" || events[0].from != "11" {
+		t.Errorf("events = %+v, want one sprint change on a.md", events)
+	}
+}
+
+func TestActivityViewShowsEntries(t *testing.T) {
+	m := testModel()
+	m.auditPath = filepath.Join(t.TempDir(), auditFileName)
+	target := m.list.Items()[2].(plan)
+	auditLog{m.auditPath}.record(planEvent{kind: eventStatusChanged, path: target.path(), to: "done", at: time.Now()})
+
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m = m2.(model)
+	if !m.activity.active || cmd == nil {
+		t.Fatal("A should open the activity view and load the log")
+	}
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	if view := m.View(); !strings.Contains(view, "status: (none) → done") {
+		t.Error("activity view should list the change")
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.activity.active {
+		t.Error("enter should close the activity view")
+	}
+	if p, ok := m.list.SelectedItem().(plan); !ok || p.path() != target.path() {
+		t.Errorf("enter should select %s", target.path())
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── planc batch ─────────────────────────────────────────────────────────────
+//
+// `planc batch --filter "status:active label:lunch" --set-status done` is
+// planc set for every plan a filter expression matches, for changes too
+// large to select by hand. A filter is space-separated terms that must all
+// match:
+//
+//	status:active,reviewed   one of these statuses
+//	label:api,infra          one of these labels (label:none: unlabeled)
+//	older:30d                not modified for 30 days (7d, 2w, 36h)
+//	newer:7d                 modified in the last 7 days
+//	title:deploy, deploy     title contains the text, ignoring case
+//
+// A leading - negates a term. --dry-run lists the plans without changing
+// them.
+
+// filterTerm is one term of a filter expression.
+type filterTerm struct {
+	negate bool
+	match  func(plan) bool
+}
+
+type planFilter []filterTerm
+
+// parsePlanFilter parses a filter expression; times are relative to now.
+func parsePlanFilter(expr string, now time.Time) (planFilter, error) {
+	var f planFilter
+	for _, word := range strings.Fields(expr) {
+		term := filterTerm{}
+		word, term.negate = strings.CutPrefix(word, "-")
+		key, value, ok := strings.Cut(word, ":")
+		if !ok {
+			key, value = "title", word
+		}
+		if value == "" {
+			return nil, fmt.Errorf("filter %q has no value", word)
+		}
+		values := strings.Split(value, ",")
+		key = strings.ToLower(key)
+		switch key {
+		case "status":
+			var statuses []string
+			for _, v := range values {
+				st, ok := statusByName(v)
+				if !ok {
+					return nil, fmt.Errorf("unknown status %q (%s)", v, statusChoices())
+				}
+				statuses = append(statuses, st)
+			}
+			term.match = func(p plan) bool { return slices.Contains(statuses, p.status) }
+		case "label":
+			labels := parseLabels(value)
+			term.match = func(p plan) bool {
+				if len(labels) == 1 && labels[0] == "none" {
+					return len(p.labels) == 0
+				}
+				return slices.ContainsFunc(labels, func(l string) bool { return slices.Contains(p.labels, l) })
+			}
+		case "older", "newer":
+			d, ok := parsePeriod(value)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s %q (use 7d, 2w, or 36h)", key, value)
+			}
+			cutoff := now.Add(-d)
+			if key == "older" {
+				term.match = func(p plan) bool { return p.modified.Before(cutoff) }
+			} else {
+				term.match = func(p plan) bool { return !p.modified.Before(cutoff) }
+			}
+		case "title":
+			text := strings.ToLower(value)
+			term.match = func(p plan) bool { return strings.Contains(strings.ToLower(p.title), text) }
+		default:
+			return nil, fmt.Errorf("unknown filter %q (want status, label, older, newer, or title)", key+":")
+		}
+		f = append(f, term)
+	}
+	return f, nil
+}
+
+func (f planFilter) matches(p plan) bool {
+	for _, t := range f {
+		if t.match(p) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func runBatch(cfg config, args []string) int {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	filter := fs.String("filter", "", `plans to change, e.g. "status:active label:lunch older:30d"`)
+	status := fs.String("set-status", "", "set the status: "+statusList())
+	var add, remove labelsFlag
+	fs.Var(&add, "add-label", "add a label (repeatable, or comma-separated)")
+	fs.Var(&remove, "remove-label", "remove a label (repeatable, or comma-separated)")
+	dryRun := fs.Bool("dry-run", false, "list the matching plans without changing them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc batch --filter EXPR [--set-status S] [--add-label L]... [--remove-label L]... [--dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if strings.TrimSpace(*filter) == "" {
+		return cliError("batch: --filter is required; use \"status:%s\" for every plan", strings.Join(statusNames(), ","))
+	}
+	f, err := parsePlanFilter(*filter, time.Now())
+	if err != nil {
+		return cliError("batch: %v", err)
+	}
+	setStatus := *status != ""
+	st, ok := statusByName(*status)
+	if setStatus && !ok {
+		return cliError("batch: unknown status %q (%s)", *status, statusChoices())
+	}
+	if !*dryRun && !setStatus && len(add) == 0 && len(remove) == 0 {
+		return cliError("batch: nothing to change; give --set-status, --add-label, --remove-label, or --dry-run")
+	}
+
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("batch: %v", err)
+	}
+	sortPlans(all)
+	var paths []string
+	for _, p := range all {
+		if f.matches(p) {
+			paths = append(paths, p.path())
+		}
+	}
+	if *dryRun {
+		for _, path := range paths {
+			fmt.Println(contractHome(path))
+		}
+		fmt.Fprintf(os.Stderr, "%d plans match\n", len(paths))
+		return 0
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "no plans match")
+		return 0
+	}
+	events, err := setPlans(paths, setStatus, st, add, remove)
+	if err != nil {
+		var be *plans.BatchError
+		if errors.As(err, &be) && len(be.Unrestored) == 0 {
+			return cliError("batch: %v (no plans changed)", err)
+		}
+		return cliError("batch: %v", err)
+	}
+	auditPath, err := auditLogPath()
+	if err != nil {
+		auditPath = ""
+	}
+	reportSet(os.Stdout, events, auditPath, time.Now())
+	return 0
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePlanFilter(t *testing.T) {
+	now := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	plans := []plan{
+		{file: "a.md", title: "Lunch order", status: "active", labels: []string{"lunch"}, modified: now.AddDate(0, 0, -40)},
+		{file: "b.md", title: "Deploy API", status: "active", labels: []string{"api", "infra"}, modified: now.AddDate(0, 0, -2)},
+		{file: "c.md", title: "Notes", status: "", modified: now.AddDate(0, 0, -10)},
+		{file: "d.md", title: "Old deploy", status: "done", labels: []string{"infra"}, modified: now.AddDate(0, 0, -90)},
+	}
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"status:active label:lunch", "a"},
+		{"status:active,new", "a,b,c"},
+		{"label:api,lunch", "a,b"},
+		{"label:none", "c"},
+		{"-label:infra", "a,c"},
+		{"older:30d", "a,d"},
+		{"newer:1w", "b"},
+		{"deploy", "b,d"},
+		{"title:DEPLOY -status:done", "b"},
+		{"", "a,b,c,d"},
+	}
+	for _, tt := range tests {
+		f, err := parsePlanFilter(tt.expr, now)
+		if err != nil {
+			t.Errorf("parsePlanFilter(%q): %v", tt.expr, err)
+			continue
+		}
+		var got []string
+		for _, p := range plans {
+			if f.matches(p) {
+				got = append(got, strings.TrimSuffix(p.file, ".md"))
+			}
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("%q matched %v, want %s", tt.expr, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"status:shipped", "owner:me", "older:soon", "label:"} {
+		if _, err := parsePlanFilter(bad, now); err == nil {
+			t.Errorf("parsePlanFilter(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	for _, name := range []string{"a", "b"} {
+		writeFile(t, filepath.Join(cfg.PlansDir, name+".md"), "---\nstatus: active\nlabels: lunch\n---\n# "+name+"\n")
+	}
+	writeFile(t, filepath.Join(cfg.PlansDir, "c.md"), "---\nstatus: active\n---\n# c\n")
+
+	if code := runBatch(cfg, []string{"--filter", "status:active label:lunch", "--set-status", "done", "--remove-label", "lunch"}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	for name, want := range map[string]string{"a": "status: done\n", "b": "status: done\n", "c": "status: active\n"} {
+		data, _ := os.ReadFile(filepath.Join(cfg.PlansDir, name+".md"))
+		if !strings.Contains(string(data), want) || strings.Contains(string(data), "lunch") {
+			t.Errorf("%s.md = %q", name, data)
+		}
+	}
+	if code := runBatch(cfg, []string{"--set-status", "done"}); code == 0 {
+		t.Error("a missing --filter should fail")
+	}
+	if code := runBatch(cfg, []string{"--filter", "status:done"}); code == 0 {
+		t.Error("no change should fail")
+	}
+}
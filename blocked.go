@@ -0,0 +1,123 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Blocked Plans ───────────────────────────────────────────────────────────
+//
+// A plan that can't start until others are done names them in blocked_by:
+// (a filename or a list of them, with or without .md). Until every blocker
+// is done the plan is blocked: its row shows ⛔ and a grayed title, the
+// preview shows a banner naming the blockers, and J jumps to the first one.
+
+// blockedMark is the list row indicator for blocked plans.
+const blockedMark = "⛔"
+
+// parseBlockedBy splits a blocked_by: value into plan references.
+func parseBlockedBy(v string) []string {
+	var refs []string
+	for _, ref := range strings.Split(v, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// renameBlocker returns refs with the ones naming oldFile changed to name
+// newFile, each keeping or leaving off .md as it was written, and whether
+// any changed.
+func renameBlocker(refs []string, oldFile, newFile string) ([]string, bool) {
+	out := slices.Clone(refs)
+	changed := false
+	for i, ref := range refs {
+		if planRefName(ref) != planRefName(oldFile) {
+			continue
+		}
+		out[i] = newFile
+		if !strings.HasSuffix(strings.ToLower(ref), ".md") {
+			out[i] = strings.TrimSuffix(newFile, ".md")
+		}
+		changed = true
+	}
+	return out, changed
+}
+
+// blockers returns the plans in all that p's blocked_by: names, in the order
+// it names them. Names that match no plan are skipped.
+func blockers(p plan, all []plan) []plan {
+	var out []plan
+	for _, ref := range p.blockedBy {
+		if b, ok := planByRef(all, ref, p); ok {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// openBlockers returns p's blockers that aren't done yet.
+func openBlockers(p plan, all []plan) []plan {
+	var out []plan
+	for _, b := range blockers(p, all) {
+		if !isDone(b.status) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// markBlocked returns a copy of plans with blocked set from the blockers'
+// statuses in all.
+func markBlocked(plans, all []plan) []plan {
+	out := make([]plan, len(plans))
+	for i, p := range plans {
+		p.blocked = len(p.blockedBy) > 0 && len(openBlockers(p, all)) > 0
+		out[i] = p
+	}
+	return out
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// jumpToBlocker selects the selected plan's first unfinished blocker, or its
+// first blocker once they're all done.
+func (m *model) jumpToBlocker() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	all := *m.planSource()
+	targets := openBlockers(item, all)
+	if len(targets) == 0 {
+		targets = blockers(item, all)
+	}
+	if len(targets) == 0 {
+		return m.setNotification(tr("This plan isn't blocked by another plan"), statusTimeout)
+	}
+	return m.jumpToPlan(targets[0].path())
+}
+
+// blockedBanner names the selected plan's unfinished blockers for the top of
+// the preview pane, or "" if it isn't blocked.
+func (m model) blockedBanner(width int) string {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok || len(item.blockedBy) == 0 {
+		return ""
+	}
+	open := openBlockers(item, *m.planSource())
+	if len(open) == 0 {
+		return ""
+	}
+	titles := make([]string, len(open))
+	for i, b := range open {
+		titles[i] = b.title
+	}
+	hint := lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render("J")
+	text := truncateForWidth(blockedMark+" "+tr("Blocked by ")+strings.Join(titles, ", "), width-4)
+	return " " + lipgloss.NewStyle().Foreground(colorRed).Render(text) + " " + hint
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestBlockedPlans(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "schema.md"), "---\nstatus: active\ncreated: 2026-01-01\n---\n# Schema\n")
+	writeFile(t, filepath.Join(dir, "api.md"), "---\nstatus: done\ncreated: 2026-01-02\n---\n# API\n")
+	writeFile(t, filepath.Join(dir, "ui.md"), "---\nstatus: reviewed\ncreated: 2026-01-03\nblocked_by: [schema, api.md]\n---\n# UI\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(all, func(p plan) bool { return p.file == "ui.md" })
+	if ui := all[i]; !slices.Equal(ui.blockedBy, []string{"schema", "api.md"}) {
+		t.Fatalf("blockedBy = %q", ui.blockedBy)
+	}
+	if open := openBlockers(all[i], all); len(open) != 1 || open[0].file != "schema.md" {
+		t.Errorf("openBlockers = %v, want only the unfinished schema plan", open)
+	}
+
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	m.list.Select(0) // ui.md, the newest
+	if p := m.list.SelectedItem().(plan); p.file != "ui.md" || !p.blocked {
+		t.Fatalf("selected %s, blocked = %v", p.file, p.blocked)
+	}
+	out := ansi.Strip(m.View())
+	if !strings.Contains(out, blockedMark) || !strings.Contains(out, "Blocked by Schema") {
+		t.Errorf("view should mark the blocked plan:\n%s", out)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	if p := m2.(model).list.SelectedItem().(plan); p.file != "schema.md" {
+		t.Errorf("J selected %s, want schema.md", p.file)
+	}
+
+	for j := range all {
+		if all[j].file == "schema.md" {
+			all[j].status = "done"
+		}
+	}
+	if marked := markBlocked(all, all); marked[i].blocked {
+		t.Error("plan should unblock once its blockers are done")
+	}
+}
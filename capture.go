@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ─── Capture ─────────────────────────────────────────────────────────────────
+//
+// I (or `planc capture [URL]`) turns a web page or the clipboard into a new
+// plan: design docs and issue descriptions become plan inputs without a
+// copy-paste-reformat detour. A URL is fetched and, if it's HTML, converted
+// to markdown (the page's <article> or <main> when it has one); anything else
+// on the clipboard is taken as markdown. The plan records its origin in a
+// source: field.
+
+const (
+	captureTimeout = 30 * time.Second
+	captureMaxSize = 5 << 20 // bytes read from a URL
+)
+
+// capture is a document ready to become a plan.
+type capture struct {
+	title  string
+	body   string // markdown
+	source string // URL, or "clipboard"
+}
+
+var urlPattern = regexp.MustCompile(`^https?://\S+$`)
+
+// captureText turns clipboard text into a capture, fetching it if it is a URL.
+func captureText(ctx context.Context, text string) (capture, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return capture{}, errors.New("clipboard is empty")
+	}
+	if urlPattern.MatchString(text) {
+		return fetchCapture(ctx, text)
+	}
+	return capture{title: markdownTitle(text), body: text + "\n", source: "clipboard"}, nil
+}
+
+// fetchCapture downloads url and converts it to markdown.
+func fetchCapture(ctx context.Context, url string) (capture, error) {
+	ctx, cancel := context.WithTimeout(ctx, captureTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return capture{}, err
+	}
+	req.Header.Set("User-Agent", "planc/"+getVersion())
+	req.Header.Set("Accept", "text/html, text/markdown;q=0.9, text/plain;q=0.8")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return capture{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return capture{}, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	body := io.LimitReader(resp.Body, captureMaxSize)
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	c := capture{source: url}
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml" || mediaType == "":
+		c.title, c.body, err = htmlToMarkdown(body)
+	case strings.HasPrefix(mediaType, "text/"):
+		var data []byte
+		data, err = io.ReadAll(body)
+		c.body = string(data)
+	default:
+		return capture{}, fmt.Errorf("%s: can't capture %s content", url, mediaType)
+	}
+	if err != nil {
+		return capture{}, err
+	}
+	if c.title == "" {
+		c.title = markdownTitle(c.body)
+	}
+	return c, nil
+}
+
+// markdownTitle returns the first heading of md, or else its first line.
+func markdownTitle(md string) string {
+	if h := headerFromBody(md); h != "" {
+		return h
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(md), "\n")
+	return truncateForWidth(strings.TrimSpace(first), 60)
+}
+
+// planBody returns the capture's body with a # title heading, adding one if
+// the body doesn't open with a heading.
+func (c capture) planBody() string {
+	body := strings.TrimSpace(c.body) + "\n"
+	if strings.HasPrefix(body, "# ") {
+		return body
+	}
+	return "# " + c.title + "\n\n" + body
+}
+
+// saveCapture writes c as a new plan in dir and returns its path.
+func saveCapture(dir string, c capture, now time.Time) (string, error) {
+	if c.title == "" {
+		c.title = "Captured plan"
+	}
+	return writeNewPlan(dir, c.title, map[string]string{"source": c.source}, c.planBody(), now)
+}
+
+// ─── HTML to Markdown ────────────────────────────────────────────────────────
+
+// skippedElements never contribute text.
+var skippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Svg: true, atom.Nav: true, atom.Header: true, atom.Footer: true,
+	atom.Aside: true, atom.Form: true, atom.Button: true, atom.Iframe: true,
+}
+
+// htmlToMarkdown converts an HTML document to markdown, returning its
+// <title> too. Only the <article> or <main> element is converted when the
+// page has one; navigation and scripts are dropped.
+func htmlToMarkdown(r io.Reader) (title, md string, err error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", "", err
+	}
+	root := doc
+	if n := findElement(doc, atom.Article); n != nil {
+		root = n
+	} else if n := findElement(doc, atom.Main); n != nil {
+		root = n
+	} else if n := findElement(doc, atom.Body); n != nil {
+		root = n
+	}
+	if n := findElement(doc, atom.Title); n != nil {
+		title = strings.Join(strings.Fields(textContent(n)), " ")
+	}
+	var c mdConverter
+	c.block(root)
+	return title, c.String(), nil
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		if found := findElement(ch, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		b.WriteString(textContent(ch))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// mdConverter accumulates markdown blocks. Inline content is collected into
+// the current paragraph and flushed as a block.
+type mdConverter struct {
+	blocks []string
+	inline strings.Builder
+	prefix string // blockquote/list indentation for new blocks
+}
+
+func (c *mdConverter) String() string {
+	c.flush()
+	return strings.Join(c.blocks, "\n\n") + "\n"
+}
+
+// flush ends the current paragraph.
+func (c *mdConverter) flush() {
+	text := strings.TrimSpace(c.inline.String())
+	c.inline.Reset()
+	if text != "" {
+		c.emit(text)
+	}
+}
+
+// emit adds a block, prefixing each line for the enclosing blockquote/list.
+func (c *mdConverter) emit(block string) {
+	if c.prefix != "" {
+		lines := strings.Split(block, "\n")
+		for i, l := range lines {
+			lines[i] = c.prefix + l
+		}
+		block = strings.Join(lines, "\n")
+	}
+	c.blocks = append(c.blocks, block)
+}
+
+// block converts n's children as block content.
+func (c *mdConverter) block(n *html.Node) {
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		c.node(ch)
+	}
+}
+
+func (c *mdConverter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.inline.WriteString(collapseSpace(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if skippedElements[n.DataAtom] {
+		return
+	}
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		c.flush()
+		level := int(n.Data[1] - '0')
+		if text := strings.TrimSpace(c.inlineText(n)); text != "" {
+			c.emit(strings.Repeat("#", level) + " " + text)
+		}
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Main, atom.Figure, atom.Dl:
+		c.flush()
+		c.block(n)
+		c.flush()
+	case atom.Br:
+		c.inline.WriteString("  \n")
+	case atom.Hr:
+		c.flush()
+		c.emit("---")
+	case atom.Pre:
+		c.flush()
+		lang := ""
+		if code := findElement(n, atom.Code); code != nil {
+			lang = strings.TrimPrefix(attr(code, "class"), "language-")
+			if strings.ContainsAny(lang, " ") {
+				lang = ""
+			}
+		}
+		c.emit("```" + lang + "\n" + strings.TrimRight(textContent(n), "\n") + "\n```")
+	case atom.Blockquote:
+		c.flush()
+		saved := c.prefix
+		c.prefix += "> "
+		c.block(n)
+		c.flush()
+		c.prefix = saved
+	case atom.Ul, atom.Ol:
+		c.flush()
+		c.list(n, n.DataAtom == atom.Ol)
+	case atom.Table:
+		c.flush()
+		c.table(n)
+	default:
+		c.inline.WriteString(c.inlineText(n))
+	}
+}
+
+// list emits a list as one block, nesting sublists by indentation.
+func (c *mdConverter) list(n *html.Node, ordered bool) {
+	var lines []string
+	i := 1
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", i)
+		}
+		i++
+		var text strings.Builder
+		var nested []string
+		for ch := li.FirstChild; ch != nil; ch = ch.NextSibling {
+			if ch.Type == html.ElementNode && (ch.DataAtom == atom.Ul || ch.DataAtom == atom.Ol) {
+				var sub mdConverter
+				sub.list(ch, ch.DataAtom == atom.Ol)
+				for _, l := range strings.Split(strings.TrimSpace(sub.String()), "\n") {
+					nested = append(nested, "  "+l)
+				}
+				continue
+			}
+			text.WriteString(c.inlineText(ch))
+		}
+		lines = append(lines, marker+strings.TrimSpace(text.String()))
+		lines = append(lines, nested...)
+	}
+	if len(lines) > 0 {
+		c.emit(strings.Join(lines, "\n"))
+	}
+}
+
+// table emits a GFM table; the first row is the header.
+func (c *mdConverter) table(n *html.Node) {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Tr {
+			var row []string
+			for cell := n.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					row = append(row, strings.ReplaceAll(strings.TrimSpace(c.inlineText(cell)), "|", `\|`))
+				}
+			}
+			rows = append(rows, row)
+			return
+		}
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			walk(ch)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		b.WriteString("| " + strings.Join(row, " | ") + " |")
+		if i == 0 {
+			b.WriteString("\n|" + strings.Repeat(" --- |", len(row)))
+		}
+		if i < len(rows)-1 {
+			b.WriteString("\n")
+		}
+	}
+	c.emit(b.String())
+}
+
+// inlineText renders n as inline markdown.
+func (c *mdConverter) inlineText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return collapseSpace(n.Data)
+	}
+	if n.Type != html.ElementNode || skippedElements[n.DataAtom] {
+		return ""
+	}
+	var inner strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		inner.WriteString(c.inlineText(ch))
+	}
+	text := inner.String()
+	trimmed := strings.TrimSpace(text)
+	switch n.DataAtom {
+	case atom.Strong, atom.B:
+		if trimmed != "" {
+			return "**" + trimmed + "**"
+		}
+	case atom.Em, atom.I:
+		if trimmed != "" {
+			return "_" + trimmed + "_"
+		}
+	case atom.Code:
+		return "`" + textContent(n) + "`"
+	case atom.A:
+		if href := attr(n, "href"); href != "" && trimmed != "" && !strings.HasPrefix(href, "#") {
+			return "[" + trimmed + "](" + href + ")"
+		}
+	case atom.Img:
+		if src := attr(n, "src"); src != "" {
+			return "![" + attr(n, "alt") + "](" + src + ")"
+		}
+	case atom.Br:
+		return "  \n"
+	}
+	return text
+}
+
+var spaceRun = regexp.MustCompile(`\s+`)
+
+func collapseSpace(s string) string {
+	return spaceRun.ReplaceAllString(s, " ")
+}
+
+// ─── Command and key ─────────────────────────────────────────────────────────
+
+func runCapture(cfg config, args []string) int {
+	fs := flag.NewFlagSet("capture", flag.ContinueOnError)
+	dir := fs.String("dir", "", "directory for the new plan (default: the agent plans directory)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc capture [--dir DIR] [URL]")
+		fmt.Fprintln(fs.Output(), "Without a URL, the clipboard is captured (a URL on the clipboard is fetched).")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+	var c capture
+	var err error
+	if fs.NArg() == 1 {
+		c, err = fetchCapture(context.Background(), fs.Arg(0))
+	} else {
+		var text string
+		if text, err = clipboard.ReadAll(); err == nil {
+			c, err = captureText(context.Background(), text)
+		}
+	}
+	if err != nil {
+		return cliError("capture: %v", err)
+	}
+	target := cfg.PlansDir
+	if *dir != "" {
+		target = expandHome(*dir)
+	}
+	path, err := saveCapture(target, c, time.Now())
+	if err != nil {
+		return cliError("capture: %v", err)
+	}
+	fmt.Println(contractHome(path))
+	return 0
+}
+
+// capturePlan captures the clipboard into a new plan in dir.
+func capturePlan(agentDir, projectGlob, dir string) tea.Cmd {
+	return func() tea.Msg {
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			return errMsg{fmt.Errorf("capture: clipboard: %w", err)}
+		}
+		c, err := captureText(context.Background(), text)
+		if err != nil {
+			return errMsg{fmt.Errorf("capture: %w", err)}
+		}
+		path, err := saveCapture(dir, c, time.Now())
+		if err != nil {
+			return errMsg{fmt.Errorf("capture: %w", err)}
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return planFileMsg{plans: plans, path: path, message: "Captured: " + filepath.Base(path),
+			event: planEvent{kind: eventPlanCreated, path: path, to: c.title}}
+	}
+}
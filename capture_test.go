@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	page := `<html><head><title>Design: Sync  v2</title><script>x()</script></head>
+<body><nav><a href="/">Home</a></nav>
+<article>
+<h1>Sync v2</h1>
+<p>We <strong>replace</strong> polling with <a href="https://example.com/push">push</a> and <code>etags</code>.</p>
+<ul><li>Fast</li><li>Cheap<ul><li>really</li></ul></li></ul>
+<ol><li>Ship</li><li>Measure</li></ol>
+<pre><code class="language-go">func main() {
+	sync()
+}</code></pre>
+<blockquote><p>Quoted</p></blockquote>
+<table><tr><th>Step</th><th>Owner</th></tr><tr><td>Design</td><td>Ana</td></tr></table>
+</article>
+<footer>Copyright</footer></body></html>`
+	title, md, err := htmlToMarkdown(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "Design: Sync v2" {
+		t.Errorf("title = %q", title)
+	}
+	want := "# Sync v2\n\n" +
+		"We **replace** polling with [push](https://example.com/push) and `etags`.\n\n" +
+		"- Fast\n- Cheap\n  - really\n\n" +
+		"1. Ship\n2. Measure\n\n" +
+		"```go\nfunc main() {\n\tsync()\n}\n```\n\n" +
+		"> Quoted\n\n" +
+		"| Step | Owner |\n| --- | --- |\n| Design | Ana |\n"
+	if md != want {
+		t.Errorf("markdown:\n%s\nwant:\n%s", md, want)
+	}
+}
+
+func TestCaptureURLSavesPlan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/doc":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<title>Issue 42</title><main><p>Crash on save.</p></main>`))
+		case "/notes.md":
+			w.Header().Set("Content-Type", "text/markdown")
+			w.Write([]byte("# Notes\n\nPlain markdown.\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	c, err := captureText(context.Background(), "  "+srv.URL+"/doc\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := saveCapture(dir, c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	fm, body := parseFrontmatter(got)
+	if fm["source"] != srv.URL+"/doc" {
+		t.Errorf("frontmatter = %v", fm)
+	}
+	if !strings.Contains(body, "# Issue 42\n\nCrash on save.") {
+		t.Errorf("body = %q", body)
+	}
+
+	c, err = fetchCapture(context.Background(), srv.URL+"/notes.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.title != "Notes" || c.planBody() != "# Notes\n\nPlain markdown.\n" {
+		t.Errorf("markdown capture = %+v", c)
+	}
+
+	if _, err := fetchCapture(context.Background(), srv.URL+"/missing"); err == nil {
+		t.Error("fetching a 404 should fail")
+	}
+}
+
+func TestCaptureClipboardText(t *testing.T) {
+	c, err := captureText(context.Background(), "Idea: cache the index\n\nDetails here.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.title != "Idea: cache the index" || c.source != "clipboard" {
+		t.Errorf("capture = %+v", c)
+	}
+	if _, err := captureText(context.Background(), "   "); err == nil {
+		t.Error("empty clipboard should fail")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// ─── planc cat ───────────────────────────────────────────────────────────────
+//
+// `planc cat PLAN...` prints plans rendered as in the preview pane, without
+// the frontmatter, for a quick look without starting the TUI. The width and
+// the dark or light style follow the terminal; when the output isn't a
+// terminal it is wrapped at 80 columns without colors.
+
+// catStyles are the --style values; auto picks one as described above.
+var catStyles = []string{"auto", "dark", "light", "notty"}
+
+// catStyle resolves --style for output to a terminal (tty) or not.
+func catStyle(style string, tty bool) string {
+	switch {
+	case style != "auto":
+		return style
+	case !tty:
+		return "notty"
+	case lipgloss.HasDarkBackground():
+		return "dark"
+	}
+	return "light"
+}
+
+// renderPlanFile renders the plan at path like the preview pane does.
+func renderPlanFile(path, style string, width int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	fm, body := parseFrontmatter(string(data))
+	if t := fm["title"]; t != "" {
+		body = withTitleHeading(body, t)
+	}
+	return glamourRender(body, style, width), nil
+}
+
+func runCat(cfg config, args []string) int {
+	fs := flag.NewFlagSet("cat", flag.ContinueOnError)
+	width := fs.Int("width", 0, "wrap at this many columns (default: the terminal's width, or 80)")
+	style := fs.String("style", "auto", "auto, dark, light, or notty (plain text)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc cat [--width N] [--style S] PLAN...")
+		fs.PrintDefaults()
+	}
+	// Flags may come before or after the plans.
+	var names []string
+	for rest := args; ; {
+		if err := fs.Parse(rest); err != nil {
+			return 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		rest = fs.Args()[1:]
+	}
+	if len(names) == 0 {
+		fs.Usage()
+		return 2
+	}
+	if !slices.Contains(catStyles, *style) {
+		return cliError("cat: unknown style %q (want auto, dark, light, or notty)", *style)
+	}
+
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	w := *width
+	if w <= 0 {
+		w = 80
+		if cols, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && tty && cols > 0 {
+			w = cols
+		}
+	}
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("cat: %v", err)
+	}
+	paths, err := resolvePlanPaths(all, names)
+	if err != nil {
+		return cliError("cat: %v", err)
+	}
+	s := catStyle(*style, tty)
+	for i, path := range paths {
+		out, err := renderPlanFile(path, s, w)
+		if err != nil {
+			return cliError("cat: %v", err)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Print(out)
+	}
+	return 0
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCatStyle(t *testing.T) {
+	if got := catStyle("auto", false); got != "notty" {
+		t.Errorf("piped auto = %q", got)
+	}
+	if got := catStyle("light", false); got != "light" {
+		t.Errorf("explicit style = %q", got)
+	}
+}
+
+func TestRenderPlanFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.md")
+	writeFile(t, path, "---\nstatus: active\ntitle: Auth rollout\n---\n# Auth\n\nShip **it**.\n")
+	out, err := renderPlanFile(path, "notty", 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "status:") || !strings.Contains(out, "Auth rollout") || !strings.Contains(out, "Ship") {
+		t.Errorf("rendered:\n%s", out)
+	}
+	if _, err := renderPlanFile(filepath.Join(t.TempDir(), "gone.md"), "notty", 80); err == nil {
+		t.Error("missing file rendered")
+	}
+}
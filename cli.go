@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ─── Subcommands ─────────────────────────────────────────────────────────────
+//
+// `planc <command> [args]` runs a non-interactive command instead of the TUI.
+// Commands read config with loadConfigRaw so they never trigger first-time
+// setup, and return a process exit code.
+
+type subcommand struct {
+	summary string
+	run     func(cfg config, args []string) int
+}
+
+var subcommands = map[string]subcommand{
+	"archive":        {"Move done plans untouched for archive_after_days into archive/", runArchive},
+	"batch":          {"Change status and labels of every plan a --filter expression matches", runBatch},
+	"capture":        {"Save a URL (or the clipboard) as a new plan, converted to markdown", runCapture},
+	"cat":            {"Print plans rendered for the terminal, without frontmatter", runCat},
+	"comments-count": {"Print the number of open comments in plans", runCommentsCount},
+	"completion":     {"Print a bash, zsh, or fish completion script", runCompletion},
+	"csv":            {"Print the plan table (file, title, status, labels, dates, due, owner) as CSV", runCSV},
+	"digest":         {"Summarize a period as markdown (created, completed, stalled, most-commented)", runDigest},
+	"export":         {"Export plans as md, html, pdf, review, csv, json, or a static HTML site", runExport},
+	"feed":           {"Print an Atom feed of plan creations and status changes", runFeed},
+	"grep":           {"Search plan bodies; prints file, line, heading, and text per match", runGrep},
+	"ical":           {"Print an iCalendar file of plan due: dates", runICal},
+	"import":         {"Copy plans from a directory or .zip into the plans directory, skipping duplicates", runImport},
+	"labels":         {"Print the labels of plans, comma-separated", runLabels},
+	"lint":           {"Check plans for CI (status, labels, titles, comments); exits 1 on problems", runLint},
+	"list":           {"Print plans (file, title, status, labels, created, modified) as TSV or --json", runList},
+	"log":            {"Show the audit log of plan changes, optionally for one plan", runLog},
+	"mcp":            {"Serve plans to a coding agent over the Model Context Protocol (stdio)", runMCP},
+	"migrate":        {"Rewrite legacy frontmatter (project → labels, pending → reviewed)", runMigrate},
+	"new":            {"Create a plan, from a template with its variables filled in", runNew},
+	"prune":          {"Remove stale caches, installed-update state, old crash reports, and orphaned notes", runPrune},
+	"serve":          {"Serve plans over a local HTTP JSON API for editors and dashboards", runServe},
+	"set":            {"Change plans' status and labels (--status, --add-label, --remove-label)", runSet},
+	"stats":          {"Report counts by status and label, weekly throughput, and time to done (--json)", runStats},
+	"status":         {"Print the statuses of plans, or with none how many plans have each status", runStatus},
+	"summary":        {"Print a compact overview: status counts, top labels, newest and stale plans", runSummary},
+	"title":          {"Print the titles of plans", runTitle},
+	"watch":          {"Print plan changes as they happen, as lines or newline-delimited JSON (--json)", runWatch},
+}
+
+// runSubcommand runs the subcommand named by args[0], if there is one.
+func runSubcommand(args []string) (code int, ok bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	cmd, ok := subcommands[args[0]]
+	if !ok {
+		return 0, false
+	}
+	cfg := loadConfigRaw()
+	applyConfigGlobals(cfg)
+	return cmd.run(cfg, args[1:]), true
+}
+
+// printSubcommands lists the available subcommands for --help.
+func printSubcommands() {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-14s  %s\n", name, subcommands[name].summary)
+	}
+}
+
+// cliError prints an error for a subcommand and returns exit code 1.
+func cliError(format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, "planc: "+format+"\n", args...)
+	return 1
+}
@@ -1,21 +1,22 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/fsnotify/fsnotify"
+	"github.com/jakebf/planc/plans"
 )
 
 // lastSelfWrite tracks when we last wrote to a plan file ourselves.
 // The file watcher checks this to skip events caused by our own writes.
-var lastSelfWrite atomic.Int64
+var lastSelfWrite = &plans.LastWrite
 
 // rendererPool caches glamour renderers keyed by "style:width".
 // Each key maps to a sync.Pool so concurrent goroutines get their own instance.
@@ -67,13 +68,17 @@ func glamourRender(markdown, style string, width int) string {
 	}
 	r, err := getRenderer(style, pw)
 	if err != nil {
+		logger.Error("render failed", "err", err)
 		return markdown
 	}
+	start := time.Now()
 	rendered, err := r.Render(markdown)
 	putRenderer(style, pw, r)
 	if err != nil {
+		logger.Error("render failed", "bytes", len(markdown), "err", err)
 		return markdown
 	}
+	logger.Debug("render", "bytes", len(markdown), "width", pw, "duration", time.Since(start))
 	return rendered
 }
 
@@ -85,12 +90,20 @@ func renderMarkdown(file, markdown, style string, width int) tea.Cmd {
 
 func renderPlan(p plan, style string, width int) tea.Cmd {
 	return func() tea.Msg {
-		data, err := os.ReadFile(p.path())
+		content, err := renderPlanFile(p.path(), style, width)
 		if err != nil {
 			return planContentMsg{file: p.path(), content: fmt.Sprintf("Error reading %s: %v", p.file, err)}
 		}
-		_, body := parseFrontmatter(string(data))
-		return planContentMsg{file: p.path(), content: glamourRender(body, style, width)}
+		return planContentMsg{file: p.path(), content: content}
+	}
+}
+
+// largePlanPlaceholder stands in for the preview of a plan too large to
+// render automatically.
+func largePlanPlaceholder(p plan) tea.Cmd {
+	return func() tea.Msg {
+		content := fmt.Sprintf("\n  Large plan (%s)\n\n  Preview rendering is skipped to keep planc responsive.\n  Press v to render it, or e to open it in your editor.\n", formatSize(p.size))
+		return planContentMsg{file: p.path(), content: content}
 	}
 }
 
@@ -102,37 +115,33 @@ func reloadAllPlans(agentDir, projectGlob string) tea.Msg {
 	return reloadMsg{plans: plans}
 }
 
+// deletePlan moves p to the trash (see trash.go) and rescans.
 func deletePlan(agentDir, projectGlob string, p plan) tea.Cmd {
 	return func() tea.Msg {
-		if err := os.Remove(p.path()); err != nil && !os.IsNotExist(err) {
+		trashed, err := plans.Trash(p.path(), time.Now())
+		if err != nil {
 			return errMsg{fmt.Errorf("could not delete file: %w", err)}
 		}
 		plans, err := scanAllPlans(agentDir, projectGlob)
 		if err != nil {
 			return errMsg{err}
 		}
-		return reloadMsg{plans: plans}
+		return reloadMsg{plans: plans, events: []planEvent{{kind: eventPlanDeleted, path: p.path(), to: trashed}}}
 	}
 }
 
 func setPlanStatus(p plan, newStatus string) tea.Cmd {
 	return func() tea.Msg {
-		if err := setFrontmatter(p.path(), map[string]string{"status": newStatus}); err != nil {
+		if err := plans.SetStatus(p.path(), newStatus); err != nil {
 			return errMsg{err}
 		}
-		updated := p
-		updated.status = newStatus
-		return statusUpdatedMsg{oldPlan: p, newPlan: updated}
+		return statusUpdatedMsg{oldPlan: p, newPlan: withStatus(p, newStatus, time.Now())}
 	}
 }
 
 func setLabels(p plan, labels []string) tea.Cmd {
 	return func() tea.Msg {
-		updates := map[string]string{
-			"labels":  labelsString(labels),
-			"project": "", // migrate away from project
-		}
-		if err := setFrontmatter(p.path(), updates); err != nil {
+		if err := plans.SetLabels(p.path(), labels); err != nil {
 			return errMsg{err}
 		}
 		updated := p
@@ -142,72 +151,122 @@ func setLabels(p plan, labels []string) tea.Cmd {
 	}
 }
 
+func setPlanTitle(p plan, title string) tea.Cmd {
+	return func() tea.Msg {
+		if err := plans.SetTitle(p.path(), title); err != nil {
+			return errMsg{err}
+		}
+		data, err := os.ReadFile(p.path())
+		if err != nil {
+			return errMsg{err}
+		}
+		fm, body := parseFrontmatter(string(data))
+		updated := p
+		updated.title, updated.untitled = planTitle(fm, body, p.file)
+		return titleUpdatedMsg{plan: updated, override: title}
+	}
+}
+
 func batchSetStatus(agentDir, projectGlob string, paths []string, status string) tea.Cmd {
 	return func() tea.Msg {
-		var failed int
-		for _, p := range paths {
-			if err := setFrontmatter(p, map[string]string{"status": status}); err != nil {
-				failed++
-			}
+		now := time.Now()
+		err := plans.BatchSetFrontmatter(paths, func(_ string, fields map[string]string) map[string]string {
+			return plans.StatusUpdates(fields, status, now)
+		})
+		if err != nil {
+			return batchFailed(agentDir, projectGlob, paths, err)
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		label := statusLabel(status)
+		return batchDoneMsg{
+			plans:   plans,
+			files:   paths,
+			message: fmt.Sprintf("%d plans → %s", len(paths), label),
+		}
+	}
+}
+
+func batchUpdateLabels(agentDir, projectGlob string, paths []string, add []string, remove []string) tea.Cmd {
+	return func() tea.Msg {
+		err := plans.BatchSetFrontmatter(paths, func(_ string, fields map[string]string) map[string]string {
+			return plans.LabelUpdates(fields, add, remove)
+		})
+		if err != nil {
+			return batchFailed(agentDir, projectGlob, paths, err)
 		}
 		plans, err := scanAllPlans(agentDir, projectGlob)
 		if err != nil {
 			return errMsg{err}
 		}
-		label := status
-		if label == "" {
-			label = "new"
+		var parts []string
+		if len(add) > 0 {
+			parts = append(parts, "+"+strings.Join(add, ","))
 		}
-		msg := fmt.Sprintf("%d plans → %s", len(paths), label)
-		if failed > 0 {
-			msg += fmt.Sprintf(" (%d failed)", failed)
+		if len(remove) > 0 {
+			parts = append(parts, "-"+strings.Join(remove, ","))
 		}
 		return batchDoneMsg{
 			plans:   plans,
 			files:   paths,
-			message: msg,
+			message: fmt.Sprintf("%d plans %s", len(paths), strings.Join(parts, " ")),
 		}
 	}
 }
 
-func batchUpdateLabels(agentDir, projectGlob string, paths []string, add []string, remove []string) tea.Cmd {
+// batchFailed reports a batch write that was rolled back. When every file was
+// restored nothing changed, so it is a plain error and the selection stays
+// for a retry. Otherwise the list is rescanned to show the files that were
+// left modified, which the error names.
+func batchFailed(agentDir, projectGlob string, paths []string, err error) tea.Msg {
+	var be *plans.BatchError
+	if !errors.As(err, &be) || len(be.Unrestored) == 0 {
+		return errMsg{fmt.Errorf("%w (no plans changed)", err)}
+	}
+	all, scanErr := scanAllPlans(agentDir, projectGlob)
+	if scanErr != nil {
+		return errMsg{scanErr}
+	}
+	return batchDoneMsg{
+		plans:   all,
+		files:   paths,
+		message: fmt.Sprintf("Error: %v (%d of %d plans unchanged)", err, len(be.Untouched(paths)), len(paths)),
+	}
+}
+
+// generateTitles derives a title for each untitled plan in paths and writes it
+// as a # heading at the top of the body. Plans that already have a heading or
+// have no usable prose are skipped.
+func generateTitles(agentDir, projectGlob string, paths []string) tea.Cmd {
 	return func() tea.Msg {
-		var failed int
+		var titled, skipped int
 		for _, p := range paths {
-			// Read current labels from file
 			data, err := os.ReadFile(p)
 			if err != nil {
-				failed++
+				skipped++
 				continue
 			}
-			fm, _ := parseFrontmatter(string(data))
-			existing := parseLabels(fm["labels"])
-			if len(existing) == 0 && fm["project"] != "" {
-				existing = []string{fm["project"]}
-			}
-			newLabels := applyLabelChanges(existing, add, remove)
-			updates := map[string]string{
-				"labels":  labelsString(newLabels),
-				"project": "", // migrate away from project
+			_, body := parseFrontmatter(string(data))
+			title := deriveTitle(body)
+			if headerFromBody(body) != "" || title == "" {
+				skipped++
+				continue
 			}
-			if err := setFrontmatter(p, updates); err != nil {
-				failed++
+			if err := writeCommentBody(p, "# "+title+"\n\n"+strings.TrimLeft(body, "\n")); err != nil {
+				skipped++
+				continue
 			}
+			titled++
 		}
 		plans, err := scanAllPlans(agentDir, projectGlob)
 		if err != nil {
 			return errMsg{err}
 		}
-		var parts []string
-		if len(add) > 0 {
-			parts = append(parts, "+"+strings.Join(add, ","))
-		}
-		if len(remove) > 0 {
-			parts = append(parts, "-"+strings.Join(remove, ","))
-		}
-		msg := fmt.Sprintf("%d plans %s", len(paths), strings.Join(parts, " "))
-		if failed > 0 {
-			msg += fmt.Sprintf(" (%d failed)", failed)
+		msg := fmt.Sprintf("%d titles generated", titled)
+		if skipped > 0 {
+			msg += fmt.Sprintf(" (%d skipped)", skipped)
 		}
 		return batchDoneMsg{
 			plans:   plans,
@@ -219,25 +278,7 @@ func batchUpdateLabels(agentDir, projectGlob string, paths []string, add []strin
 
 // applyLabelChanges applies add/remove to existing labels, returning a new slice.
 func applyLabelChanges(existing []string, add []string, remove []string) []string {
-	removeSet := make(map[string]bool)
-	for _, r := range remove {
-		removeSet[r] = true
-	}
-	var result []string
-	seen := make(map[string]bool)
-	for _, l := range existing {
-		if !removeSet[l] && !seen[l] {
-			result = append(result, l)
-			seen[l] = true
-		}
-	}
-	for _, a := range add {
-		if !seen[a] {
-			result = append(result, a)
-			seen[a] = true
-		}
-	}
-	return result
+	return plans.ApplyLabelChanges(existing, add, remove)
 }
 
 // runBackgroundEditor launches the editor in the background (for GUI editors).
@@ -275,6 +316,54 @@ func (s diskStore) setLabels(p plan, labels []string) tea.Cmd {
 	return setLabels(p, labels)
 }
 
+func (s diskStore) setTitle(p plan, title string) tea.Cmd {
+	return setPlanTitle(p, title)
+}
+
+func (s diskStore) repairPlan(p plan) tea.Cmd {
+	return repairPlan(s.agentDir, s.projectGlob, p)
+}
+
+func (s diskStore) batchSetField(paths []string, k, v string) tea.Cmd {
+	return batchSetField(s.agentDir, s.projectGlob, paths, k, v)
+}
+
+func (s diskStore) supersedePlan(newer, older plan) tea.Cmd {
+	return supersedePlan(s.agentDir, s.projectGlob, newer, older)
+}
+
+func (s diskStore) supersedeDuplicates(groups []duplicateGroup) tea.Cmd {
+	return supersedeDuplicates(s.agentDir, s.projectGlob, groups)
+}
+
+func (s diskStore) deletePlans(ps []plan) tea.Cmd {
+	return deletePlans(s.agentDir, s.projectGlob, ps)
+}
+
+func (s diskStore) createPlan(dir, title string) tea.Cmd {
+	return createPlan(s.agentDir, s.projectGlob, dir, title)
+}
+
+func (s diskStore) renamePlan(p plan, name string) tea.Cmd {
+	return renamePlan(s.agentDir, s.projectGlob, p, name)
+}
+
+func (s diskStore) movePlan(p plan, dir string) tea.Cmd {
+	return movePlan(s.agentDir, s.projectGlob, p, dir)
+}
+
+func (s diskStore) duplicatePlan(p plan) tea.Cmd {
+	return duplicatePlan(s.agentDir, s.projectGlob, p)
+}
+
+func (s diskStore) forkPlan(p plan, name string) tea.Cmd {
+	return forkPlan(s.agentDir, s.projectGlob, p, name)
+}
+
+func (s diskStore) archivePlan(p plan) tea.Cmd {
+	return archivePlan(s.agentDir, s.projectGlob, p)
+}
+
 func (s diskStore) batchSetStatus(paths []string, status string) tea.Cmd {
 	return batchSetStatus(s.agentDir, s.projectGlob, paths, status)
 }
@@ -283,7 +372,11 @@ func (s diskStore) batchUpdateLabels(paths []string, add []string, remove []stri
 	return batchUpdateLabels(s.agentDir, s.projectGlob, paths, add, remove)
 }
 
-// watchDir watches the plans directory for .md file changes.
+func (s diskStore) generateTitles(paths []string) tea.Cmd {
+	return generateTitles(s.agentDir, s.projectGlob, paths)
+}
+
+// watchDir watches the plans directory for plan file changes.
 // Sends a fileChangedMsg each time a write/create/remove is detected,
 // with a small debounce to coalesce rapid writes.
 func watchDir(watcher *fsnotify.Watcher) tea.Cmd {
@@ -294,9 +387,16 @@ func watchDir(watcher *fsnotify.Watcher) tea.Cmd {
 				if !ok {
 					return nil
 				}
-				if !strings.HasSuffix(ev.Name, ".md") {
+				if !isPlanFile(ev.Name) {
+					// A watched directory going away is reported as a change
+					// with no files, so the model checks the plans directory.
+					if ev.Has(fsnotify.Remove) || ev.Has(fsnotify.Rename) {
+						logger.Debug("watch dir removed", "path", ev.Name)
+						return fileChangedMsg{}
+					}
 					continue
 				}
+				logger.Debug("watch event", "op", ev.Op.String(), "path", ev.Name)
 				if ev.Has(fsnotify.Write) || ev.Has(fsnotify.Create) || ev.Has(fsnotify.Remove) {
 					changed := map[string]bool{ev.Name: true}
 					time.Sleep(100 * time.Millisecond)
@@ -307,7 +407,7 @@ func watchDir(watcher *fsnotify.Watcher) tea.Cmd {
 							if !ok {
 								break drain
 							}
-							if strings.HasSuffix(extra.Name, ".md") {
+							if isPlanFile(extra.Name) {
 								changed[extra.Name] = true
 							}
 						default:
@@ -316,6 +416,7 @@ func watchDir(watcher *fsnotify.Watcher) tea.Cmd {
 					}
 					// Skip events caused by our own writes (status/project changes)
 					if time.Since(time.UnixMilli(lastSelfWrite.Load())) < 500*time.Millisecond {
+						logger.Debug("watch skipped self-write", "files", len(changed))
 						continue
 					}
 					files := make([]string, 0, len(changed))
@@ -324,10 +425,11 @@ func watchDir(watcher *fsnotify.Watcher) tea.Cmd {
 					}
 					return fileChangedMsg{files: files}
 				}
-			case _, ok := <-watcher.Errors:
+			case err, ok := <-watcher.Errors:
 				if !ok {
 					return nil
 				}
+				logger.Error("watch error", "err", err)
 			}
 		}
 	}
@@ -86,6 +86,24 @@ func TestBatchSetStatus(t *testing.T) {
 	}
 }
 
+func TestBatchSetStatusFailureChangesNothing(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "plan-a.md")
+	writeFile(t, a, "# Plan A\n")
+
+	msg := batchSetStatus(dir, "", []string{a, filepath.Join(dir, "gone.md")}, "active")()
+	e, ok := msg.(errMsg)
+	if !ok {
+		t.Fatalf("expected errMsg, got %T", msg)
+	}
+	if !strings.Contains(e.err.Error(), "gone.md") || !strings.Contains(e.err.Error(), "no plans changed") {
+		t.Errorf("err = %v", e.err)
+	}
+	if data, _ := os.ReadFile(a); string(data) != "# Plan A\n" {
+		t.Errorf("plan-a.md changed: %q", data)
+	}
+}
+
 func TestBatchUpdateLabels(t *testing.T) {
 	dir := t.TempDir()
 
@@ -178,3 +196,33 @@ func TestReloadAllPlansEmptyForMissingDir(t *testing.T) {
 		t.Fatalf("expected 0 plans, got %d", len(reload.plans))
 	}
 }
+
+func TestGenerateTitles(t *testing.T) {
+	dir := t.TempDir()
+	untitled := filepath.Join(dir, "plan-a.md")
+	titled := filepath.Join(dir, "plan-b.md")
+	writeFile(t, untitled, "---\nstatus: active\n---\nRefactor the cache layer. More detail follows.\n")
+	writeFile(t, titled, "# Existing\n\nBody\n")
+
+	msg := generateTitles(dir, "", []string{untitled, titled})()
+	done, ok := msg.(batchDoneMsg)
+	if !ok {
+		t.Fatalf("expected batchDoneMsg, got %T", msg)
+	}
+	if done.message != "1 titles generated (1 skipped)" {
+		t.Errorf("message = %q", done.message)
+	}
+	data, _ := os.ReadFile(untitled)
+	fields, body := parseFrontmatter(string(data))
+	if fields["status"] != "active" {
+		t.Errorf("frontmatter lost: %v", fields)
+	}
+	if !strings.HasPrefix(body, "# Refactor the cache layer\n\n") {
+		t.Errorf("body = %q, want generated heading", body)
+	}
+	for _, p := range done.plans {
+		if p.file == "plan-a.md" && (p.untitled || p.title != "Refactor the cache layer") {
+			t.Errorf("rescanned plan = %+v", p)
+		}
+	}
+}
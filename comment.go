@@ -3,19 +3,19 @@ package main
 import (
 	"fmt"
 	"os"
-	"regexp"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/jakebf/planc/plans"
 )
 
 // ─── Comment Mode Types ──────────────────────────────────────────────────────
 
-var commentRegex = regexp.MustCompile(`^>\s*\*\*\[comment\]:\*\*\s*(.+)$`)
+// commentRegex matches a comment blockquote (see plans.CommentPattern).
+var commentRegex = plans.CommentPattern
 
 type tocEntry struct {
 	level      int    // 1-6 for headings, 0 for comments
@@ -23,6 +23,7 @@ type tocEntry struct {
 	rawLine    int    // line number in raw body (after frontmatter)
 	renderLine int    // line number in glamour-rendered output
 	isComment  bool
+	resolved   bool // comment is marked [resolved]
 }
 
 type commentState struct {
@@ -35,25 +36,19 @@ type commentState struct {
 	commentInput textinput.Model
 	planFile     string
 	rawBody      string // cached raw markdown body (sans frontmatter)
+	jumpLine     int    // raw line to place the ToC cursor at on next load (0 = top)
 }
 
 // bodyHasComments returns true if the markdown body contains any comment blockquotes.
 func bodyHasComments(body string) bool {
-	inFence := false
-	for _, line := range strings.Split(body, "\n") {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "```") {
-			inFence = !inFence
-			continue
-		}
-		if inFence {
-			continue
-		}
-		if commentRegex.MatchString(trimmed) {
-			return true
-		}
-	}
-	return false
+	total, _ := countComments(body)
+	return total > 0
+}
+
+// countComments returns how many comment blockquotes the body has and how
+// many of them are still open (not [resolved]).
+func countComments(body string) (total, open int) {
+	return plans.CountComments(body)
 }
 
 // ─── ToC Extraction ──────────────────────────────────────────────────────────
@@ -81,9 +76,10 @@ func extractToc(rawBody string) []tocEntry {
 		if m := commentRegex.FindStringSubmatch(trimmed); m != nil {
 			toc = append(toc, tocEntry{
 				level:     0,
-				text:      m[1],
+				text:      m[2],
 				rawLine:   i,
 				isComment: true,
+				resolved:  m[1] == "resolved",
 			})
 			continue
 		}
@@ -180,123 +176,29 @@ func computeRenderLines(toc []tocEntry, rendered string) {
 
 // injectComment inserts a comment blockquote after the given heading line.
 func injectComment(rawBody string, headingLine int, text string) string {
-	lines := strings.Split(rawBody, "\n")
-	if headingLine < 0 || headingLine >= len(lines) {
-		return rawBody
-	}
-
-	comment := fmt.Sprintf("> **[comment]:** %s", text)
-
-	// Insert after the heading line with blank lines for clean formatting
-	var result []string
-	result = append(result, lines[:headingLine+1]...)
-	result = append(result, "")
-	result = append(result, comment)
-	result = append(result, "")
-	if headingLine+1 < len(lines) {
-		// Skip a leading blank line after heading to avoid double blanks
-		rest := lines[headingLine+1:]
-		if len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
-			rest = rest[1:]
-		}
-		result = append(result, rest...)
-	}
-
-	return strings.Join(result, "\n")
+	return plans.InjectComment(rawBody, headingLine, text)
 }
 
 // removeComment removes a comment line and any adjacent blank line.
 func removeComment(rawBody string, commentLine int) string {
-	lines := strings.Split(rawBody, "\n")
-	if commentLine < 0 || commentLine >= len(lines) {
-		return rawBody
-	}
-
-	// Remove the comment line
-	var result []string
-	result = append(result, lines[:commentLine]...)
-
-	// Skip trailing blank line if present
-	rest := lines[commentLine+1:]
-	if len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
-		rest = rest[1:]
-	}
-
-	// Also remove preceding blank line if present
-	if len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
-		result = result[:len(result)-1]
-	}
+	return plans.RemoveComment(rawBody, commentLine)
+}
 
-	result = append(result, rest...)
-	return strings.Join(result, "\n")
+// setCommentResolved rewrites a comment's marker to [resolved] or back to
+// [comment], keeping its text.
+func setCommentResolved(rawBody string, commentLine int, resolved bool) string {
+	return plans.SetCommentResolved(rawBody, commentLine, resolved)
 }
 
-// replaceComment replaces the text of an existing comment in-place.
+// replaceComment replaces the text of an existing comment in-place. An
+// edited comment is open again, even if it had been resolved.
 func replaceComment(rawBody string, commentLine int, newText string) string {
-	lines := strings.Split(rawBody, "\n")
-	if commentLine < 0 || commentLine >= len(lines) {
-		return rawBody
-	}
-
-	lines[commentLine] = fmt.Sprintf("> **[comment]:** %s", newText)
-	return strings.Join(lines, "\n")
+	return plans.ReplaceComment(rawBody, commentLine, newText)
 }
 
 // writeCommentBody writes a new body back to the plan file, preserving frontmatter.
 func writeCommentBody(filePath, newBody string) error {
-	info, err := os.Stat(filePath)
-	if err != nil {
-		return err
-	}
-	perm := info.Mode().Perm()
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return err
-	}
-	existing, _ := parseFrontmatter(string(data))
-
-	var result string
-	if len(existing) > 0 {
-		var buf strings.Builder
-		buf.WriteString("---\n")
-		written := make(map[string]bool)
-		for _, key := range []string{"status", "labels", "project"} {
-			if v, ok := existing[key]; ok && v != "" {
-				fmt.Fprintf(&buf, "%s: %s\n", key, v)
-				written[key] = true
-			}
-		}
-		var extra []string
-		for k := range existing {
-			if !written[k] {
-				extra = append(extra, k)
-			}
-		}
-		if len(extra) > 0 {
-			sortStrings(extra)
-			for _, k := range extra {
-				if v := existing[k]; v != "" {
-					fmt.Fprintf(&buf, "%s: %s\n", k, v)
-				}
-			}
-		}
-		buf.WriteString("---\n")
-		result = buf.String() + newBody
-	} else {
-		result = newBody
-	}
-
-	lastSelfWrite.Store(time.Now().UnixMilli())
-	return os.WriteFile(filePath, []byte(result), perm)
-}
-
-// sortStrings sorts a string slice in-place (avoids import cycle with sort).
-func sortStrings(s []string) {
-	for i := 1; i < len(s); i++ {
-		for j := i; j > 0 && s[j] < s[j-1]; j-- {
-			s[j], s[j-1] = s[j-1], s[j]
-		}
-	}
+	return plans.WriteBody(filePath, newBody)
 }
 
 // ─── Async Commands ──────────────────────────────────────────────────────────
@@ -384,20 +286,8 @@ func renderTocPane(m model, width, height int) string {
 	hintStyle := lipgloss.NewStyle().Foreground(colorDim)
 	var header string
 	if item, ok := m.list.SelectedItem().(plan); ok {
-		var statusIcon, statusLabel string
-		var statusStyle lipgloss.Style
-		switch item.status {
-		case "active":
-			statusIcon, statusLabel, statusStyle = "●", "active", activeStyle
-		case "reviewed":
-			statusIcon, statusLabel, statusStyle = "○", "reviewed", reviewedStyle
-		case "done":
-			statusIcon, statusLabel, statusStyle = "✓", "done", doneStyle
-		default:
-			statusIcon, statusLabel, statusStyle = "·", "new", unsetStyle
-		}
-		header = " " + statusStyle.Render(statusIcon) + " " +
-			hintStyle.Render("s") + " " + statusStyle.Render(statusLabel) +
+		header = " " + styledStatusIcon(item.status) + " " +
+			hintStyle.Render("s") + " " + statusStyle(item.status).Render(statusLabel(item.status)) +
 			hintStyle.Render(" · ")
 		header += hintStyle.Render("l")
 		if len(item.labels) > 0 {
@@ -429,10 +319,15 @@ func renderTocPane(m model, width, height int) string {
 		var line string
 		if entry.isComment {
 			text := truncateForWidth(entry.text, width-6)
+			style := commentStyle
+			if entry.resolved {
+				text = "✓ " + truncateForWidth(entry.text, width-8)
+				style = dimStyle
+			}
 			if isCursor {
-				line = fmt.Sprintf("%s%s", bar, accentStyle.Render("💬 "+text))
+				line = fmt.Sprintf("%s%s", bar, accentStyle.Render(commentIcon()+" "+text))
 			} else {
-				line = fmt.Sprintf("%s%s", bar, commentStyle.Render("💬 "+text))
+				line = fmt.Sprintf("%s%s", bar, style.Render(commentIcon()+" "+text))
 			}
 		} else {
 			indent := strings.Repeat("  ", entry.level-1)
@@ -158,6 +158,43 @@ func TestReplaceComment(t *testing.T) {
 	}
 }
 
+func TestResolveComment(t *testing.T) {
+	body := "# Title\n\n> **[comment]:** Use a queue\n\n## Step\n\n> **[resolved]:** Done already\n\n```\n> **[comment]:** not a comment\n```\n"
+	total, open := countComments(body)
+	if total != 2 || open != 1 {
+		t.Fatalf("countComments = %d, %d; want 2, 1", total, open)
+	}
+
+	toc := extractToc(body)
+	var comments []tocEntry
+	for _, e := range toc {
+		if e.isComment {
+			comments = append(comments, e)
+		}
+	}
+	if len(comments) != 2 || comments[0].resolved || !comments[1].resolved {
+		t.Fatalf("comment entries = %+v", comments)
+	}
+	if comments[1].text != "Done already" {
+		t.Errorf("resolved text = %q", comments[1].text)
+	}
+
+	resolved := setCommentResolved(body, comments[0].rawLine, true)
+	if !strings.Contains(resolved, "> **[resolved]:** Use a queue") {
+		t.Errorf("comment not resolved:\n%s", resolved)
+	}
+	if _, open := countComments(resolved); open != 0 {
+		t.Errorf("open after resolve = %d", open)
+	}
+	reopened := setCommentResolved(resolved, comments[1].rawLine, false)
+	if !strings.Contains(reopened, "> **[comment]:** Done already") {
+		t.Errorf("comment not reopened:\n%s", reopened)
+	}
+	if got := setCommentResolved(body, 0, true); got != body {
+		t.Error("resolving a non-comment line should be a no-op")
+	}
+}
+
 func TestHeadingWords(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ─── planc completion ────────────────────────────────────────────────────────
+//
+// `planc completion bash|zsh|fish` prints a completion script. The scripts
+// are thin: on every tab they run `planc completion --complete -- WORDS...`
+// with the words typed so far, and planc answers with candidates for the
+// last one, so plan names and labels come from a fresh scan. No candidates
+// means the shell completes file names, as for -o and plan paths.
+
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionFlags lists each command's flags; "" is planc itself. Its keys
+// are the commands completed after planc: the subcommands and open.
+var completionFlags = map[string][]string{
+	"":               {"--help", "--version", "--setup", "--demo", "--archived", "--label", "--status", "--config", "--log-file"},
+	"archive":        {"--days", "--dry-run"},
+	"batch":          {"--filter", "--set-status", "--add-label", "--remove-label", "--dry-run"},
+	"capture":        {"--dir"},
+	"cat":            {"--width", "--style"},
+	"comments-count": {},
+	"completion":     {},
+	"csv":            {"-o"},
+	"digest":         {"--since"},
+	"export":         {"--format", "--status", "--label", "-o"},
+	"feed":           {"-n", "-o"},
+	"grep":           {"-i", "-F", "--json"},
+	"ical":           {"--alarm", "-o"},
+	"import":         {"--dry-run"},
+	"labels":         {},
+	"lint":           {},
+	"list":           {"--json", "--status", "--label"},
+	"log":            {},
+	"mcp":            {},
+	"migrate":        {"--dry-run"},
+	"new":            {"--label", "--status", "--template", "--var", "--dir", "--random-name", "--list-templates"},
+	"open":           {},
+	"prune":          {"--days", "--dry-run"},
+	"serve":          {"--port"},
+	"set":            {"--status", "--add-label", "--remove-label"},
+	"stats":          {"--json", "--weeks"},
+	"status":         {},
+	"summary":        {"--line"},
+	"title":          {},
+	"watch":          {"--json"},
+}
+
+// completionBoolFlags don't take a value.
+var completionBoolFlags = []string{"--help", "--version", "--setup", "--demo", "--archived", "--json", "--dry-run", "--random-name", "--list-templates", "--line", "-i", "-F"}
+
+// completionPlanArgs take plans as arguments.
+var completionPlanArgs = []string{"open", "cat", "set", "export", "log", "title", "status", "labels", "comments-count"}
+
+var exportFormatNames = []string{"md", "html", "pdf", "review", "csv", "json", "site"}
+
+// completeArgs returns the candidates for the last of words, the arguments
+// typed after planc. Plans are only scanned when needed.
+func completeArgs(cfg config, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	cur := words[len(words)-1]
+	scanned := false
+	var all []plan
+	plans := func() []plan {
+		if !scanned {
+			all, _ = scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+			scanned = true
+		}
+		return all
+	}
+
+	var candidates []string
+	cmd := ""
+	if len(words) > 1 && !strings.HasPrefix(words[0], "-") {
+		cmd = words[0]
+	}
+	prev := ""
+	if len(words) > 1 {
+		prev = words[len(words)-2]
+	}
+	switch {
+	case strings.HasPrefix(prev, "-") && !slices.Contains(completionBoolFlags, prev):
+		candidates = completeFlagValue(cfg, prev, plans)
+	case strings.HasPrefix(cur, "-"):
+		candidates = completionFlags[cmd]
+	case len(words) == 1:
+		for name := range completionFlags {
+			if name != "" {
+				candidates = append(candidates, name)
+			}
+		}
+		candidates = append(candidates, planNames(plans())...)
+	case cmd == "completion":
+		candidates = completionShells
+	case slices.Contains(completionPlanArgs, cmd):
+		candidates = planNames(plans())
+	}
+
+	var out []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, cur) {
+			out = append(out, c)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// completeFlagValue returns the candidates for a flag's value; none for
+// free-form values and files.
+func completeFlagValue(cfg config, flag string, plans func() []plan) []string {
+	switch flag {
+	case "--status", "--set-status":
+		return statusNames()
+	case "--label", "--add-label", "--remove-label":
+		return recentLabels(plans())
+	case "--format":
+		return exportFormatNames
+	case "--style":
+		return catStyles
+	case "--template":
+		dir, err := templatesDir(cfg)
+		if err != nil {
+			return nil
+		}
+		tmpls, _ := loadTemplates(dir)
+		names := make([]string, len(tmpls))
+		for i, t := range tmpls {
+			names[i] = t.name
+		}
+		return names
+	}
+	return nil
+}
+
+// planNames returns the plans' file names without .md, once each.
+func planNames(plans []plan) []string {
+	var names []string
+	for _, p := range plans {
+		name := strings.TrimSuffix(p.file, ".md")
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+const bashCompletion = `# bash completion for planc
+# Load it with: source <(planc completion bash)
+_planc() {
+    local IFS=$'\n'
+    COMPREPLY=($(planc completion --complete -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _planc planc
+`
+
+const zshCompletion = `#compdef planc
+# zsh completion for planc
+# Load it with: source <(planc completion zsh), or save it as _planc in $fpath
+_planc() {
+    local -a candidates
+    candidates=(${(f)"$(planc completion --complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    if (( ${#candidates} )); then
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+if [[ $zsh_eval_context[-1] == loadautofunc ]]; then
+    _planc "$@"
+else
+    compdef _planc planc
+fi
+`
+
+const fishCompletion = `# fish completion for planc
+# Load it with: planc completion fish | source, or save it to ~/.config/fish/completions/planc.fish
+function __planc_complete
+    set -l words (commandline -opc)[2..-1] (commandline -ct)
+    set -l candidates (planc completion --complete -- $words 2>/dev/null)
+    if test (count $candidates) -eq 0
+        __fish_complete_path (commandline -ct)
+    else
+        printf '%s\n' $candidates
+    end
+end
+complete -c planc -f -a '(__planc_complete)'
+`
+
+var completionScripts = map[string]string{"bash": bashCompletion, "zsh": zshCompletion, "fish": fishCompletion}
+
+func runCompletion(cfg config, args []string) int {
+	if len(args) > 0 && args[0] == "--complete" {
+		words := args[1:]
+		if len(words) > 0 && words[0] == "--" {
+			words = words[1:]
+		}
+		for _, c := range completeArgs(cfg, words) {
+			fmt.Println(c)
+		}
+		return 0
+	}
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: planc completion bash|zsh|fish")
+		return 2
+	}
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return cliError("completion: unknown shell %q (want bash, zsh, or fish)", args[0])
+	}
+	io.WriteString(os.Stdout, script)
+	return 0
+}
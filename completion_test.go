@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompletionFlagsCoverSubcommands(t *testing.T) {
+	for name := range subcommands {
+		if _, ok := completionFlags[name]; !ok {
+			t.Errorf("completionFlags lacks %q", name)
+		}
+	}
+	for name := range completionFlags {
+		if _, ok := subcommands[name]; !ok && name != "" && name != "open" {
+			t.Errorf("completionFlags has unknown command %q", name)
+		}
+	}
+	for shell := range completionScripts {
+		if !slices.Contains(completionShells, shell) {
+			t.Errorf("completionShells lacks %q", shell)
+		}
+	}
+}
+
+func TestCompleteArgs(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	cfg.TemplatesDir = t.TempDir()
+	writeFile(t, filepath.Join(cfg.PlansDir, "humming-narwhal.md"), "---\nlabels: infra, api\n---\n# Hum\n")
+	writeFile(t, filepath.Join(cfg.PlansDir, "setup-ci.md"), "# CI\n")
+	writeTemplate(t, cfg.TemplatesDir, "bug.md", bugTemplate)
+
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{[]string{"se"}, "serve,set,setup-ci"},
+		{[]string{"--v"}, "--version"},
+		{[]string{"open", "hum"}, "humming-narwhal"},
+		{[]string{"set", "humming-narwhal", "--add-label", ""}, "api,infra"},
+		{[]string{"set", "humming-narwhal", "--st"}, "--status"},
+		{[]string{"list", "--status", "a"}, "active"},
+		{[]string{"list", "--json", ""}, ""},
+		{[]string{"export", "--format", "s"}, "site"},
+		{[]string{"new", "--template", ""}, "bug"},
+		{[]string{"csv", "-o", ""}, ""}, // files, from the shell
+		{[]string{"completion", "z"}, "zsh"},
+		{[]string{"new", ""}, ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(completeArgs(cfg, tt.words), ","); got != tt.want {
+			t.Errorf("completeArgs(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
@@ -5,26 +5,48 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
 )
 
 // ─── Config ──────────────────────────────────────────────────────────────────
 
 type config struct {
-	PlansDir        string   `json:"plans_dir"`                    // path to agent plans directory
-	ProjectPlanGlob string   `json:"project_plans_glob,omitempty"` // glob pattern for project plan directories
-	Primary         []string `json:"primary"`                      // enter: main AI assistant
-	Editor          []string `json:"editor"`                       // e: text editor
-	PromptPrefix    string   `json:"prompt_prefix"`                // prefix for primary command path arg
-	EditorMode      string   `json:"editor_mode,omitempty"`        // "background", "foreground", or "" (auto)
-	ShowAll         bool     `json:"show_all,omitempty"`           // persist active vs all filter
-	Installed       string   `json:"installed,omitempty"`          // RFC3339 timestamp of first setup
+	PlansDir         string            `json:"plans_dir"`                    // path to agent plans directory
+	ProjectPlanGlob  string            `json:"project_plans_glob,omitempty"` // glob pattern for project plan directories
+	RecursiveScan    bool              `json:"recursive_scan,omitempty"`     // also scan subdirectories of plans_dir
+	PlanFiles        []string          `json:"plan_files,omitempty"`         // plan file globs or extensions (default *.md)
+	Primary          []string          `json:"primary"`                      // enter: main AI assistant
+	Editor           []string          `json:"editor"`                       // e: text editor
+	PromptPrefix     string            `json:"prompt_prefix"`                // prefix for primary command path arg
+	PromptTemplate   string            `json:"prompt_template,omitempty"`    // P: plan wrapped for web-based agents
+	TemplatesDir     string            `json:"templates_dir,omitempty"`      // plan templates (default: templates/ next to config.json)
+	Glyphs           map[string]string `json:"glyphs,omitempty"`             // status/comment/selection glyph overrides
+	Statuses         []statusDef       `json:"statuses,omitempty"`           // status vocabulary in cycle order (default new, reviewed, active, done)
+	Locale           string            `json:"locale,omitempty"`             // UI language, e.g. "es" (default: LC_ALL/LC_MESSAGES/LANG)
+	PrimaryMode      string            `json:"primary_mode,omitempty"`       // "background" runs c detached with its output shown by L, or "" (foreground)
+	Shell            string            `json:"shell,omitempty"`              // shell for c/e: "cmd", "powershell", "pwsh", or a POSIX shell (default: cmd on Windows, $SHELL)
+	EditorMode       string            `json:"editor_mode,omitempty"`        // "background", "foreground", or "" (auto)
+	ShowAll          bool              `json:"show_all,omitempty"`           // persist active vs all filter
+	ShowTokens       bool              `json:"show_tokens,omitempty"`        // show token estimate in list rows
+	ShowReadingTime  bool              `json:"show_reading_time,omitempty"`  // show reading time in list rows
+	AgeColors        bool              `json:"age_colors,omitempty"`         // tint list rows by time since last modification
+	ShowMetadata     bool              `json:"show_metadata,omitempty"`      // show every frontmatter key above the preview
+	RemindAfterDays  int               `json:"remind_after_days,omitempty"`  // startup reminder for active plans untouched this long (0 = 14, -1 = off)
+	ArchiveAfterDays int               `json:"archive_after_days,omitempty"` // Z and planc archive move done plans untouched this long (0 = 30)
+	AutoTitle        bool              `json:"auto_title,omitempty"`         // write derived titles for untitled plans at startup
+	LintSections     []string          `json:"lint_sections,omitempty"`      // headings every plan should have (completeness lint)
+	LabelsAsList     bool              `json:"labels_as_list,omitempty"`     // write YAML labels as a "- item" sequence
+	SortByModified   bool              `json:"sort_by_modified,omitempty"`   // list date column and order use modification time
+	SortBy           string            `json:"sort_by,omitempty"`            // group keys before the date, e.g. "status,label"
+	EmbeddingURL     string            `json:"embedding_url,omitempty"`      // Ollama/OpenAI-style embeddings endpoint for semantic search
+	EmbeddingModel   string            `json:"embedding_model,omitempty"`    // model name sent to the embeddings endpoint
+	Installed        string            `json:"installed,omitempty"`          // RFC3339 timestamp of first setup
 }
 
 func defaultPlansDir() string {
@@ -47,7 +69,41 @@ func newDefaultConfig() config {
 	}
 }
 
+// configEnv names the environment variable that points planc at another
+// config file. --config PATH sets it, so the setup wizard and everything
+// else planc runs use the same file.
+const configEnv = "PLANC_CONFIG"
+
+// extractConfigFlag removes --config PATH / --config=PATH from args and
+// returns the path ("" if absent) and the remaining args. ok is false if
+// --config is missing its path.
+func extractConfigFlag(args []string) (path string, rest []string, ok bool) {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if v, found := strings.CutPrefix(a, "--config="); found {
+			path = v
+			continue
+		}
+		if a == "--config" {
+			if i+1 >= len(args) {
+				return "", nil, false
+			}
+			path = args[i+1]
+			i++
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return path, rest, true
+}
+
+// configPath returns the config file: $PLANC_CONFIG, else config.json in
+// planc's directory under the user config directory. Other state kept next
+// to config.json (templates, update checks, crash reports) follows it.
 func configPath() (string, error) {
+	if path := os.Getenv(configEnv); path != "" {
+		return filepath.Abs(expandHome(path))
+	}
 	cfgDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", fmt.Errorf("cannot determine config directory: %w", err)
@@ -57,14 +113,7 @@ func configPath() (string, error) {
 
 // expandHome expands a leading "~/" to the user's home directory.
 func expandHome(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return path
-		}
-		return filepath.Join(home, path[2:])
-	}
-	return path
+	return plans.ExpandHome(path)
 }
 
 // contractHome replaces the user's home directory prefix with "~/" for display.
@@ -129,7 +178,30 @@ func loadConfig() config {
 	return cfg
 }
 
+// applyConfigGlobals installs the package state cfg controls: list and sort
+// settings, the plan file patterns, statuses, glyphs, shell, and locale. It
+// runs at startup, before subcommands, and when the config is reloaded.
+func applyConfigGlobals(cfg config) {
+	labelsAsList.Store(cfg.LabelsAsList)
+	sortByModified.Store(cfg.SortByModified)
+	recursiveScan.Store(cfg.RecursiveScan)
+	setPlanFiles(cfg.PlanFiles)
+	setSortKeys(parseSortKeys(cfg.SortBy))
+	setStatuses(cfg.Statuses) // before setGlyphs, which pads to the status icons
+	setGlyphs(cfg.Glyphs)
+	commandShell.Store(cfg.Shell)
+	setLocale(cfg.Locale)
+}
+
+// scratchSession is set when the session's plans directory isn't the
+// configured one (`planc -` and `planc --archived`), so the config is left
+// alone.
+var scratchSession atomic.Bool
+
 func saveConfig(path string, cfg config) error {
+	if scratchSession.Load() {
+		return nil
+	}
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
@@ -200,7 +272,7 @@ func showWelcome(scanner *bufio.Scanner) {
 	}
 	fmt.Println()
 	time.Sleep(400 * time.Millisecond)
-	fmt.Println(dim.Render("  A tiny TUI for browsing and annotating AI agent plans."))
+	fmt.Println(dim.Render("  " + tr("A tiny TUI for browsing and annotating AI agent plans.")))
 	fmt.Println()
 
 	time.Sleep(400 * time.Millisecond)
@@ -213,11 +285,11 @@ func showWelcome(scanner *bufio.Scanner) {
 	fmt.Println("  " + key.Render("n/p") + dim.Render("   next/prev   ") + key.Render("?") + dim.Render(" all keybindings"))
 	fmt.Println()
 	time.Sleep(200 * time.Millisecond)
-	fmt.Println(dim.Render("  Status and labels are stored as YAML frontmatter."))
-	fmt.Println(dim.Render("  Plans with no user action are not modified at all."))
+	fmt.Println(dim.Render("  " + tr("Status and labels are stored as YAML frontmatter.")))
+	fmt.Println(dim.Render("  " + tr("Plans with no user action are not modified at all.")))
 	fmt.Println()
 
-	fmt.Print(dim.Render("  Press enter to continue to setup..."))
+	fmt.Print(dim.Render("  " + tr("Press enter to continue to setup...")))
 	scanner.Scan()
 	fmt.Println()
 }
@@ -229,12 +301,14 @@ func runSetup(path string, current config, scanner *bufio.Scanner) config {
 		scanner = bufio.NewScanner(os.Stdin)
 	}
 
-	fmt.Println(promptStyle.Render("  planc setup"))
-	fmt.Println(dimStyle.Render("  Press enter to keep the current value."))
+	fmt.Println(promptStyle.Render("  " + tr("planc setup")))
+	fmt.Println(dimStyle.Render("  " + tr("Press enter to keep the current value.")))
 	fmt.Println()
 
-	prompt := func(label, defVal string) string {
-		fmt.Printf("%s %s: ", promptStyle.Render(label), dimStyle.Render("["+defVal+"]"))
+	// label pads a prompt label so the answers line up.
+	label := func(s string) string { return fmt.Sprintf("%-24s", tr(s)) }
+	prompt := func(name, defVal string) string {
+		fmt.Printf("%s %s: ", promptStyle.Render(label(name)), dimStyle.Render("["+defVal+"]"))
 		if scanner.Scan() {
 			if line := strings.TrimSpace(scanner.Text()); line != "" {
 				return line
@@ -246,18 +320,18 @@ func runSetup(path string, current config, scanner *bufio.Scanner) config {
 	cfg := current
 
 	// Agent plans path
-	fmt.Println(dimStyle.Render("  Primary directory to scan for .md plan files."))
-	cfg.PlansDir = expandHome(prompt("Agent plans path        ", current.PlansDir))
+	fmt.Println(dimStyle.Render("  " + tr("Primary directory to scan for .md plan files.")))
+	cfg.PlansDir = expandHome(prompt("Agent plans path", current.PlansDir))
 	fmt.Println()
 
 	// Additional plans glob
-	fmt.Println(dimStyle.Render("  Scan additional directories for plans, e.g. per-project plans/"))
-	fmt.Println(dimStyle.Render("  folders. Use ** to match across projects: ~/code/**/plans"))
+	fmt.Println(dimStyle.Render("  " + tr("Scan additional directories for plans, e.g. per-project plans/")))
+	fmt.Println(dimStyle.Render("  " + tr("folders. Use ** to match across projects: ~/code/**/plans")))
 	projectDefault := current.ProjectPlanGlob
 	if projectDefault == "" {
-		fmt.Printf("%s %s: ", promptStyle.Render("Additional plans (glob) "), dimStyle.Render("[]"))
+		fmt.Printf("%s %s: ", promptStyle.Render(label("Additional plans (glob)")), dimStyle.Render("[]"))
 	} else {
-		fmt.Printf("%s %s: ", promptStyle.Render("Additional plans (glob) "), dimStyle.Render("["+projectDefault+"]")+" "+dimStyle.Render(`"none" to clear`))
+		fmt.Printf("%s %s: ", promptStyle.Render(label("Additional plans (glob)")), dimStyle.Render("["+projectDefault+"]")+" "+dimStyle.Render(tr(`"none" to clear`)))
 	}
 	if scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -273,25 +347,25 @@ func runSetup(path string, current config, scanner *bufio.Scanner) config {
 	fmt.Println()
 
 	// Editor command
-	fmt.Println(dimStyle.Render("  Command to open a plan for editing (e key)."))
-	cfg.Editor = splitShellWords(prompt("Editor command          ", strings.Join(current.Editor, " ")))
+	fmt.Println(dimStyle.Render("  " + tr("Command to open a plan for editing (e key).")))
+	cfg.Editor = splitShellWords(prompt("Editor command", strings.Join(current.Editor, " ")))
 	fmt.Println()
 
 	// Coding agent command
-	fmt.Println(dimStyle.Render("  Command to send a plan to your coding agent (c key)."))
-	fmt.Println(dimStyle.Render("  The plan path is appended as the last argument."))
-	cfg.Primary = splitShellWords(prompt("Coding agent command    ", strings.Join(current.Primary, " ")))
+	fmt.Println(dimStyle.Render("  " + tr("Command to send a plan to your coding agent (c key).")))
+	fmt.Println(dimStyle.Render("  " + tr("The plan path is appended as the last argument.")))
+	cfg.Primary = splitShellWords(prompt("Coding agent command", strings.Join(current.Primary, " ")))
 	fmt.Println()
 
 	// Prompt prefix
-	fmt.Println(dimStyle.Render("  Text prepended to the plan path when passed to the coding agent."))
-	cfg.PromptPrefix = prompt("Prompt prefix           ", current.PromptPrefix)
+	fmt.Println(dimStyle.Render("  " + tr("Text prepended to the plan path when passed to the coding agent.")))
+	cfg.PromptPrefix = prompt("Prompt prefix", current.PromptPrefix)
 	fmt.Println()
 
 	if err := saveConfig(path, cfg); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not save config: %v\n", err)
+		fmt.Fprintln(os.Stderr, trf("Warning: could not save config: %v", err))
 	} else {
-		fmt.Printf("%s %s\n\n", dimStyle.Render("Saved to"), path)
+		fmt.Printf("%s %s\n\n", dimStyle.Render(tr("Saved to")), path)
 	}
 	return cfg
 }
@@ -354,8 +428,7 @@ func isTerminalEditor(cmd []string) bool {
 	if len(cmd) == 0 {
 		return false
 	}
-	base := filepath.Base(cmd[0])
-	switch base {
+	switch strings.ToLower(commandBase(cmd[0])) {
 	case "vim", "vi", "nvim", "nano", "emacs", "hx", "micro":
 		return true
 	}
@@ -379,32 +452,5 @@ func commandLabel(cmd []string) string {
 	if len(cmd) == 0 {
 		return "unknown"
 	}
-	return filepath.Base(cmd[0])
-}
-
-// shellQuote returns a quoted shell string appropriate for the current platform.
-func shellQuote(s string) string {
-	if runtime.GOOS == "windows" {
-		// cmd.exe double-quote escaping: double any internal quotes.
-		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
-	}
-	return "'" + strings.ReplaceAll(s, "'", "'\"'\"'") + "'"
-}
-
-// shellCommand builds an exec.Cmd that runs args through the user's shell.
-// On Unix, uses $SHELL -ic for interactive mode (aliases, rc files).
-// On Windows, uses cmd.exe /C.
-func shellCommand(args ...string) *exec.Cmd {
-	quoted := make([]string, len(args))
-	for i, a := range args {
-		quoted[i] = shellQuote(a)
-	}
-	if runtime.GOOS == "windows" {
-		return exec.Command("cmd", append([]string{"/C"}, quoted...)...)
-	}
-	shell := os.Getenv("SHELL")
-	if shell == "" {
-		shell = "sh"
-	}
-	return exec.Command(shell, "-ic", strings.Join(quoted, " "))
+	return commandBase(cmd[0])
 }
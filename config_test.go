@@ -8,6 +8,13 @@ import (
 	"testing"
 )
 
+func TestMain(m *testing.M) {
+	// Tests point XDG_CONFIG_HOME at temp dirs; $PLANC_CONFIG would win and
+	// send their writes to the developer's own config file.
+	os.Unsetenv(configEnv)
+	os.Exit(m.Run())
+}
+
 func TestExpandCommand(t *testing.T) {
 	// With {file} placeholder — expands in place, no extra arg
 	args := []string{"claude", "--file", "{file}", "--verbose"}
@@ -63,6 +70,28 @@ func TestExpandHome(t *testing.T) {
 	}
 }
 
+func TestConfigFlagAndEnv(t *testing.T) {
+	path, rest, ok := extractConfigFlag([]string{"list", "--config=/tmp/work.json", "--json"})
+	if path != "/tmp/work.json" || strings.Join(rest, " ") != "list --json" || !ok {
+		t.Errorf("extractConfigFlag = %q, %q, %v", path, rest, ok)
+	}
+	if _, _, ok := extractConfigFlag([]string{"--config"}); ok {
+		t.Error("--config without a path accepted")
+	}
+
+	work := filepath.Join(t.TempDir(), "work.json")
+	t.Setenv(configEnv, work)
+	if got, err := configPath(); err != nil || got != work {
+		t.Errorf("configPath with $%s = %q, %v", configEnv, got, err)
+	}
+	if err := saveConfig(work, config{PlansDir: "/work/plans"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := loadConfigRaw(); cfg.PlansDir != "/work/plans" {
+		t.Errorf("PlansDir = %q from $%s", cfg.PlansDir, configEnv)
+	}
+}
+
 func TestLoadConfigSetsInstalledAndExpandsHome(t *testing.T) {
 	cfgRoot := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", cfgRoot)
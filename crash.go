@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Crash Recovery ──────────────────────────────────────────────────────────
+//
+// Bubble Tea already catches panics in Update, View, and commands and
+// restores the terminal, but all it leaves behind is a stack trace printed
+// into a scrolled-away screen. crashGuard wraps the model to record the panic,
+// its stack, the model's shape, and the most recent message types, and main
+// writes them to a crash report in the config directory once the terminal is
+// back. The report is redacted: it holds counts, modes, and Go type names,
+// never plan paths, titles, or content.
+
+// recentMsgLimit is how many message types a crash report lists.
+const recentMsgLimit = 20
+
+// crashRecorder collects what a crash report needs. It is shared by every
+// copy of crashGuard and by the command goroutines, so it is locked.
+type crashRecorder struct {
+	mu     sync.Mutex
+	recent []string // type names of the last messages, oldest first
+	state  string   // model summary as of the last message
+	panic  string   // panic value, "" until a panic is caught
+	stack  []byte
+}
+
+// note records msg's type before it is handled.
+func (r *crashRecorder) note(msg tea.Msg) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recent = append(r.recent, fmt.Sprintf("%T", msg))
+	if len(r.recent) > recentMsgLimit {
+		r.recent = r.recent[len(r.recent)-recentMsgLimit:]
+	}
+}
+
+// snapshot records the model's state after a message was handled.
+func (r *crashRecorder) snapshot(m model) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state = m.crashState()
+}
+
+// capture records the first panic; later ones are usually fallout.
+func (r *crashRecorder) capture(v any, stack []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.panic == "" {
+		r.panic = fmt.Sprint(v)
+		r.stack = stack
+	}
+}
+
+// crashed reports whether a panic was captured.
+func (r *crashRecorder) crashed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.panic != ""
+}
+
+// report renders the crash report text.
+func (r *crashRecorder) report(now time.Time) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	fmt.Fprintf(&b, "planc %s crash report — %s\n\n", getVersion(), now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %s\n\n", r.panic)
+	fmt.Fprintf(&b, "state:\n%s\n", r.state)
+	b.WriteString("recent messages (oldest first):\n")
+	for _, t := range r.recent {
+		b.WriteString("  " + t + "\n")
+	}
+	fmt.Fprintf(&b, "\nstack:\n%s", r.stack)
+	return b.String()
+}
+
+// write saves the report to dir as crash-<time>.txt and returns its path.
+func (r *crashRecorder) write(dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405")+".txt")
+	return path, os.WriteFile(path, []byte(r.report(now)), 0644)
+}
+
+// crashDir returns the directory crash reports are written to: the config
+// directory, or the temp directory if that can't be determined.
+func crashDir() string {
+	if path, err := configPath(); err == nil {
+		return filepath.Dir(path)
+	}
+	return os.TempDir()
+}
+
+// crashState summarizes the model for a crash report without plan data.
+func (m model) crashState() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "  size: %dx%d ready=%v\n", m.width, m.height, m.ready)
+	fmt.Fprintf(&b, "  plans: %d (demo %d), visible: %d, selected: %d\n",
+		len(m.allPlans), len(m.demo.plans), len(m.list.Items()), len(m.selected))
+	fmt.Fprintf(&b, "  list index: %d, preview cache: %d, focused pane: %d\n",
+		m.list.Index(), len(m.previewCache), m.focused)
+	modes := map[string]bool{
+		"demo": m.demo.active, "comment": m.comment.active, "commentEditing": m.comment.editing,
+		"todo": m.todo.active, "related": m.related.active, "supersede": m.supersede.active, "metadata": m.metadata.active, "history": m.showHistory, "labels": m.settingLabels, "status": m.settingStatus,
+		"title": m.settingTitle, "rename": m.renaming, "fork": m.forking, "move": m.move.active, "dedupe": m.dedupe.active, "trash": m.trash.active, "field": m.settingField, "newPlan": m.creatingPlan,
+		"sort": m.settingSort, "due": m.settingDue, "info": m.showInfo, "confirmDelete": m.confirmDelete,
+		"filtering": m.list.SettingFilter(), "showDone": m.showDone,
+	}
+	var on []string
+	for name, active := range modes {
+		if active {
+			on = append(on, name)
+		}
+	}
+	slices.Sort(on)
+	fmt.Fprintf(&b, "  modes: %s\n", strings.Join(on, ", "))
+	fmt.Fprintf(&b, "  group: %q, label filter set: %v, source filter set: %v, owner filter set: %v\n",
+		m.groupBy, m.labelFilter != "", m.sourceFilter != "", m.ownerFilter != "")
+	return b.String()
+}
+
+// crashGuard wraps model so panics in Update, View, and the commands Update
+// returns are recorded before Bubble Tea's own recovery restores the
+// terminal.
+type crashGuard struct {
+	model
+	rec *crashRecorder
+}
+
+// recoverInto re-panics after recording the panic, so Bubble Tea still
+// shuts down and restores the terminal. Must be deferred directly.
+func (r *crashRecorder) recoverInto() {
+	if v := recover(); v != nil {
+		r.capture(v, debug.Stack())
+		panic(v)
+	}
+}
+
+func (g crashGuard) Init() tea.Cmd {
+	defer g.rec.recoverInto()
+	return g.rec.guard(g.model.Init())
+}
+
+func (g crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.rec.recoverInto()
+	g.rec.note(msg)
+	next, cmd := g.model.Update(msg)
+	g.model = next.(model)
+	g.rec.snapshot(g.model)
+	return g, g.rec.guard(cmd)
+}
+
+func (g crashGuard) View() string {
+	defer g.rec.recoverInto()
+	return g.model.View()
+}
+
+// guard wraps cmd so a panic inside it is recorded. Batches returned by a
+// command are guarded too.
+func (r *crashRecorder) guard(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer r.recoverInto()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i := range batch {
+				batch[i] = r.guard(batch[i])
+			}
+		}
+		return msg
+	}
+}
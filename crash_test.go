@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type panicMsg struct{}
+
+func TestCrashGuardRecordsCommandPanic(t *testing.T) {
+	rec := &crashRecorder{}
+	g := crashGuard{model: testModel(), rec: rec}
+	next, _ := g.Update(tea.KeyMsg{Type: tea.KeyDown})
+	g = next.(crashGuard)
+
+	cmd := rec.guard(func() tea.Msg { panic("boom") })
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("guard should re-panic so Bubble Tea restores the terminal")
+			}
+		}()
+		cmd()
+	}()
+	if !rec.crashed() {
+		t.Fatal("panic not captured")
+	}
+
+	report := rec.report(time.Now())
+	for _, want := range []string{"panic: boom", "tea.KeyMsg", "plans: 4", "stack:"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	for _, p := range testPlans() {
+		if strings.Contains(report, p.title) || strings.Contains(report, p.file) {
+			t.Errorf("report leaks plan %q", p.file)
+		}
+	}
+}
+
+func TestCrashGuardRecordsUpdatePanic(t *testing.T) {
+	rec := &crashRecorder{}
+	g := crashGuard{model: testModel(), rec: rec}
+	g.model.store = nil // setStatus on a nil store panics
+
+	func() {
+		defer func() { recover() }()
+		g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	}()
+	if !rec.crashed() {
+		t.Fatal("panic not captured")
+	}
+
+	path, err := rec.write(t.TempDir(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "recent messages (oldest first):\n  tea.KeyMsg\n") {
+		t.Errorf("report = %s", data)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ─── CSV Export ──────────────────────────────────────────────────────────────
+//
+// The plan table as CSV, for sorting and filtering in a spreadsheet.
+// `planc csv` exports every plan; the E export prompt's c exports the
+// selection. Files start with a UTF-8 BOM so Excel detects the encoding.
+
+var csvHeader = []string{"file", "title", "status", "labels", "created", "modified", "due", "owner"}
+
+// planCSV renders plans as CSV. fields returns a plan's frontmatter, for the
+// columns the plan struct doesn't carry (due, owner).
+func planCSV(plans []plan, fields func(plan) map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Write(csvHeader)
+	for _, p := range plans {
+		fm := fields(p)
+		w.Write([]string{
+			p.path(),
+			p.title,
+			exportStatus(p.status),
+			labelsString(p.labels),
+			csvTime(p.created),
+			csvTime(p.modified),
+			fm["due"],
+			fm["owner"],
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// diskFields reads a plan's frontmatter from disk; unreadable plans have none.
+func diskFields(p plan) map[string]string {
+	data, err := os.ReadFile(p.path())
+	if err != nil {
+		return nil
+	}
+	fm, _ := parseFrontmatter(string(data))
+	return fm
+}
+
+func runCSV(cfg config, args []string) int {
+	fs := flag.NewFlagSet("csv", flag.ContinueOnError)
+	output := fs.String("o", "", "write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc csv [-o FILE]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("csv: %v", err)
+	}
+	data, err := planCSV(plans, diskFields)
+	if err != nil {
+		return cliError("csv: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.WriteFile(expandHome(*output), data, 0644); err != nil {
+		return cliError("csv: %v", err)
+	}
+	return 0
+}
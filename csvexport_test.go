@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanCSV(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\nstatus: active\nlabels: api, \"auth\"\ndue: 2026-04-01\nowner: sam\n---\n# Auth, v2\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "# Plain\n")
+	plans, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := planCSV(plans, diskFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, ok := strings.CutPrefix(string(data), "\ufeff")
+	if !ok {
+		t.Error("CSV should start with a UTF-8 BOM")
+	}
+	rows, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+		t.Fatalf("rows = %q", rows)
+	}
+	byFile := map[string][]string{}
+	for _, r := range rows[1:] {
+		byFile[filepath.Base(r[0])] = r
+	}
+	a := byFile["a.md"]
+	if a[1] != "Auth, v2" || a[2] != "active" || a[6] != "2026-04-01" || a[7] != "sam" {
+		t.Errorf("a.md row = %q", a)
+	}
+	if b := byFile["b.md"]; b[2] != "new" || b[6] != "" {
+		t.Errorf("b.md row = %q", b)
+	}
+}
+
+func TestExportPlansCSV(t *testing.T) {
+	dir := t.TempDir()
+	p := plan{dir: "/demo", file: "x.md", title: "X", created: time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)}
+	content := map[string]string{"x.md": "---\nowner: kim\n---\n# X\n"}
+	msg := exportPlans([]plan{p}, content, "csv", dir, time.Now())()
+	done, ok := msg.(exportedMsg)
+	if !ok || !strings.HasSuffix(done.path, ".csv") {
+		t.Fatalf("got %#v", msg)
+	}
+	data, err := os.ReadFile(done.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "2026-01-02 03:04,,,kim") {
+		t.Errorf("csv = %q", data)
+	}
+}
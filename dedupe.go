@@ -0,0 +1,474 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Duplicate Plans ─────────────────────────────────────────────────────────
+//
+// Agents re-plan the same feature more often than anyone supersedes the old
+// plan. = looks for plans with near-identical titles (by word overlap) or
+// bodies (by overlap of three-word shingles), groups them, and shows each
+// group side by side, newest first. The newest plan is kept by default; s
+// marks the others superseded by it and d moves them to the trash, for one
+// group or (S, D) for every group at once. Plans already superseded are left
+// out.
+
+const (
+	dupTitleThreshold = 0.75 // title word overlap that makes two plans duplicates
+	dupBodyThreshold  = 0.7  // body shingle overlap that does
+	dupShingleWords   = 3
+)
+
+// dupStopWords are left out of title comparisons.
+var dupStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "of": true,
+	"to": true, "for": true, "in": true, "on": true, "with": true, "plan": true,
+}
+
+// duplicateGroup is a set of near-identical plans, newest first, and the one
+// to keep.
+type duplicateGroup struct {
+	plans []plan
+	keep  int
+}
+
+// others returns the group's plans other than the one kept.
+func (g duplicateGroup) others() []plan {
+	var out []plan
+	for i, p := range g.plans {
+		if i != g.keep {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// dupWords lowercases s and splits it into words.
+func dupWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// titleWordSet returns the significant words of a title.
+func titleWordSet(title string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range dupWords(title) {
+		if !dupStopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// shingleSet returns the runs of dupShingleWords consecutive words in body.
+func shingleSet(body string) map[string]bool {
+	words := dupWords(body)
+	set := make(map[string]bool)
+	for i := 0; i+dupShingleWords <= len(words); i++ {
+		set[strings.Join(words[i:i+dupShingleWords], " ")] = true
+	}
+	return set
+}
+
+// jaccard returns the overlap of two sets: shared members over all members.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// findDuplicates groups the plans in all whose titles or bodies (from
+// bodies, keyed by path) are near-identical. Groups come in all's order and
+// list their plans newest first.
+func findDuplicates(all []plan, bodies map[string]string) []duplicateGroup {
+	var candidates []plan
+	for _, p := range all {
+		if _, superseded := newerPlan(p, all); !superseded {
+			candidates = append(candidates, p)
+		}
+	}
+	titles := make([]map[string]bool, len(candidates))
+	shingles := make([]map[string]bool, len(candidates))
+	for i, p := range candidates {
+		if !p.untitled {
+			titles[i] = titleWordSet(p.title)
+		}
+		shingles[i] = shingleSet(bodies[p.path()])
+	}
+
+	// Union-find over candidate indices.
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			if isDuplicate(titles[i], titles[j], shingles[i], shingles[j]) {
+				parent[root(j)] = root(i)
+			}
+		}
+	}
+
+	members := make(map[int][]plan)
+	var order []int
+	for i, p := range candidates {
+		r := root(i)
+		if _, ok := members[r]; !ok {
+			order = append(order, r)
+		}
+		members[r] = append(members[r], p)
+	}
+	var groups []duplicateGroup
+	for _, r := range order {
+		if ps := members[r]; len(ps) > 1 {
+			slices.SortStableFunc(ps, func(a, b plan) int { return b.created.Compare(a.created) })
+			groups = append(groups, duplicateGroup{plans: ps})
+		}
+	}
+	return groups
+}
+
+// isDuplicate reports whether two plans' title words or body shingles
+// overlap enough to call them the same plan. Titles need two significant
+// words each, so short generic titles don't match on their own.
+func isDuplicate(titleA, titleB, bodyA, bodyB map[string]bool) bool {
+	if len(titleA) >= 2 && len(titleB) >= 2 && jaccard(titleA, titleB) >= dupTitleThreshold {
+		return true
+	}
+	// Overlap can't exceed the smaller set over the larger, so skip bodies
+	// of very different lengths without comparing them.
+	small, large := min(len(bodyA), len(bodyB)), max(len(bodyA), len(bodyB))
+	if small == 0 || float64(small) < dupBodyThreshold*float64(large) {
+		return false
+	}
+	return jaccard(bodyA, bodyB) >= dupBodyThreshold
+}
+
+// duplicatesMsg carries the result of a duplicate search.
+type duplicatesMsg struct {
+	groups []duplicateGroup
+	bodies map[string]string // plan bodies by path, for the side-by-side view
+}
+
+// scanDuplicates reads the bodies of all (from content, keyed by filename,
+// in demo mode) and groups the duplicates among them.
+func scanDuplicates(all []plan, content map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		bodies := make(map[string]string, len(all))
+		for _, p := range all {
+			if content != nil {
+				bodies[p.path()] = content[p.file]
+				continue
+			}
+			if _, body, err := plans.Read(p.path()); err == nil {
+				bodies[p.path()] = body
+			}
+		}
+		return duplicatesMsg{groups: findDuplicates(all, bodies), bodies: bodies}
+	}
+}
+
+// supersedeDuplicates marks the plans in each group other than the kept one
+// superseded by it. A kept plan that replaces a single other plan, and
+// supersedes nothing yet, names it in supersedes: too.
+func supersedeDuplicates(agentDir, projectGlob string, groups []duplicateGroup) tea.Cmd {
+	updates, paths := duplicateUpdates(groups)
+	return setSupersedeFields(agentDir, projectGlob, updates, paths, dedupeMessage(groups))
+}
+
+// duplicateUpdates returns the supersede keys to write for groups, by path.
+func duplicateUpdates(groups []duplicateGroup) (map[string][2]string, []string) {
+	updates := make(map[string][2]string)
+	var paths []string
+	for _, g := range groups {
+		keep, others := g.plans[g.keep], g.others()
+		for _, o := range others {
+			updates[o.path()] = [2]string{"superseded_by", keep.file}
+			paths = append(paths, o.path())
+		}
+		if len(others) == 1 && keep.supersedes == "" {
+			updates[keep.path()] = [2]string{"supersedes", others[0].file}
+			paths = append(paths, keep.path())
+		}
+	}
+	return updates, paths
+}
+
+func dedupeMessage(groups []duplicateGroup) string {
+	n := 0
+	for _, g := range groups {
+		n += len(g.plans) - 1
+	}
+	return fmt.Sprintf("%d duplicate plans superseded", n)
+}
+
+// deletePlans moves each plan to the trash in turn, stopping at the first
+// error, and rescans.
+func deletePlans(agentDir, projectGlob string, ps []plan) tea.Cmd {
+	return func() tea.Msg {
+		var events []planEvent
+		var err error
+		now := time.Now()
+		for _, p := range ps {
+			var trashed string
+			if trashed, err = plans.Trash(p.path(), now); err != nil {
+				break
+			}
+			events = append(events, planEvent{kind: eventPlanDeleted, path: p.path(), to: trashed})
+		}
+		if err != nil && len(events) == 0 {
+			return errMsg{fmt.Errorf("could not delete file: %w", err)}
+		}
+		all, scanErr := scanAllPlans(agentDir, projectGlob)
+		if scanErr != nil {
+			return errMsg{scanErr}
+		}
+		if err != nil {
+			logger.Error("delete failed", "err", err)
+		}
+		return reloadMsg{plans: all, events: events}
+	}
+}
+
+func (s demoStore) supersedeDuplicates(groups []duplicateGroup) tea.Cmd {
+	updates, paths := duplicateUpdates(groups)
+	plans := *s.plans
+	return func() tea.Msg {
+		updated := slices.Clone(plans)
+		for i, p := range updated {
+			switch u := updates[p.path()]; u[0] {
+			case "supersedes":
+				updated[i].supersedes = u[1]
+			case "superseded_by":
+				updated[i].supersededBy = u[1]
+			}
+		}
+		return batchDoneMsg{plans: updated, files: paths, message: dedupeMessage(groups)}
+	}
+}
+
+func (s demoStore) deletePlans(ps []plan) tea.Cmd {
+	plans := *s.plans
+	return func() tea.Msg {
+		gone := make(map[string]bool, len(ps))
+		var events []planEvent
+		for _, p := range ps {
+			gone[p.path()] = true
+			events = append(events, planEvent{kind: eventPlanDeleted, path: p.path()})
+		}
+		var remaining []plan
+		for _, p := range plans {
+			if !gone[p.path()] {
+				remaining = append(remaining, p)
+			}
+		}
+		return reloadMsg{plans: remaining, events: events}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+type dedupeState struct {
+	active  bool
+	groups  []duplicateGroup
+	bodies  map[string]string
+	group   int    // index of the group on screen
+	confirm string // "d" or "D" while a delete awaits y
+}
+
+// openDedupe starts a duplicate search over every plan.
+func (m *model) openDedupe() tea.Cmd {
+	var content map[string]string
+	if m.demo.active {
+		content = m.demo.content
+	}
+	return tea.Batch(
+		scanDuplicates(slices.Clone(*m.planSource()), content),
+		m.setNotification(tr("Looking for duplicate plans..."), statusTimeout),
+	)
+}
+
+// showDuplicates opens the dedupe view on a search's results.
+func (m *model) showDuplicates(msg duplicatesMsg) tea.Cmd {
+	if len(msg.groups) == 0 {
+		return m.setNotification(tr("No duplicate plans found"), statusTimeout)
+	}
+	m.notification = ""
+	m.dedupe = dedupeState{active: true, groups: msg.groups, bodies: msg.bodies}
+	return nil
+}
+
+// resolveGroups drops the groups at indices from the view, closing it once
+// none are left, and returns them.
+func (m *model) resolveGroups(indices ...int) []duplicateGroup {
+	var done, left []duplicateGroup
+	for i, g := range m.dedupe.groups {
+		if slices.Contains(indices, i) {
+			done = append(done, g)
+		} else {
+			left = append(left, g)
+		}
+	}
+	m.dedupe.groups = left
+	m.dedupe.group = min(m.dedupe.group, max(len(left)-1, 0))
+	m.dedupe.active = len(left) > 0
+	return done
+}
+
+// allGroups returns the indices of every group on view.
+func (m model) allGroups() []int {
+	indices := make([]int, len(m.dedupe.groups))
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+func (m model) handleDedupeKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	d := &m.dedupe
+	if key.Matches(msg, m.keys.ForceQuit) {
+		return m, tea.Quit, true
+	}
+	if d.confirm != "" {
+		confirm := d.confirm
+		d.confirm = ""
+		if msg.String() != "y" {
+			return m, nil, true
+		}
+		indices := []int{d.group}
+		if confirm == "D" {
+			indices = m.allGroups()
+		}
+		var doomed []plan
+		for _, g := range m.resolveGroups(indices...) {
+			doomed = append(doomed, g.others()...)
+		}
+		return m, tea.Batch(
+			m.store.deletePlans(doomed),
+			m.setNotification(trf("Moved %d plans to trash", len(doomed)), statusTimeout),
+		), true
+	}
+	g := &d.groups[d.group]
+	switch msg.String() {
+	case "esc", "q", "=":
+		d.active = false
+	case "j", "down", "tab":
+		d.group = (d.group + 1) % len(d.groups)
+	case "k", "up", "shift+tab":
+		d.group = (d.group - 1 + len(d.groups)) % len(d.groups)
+	case "l", "right":
+		g.keep = min(g.keep+1, len(g.plans)-1)
+	case "h", "left":
+		g.keep = max(g.keep-1, 0)
+	case "s":
+		return m, m.store.supersedeDuplicates(m.resolveGroups(d.group)), true
+	case "S":
+		return m, m.store.supersedeDuplicates(m.resolveGroups(m.allGroups()...)), true
+	case "d", "D":
+		d.confirm = msg.String()
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+// dedupeColumns is how many plans of a group the view shows side by side.
+const dedupeColumns = 3
+
+func (m model) renderDedupeModal() string {
+	d := m.dedupe
+	g := d.groups[d.group]
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := max(m.width-4, 40)
+	contentW := modalW - 8 // helpBoxStyle borders + padding
+	shown := min(len(g.plans), dedupeColumns)
+	start := min(max(g.keep-shown+1, 0), len(g.plans)-shown)
+	colW := max((contentW-2*(shown-1))/shown, 10)
+	bodyLines := max(m.height-20, 3)
+
+	var cols []string
+	for i := start; i < start+shown; i++ {
+		p := g.plans[i]
+		var b strings.Builder
+		if i == g.keep {
+			b.WriteString(accentStyle.Render(tr("keep")) + "\n")
+		} else {
+			b.WriteString(dimStyle.Render(tr("duplicate")) + "\n")
+		}
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(truncateForWidth(p.title, colW)) + "\n")
+		b.WriteString(dimStyle.Render(truncateForWidth(p.file, colW)) + "\n")
+		meta := fmt.Sprintf("%s · %s · %s", p.created.Format("2006-01-02"), statusLabel(p.status), formatWords(p.words))
+		b.WriteString(dimStyle.Render(truncateForWidth(meta, colW)) + "\n\n")
+		n := 0
+		for _, line := range strings.Split(d.bodies[p.path()], "\n") {
+			if n == bodyLines {
+				break
+			}
+			if line = strings.TrimSpace(line); line != "" {
+				b.WriteString(truncateForWidth(line, colW) + "\n")
+				n++
+			}
+		}
+		cols = append(cols, lipgloss.NewStyle().Width(colW).Render(strings.TrimRight(b.String(), "\n")))
+		if i < start+shown-1 {
+			cols = append(cols, "  ")
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Duplicate Plans")) + "\n")
+	counter := trf("Group %d of %d", d.group+1, len(d.groups))
+	if extra := len(g.plans) - shown; extra > 0 {
+		counter += " · " + trf("%d more (h/l)", extra)
+	}
+	b.WriteString(dimStyle.Render(counter) + "\n\n")
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cols...) + "\n\n")
+	switch d.confirm {
+	case "d":
+		b.WriteString(accentStyle.Render(trf("Delete %d plans? (y/n)", len(g.plans)-1)))
+	case "D":
+		n := 0
+		for _, g := range d.groups {
+			n += len(g.plans) - 1
+		}
+		b.WriteString(accentStyle.Render(trf("Delete %d plans? (y/n)", n)))
+	default:
+		b.WriteString(dimStyle.Render(tr("h/l keep · j/k group · s/S supersede others (all groups) · d/D delete others (all groups) · esc close")))
+	}
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
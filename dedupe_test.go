@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	now := time.Now()
+	body := "Add a token bucket in front of the public API so a single client cannot starve the others during bursts."
+	all := []plan{
+		{dir: "/p", file: "limit.md", title: "Rate limiting for the API", created: now.Add(-2 * time.Hour)},
+		{dir: "/p", file: "limit-2.md", title: "API rate limiting", created: now},
+		{dir: "/p", file: "bucket.md", title: "Throttle clients", created: now.Add(-time.Hour)},
+		{dir: "/p", file: "docs.md", title: "Write the docs", created: now},
+		{dir: "/p", file: "old.md", title: "Rate limiting API", supersededBy: "limit-2.md"},
+	}
+	bodies := map[string]string{
+		"/p/bucket.md": body,
+		"/p/limit.md":  body + " Return 429 when over.",
+		"/p/docs.md":   "Document every command.",
+	}
+	groups := findDuplicates(all, bodies)
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %v", len(groups), groups)
+	}
+	var files []string
+	for _, p := range groups[0].plans {
+		files = append(files, p.file)
+	}
+	if got := strings.Join(files, " "); got != "limit-2.md bucket.md limit.md" {
+		t.Errorf("group = %s, want newest first without the superseded plan", got)
+	}
+}
+
+func TestDedupeSupersedes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\nstatus: active\ncreated: 2026-01-01\n---\n# Add dark mode support\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "---\nstatus: active\ncreated: 2026-02-01\n---\n# Dark mode support\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "---\nstatus: active\n---\n# Export to CSV\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m2, _ = m2.Update(scanDuplicates(all, nil)())
+	m = m2.(model)
+	if !m.dedupe.active {
+		t.Fatal("duplicates should open the dedupe view")
+	}
+	out := ansi.Strip(m.View())
+	if !strings.Contains(out, "Add dark mode support") || !strings.Contains(out, "Dark mode support") || strings.Contains(out, "Export to CSV") {
+		t.Errorf("view should show the two dark mode plans:\n%s", out)
+	}
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m2.(model).dedupe.active {
+		t.Error("resolving the last group should close the view")
+	}
+	if _, ok := cmd().(batchDoneMsg); !ok {
+		t.Fatal("s should write the supersede keys")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "a.md")); !strings.Contains(string(data), "superseded_by: b.md") {
+		t.Errorf("older plan not superseded:\n%s", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "b.md")); !strings.Contains(string(data), "supersedes: a.md") {
+		t.Errorf("kept plan should name the plan it supersedes:\n%s", data)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── planc:// links ──────────────────────────────────────────────────────────
+//
+// `planc planc://plan/auth.md#rollout` starts planc with auth.md selected and,
+// when there's a #heading, in comment mode at that section, so notes, chat
+// messages, and terminal hyperlinks can point into a plan. The heading is
+// matched by its text, ignoring case, or by its anchor slug ("rollout-plan"
+// for "Rollout Plan"). The OS hands planc:// links to planc once it is
+// registered as the scheme's handler; see the README.
+
+const planURLScheme = "planc"
+
+// isPlanURL reports whether arg is a planc: link rather than a plan name.
+func isPlanURL(arg string) bool {
+	return strings.HasPrefix(arg, planURLScheme+":")
+}
+
+// parsePlanURL splits planc://plan/<file>#heading into the plan's file name
+// and the heading, which is "" when there's no fragment.
+func parsePlanURL(raw string) (file, heading string, err error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", err
+	}
+	file = strings.Trim(u.Path, "/")
+	if u.Scheme != planURLScheme || u.Host != "plan" || file == "" {
+		return "", "", fmt.Errorf("%s is not a plan link (want planc://plan/<file>#heading)", raw)
+	}
+	return file, strings.TrimSpace(u.Fragment), nil
+}
+
+// headingAnchor returns the anchor slug of a heading, as Markdown renderers
+// generate for links: "Rollout Plan (v2)" is "rollout-plan-v2".
+func headingAnchor(text string) string {
+	return strings.Join(strings.Fields(slugStrip.ReplaceAllString(strings.ToLower(text), " ")), "-")
+}
+
+// headingLine returns the raw body line of the first heading in the plan at
+// path that matches heading by text or anchor.
+func headingLine(path, heading string) (int, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	_, body := parseFrontmatter(string(data))
+	for _, e := range extractToc(body) {
+		if !e.isComment && (strings.EqualFold(e.text, heading) || headingAnchor(e.text) == headingAnchor(heading)) {
+			return e.rawLine, true
+		}
+	}
+	return 0, false
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// planLink is a plan section to open once the window size is known, since
+// comment mode renders the plan at the preview's width.
+type planLink struct {
+	path string
+	line int
+}
+
+// openLinkTarget opens the pending link's plan in comment mode.
+func (m *model) openLinkTarget() tea.Cmd {
+	link := m.link
+	m.link = nil
+	return m.openCommentAt(link.path, link.line)
+}
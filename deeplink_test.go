@@ -0,0 +1,60 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParsePlanURL(t *testing.T) {
+	tests := []struct {
+		raw, file, heading string
+		wantErr            bool
+	}{
+		{"planc://plan/auth.md", "auth.md", "", false},
+		{"planc://plan/auth#rollout-plan", "auth", "rollout-plan", false},
+		{"planc://plan/auth.md#Rollout%20Plan", "auth.md", "Rollout Plan", false},
+		{"planc://plans/auth.md", "", "", true},
+		{"planc://plan/", "", "", true},
+		{"https://plan/auth.md", "", "", true},
+	}
+	for _, tt := range tests {
+		file, heading, err := parsePlanURL(tt.raw)
+		if file != tt.file || heading != tt.heading || (err != nil) != tt.wantErr {
+			t.Errorf("parsePlanURL(%q) = %q, %q, %v", tt.raw, file, heading, err)
+		}
+	}
+}
+
+func TestHeadingLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.md")
+	writeFile(t, path, "---\nstatus: active\n---\n# Auth\n\n## Rollout Plan (v2)\n\nShip it.\n")
+	for _, heading := range []string{"rollout plan (v2)", "rollout-plan-v2"} {
+		if line, ok := headingLine(path, heading); !ok || line != 2 {
+			t.Errorf("headingLine(%q) = %d, %v", heading, line, ok)
+		}
+	}
+	if _, ok := headingLine(path, "Testing"); ok {
+		t.Error("found a missing heading")
+	}
+}
+
+func TestPlanLinkOpensCommentMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	writeFile(t, path, "# Auth\n\n## Rollout\n\nShip it.\n")
+	plans, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	line, _ := headingLine(path, "rollout")
+	m.link = &planLink{path: path, line: line}
+
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = m2.(model)
+	if m.link != nil || !m.comment.active || m.comment.planFile != path || m.comment.jumpLine != line {
+		t.Errorf("link not opened: comment %+v", m.comment)
+	}
+}
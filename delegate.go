@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"hash/fnv"
 	"io"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -43,8 +42,40 @@ func labelColor(name string) lipgloss.Style {
 	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(labelColors[h.Sum32()%uint32(len(labelColors))]))
 }
 
+// displayOptions holds config-driven list rendering toggles. Shared by pointer
+// between the model and delegate so config reloads take effect immediately.
+type displayOptions struct {
+	showTokens   bool     // prefix the date column with the plan's token estimate
+	readingTime  bool     // prefix the date column with the plan's reading time
+	ageColors    bool     // tint titles of unfinished plans by time since last modification
+	lintSections []string // checklist for the completeness lint badge
+}
+
+// Age thresholds for ageColors: titles dim after staleAfter without changes
+// and turn the warning color after neglectedAfter.
+const (
+	staleAfter     = 14 * 24 * time.Hour
+	neglectedAfter = 60 * 24 * time.Hour
+)
+
+// ageTint returns the title style for p's age at now, or false if the title
+// should stay unstyled (fresh, done, or no modification time).
+func ageTint(p plan, now time.Time) (lipgloss.Style, bool) {
+	if isDone(p.status) || p.modified.IsZero() {
+		return lipgloss.Style{}, false
+	}
+	switch age := now.Sub(p.modified); {
+	case age >= neglectedAfter:
+		return lipgloss.NewStyle().Foreground(colorYellow), true
+	case age >= staleAfter:
+		return dateStyle, true
+	}
+	return lipgloss.Style{}, false
+}
+
 type planDelegate struct {
 	agentDir    string
+	display     *displayOptions
 	selected    map[string]bool
 	changed     map[string]bool
 	undoFiles   map[string]string // path → new status string (shown inline during undo window)
@@ -57,6 +88,10 @@ func (d planDelegate) Spacing() int                            { return 0 }
 func (d planDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 
 func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if h, ok := item.(groupHeader); ok {
+		renderGroupHeader(w, h, index == m.Index())
+		return
+	}
 	p, ok := item.(plan)
 	if !ok {
 		return
@@ -81,23 +116,14 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	var badge string
 	if inSelectMode {
 		if marked {
-			badge = activeStyle.Render("✓")
+			badge = activeStyle.Render(selectIcon(true))
 		} else if isCursor {
-			badge = unsetStyle.Render("✓")
+			badge = unsetStyle.Render(selectIcon(true))
 		} else {
-			badge = unsetStyle.Render("·")
+			badge = unsetStyle.Render(selectIcon(false))
 		}
 	} else {
-		switch p.status {
-		case "active":
-			badge = activeStyle.Render("●")
-		case "reviewed":
-			badge = reviewedStyle.Render("○")
-		case "done":
-			badge = doneStyle.Render("✓")
-		default:
-			badge = unsetStyle.Render("·")
-		}
+		badge = styledStatusIcon(p.status)
 		if changed && d.spinnerView != nil && *d.spinnerView != "" {
 			badge = *d.spinnerView
 		}
@@ -109,17 +135,18 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	var dateW int
 	var commentIndicator string // rendered separately so emoji stays visible
 
+	// Open (unresolved) comments add their count to the comment badge.
+	commentText := commentIcon() + " "
+	if p.openComments > 0 {
+		commentText = commentIcon() + strconv.Itoa(p.openComments) + " "
+	}
 	commentPrefixW := 0
 	if p.hasComments {
-		commentPrefixW = lipgloss.Width("💬 ")
+		commentPrefixW = lipgloss.Width(commentText)
 	}
 
 	if undoStatus, hasUndo := d.undoFiles[p.path()]; hasUndo && !marked {
-		label := undoStatus
-		if label == "" {
-			label = "new"
-		}
-		undoText := "→ " + label + " (u)"
+		undoText := "→ " + statusLabel(undoStatus) + " (u)"
 		if d.spinnerView != nil && *d.spinnerView != "" {
 			date = *d.spinnerView + " " + lipgloss.NewStyle().Foreground(colorAccent).Render(undoText)
 		} else {
@@ -131,23 +158,77 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		dateW = lipgloss.Width(date) + 1
 	} else {
 		// Show MM-DD for current year, full YYYY-MM-DD otherwise.
-		ts := p.created
+		ts := planDate(p)
 		currentYear := strconv.Itoa(time.Now().Year())
 		displayDate := ts.Format("2006-01-02")
 		if strings.HasPrefix(displayDate, currentYear+"-") {
 			displayDate = displayDate[len(currentYear)+1:]
 		}
-		// For project plans (non-agent dir), show parent dir name before date
+		// For project plans (non-agent dir), show the source name before date.
+		// Plans in agent subdirectories show their subpath before the title instead.
 		var dirPrefixW int
-		if p.dir != "" && d.agentDir != "" && p.dir != d.agentDir {
-			dirText := filepath.Base(filepath.Dir(p.dir)) + "/" + filepath.Base(p.dir) + " "
+		if name := sourceName(p.dir, d.agentDir); name != "" && d.agentDir != "" && agentSubpath(p.dir, d.agentDir) == "" {
+			dirText := name + " "
 			dirPrefixW = lipgloss.Width(dirText)
 			commentIndicator = dateStyle.Render(dirText) + commentIndicator
 		}
+		if d.display != nil && d.display.showTokens && p.tokens > 0 {
+			displayDate = formatTokens(p.tokens) + " " + displayDate
+		}
+		if d.display != nil && d.display.readingTime && p.words > 0 {
+			displayDate = readingTime(p.words) + " " + displayDate
+		}
 		date = displayDate
 		dateW = dirPrefixW + lipgloss.Width(displayDate) + commentPrefixW + 1 // +1 for leading space
-		if p.hasComments {
-			commentIndicator += lipgloss.NewStyle().Foreground(colorYellow).Render("💬 ")
+		if p.openComments > 0 {
+			commentIndicator += lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render(commentText)
+		} else if p.hasComments {
+			commentIndicator += lipgloss.NewStyle().Foreground(colorYellow).Render(commentText)
+		}
+		if p.blocked {
+			commentIndicator += blockedMark + " "
+			dateW += lipgloss.Width(blockedMark + " ")
+		}
+		if badge := dueBadge(p, time.Now()); badge != "" {
+			commentIndicator += badge + " "
+			dateW += lipgloss.Width(badge) + 1
+		}
+		if badge := priorityBadge(p); badge != "" {
+			commentIndicator += badge + " "
+			dateW += lipgloss.Width(badge) + 1
+		}
+		if badge := ownerBadge(p); badge != "" {
+			commentIndicator += badge + " "
+			dateW += lipgloss.Width(badge) + 1
+		}
+		if badge := estimateBadge(p); badge != "" {
+			commentIndicator += badge + " "
+			dateW += lipgloss.Width(badge) + 1
+		}
+		if progress := taskProgress(p); progress != "" {
+			// Checklist progress, green once every task is checked.
+			style := dateStyle
+			if p.tasksDone == p.tasks {
+				style = lipgloss.NewStyle().Foreground(colorGreen)
+			}
+			commentIndicator += style.Render(progress + " ")
+			dateW += lipgloss.Width(progress + " ")
+		}
+		if isLargePlan(p) {
+			sizeText := strings.ReplaceAll(formatSize(p.size), " ", "") + " "
+			commentIndicator += lipgloss.NewStyle().Foreground(colorYellow).Render(sizeText)
+			dateW += lipgloss.Width(sizeText)
+		}
+		if len(p.problems) > 0 {
+			commentIndicator += lipgloss.NewStyle().Foreground(colorYellow).Render("⚠ ")
+			dateW += lipgloss.Width("⚠ ")
+		}
+		if d.display != nil && len(d.display.lintSections) > 0 {
+			if missing := lintPlan(p, d.display.lintSections); len(missing) > 0 {
+				lintText := fmt.Sprintf("!%d ", len(missing))
+				commentIndicator += lipgloss.NewStyle().Foreground(colorYellow).Render(lintText)
+				dateW += lipgloss.Width(lintText)
+			}
 		}
 	}
 
@@ -156,8 +237,14 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	minTitle := 10 // reserve at least this much for the title
 	var visibleLabels []string
 	var labelPrefixW int
+	// Subpath of plans found by recursive_scan in agent subdirectories, e.g. "api/".
+	var subPrefix string
+	if sub := agentSubpath(p.dir, d.agentDir); sub != "" && d.agentDir != "" {
+		subPrefix = sub + "/"
+	}
+	subPrefixW := lipgloss.Width(subPrefix)
 	if len(p.labels) > 0 && avail > minTitle {
-		w := 2 // leading + trailing space around labels
+		w := 2 + subPrefixW // leading + trailing space around labels
 		for i, l := range p.labels {
 			lw := lipgloss.Width(l)
 			sep := 0
@@ -188,6 +275,7 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	} else {
 		labelPrefixW = 1 // just leading space
 	}
+	labelPrefixW += subPrefixW
 	title := p.title
 	plainW := labelPrefixW + lipgloss.Width(title)
 	if avail > 0 && plainW > avail {
@@ -215,6 +303,18 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	}
 
 	// Apply styling
+	if p.supersededBy != "" || p.blocked {
+		title = lipgloss.NewStyle().Foreground(colorDim).Render(title)
+	} else if isOverdue(p, time.Now()) {
+		title = lipgloss.NewStyle().Foreground(colorRed).Render(title)
+	} else if d.display != nil && d.display.ageColors {
+		if style, ok := ageTint(p, time.Now()); ok {
+			title = style.Render(title)
+		}
+	}
+	if subPrefix != "" {
+		title = dateStyle.Render(subPrefix) + title
+	}
 	var styledText string
 	if len(visibleLabels) > 0 {
 		var styledLabels string
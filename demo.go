@@ -4,6 +4,9 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -48,18 +51,74 @@ func demoPlanContents() map[string]string {
 	return contents
 }
 
+// syntheticDemoPlans generates n plans with varied statuses, labels, ages, and
+// body sizes for exercising planc at scale (--demo-size). Output is
+// deterministic so performance runs are comparable.
+func syntheticDemoPlans(n int) ([]plan, map[string]string) {
+	rng := rand.New(rand.NewSource(int64(n)))
+	now := time.Now()
+	labelPool := []string{"api", "auth", "billing", "cli", "docs", "infra", "mobile", "perf", "search", "ui", "web", "ml"}
+	statuses := []string{"", "reviewed", "active", "done", "done", "done"}
+	adjectives := []string{"eager", "calm", "bright", "humble", "swift", "quiet", "bold", "gentle", "clever", "lazy"}
+	verbs := []string{"running", "folding", "sailing", "routing", "parsing", "drifting", "caching", "mapping"}
+	nouns := []string{"otter", "whale", "falcon", "badger", "lemur", "heron", "gecko", "puffin", "walrus"}
+	topics := []string{"Refactor", "Migrate", "Add", "Remove", "Optimize", "Document", "Harden", "Rewrite"}
+	subjects := []string{"session storage", "search indexing", "billing webhooks", "CLI flags", "render cache", "retry policy", "onboarding flow", "metrics pipeline"}
+
+	plans := make([]plan, n)
+	contents := make(map[string]string, n)
+	for i := range plans {
+		file := fmt.Sprintf("%s-%s-%s-%d.md", adjectives[rng.Intn(len(adjectives))], verbs[rng.Intn(len(verbs))], nouns[rng.Intn(len(nouns))], i)
+		title := topics[rng.Intn(len(topics))] + " " + subjects[rng.Intn(len(subjects))]
+		var labels []string
+		for j := rng.Intn(4); j > 0; j-- {
+			labels = applyLabelChanges(labels, []string{labelPool[rng.Intn(len(labelPool))]}, nil)
+		}
+		created := now.Add(-time.Duration(rng.Int63n(int64(365 * 24 * time.Hour))))
+		plans[i] = plan{
+			status:   statuses[rng.Intn(len(statuses))],
+			labels:   parseLabels(labelsString(labels)),
+			title:    title,
+			created:  created,
+			modified: created,
+			file:     file,
+		}
+
+		// Most plans are short; a long tail is large enough to stress rendering.
+		sections := 1 + rng.Intn(6)
+		if rng.Intn(20) == 0 {
+			sections = 20 + rng.Intn(60)
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n\n", title)
+		for s := 0; s < sections; s++ {
+			fmt.Fprintf(&b, "## Step %d\n\n", s+1)
+			for p := rng.Intn(4); p >= 0; p-- {
+				b.WriteString("Synthetic paragraph generated for performance testing. ")
+				b.WriteString(strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 1+rng.Intn(6)))
+				b.WriteString("\n\n")
+			}
+			for t := rng.Intn(5); t > 0; t-- {
+				fmt.Fprintf(&b, "- [ ] Task %d.%d\n", s+1, t)
+			}
+			b.WriteString("\n")
+		}
+		contents[file] = b.String()
+	}
+	return plans, contents
+}
+
 // ─── demoStore ───────────────────────────────────────────────────────────────
 
 // demoStore implements planStore with in-memory operations (no disk I/O).
 type demoStore struct {
-	plans *[]plan // points to model.demo.plans
+	plans   *[]plan           // points to model.demo.plans
+	content map[string]string // model.demo.content (mutated in place, like comment saves)
 }
 
 func (s demoStore) setStatus(p plan, status string) tea.Cmd {
 	return func() tea.Msg {
-		updated := p
-		updated.status = status
-		return statusUpdatedMsg{oldPlan: p, newPlan: updated}
+		return statusUpdatedMsg{oldPlan: p, newPlan: withStatus(p, status, time.Now())}
 	}
 }
 
@@ -71,7 +130,7 @@ func (s demoStore) deletePlan(p plan) tea.Cmd {
 				remaining = append(remaining, dp)
 			}
 		}
-		return reloadMsg{plans: remaining}
+		return reloadMsg{plans: remaining, events: []planEvent{{kind: eventPlanDeleted, path: p.path()}}}
 	}
 }
 
@@ -84,6 +143,89 @@ func (s demoStore) setLabels(p plan, labels []string) tea.Cmd {
 	}
 }
 
+func (s demoStore) setTitle(p plan, title string) tea.Cmd {
+	body := s.content[p.file]
+	return func() tea.Msg {
+		updated := p
+		updated.title, updated.untitled = planTitle(map[string]string{"title": title}, body, p.file)
+		return titleUpdatedMsg{plan: updated, override: title}
+	}
+}
+
+func (s demoStore) repairPlan(p plan) tea.Cmd {
+	plans := *s.plans
+	return func() tea.Msg {
+		updated := make([]plan, len(plans))
+		copy(updated, plans)
+		for i := range updated {
+			if updated[i].path() == p.path() {
+				updated[i].problems = nil
+			}
+		}
+		return batchDoneMsg{plans: updated, files: []string{p.path()}, message: repairMessage(len(p.problems))}
+	}
+}
+
+// batchSetField only reflects keys the demo plans model (status, labels,
+// title, priority, due, estimate, owner, supersedes, superseded_by); other keys are accepted but have no visible effect.
+func (s demoStore) batchSetField(paths []string, k, v string) tea.Cmd {
+	plans := *s.plans
+	content := s.content
+	return func() tea.Msg {
+		pathSet := make(map[string]bool)
+		for _, p := range paths {
+			pathSet[p] = true
+		}
+		updated := make([]plan, len(plans))
+		copy(updated, plans)
+		for i, p := range updated {
+			if !pathSet[p.path()] {
+				continue
+			}
+			updated[i].fields = withField(p.fields, k, v)
+			switch k {
+			case "status":
+				updated[i].status = v
+			case "labels":
+				updated[i].labels = parseLabels(v)
+			case "title":
+				updated[i].title, updated[i].untitled = planTitle(map[string]string{"title": v}, content[p.file], p.file)
+			case "priority":
+				updated[i].priority = parsePriority(v)
+			case "due":
+				updated[i].due = parseDue(v)
+			case "estimate":
+				updated[i].estimate = v
+			case "owner", "assignee":
+				updated[i].owner = planOwner(updated[i].fields)
+			case "supersedes":
+				updated[i].supersedes = v
+			case "superseded_by":
+				updated[i].supersededBy = v
+			case "blocked_by":
+				updated[i].blockedBy = parseBlockedBy(v)
+			}
+		}
+		return batchDoneMsg{plans: updated, files: paths, message: setFieldMessage(len(paths), k, v)}
+	}
+}
+
+// createPlan adds an in-memory plan; directory defaults are not consulted.
+func (s demoStore) createPlan(dir, title string) tea.Cmd {
+	plans := *s.plans
+	now := time.Now()
+	file := planSlug(title) + ".md"
+	for n := 2; slices.ContainsFunc(plans, func(p plan) bool { return p.dir == dir && p.file == file }); n++ {
+		file = fmt.Sprintf("%s-%d.md", planSlug(title), n)
+	}
+	s.content[file] = expandTemplate(defaultPlanBody, title, now)
+	return func() tea.Msg {
+		updated := append(slices.Clone(plans), plan{dir: dir, file: file, title: title, created: now, modified: now})
+		path := filepath.Join(dir, file)
+		return planFileMsg{plans: updated, path: path, message: "Created: " + file, event: planEvent{kind: eventPlanCreated, path: path, to: title}}
+	}
+}
+
 func (s demoStore) batchSetStatus(paths []string, status string) tea.Cmd {
 	plans := *s.plans
 	return func() tea.Msg {
@@ -98,10 +240,7 @@ func (s demoStore) batchSetStatus(paths []string, status string) tea.Cmd {
 				updated[i].status = status
 			}
 		}
-		label := status
-		if label == "" {
-			label = "new"
-		}
+		label := statusLabel(status)
 		return batchDoneMsg{
 			plans:   updated,
 			files:   paths,
@@ -140,18 +279,74 @@ func (s demoStore) batchUpdateLabels(paths []string, add []string, remove []stri
 	}
 }
 
+// generateTitles titles the untitled plans in paths. The rewritten bodies
+// travel back in the batchDoneMsg, which applies them on the UI goroutine,
+// since View reads s.content concurrently.
+func (s demoStore) generateTitles(paths []string) tea.Cmd {
+	plans := *s.plans
+	bodies := make(map[string]string)
+	for _, p := range plans {
+		if slices.Contains(paths, p.path()) && p.untitled {
+			bodies[p.file] = s.content[p.file]
+		}
+	}
+	return func() tea.Msg {
+		updated := slices.Clone(plans)
+		content := make(map[string]string)
+		for i, p := range updated {
+			body, ok := bodies[p.file]
+			if !ok || !slices.Contains(paths, p.path()) {
+				continue
+			}
+			if title := deriveTitle(body); title != "" {
+				content[p.file] = "# " + title + "\n\n" + strings.TrimLeft(body, "\n")
+				updated[i].title = title
+				updated[i].untitled = false
+			}
+		}
+		return batchDoneMsg{
+			plans:   updated,
+			files:   paths,
+			message: fmt.Sprintf("%d titles generated", len(content)),
+			content: content,
+		}
+	}
+}
+
 func (m *model) enterDemoMode() {
 	clear(m.selected)
 	m.demo.active = true
-	m.demo.plans = demoPlans()
-	m.demo.content = demoPlanContents()
-	m.store = demoStore{plans: &m.demo.plans}
+	if m.demo.size > 0 {
+		m.demo.plans, m.demo.content = syntheticDemoPlans(m.demo.size)
+	} else {
+		m.demo.plans = demoPlans()
+		m.demo.content = demoPlanContents()
+	}
+	for i, p := range m.demo.plans {
+		body := m.demo.content[p.file]
+		m.demo.plans[i].size = len(body)
+		m.demo.plans[i].tokens = estimateTokens(body)
+		_, planBody := parseFrontmatter(body)
+		m.demo.plans[i].words = countWords(planBody)
+		m.demo.plans[i].headings = bodyHeadings(body)
+		total, open := countComments(body)
+		m.demo.plans[i].hasComments = total > 0
+		m.demo.plans[i].openComments = open
+		m.demo.plans[i].tasks, m.demo.plans[i].tasksDone = countTasks(body)
+		m.demo.plans[i].links = bodyLinks(body)
+	}
+	linkPlans(m.demo.plans)
+	m.store = demoStore{plans: &m.demo.plans, content: m.demo.content}
 	m.showDone = false
 	m.labelFilter = ""
+	m.sourceFilter = ""
+	m.ownerFilter = ""
+	m.followUpFilter = false
+	m.statusFilter = ""
 	m.lastStatusChange = nil
 	m.batchKeepFiles = nil
 	visible := m.visiblePlans()
-	m.list.SetItems(plansToItems(visible))
+	m.setListItems(visible)
 	m.list.ResetSelected()
 	m.prevIndex = -1
 	m.previewCache = make(map[string]string)
@@ -168,6 +363,10 @@ func (m *model) exitDemoMode() {
 	m.store = diskStore{agentDir: m.dir, projectGlob: m.cfg.ProjectPlanGlob}
 	m.showDone = m.cfg.ShowAll
 	m.labelFilter = ""
+	m.sourceFilter = ""
+	m.ownerFilter = ""
+	m.followUpFilter = false
+	m.statusFilter = ""
 	m.lastStatusChange = nil
 	m.batchKeepFiles = nil
 	// Re-scan from disk since watcher was ignoring changes during demo
@@ -176,7 +375,7 @@ func (m *model) exitDemoMode() {
 		sortPlans(m.allPlans)
 	}
 	visible := m.visiblePlans()
-	m.list.SetItems(plansToItems(visible))
+	m.setListItems(visible)
 	m.list.ResetSelected()
 	m.prevIndex = -1
 	m.previewCache = make(map[string]string)
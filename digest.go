@@ -0,0 +1,156 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─── planc digest ────────────────────────────────────────────────────────────
+//
+// `planc digest --since 7d` summarizes a period as markdown for a standup doc
+// or email: plans created, plans completed, plans stalled (unfinished and
+// untouched for the whole period), and the plans with the most open
+// comments. Completions come from the audit log, plus done plans modified in
+// the period for history that predates it.
+
+const digestTopCommented = 5
+
+// parseSince parses a --since value relative to now: "7d", "2w", a Go
+// duration such as "36h", or a date (2006-01-02).
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if d, ok := parsePeriod(s); ok {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (use 7d, 2w, 36h, or 2006-01-02)", s)
+}
+
+// parsePeriod parses a positive length of time: "7d", "2w", or a Go
+// duration such as "36h".
+func parsePeriod(s string) (time.Duration, bool) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			if v, err := strconv.Atoi(n); err == nil && v > 0 {
+				return time.Duration(v) * unit, true
+			}
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, true
+	}
+	return 0, false
+}
+
+// digest is the analysis behind a digest report.
+type digest struct {
+	since, until time.Time
+	created      []plan
+	completed    []plan
+	stalled      []plan
+	commented    []plan
+	counts       map[string]int // current plans by status
+}
+
+func buildDigest(plans []plan, audit []auditEntry, since, now time.Time) digest {
+	d := digest{since: since, until: now, counts: statusCounts(plans)}
+	doneAt := make(map[string]bool)
+	for _, e := range audit {
+		if eventKind(e.Event) == eventStatusChanged && isDone(e.To) && !e.Time.Before(since) {
+			doneAt[e.File] = true
+		}
+	}
+	for _, p := range plans {
+		if !p.created.Before(since) {
+			d.created = append(d.created, p)
+		}
+		if isDone(p.status) && (doneAt[p.path()] || !p.modified.Before(since)) {
+			d.completed = append(d.completed, p)
+		}
+		if isInProgress(p.status) && p.modified.Before(since) {
+			d.stalled = append(d.stalled, p)
+		}
+		if !isDone(p.status) && p.openComments > 0 {
+			d.commented = append(d.commented, p)
+		}
+	}
+	// Oldest untouched first: those are the most stalled.
+	slices.SortFunc(d.stalled, func(a, b plan) int { return a.modified.Compare(b.modified) })
+	slices.SortStableFunc(d.commented, func(a, b plan) int { return cmp.Compare(b.openComments, a.openComments) })
+	if len(d.commented) > digestTopCommented {
+		d.commented = d.commented[:digestTopCommented]
+	}
+	return d
+}
+
+// markdown renders the digest.
+func (d digest) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Plans digest: %s – %s\n\n", d.since.Format("Jan 2"), d.until.Format("Jan 2, 2006"))
+	fmt.Fprintf(&b, "%d created · %d completed · %d stalled", len(d.created), len(d.completed), len(d.stalled))
+	for _, name := range inProgressNames() {
+		fmt.Fprintf(&b, " · %d %s", d.counts[name], name)
+	}
+	b.WriteString("\n")
+
+	section := func(title, empty string, plans []plan, detail func(plan) string) {
+		fmt.Fprintf(&b, "\n## %s\n\n", title)
+		if len(plans) == 0 {
+			b.WriteString("_" + empty + "_\n")
+			return
+		}
+		for _, p := range plans {
+			fmt.Fprintf(&b, "- **%s** (`%s`) — %s\n", p.title, p.file, detail(p))
+		}
+	}
+	section("Created", "No new plans.", d.created, func(p plan) string {
+		return exportStatus(p.status) + ", " + p.created.Format("Jan 2")
+	})
+	section("Completed", "Nothing completed.", d.completed, func(p plan) string {
+		return "updated " + p.modified.Format("Jan 2")
+	})
+	section("Stalled", "Nothing stalled.", d.stalled, func(p plan) string {
+		return fmt.Sprintf("%s, untouched since %s", p.status, p.modified.Format("Jan 2"))
+	})
+	section("Most open comments", "No open comments.", d.commented, func(p plan) string {
+		if p.openComments == 1 {
+			return "1 open comment"
+		}
+		return fmt.Sprintf("%d open comments", p.openComments)
+	})
+	return b.String()
+}
+
+func runDigest(cfg config, args []string) int {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	sinceFlag := fs.String("since", "7d", "start of the period: 7d, 2w, 36h, or 2006-01-02")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc digest [--since 7d]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	now := time.Now()
+	since, err := parseSince(*sinceFlag, now)
+	if err != nil {
+		return cliError("digest: %v", err)
+	}
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("digest: %v", err)
+	}
+	var audit []auditEntry
+	if path, err := auditLogPath(); err == nil {
+		audit, _ = readAuditLog(path)
+	}
+	fmt.Fprint(os.Stdout, buildDigest(plans, audit, since, now).markdown())
+	return 0
+}
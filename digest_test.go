@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"7d":         now.AddDate(0, 0, -7),
+		"2w":         now.AddDate(0, 0, -14),
+		"36h":        now.Add(-36 * time.Hour),
+		"2026-03-01": time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for in, want := range cases {
+		got, err := parseSince(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseSince(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "0d", "-3d", "soon"} {
+		if _, err := parseSince(bad, now); err == nil {
+			t.Errorf("parseSince(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	since := now.AddDate(0, 0, -7)
+	old := now.AddDate(0, 0, -30)
+	plans := []plan{
+		{dir: "/p", file: "new.md", title: "New", status: "reviewed", created: now.AddDate(0, 0, -1), modified: now},
+		{dir: "/p", file: "done.md", title: "Done", status: "done", created: old, modified: old},
+		{dir: "/p", file: "stuck.md", title: "Stuck", status: "active", created: old, modified: old, openComments: 1},
+		{dir: "/p", file: "busy.md", title: "Busy", status: "active", created: old, modified: now, openComments: 4},
+		{dir: "/p", file: "old-done.md", title: "Old done", status: "done", created: old, modified: old},
+	}
+	audit := []auditEntry{
+		{Time: now.AddDate(0, 0, -2), Event: string(eventStatusChanged), File: "/p/done.md", To: "done"},
+		{Time: now.AddDate(0, 0, -20), Event: string(eventStatusChanged), File: "/p/old-done.md", To: "done"},
+	}
+	d := buildDigest(plans, audit, since, now)
+	names := func(ps []plan) string {
+		var s []string
+		for _, p := range ps {
+			s = append(s, p.file)
+		}
+		return strings.Join(s, ",")
+	}
+	for _, c := range []struct{ name, got, want string }{
+		{"created", names(d.created), "new.md"},
+		{"completed", names(d.completed), "done.md"},
+		{"stalled", names(d.stalled), "stuck.md"},
+		{"commented", names(d.commented), "busy.md,stuck.md"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %s, want %s", c.name, c.got, c.want)
+		}
+	}
+	md := d.markdown()
+	for _, want := range []string{"# Plans digest: Mar 8 – Mar 15, 2026", "1 created · 1 completed · 1 stalled", "- **Busy** (`busy.md`) — 4 open comments"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("digest missing %q:\n%s", want, md)
+		}
+	}
+}
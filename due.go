@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Due Dates ───────────────────────────────────────────────────────────────
+//
+// The due: frontmatter key is a deadline in any created: format. Unfinished
+// plans show how long until it in the list row ("due in 3d"), yellow when it
+// is today or tomorrow and red, title included, once it has passed. d sets it
+// from a prompt that takes a date, a weekday, or an offset like +2w, and
+// up/down step the date a day at a time.
+
+// parseDue returns the date a due: value names, or the zero time.
+func parseDue(v string) time.Time {
+	t, _ := parseFrontmatterTime(strings.TrimSpace(v))
+	return t
+}
+
+// startOfDay returns midnight at the start of t's day.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// dueDays returns the calendar days from now until due: 0 today, 1
+// tomorrow, negative once it has passed.
+func dueDays(due, now time.Time) int {
+	d := startOfDay(due.In(now.Location())).Sub(startOfDay(now))
+	return int(math.Round(d.Hours() / 24))
+}
+
+// isOverdue reports whether p is unfinished and its due date has passed.
+func isOverdue(p plan, now time.Time) bool {
+	return !p.due.IsZero() && !isDone(p.status) && dueDays(p.due, now) < 0
+}
+
+// dueLabel describes when a plan is due relative to now, e.g. "due in 3d".
+func dueLabel(due, now time.Time) string {
+	switch days := dueDays(due, now); {
+	case days < 0:
+		return fmt.Sprintf("%dd overdue", -days)
+	case days == 0:
+		return "due today"
+	case days == 1:
+		return "due tomorrow"
+	case days < 14:
+		return fmt.Sprintf("due in %dd", days)
+	case days < 60:
+		return fmt.Sprintf("due in %dw", days/7)
+	case due.Year() == now.Year():
+		return "due " + due.Format("Jan 2")
+	default:
+		return "due " + due.Format("2006-01-02")
+	}
+}
+
+// dueBadge renders p's due date for the list row, or "" for plans that are
+// done or have none.
+func dueBadge(p plan, now time.Time) string {
+	if p.due.IsZero() || isDone(p.status) {
+		return ""
+	}
+	style := dateStyle
+	switch days := dueDays(p.due, now); {
+	case days < 0:
+		style = lipgloss.NewStyle().Bold(true).Foreground(colorRed)
+	case days <= 1:
+		style = lipgloss.NewStyle().Foreground(colorYellow)
+	}
+	return style.Render(dueLabel(p.due, now))
+}
+
+var dueOffsetRegex = regexp.MustCompile(`^\+?(\d+)([dw])$`)
+
+// parseDueInput reads the due prompt: a date (2026-03-20), today, tomorrow,
+// a weekday (fri, the next one after today), or an offset (+3d, 2w). It
+// returns the date as YYYY-MM-DD, or "" for empty input, which clears due:.
+func parseDueInput(s string, now time.Time) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := startOfDay(now)
+	var day time.Time
+	switch {
+	case s == "":
+		return "", nil
+	case s == "today":
+		day = today
+	case s == "tomorrow":
+		day = today.AddDate(0, 0, 1)
+	case dueOffsetRegex.MatchString(s):
+		m := dueOffsetRegex.FindStringSubmatch(s)
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		day = today.AddDate(0, 0, n)
+	default:
+		if wd, ok := parseWeekday(s); ok {
+			ahead := (int(wd) - int(today.Weekday()) + 7) % 7
+			if ahead == 0 {
+				ahead = 7
+			}
+			day = today.AddDate(0, 0, ahead)
+			break
+		}
+		t, ok := parseFrontmatterTime(s)
+		if !ok {
+			return "", fmt.Errorf("not a date: %s", s)
+		}
+		day = t
+	}
+	return day.Format("2006-01-02"), nil
+}
+
+// parseWeekday accepts a weekday name or its three-letter abbreviation.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || s == name[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// ─── Due Prompt ──────────────────────────────────────────────────────────────
+
+// openDueModal opens the due prompt for the given plans, prefilled with the
+// first one's due date.
+func (m *model) openDueModal(files []string, current time.Time) tea.Cmd {
+	if len(files) == 0 {
+		return nil
+	}
+	m.settingDue = true
+	m.dueFiles = files
+	m.dueErr = ""
+	m.dueInput.SetValue("")
+	if !current.IsZero() {
+		m.dueInput.SetValue(current.Format("2006-01-02"))
+	}
+	m.dueInput.CursorEnd()
+	m.dueInput.Focus()
+	return textinput.Blink
+}
+
+// stepDue moves the prompt's date by days, starting from today when the
+// input isn't a date yet.
+func (m *model) stepDue(days int) {
+	now := time.Now()
+	day := startOfDay(now)
+	if v, err := parseDueInput(m.dueInput.Value(), now); err == nil && v != "" {
+		day, _ = time.ParseInLocation("2006-01-02", v, now.Location())
+	} else {
+		days = 0
+	}
+	m.dueInput.SetValue(day.AddDate(0, 0, days).Format("2006-01-02"))
+	m.dueInput.CursorEnd()
+	m.dueErr = ""
+}
+
+func (m model) handleDueModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.settingDue = false
+		m.dueInput.Blur()
+		return m, nil, true
+	case msg.Type == tea.KeyUp:
+		m.stepDue(1)
+		return m, nil, true
+	case msg.Type == tea.KeyDown:
+		m.stepDue(-1)
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		v, err := parseDueInput(m.dueInput.Value(), time.Now())
+		if err != nil {
+			m.dueErr = err.Error()
+			return m, nil, true
+		}
+		m.settingDue = false
+		m.dueInput.Blur()
+		return m, m.store.batchSetField(m.dueFiles, "due", v), true
+	}
+	m.dueErr = ""
+	var cmd tea.Cmd
+	m.dueInput, cmd = m.dueInput.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) renderDueModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	warnStyle := lipgloss.NewStyle().Foreground(colorYellow)
+
+	var b strings.Builder
+	title := tr("Set due date")
+	if len(m.dueFiles) > 1 {
+		title = trf("Set due date (%d plans)", len(m.dueFiles))
+	}
+	b.WriteString(helpTitleStyle.Render(title) + "\n")
+	b.WriteString("due " + m.dueInput.View() + "\n")
+	now := time.Now()
+	switch v, err := parseDueInput(m.dueInput.Value(), now); {
+	case m.dueErr != "":
+		b.WriteString(warnStyle.Render(m.dueErr) + "\n")
+	case err == nil && v != "":
+		day, _ := time.ParseInLocation("2006-01-02", v, now.Location())
+		b.WriteString(dimStyle.Render(day.Format("Mon, Jan 2, 2006")+" · "+dueLabel(day, now)) + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("2026-03-20, today, fri, +3d, +2w · ↑/↓ ±1 day · empty clears · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestParseDueInput(t *testing.T) {
+	now := time.Date(2026, 3, 18, 15, 0, 0, 0, time.Local) // a Wednesday
+	tests := map[string]string{
+		"":           "",
+		"today":      "2026-03-18",
+		"Tomorrow":   "2026-03-19",
+		"fri":        "2026-03-20",
+		"wed":        "2026-03-25",
+		"+3d":        "2026-03-21",
+		"2w":         "2026-04-01",
+		"2026-05-01": "2026-05-01",
+	}
+	for in, want := range tests {
+		if got, err := parseDueInput(in, now); err != nil || got != want {
+			t.Errorf("parseDueInput(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseDueInput("soon", now); err == nil {
+		t.Error("parseDueInput(soon) should fail")
+	}
+}
+
+func TestDueLabel(t *testing.T) {
+	now := time.Date(2026, 3, 18, 23, 0, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2026, 3, 18+d, 0, 0, 0, 0, time.Local) }
+	for _, tt := range []struct {
+		days int
+		want string
+	}{
+		{-2, "2d overdue"}, {0, "due today"}, {1, "due tomorrow"}, {5, "due in 5d"}, {21, "due in 3w"}, {90, "due Jun 16"},
+	} {
+		if got := dueLabel(day(tt.days), now); got != tt.want {
+			t.Errorf("dueLabel(%+d days) = %q, want %q", tt.days, got, tt.want)
+		}
+	}
+	if !isOverdue(plan{due: day(-1), status: "active"}, now) || isOverdue(plan{due: day(-1), status: "done"}, now) {
+		t.Error("only unfinished plans past their due date are overdue")
+	}
+}
+
+func TestDueModal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+	writeFile(t, path, "---\nstatus: active\ndue: 2020-01-01\n---\n# Plan\n")
+	plans, _ := scanPlans(dir)
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "d overdue") {
+		t.Errorf("list row should show the plan as overdue:\n%s", out)
+	}
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = m2.(model)
+	if !m.settingDue || m.dueInput.Value() != "2020-01-01" {
+		t.Fatalf("d should open the due prompt with the current date, got %q", m.dueInput.Value())
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = m2.(model)
+	if m.dueInput.Value() != "2020-01-02" {
+		t.Errorf("up should add a day, got %q", m.dueInput.Value())
+	}
+	m.dueInput.SetValue("+3d")
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.settingDue || cmd == nil {
+		t.Fatal("enter should close the prompt and save")
+	}
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	data, _ := os.ReadFile(path)
+	want := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	if fm, _ := parseFrontmatter(string(data)); fm["due"] != want {
+		t.Errorf("due = %q, want %q", fm["due"], want)
+	}
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "due in 3d") {
+		t.Errorf("list row should show the new due date:\n%s", out)
+	}
+}
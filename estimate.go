@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ─── Estimates ───────────────────────────────────────────────────────────────
+//
+// The estimate: frontmatter key sizes a plan's work, either as a T-shirt size
+// (XS, S, M, L, XL) or a duration in minutes, hours, working days, or working
+// weeks (90m, 3h, 2d, 1w). Rows show it next to the priority badge, and the
+// title bar sums the estimates of the unfinished plans in view ("~2d of
+// work").
+
+const (
+	hoursPerDay  = 8
+	hoursPerWeek = 5 * hoursPerDay
+)
+
+// estimateSizes maps T-shirt sizes to working hours.
+var estimateSizes = map[string]float64{
+	"xs": 2,
+	"s":  4,
+	"m":  hoursPerDay,
+	"l":  3 * hoursPerDay,
+	"xl": hoursPerWeek,
+}
+
+// estimateUnits maps duration suffixes to working hours.
+var estimateUnits = map[string]float64{
+	"m": 1.0 / 60, "min": 1.0 / 60, "mins": 1.0 / 60, "minutes": 1.0 / 60,
+	"h": 1, "hr": 1, "hrs": 1, "hour": 1, "hours": 1,
+	"d": hoursPerDay, "day": hoursPerDay, "days": hoursPerDay,
+	"w": hoursPerWeek, "wk": hoursPerWeek, "week": hoursPerWeek, "weeks": hoursPerWeek,
+}
+
+// parseEstimate returns the working hours an estimate: value stands for. A
+// bare number is hours.
+func parseEstimate(v string) (float64, bool) {
+	s := strings.ToLower(strings.TrimSpace(v))
+	if h, ok := estimateSizes[s]; ok {
+		return h, true
+	}
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	num, unit := s, "h"
+	if i >= 0 {
+		num, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	perUnit, ok := estimateUnits[unit]
+	if err != nil || !ok || n <= 0 {
+		return 0, false
+	}
+	return n * perUnit, true
+}
+
+// formatEstimate renders working hours compactly: "45m", "5h", "2.5d", "2w".
+func formatEstimate(h float64) string {
+	trim := func(f float64) string { return strconv.FormatFloat(f, 'f', 1, 64) }
+	switch {
+	case h < 1:
+		return strconv.Itoa(int(h*60+0.5)) + "m"
+	case h < hoursPerDay:
+		return strings.TrimSuffix(trim(h), ".0") + "h"
+	case h < hoursPerWeek:
+		return strings.TrimSuffix(trim(h/hoursPerDay), ".0") + "d"
+	}
+	return strings.TrimSuffix(trim(h/hoursPerWeek), ".0") + "w"
+}
+
+// estimateBadge renders p's estimate for the list row, or "": sizes as
+// written, durations normalized.
+func estimateBadge(p plan) string {
+	if p.estimate == "" {
+		return ""
+	}
+	s := strings.TrimSpace(p.estimate)
+	if _, ok := estimateSizes[strings.ToLower(s)]; ok {
+		return dateStyle.Render(strings.ToUpper(s))
+	}
+	h, ok := parseEstimate(s)
+	if !ok {
+		return ""
+	}
+	return dateStyle.Render(formatEstimate(h))
+}
+
+// totalEstimate sums the estimates of the unfinished plans among plans.
+func totalEstimate(plans []plan) float64 {
+	var total float64
+	for _, p := range plans {
+		if isDone(p.status) {
+			continue
+		}
+		if h, ok := parseEstimate(p.estimate); ok {
+			total += h
+		}
+	}
+	return total
+}
+
+// viewPlans returns the plans the list shows: the current tab and filters,
+// narrowed by the / search while one is applied.
+func (m model) viewPlans() []plan {
+	if !m.list.IsFiltered() {
+		return m.filteredPlans(m.showDone)
+	}
+	var plans []plan
+	for _, item := range m.list.VisibleItems() {
+		if p, ok := item.(plan); ok {
+			plans = append(plans, p)
+		}
+	}
+	return plans
+}
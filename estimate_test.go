@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestParseEstimate(t *testing.T) {
+	for v, want := range map[string]float64{
+		"M":        8,
+		"xl":       40,
+		"3h":       3,
+		"3":        3,
+		"90m":      1.5,
+		"2d":       16,
+		"1.5 days": 12,
+		"1w":       40,
+	} {
+		if got, ok := parseEstimate(v); !ok || got != want {
+			t.Errorf("parseEstimate(%q) = %v, %v; want %v", v, got, ok, want)
+		}
+	}
+	for _, v := range []string{"", "soon", "-2h", "3 fortnights"} {
+		if _, ok := parseEstimate(v); ok {
+			t.Errorf("parseEstimate(%q) should fail", v)
+		}
+	}
+	for h, want := range map[float64]string{0.75: "45m", 5: "5h", 20: "2.5d", 80: "2w"} {
+		if got := formatEstimate(h); got != want {
+			t.Errorf("formatEstimate(%v) = %q, want %q", h, got, want)
+		}
+	}
+}
+
+func TestEstimateTotalInTitle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\nstatus: active\nestimate: M\n---\n# Alpha\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "---\nstatus: active\nestimate: 4h\n---\n# Beta\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "---\nstatus: done\nestimate: 1w\n---\n# Gamma\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	out := ansi.Strip(m2.(model).View())
+	if !strings.Contains(out, "~1.5d of work") {
+		t.Errorf("title should total unfinished estimates:\n%s", out)
+	}
+	if !strings.Contains(out, "M ") || !strings.Contains(out, "4h ") {
+		t.Errorf("rows should show their estimates:\n%s", out)
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ─── Plan Events ─────────────────────────────────────────────────────────────
+//
+// Every plan mutation is published as a planEvent on the model's eventBus.
+// Mutation messages implement eventSource, and Update publishes their events
+// before handling them, so features that react to changes (hooks, webhooks,
+// notifications, an audit log) subscribe to the bus instead of adding code
+// to each Update case. Events are published in demo mode too.
+
+// eventKind names a plan lifecycle event. Values are stable strings so they
+// can be written to logs and sent to external tools as-is.
+type eventKind string
+
+const (
+	eventStatusChanged   eventKind = "status_changed"
+	eventLabelsChanged   eventKind = "labels_changed"
+	eventTitleChanged    eventKind = "title_changed"
+	eventFieldChanged    eventKind = "field_changed"
+	eventPlanCreated     eventKind = "plan_created"
+	eventPlanRenamed     eventKind = "plan_renamed"
+	eventPlanMoved       eventKind = "plan_moved"
+	eventPlanDuplicated  eventKind = "plan_duplicated"
+	eventPlanArchived    eventKind = "plan_archived"
+	eventPlanDeleted     eventKind = "plan_deleted"
+	eventPlanRestored    eventKind = "plan_restored"
+	eventCommentAdded    eventKind = "comment_added"
+	eventCommentEdited   eventKind = "comment_edited"
+	eventCommentDeleted  eventKind = "comment_deleted"
+	eventCommentResolved eventKind = "comment_resolved"
+	eventCommentReopened eventKind = "comment_reopened"
+	eventTaskChecked     eventKind = "task_checked"
+	eventTaskUnchecked   eventKind = "task_unchecked"
+)
+
+// planEvent describes one change to one plan. from/to hold the old and new
+// value where the kind has one: the status, labels ("a, b"), title, field
+// value, path (for renames and moves), or comment or task text.
+type planEvent struct {
+	kind  eventKind
+	path  string // the plan's path after the change
+	field string // frontmatter key, for eventFieldChanged
+	from  string
+	to    string
+	at    time.Time // set by publish
+	demo  bool      // published in demo mode; nothing was written
+}
+
+// eventSource is implemented by messages that report plan mutations. before
+// is the plan list as it was when the message arrived, for messages that
+// only carry the updated list.
+type eventSource interface {
+	planEvents(before []plan) []planEvent
+}
+
+// eventQueueSize is how many events can wait for slow subscribers before
+// publish starts dropping them.
+const eventQueueSize = 256
+
+// eventBus delivers published events to subscribers on one goroutine, in
+// publish order, so a slow subscriber never blocks the Update loop: once
+// eventQueueSize events are waiting, new ones are dropped and logged.
+type eventBus struct {
+	mu       sync.Mutex
+	handlers []func(planEvent)
+	queue    chan planEvent
+	dropped  atomic.Int64
+}
+
+func newEventBus() *eventBus {
+	b := &eventBus{queue: make(chan planEvent, eventQueueSize)}
+	go func() {
+		for ev := range b.queue {
+			b.mu.Lock()
+			handlers := slices.Clone(b.handlers)
+			b.mu.Unlock()
+			for _, h := range handlers {
+				h(ev)
+			}
+		}
+	}()
+	return b
+}
+
+// subscribe registers h to receive every event published from now on.
+func (b *eventBus) subscribe(h func(planEvent)) {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, h)
+	b.mu.Unlock()
+}
+
+// publish queues events for delivery, stamping each with the current time,
+// without waiting for room in the queue. It is a no-op until something
+// subscribes.
+func (b *eventBus) publish(events ...planEvent) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	idle := len(b.handlers) == 0
+	b.mu.Unlock()
+	if idle {
+		return
+	}
+	now := time.Now()
+	for _, ev := range events {
+		ev.at = now
+		select {
+		case b.queue <- ev:
+		default:
+			n := b.dropped.Add(1)
+			logger.Warn("event dropped; subscribers are behind", "kind", ev.kind, "path", ev.path, "dropped", n)
+		}
+	}
+}
+
+// ─── Event Sources ───────────────────────────────────────────────────────────
+
+func (msg statusUpdatedMsg) planEvents([]plan) []planEvent {
+	return []planEvent{{kind: eventStatusChanged, path: msg.newPlan.path(), from: msg.oldPlan.status, to: msg.newPlan.status}}
+}
+
+func (msg labelsUpdatedMsg) planEvents(before []plan) []planEvent {
+	ev := planEvent{kind: eventLabelsChanged, path: msg.plan.path(), to: labelsString(msg.plan.labels)}
+	if i := slices.IndexFunc(before, func(p plan) bool { return p.path() == msg.plan.path() }); i >= 0 {
+		ev.from = labelsString(before[i].labels)
+	}
+	return []planEvent{ev}
+}
+
+func (msg titleUpdatedMsg) planEvents(before []plan) []planEvent {
+	ev := planEvent{kind: eventTitleChanged, path: msg.plan.path(), to: msg.plan.title}
+	if i := slices.IndexFunc(before, func(p plan) bool { return p.path() == msg.plan.path() }); i >= 0 {
+		ev.from = before[i].title
+	}
+	return []planEvent{ev}
+}
+
+func (msg planFileMsg) planEvents([]plan) []planEvent {
+	if msg.event.kind == "" {
+		return nil
+	}
+	return []planEvent{msg.event}
+}
+
+func (msg reloadMsg) planEvents([]plan) []planEvent {
+	return msg.events
+}
+
+func (msg commentSavedMsg) planEvents([]plan) []planEvent {
+	if msg.event.kind == "" {
+		return nil
+	}
+	return []planEvent{msg.event}
+}
+
+func (msg tasksSavedMsg) planEvents([]plan) []planEvent {
+	return []planEvent{msg.event}
+}
+
+// planEvents returns the changes the batch reported, or else the status,
+// label, and title changes found by comparing the plans before and after.
+func (msg batchDoneMsg) planEvents(before []plan) []planEvent {
+	if msg.events != nil {
+		return msg.events
+	}
+	return diffPlanEvents(before, msg.plans, msg.files)
+}
+
+// fieldEvent describes setting frontmatter key k from one value to another,
+// using the dedicated kind for keys that have one.
+func fieldEvent(path, k, from, to string) planEvent {
+	switch k {
+	case "status":
+		return planEvent{kind: eventStatusChanged, path: path, from: from, to: to}
+	case "labels":
+		return planEvent{kind: eventLabelsChanged, path: path, from: from, to: to}
+	case "title":
+		return planEvent{kind: eventTitleChanged, path: path, from: from, to: to}
+	}
+	return planEvent{kind: eventFieldChanged, path: path, field: k, from: from, to: to}
+}
+
+// diffPlanEvents returns status, label, and title change events for the
+// plans at paths whose values differ between before and after.
+func diffPlanEvents(before, after []plan, paths []string) []planEvent {
+	old := make(map[string]plan, len(before))
+	for _, p := range before {
+		old[p.path()] = p
+	}
+	var events []planEvent
+	for _, p := range after {
+		o, ok := old[p.path()]
+		if !ok || !slices.Contains(paths, p.path()) {
+			continue
+		}
+		if o.status != p.status {
+			events = append(events, planEvent{kind: eventStatusChanged, path: p.path(), from: o.status, to: p.status})
+		}
+		if from, to := labelsString(o.labels), labelsString(p.labels); from != to {
+			events = append(events, planEvent{kind: eventLabelsChanged, path: p.path(), from: from, to: to})
+		}
+		if o.title != p.title {
+			events = append(events, planEvent{kind: eventTitleChanged, path: p.path(), from: o.title, to: p.title})
+		}
+	}
+	return events
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// collectEvents subscribes to bus and returns a function that waits for n
+// events and returns them.
+func collectEvents(t *testing.T, bus *eventBus) func(n int) []planEvent {
+	t.Helper()
+	ch := make(chan planEvent, 16)
+	bus.subscribe(func(ev planEvent) { ch <- ev })
+	return func(n int) []planEvent {
+		t.Helper()
+		var got []planEvent
+		for len(got) < n {
+			select {
+			case ev := <-ch:
+				got = append(got, ev)
+			case <-time.After(time.Second):
+				t.Fatalf("got %d events, want %d: %+v", len(got), n, got)
+			}
+		}
+		return got
+	}
+}
+
+func TestEventBusDeliversInOrder(t *testing.T) {
+	bus := newEventBus()
+	bus.publish(planEvent{kind: eventPlanDeleted, path: "/p/ignored.md"}) // no subscribers yet
+	wait := collectEvents(t, bus)
+	bus.publish(planEvent{kind: eventStatusChanged, path: "/p/a.md"}, planEvent{kind: eventPlanDeleted, path: "/p/b.md"})
+
+	got := wait(2)
+	if got[0].path != "/p/a.md" || got[1].path != "/p/b.md" {
+		t.Errorf("events = %+v", got)
+	}
+	if got[0].at.IsZero() {
+		t.Error("publish should stamp the event time")
+	}
+}
+
+func TestEventBusDropsWhenSubscribersAreBehind(t *testing.T) {
+	bus := newEventBus()
+	release := make(chan struct{})
+	defer close(release)
+	bus.subscribe(func(planEvent) { <-release })
+
+	done := make(chan struct{})
+	go func() {
+		for range eventQueueSize + 10 {
+			bus.publish(planEvent{kind: eventStatusChanged, path: "/p/a.md"})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a stuck subscriber")
+	}
+	if bus.dropped.Load() == 0 {
+		t.Error("events past the queue should be dropped")
+	}
+}
+
+func TestUpdatePublishesMutationEvents(t *testing.T) {
+	m := testModel()
+	m.store = demoStore{plans: &m.allPlans, content: map[string]string{}}
+	wait := collectEvents(t, m.events)
+	p := m.allPlans[0]
+
+	m2, _ := m.Update(m.store.setStatus(p, "done")())
+	m = m2.(model)
+	m2, _ = m.Update(m.store.setLabels(p, []string{"kokua", "ui"})())
+	m = m2.(model)
+	m2, _ = m.Update(m.store.deletePlan(p)())
+	m = m2.(model)
+
+	got := wait(3)
+	want := []planEvent{
+		{kind: eventStatusChanged, path: p.path(), from: "active", to: "done"},
+		{kind: eventLabelsChanged, path: p.path(), from: "kokua", to: "kokua, ui"},
+		{kind: eventPlanDeleted, path: p.path()},
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.kind != w.kind || g.path != w.path || g.from != w.from || g.to != w.to {
+			t.Errorf("event %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestCommentSavePublishesEvent(t *testing.T) {
+	m := testModel()
+	m.demo.active = true
+	m.demo.content = map[string]string{}
+	m.comment.planFile = "/p/a.md"
+	wait := collectEvents(t, m.events)
+
+	msg := m.cmdSaveComment("# A\n", planEvent{kind: eventCommentDeleted, from: "old note"})()
+	if _, ok := msg.(commentSavedMsg); !ok {
+		t.Fatalf("msg = %T", msg)
+	}
+	m.Update(msg)
+
+	if got := wait(1)[0]; got.kind != eventCommentDeleted || got.path != "/p/a.md" || got.from != "old note" {
+		t.Errorf("event = %+v", got)
+	}
+}
+
+func TestBatchDoneEventsDiffPlans(t *testing.T) {
+	before := []plan{
+		{dir: "/p", file: "a.md", status: "active", labels: []string{"x"}},
+		{dir: "/p", file: "b.md", status: "active"},
+		{dir: "/p", file: "c.md", status: "reviewed"},
+	}
+	after := []plan{
+		{dir: "/p", file: "a.md", status: "done", labels: []string{"x"}},
+		{dir: "/p", file: "b.md", status: "active"},
+		{dir: "/p", file: "c.md", status: "done"}, // changed, but not part of the batch
+	}
+	var msg tea.Msg = batchDoneMsg{plans: after, files: []string{"/p/a.md", "/p/b.md"}}
+	got := msg.(eventSource).planEvents(before)
+	if len(got) != 1 || got[0].path != "/p/a.md" || got[0].from != "active" || got[0].to != "done" {
+		t.Errorf("events = %+v", got)
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// ─── Combined Export ─────────────────────────────────────────────────────────
+//
+// E stitches the selected plans (or the highlighted one) into one document for
+// planning reviews: a table of contents, then each plan under a header with
+// its status, labels, and dates. Markdown is written as-is, HTML is rendered
+// with goldmark, and PDF is printed from the HTML by wkhtmltopdf or headless
+// Chrome/Chromium, whichever is on PATH. Files go to the working directory,
+// like screenshots.
+//
+// The review format is HTML with every comment blockquote turned into a
+// callout next to the section it annotates, open and resolved comments told
+// apart, for handing a reviewed plan back to a stakeholder or attaching it to
+// a PR.
+
+// exportFormats maps the format prompt's keys to file extensions ("review"
+// is the annotated HTML review).
+var exportFormats = map[string]string{"m": "md", "h": "html", "p": "pdf", "c": "csv", "r": "review"}
+
+// exportDoc is one plan's part of an export.
+type exportDoc struct {
+	plan plan
+	body string // without frontmatter
+}
+
+// exportMarkdown builds the combined markdown document.
+func exportMarkdown(docs []exportDoc, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Plan review — %s\n\n", now.Format("January 2, 2006"))
+	b.WriteString("## Contents\n\n")
+	for i, d := range docs {
+		fmt.Fprintf(&b, "%d. [%s](#plan-%d) — %s\n", i+1, d.plan.title, i+1, exportStatus(d.plan.status))
+	}
+	for i, d := range docs {
+		fmt.Fprintf(&b, "\n---\n\n<a id=\"plan-%d\"></a>\n\n## %d. %s\n\n", i+1, i+1, d.plan.title)
+		b.WriteString(exportMeta(d.plan) + "\n\n")
+		b.WriteString(strings.TrimSpace(demoteHeadings(stripTitleHeading(d.body, d.plan.title))) + "\n")
+	}
+	return b.String()
+}
+
+func exportStatus(status string) string {
+	return statusLabel(status)
+}
+
+// exportMeta renders a plan's status line.
+func exportMeta(p plan) string {
+	parts := []string{"**Status:** " + exportStatus(p.status)}
+	if len(p.labels) > 0 {
+		parts = append(parts, "**Labels:** "+labelsString(p.labels))
+	}
+	if !p.created.IsZero() {
+		parts = append(parts, "**Created:** "+p.created.Format("2006-01-02"))
+	}
+	if !p.modified.IsZero() {
+		parts = append(parts, "**Modified:** "+p.modified.Format("2006-01-02"))
+	}
+	return strings.Join(parts, " · ") + "  \n`" + p.file + "`"
+}
+
+// stripTitleHeading drops a leading "# title" heading, which the export's
+// per-plan header already shows.
+func stripTitleHeading(body, title string) string {
+	trimmed := strings.TrimLeft(body, "\r\n")
+	first, rest, _ := strings.Cut(trimmed, "\n")
+	if h, ok := strings.CutPrefix(strings.TrimSpace(first), "# "); ok && strings.TrimSpace(h) == title {
+		return rest
+	}
+	return body
+}
+
+// demoteHeadings pushes every ATX heading outside code fences down two
+// levels, so plan sections nest under the export's "## N. Title" headers.
+func demoteHeadings(body string) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level > 6 || (len(line) > level && line[level] != ' ') {
+			continue
+		}
+		lines[i] = strings.Repeat("#", min(level+2, 6)) + line[level:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// annotateComments replaces comment blockquotes outside fenced code with
+// HTML callouts and opens the body with a count of open and resolved
+// comments.
+func annotateComments(body string) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		m := commentRegex.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		class, label := "open", "Comment"
+		if m[1] == "resolved" {
+			class, label = "resolved", "Resolved"
+		}
+		// Blank lines on both sides make goldmark treat the callout as an
+		// HTML block rather than part of a paragraph.
+		lines[i] = fmt.Sprintf("\n<aside class=\"comment %s\"><strong>%s</strong> %s</aside>\n",
+			class, label, html.EscapeString(strings.TrimSpace(m[2])))
+	}
+	total, open := countComments(body)
+	summary := "_No review comments._"
+	if total > 0 {
+		summary = fmt.Sprintf("**Review:** %d open · %d resolved", open, total-open)
+	}
+	return summary + "\n\n" + strings.Join(lines, "\n")
+}
+
+// exportCSS keeps the HTML readable on screen and splits plans onto separate
+// pages when printed.
+const exportCSS = `body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;max-width:48rem;margin:2rem auto;padding:0 1rem;line-height:1.5;color:#1f2328}
+h1,h2,h3{line-height:1.25}
+hr{page-break-after:always;border:0;border-top:1px solid #d0d7de;margin:2rem 0}
+blockquote{margin:0;padding:0 1rem;color:#57606a;border-left:.25rem solid #d0d7de}
+pre{background:#f6f8fa;padding:1rem;overflow:auto}
+code{font-family:ui-monospace,SFMono-Regular,Menlo,monospace;font-size:85%}
+table{border-collapse:collapse}th,td{border:1px solid #d0d7de;padding:.25rem .75rem}
+aside.comment{margin:1rem 0;padding:.5rem 1rem;border-left:.25rem solid #d4a72c;background:#fff8c5;border-radius:.25rem}
+aside.comment.resolved{border-color:#2da44e;background:#dafbe1;color:#57606a}
+aside.comment strong{margin-right:.5rem}
+@media print{hr{border:0;margin:0}}`
+
+// exportHTML renders the combined markdown as a standalone page.
+func exportHTML(markdown, title string) (string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(gmhtml.WithUnsafe()), // keep the <a id> anchors
+	)
+	var body bytes.Buffer
+	if err := md.Convert([]byte(markdown), &body); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title><style>%s</style></head>\n<body>\n%s</body></html>\n",
+		html.EscapeString(title), exportCSS, body.String()), nil
+}
+
+// pdfConverters are tried in order; each gets the HTML and PDF paths.
+var pdfConverters = []struct {
+	name string
+	args func(in, out string) []string
+}{
+	{"wkhtmltopdf", func(in, out string) []string { return []string{"--quiet", in, out} }},
+	{"chromium", chromePrintArgs},
+	{"chromium-browser", chromePrintArgs},
+	{"google-chrome", chromePrintArgs},
+}
+
+func chromePrintArgs(in, out string) []string {
+	return []string{"--headless", "--disable-gpu", "--no-pdf-header-footer", "--print-to-pdf=" + out, "file://" + in}
+}
+
+// printPDF converts the HTML file at in to a PDF at out.
+func printPDF(in, out string) error {
+	for _, c := range pdfConverters {
+		bin, err := exec.LookPath(c.name)
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(bin, c.args(in, out)...)
+		logger.Info("launch", "args", cmd.Args)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %v: %s", c.name, err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	return errors.New("PDF export needs wkhtmltopdf or Chrome/Chromium on PATH")
+}
+
+// exportFileName returns the default name of an export made at now.
+func exportFileName(ext string, now time.Time) string {
+	base := "planc-export-" + now.Format("20060102-150405")
+	switch ext {
+	case "review":
+		return base + "-review.html"
+	case "site":
+		return base // a directory
+	}
+	return base + "." + ext
+}
+
+// loadExportDocs reads each plan's body, from content by filename when it's
+// non-nil (demo mode).
+func loadExportDocs(plans []plan, content map[string]string) ([]exportDoc, error) {
+	docs := make([]exportDoc, 0, len(plans))
+	for _, p := range plans {
+		var body string
+		if content != nil {
+			body = content[p.file]
+		} else {
+			data, err := os.ReadFile(p.path())
+			if err != nil {
+				return nil, err
+			}
+			_, body = parseFrontmatter(string(data))
+		}
+		docs = append(docs, exportDoc{plan: p, body: body})
+	}
+	return docs, nil
+}
+
+// writeExport writes plans to path as ext: one md, html, pdf, csv (the
+// metadata table), review (annotated HTML), or json document, or a site
+// directory. content, when non-nil, supplies bodies by filename (demo mode).
+func writeExport(plans []plan, content map[string]string, ext, path string, now time.Time) error {
+	if ext == "csv" {
+		fields := diskFields
+		if content != nil {
+			fields = func(p plan) map[string]string {
+				fm, _ := parseFrontmatter(content[p.file])
+				return fm
+			}
+		}
+		data, err := planCSV(plans, fields)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, 0644)
+	}
+	docs, err := loadExportDocs(plans, content)
+	if err != nil {
+		return err
+	}
+	switch ext {
+	case "json":
+		return writeExportJSON(docs, path, now)
+	case "site":
+		return writeExportSite(docs, path, now)
+	case "review":
+		for i := range docs {
+			docs[i].body = annotateComments(docs[i].body)
+		}
+	}
+	markdown := exportMarkdown(docs, now)
+	if ext == "md" {
+		return os.WriteFile(path, []byte(markdown), 0644)
+	}
+	page, err := exportHTML(markdown, "Plan review — "+now.Format("January 2, 2006"))
+	if err != nil {
+		return err
+	}
+	if ext != "pdf" {
+		return os.WriteFile(path, []byte(page), 0644)
+	}
+	htmlPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+	if err := os.WriteFile(htmlPath, []byte(page), 0644); err != nil {
+		return err
+	}
+	if err := printPDF(htmlPath, path); err != nil {
+		return fmt.Errorf("%w (HTML saved as %s)", err, contractHome(htmlPath))
+	}
+	os.Remove(htmlPath)
+	return nil
+}
+
+// exportPlans writes plans as one document in dir with the given extension
+// (see writeExport).
+func exportPlans(plans []plan, content map[string]string, ext, dir string, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		path := filepath.Join(dir, exportFileName(ext, now))
+		if err := writeExport(plans, content, ext, path, now); err != nil {
+			return errMsg{fmt.Errorf("export: %w", err)}
+		}
+		return exportedMsg{path: path, count: len(plans)}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// openExportPrompt asks for the export format for the selected plans, or
+// the highlighted plan when nothing is selected.
+func (m *model) openExportPrompt() bool {
+	var plans []plan
+	for _, item := range m.list.Items() {
+		if p, ok := item.(plan); ok && m.selected[p.path()] {
+			plans = append(plans, p)
+		}
+	}
+	if len(plans) == 0 {
+		p, ok := m.list.SelectedItem().(plan)
+		if !ok {
+			return false
+		}
+		plans = []plan{p}
+	}
+	m.exportPlans = plans
+	target := "1 plan"
+	if len(plans) != 1 {
+		target = fmt.Sprintf("%d plans", len(plans))
+	}
+	m.notification = fmt.Sprintf("Export %s as (m)arkdown, (h)tml, (p)df, (c)sv, or annotated (r)eview? esc cancels", target)
+	return true
+}
+
+func (m model) handleExportPrompt(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	plans := m.exportPlans
+	m.exportPlans = nil
+	m.notification = ""
+	if key.Matches(msg, m.keys.ForceQuit) {
+		return m, tea.Quit, true
+	}
+	ext, ok := exportFormats[msg.String()]
+	if !ok {
+		return m, nil, true
+	}
+	var content map[string]string
+	if m.demo.active {
+		content = m.demo.content
+	}
+	clear(m.selected)
+	return m, tea.Batch(
+		exportPlans(plans, content, ext, screenshotDir(), time.Now()),
+		m.setNotification(tr("Exporting..."), statusTimeout),
+	), true
+}
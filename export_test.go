@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	docs := []exportDoc{
+		{plan: plan{file: "a.md", title: "Auth rewrite", status: "active", labels: []string{"backend"}},
+			body: "# Auth rewrite\n\n## Steps\n\n```sh\n# not a heading\n```\n"},
+		{plan: plan{file: "b.md", title: "Docs"}, body: "Just prose.\n"},
+	}
+	got := exportMarkdown(docs, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	for _, want := range []string{
+		"# Plan review — March 2, 2026",
+		"1. [Auth rewrite](#plan-1) — active",
+		"2. [Docs](#plan-2) — new",
+		"<a id=\"plan-1\"></a>\n\n## 1. Auth rewrite",
+		"**Status:** active · **Labels:** backend",
+		"#### Steps",
+		"# not a heading",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("export missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "### Auth rewrite") {
+		t.Error("the plan's own title heading should be dropped")
+	}
+}
+
+func TestExportPlansHTML(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\nstatus: done\n---\n# A\n\n> **[comment]:** ship it\n")
+	p := plan{dir: dir, file: "a.md", title: "A", status: "done"}
+
+	msg := exportPlans([]plan{p}, nil, "html", dir, time.Now())()
+	done, ok := msg.(exportedMsg)
+	if !ok {
+		t.Fatalf("expected exportedMsg, got %#v", msg)
+	}
+	data, err := os.ReadFile(done.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, want := range []string{"<!DOCTYPE html>", `<a id="plan-1"></a>`, "<blockquote>"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	if strings.Contains(page, "status: done") {
+		t.Error("frontmatter should not be exported")
+	}
+}
+
+func TestExportPlansReview(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "# A\n\n## Rollout\n\n> **[comment]:** needs a <flag>\n> **[resolved]:** canary first\n\nShip it.\n\n```\n> **[comment]:** not a comment\n```\n")
+	p := plan{dir: dir, file: "a.md", title: "A"}
+
+	msg := exportPlans([]plan{p}, nil, "review", dir, time.Now())()
+	done, ok := msg.(exportedMsg)
+	if !ok {
+		t.Fatalf("expected exportedMsg, got %#v", msg)
+	}
+	if !strings.HasSuffix(done.path, "-review.html") {
+		t.Errorf("path = %s", done.path)
+	}
+	data, err := os.ReadFile(done.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, want := range []string{
+		"<strong>Review:</strong> 1 open · 1 resolved",
+		"<h4>Rollout</h4>\n<aside class=\"comment open\"><strong>Comment</strong> needs a &lt;flag&gt;</aside>",
+		`<aside class="comment resolved"><strong>Resolved</strong> canary first</aside>`,
+		"&gt; **[comment]:** not a comment",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("review missing %q in:\n%s", want, page)
+		}
+	}
+}
+
+func TestExportPromptExportsSelection(t *testing.T) {
+	m := testModel()
+	m.enterDemoMode()
+	items := m.list.Items()
+	m.selected[items[0].(plan).path()] = true
+	m.selected[items[1].(plan).path()] = true
+
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	m = m2.(model)
+	if len(m.exportPlans) != 2 || !strings.Contains(m.notification, "Export 2 plans") {
+		t.Fatalf("E should prompt for 2 plans, got %d (%q)", len(m.exportPlans), m.notification)
+	}
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = m2.(model)
+	if m.exportPlans != nil || cmd != nil {
+		t.Error("esc should cancel the export")
+	}
+}
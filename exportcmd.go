@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ─── planc export ────────────────────────────────────────────────────────────
+//
+// `planc export` writes the E export from the command line, for all plans,
+// the plans named as arguments, or those matching --status and --label. Two
+// formats are CLI-only: json, one document with each plan's metadata, review
+// comments, and markdown body; and site, a directory of static HTML (an
+// index page and one page per plan, comments shown as callouts) to share a
+// review packet with people who don't use the terminal.
+
+// planComment is a review comment under the heading it belongs to.
+type planComment struct {
+	Heading  string `json:"heading"`
+	Text     string `json:"text"`
+	Resolved bool   `json:"resolved"`
+}
+
+// planDocument is a plan with its comments and body, as JSON.
+type planDocument struct {
+	listEntry
+	Comments []planComment `json:"comments"`
+	Body     string        `json:"body"`
+}
+
+func newPlanDocument(p plan, body string) planDocument {
+	comments := []planComment{}
+	heading := ""
+	for _, e := range extractToc(body) {
+		if !e.isComment {
+			heading = e.text
+			continue
+		}
+		comments = append(comments, planComment{Heading: heading, Text: e.text, Resolved: e.resolved})
+	}
+	return planDocument{listEntry: newListEntry(p), Comments: comments, Body: body}
+}
+
+func writeExportJSON(docs []exportDoc, path string, now time.Time) error {
+	out := struct {
+		Exported time.Time      `json:"exported"`
+		Plans    []planDocument `json:"plans"`
+	}{now, make([]planDocument, len(docs))}
+	for i, d := range docs {
+		out.Plans[i] = newPlanDocument(d.plan, d.body)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// sitePageNames returns each plan's page file name: its own file name as
+// .html, numbered when plans from different projects share one.
+func sitePageNames(docs []exportDoc) []string {
+	names := make([]string, len(docs))
+	used := make(map[string]int)
+	for i, d := range docs {
+		stem := strings.TrimSuffix(d.plan.file, ".md")
+		used[stem]++
+		if n := used[stem]; n > 1 {
+			stem = fmt.Sprintf("%s-%d", stem, n)
+		}
+		names[i] = stem + ".html"
+	}
+	return names
+}
+
+// writeExportSite writes an index page and one page per plan to dir.
+func writeExportSite(docs []exportDoc, dir string, now time.Time) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	title := "Plan review — " + now.Format("January 2, 2006")
+	names := sitePageNames(docs)
+
+	var index strings.Builder
+	fmt.Fprintf(&index, "# %s\n\n", title)
+	index.WriteString("| Plan | Status | Labels | Comments | Modified |\n|---|---|---|---|---|\n")
+	for i, d := range docs {
+		total, open := countComments(d.body)
+		comments := ""
+		if total > 0 {
+			comments = fmt.Sprintf("%d open · %d resolved", open, total-open)
+		}
+		fmt.Fprintf(&index, "| <a href=\"%s\">%s</a> | %s | %s | %s | %s |\n",
+			html.EscapeString(names[i]), html.EscapeString(d.plan.title), exportStatus(d.plan.status),
+			html.EscapeString(labelsString(d.plan.labels)), comments, d.plan.modified.Format("2006-01-02"))
+	}
+	page, err := exportHTML(index.String(), title)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(page), 0644); err != nil {
+		return err
+	}
+
+	for i, d := range docs {
+		markdown := "<a href=\"index.html\">← All plans</a>\n\n# " + d.plan.title + "\n\n" + exportMeta(d.plan) + "\n\n" +
+			annotateComments(stripTitleHeading(d.body, d.plan.title))
+		page, err := exportHTML(markdown, d.plan.title)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, names[i]), []byte(page), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runExport(cfg config, args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "html", "md, html, pdf, review (HTML with comment callouts), csv, json, or site (a directory of HTML pages)")
+	status := fs.String("status", "", "only plans with these statuses, comma-separated ("+statusList()+")")
+	label := fs.String("label", "", "only plans with this label")
+	output := fs.String("o", "", "write to this file (or directory, for site) instead of planc-export-<time> in the current directory")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc export [--format FORMAT] [--status LIST] [--label LABEL] [-o PATH] [PLAN...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	ext := *format
+	switch ext {
+	case "md", "html", "pdf", "review", "csv", "json", "site":
+	default:
+		return cliError("export: unknown format %q", ext)
+	}
+	var statuses []string
+	for _, s := range strings.Split(*status, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses = append(statuses, s)
+		}
+	}
+
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("export: %v", err)
+	}
+	if fs.NArg() > 0 {
+		named := make([]plan, 0, fs.NArg())
+		for _, arg := range fs.Args() {
+			path, err := openPlanPath(all, arg)
+			if err != nil {
+				return cliError("export: %v", err)
+			}
+			for _, p := range all {
+				if p.path() == path {
+					named = append(named, p)
+				}
+			}
+		}
+		all = named
+	}
+	plans := matchPlans(all, statuses, *label)
+	if len(plans) == 0 {
+		return cliError("export: no plans match")
+	}
+	if fs.NArg() == 0 {
+		sortPlans(plans)
+	}
+
+	now := time.Now()
+	path := *output
+	if path == "" {
+		path = exportFileName(ext, now)
+	}
+	if err := writeExport(plans, nil, ext, path, now); err != nil {
+		return cliError("export: %v", err)
+	}
+	fmt.Println(path)
+	return 0
+}
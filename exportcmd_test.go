@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteExportJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+	writeFile(t, path, "---\nstatus: active\n---\n# Plan\n\n## Steps\n\n> **[resolved]:** Done\n")
+	p := plan{dir: dir, file: "plan.md", title: "Plan", status: "active"}
+
+	out := filepath.Join(dir, "export.json")
+	if err := writeExport([]plan{p}, nil, "json", out, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Plans []planDocument `json:"plans"`
+	}
+	data, _ := os.ReadFile(out)
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Plans) != 1 || got.Plans[0].Status != "active" || !strings.HasPrefix(got.Plans[0].Body, "# Plan") {
+		t.Fatalf("export = %s", data)
+	}
+	if c := got.Plans[0].Comments; len(c) != 1 || c[0] != (planComment{Heading: "Steps", Text: "Done", Resolved: true}) {
+		t.Errorf("comments = %+v", c)
+	}
+}
+
+func TestWriteExportSite(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"a", "b"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(dir, "a", "plan.md"), "# Plan\n\n> **[comment]:** Why?\n")
+	writeFile(t, filepath.Join(dir, "b", "plan.md"), "# Plan <b>\n")
+	plans := []plan{
+		{dir: filepath.Join(dir, "a"), file: "plan.md", title: "Plan"},
+		{dir: filepath.Join(dir, "b"), file: "plan.md", title: "Plan <b>"},
+	}
+
+	site := filepath.Join(dir, "site")
+	if err := writeExport(plans, nil, "site", site, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	index, _ := os.ReadFile(filepath.Join(site, "index.html"))
+	for _, want := range []string{`href="plan.html"`, `href="plan-2.html"`, "Plan &lt;b&gt;", "1 open · 0 resolved"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("index lacks %q:\n%s", want, index)
+		}
+	}
+	page, _ := os.ReadFile(filepath.Join(site, "plan.html"))
+	if !strings.Contains(string(page), `<aside class="comment open">`) || !strings.Contains(string(page), `href="index.html"`) {
+		t.Errorf("page = %s", page)
+	}
+	if _, err := os.Stat(filepath.Join(site, "plan-2.html")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunExport(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	writeFile(t, filepath.Join(cfg.PlansDir, "a.md"), "---\nstatus: done\nlabels: api\n---\n# A\n")
+	writeFile(t, filepath.Join(cfg.PlansDir, "b.md"), "---\nlabels: api\n---\n# B\n")
+	writeFile(t, filepath.Join(cfg.PlansDir, "c.md"), "# C\n")
+
+	out := filepath.Join(t.TempDir(), "review.md")
+	if code := runExport(cfg, []string{"--format", "md", "--label", "api", "--status", "new", "-o", out}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	data, _ := os.ReadFile(out)
+	if !strings.Contains(string(data), "1. [B]") || strings.Contains(string(data), "[A]") || strings.Contains(string(data), "[C]") {
+		t.Errorf("export = %s", data)
+	}
+
+	if code := runExport(cfg, []string{"--format", "md", "-o", out, "c", "a"}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	data, _ = os.ReadFile(out)
+	if !strings.Contains(string(data), "1. [C]") || !strings.Contains(string(data), "2. [A]") {
+		t.Errorf("named plans should keep their order: %s", data)
+	}
+
+	if code := runExport(cfg, []string{"--format", "docx"}); code == 0 {
+		t.Error("an unknown format should fail")
+	}
+	if code := runExport(cfg, []string{"--label", "none"}); code == 0 {
+		t.Error("no matching plans should fail")
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ─── planc feed ──────────────────────────────────────────────────────────────
+//
+// An Atom feed of plan creations and status changes, built from the audit log
+// (see audit.go), so teammates can follow a plans directory in a feed reader.
+// `planc feed` prints it; -o writes it to a file that any static web server
+// (or a shared folder) can publish, and `planc serve` serves it at /feed.
+
+const feedLimit = 50
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+// feedEntries returns the newest limit creations and status changes,
+// newest first.
+func feedEntries(entries []auditEntry, limit int) []auditEntry {
+	var out []auditEntry
+	for i := len(entries) - 1; i >= 0 && len(out) < limit; i-- {
+		switch eventKind(entries[i].Event) {
+		case eventPlanCreated, eventStatusChanged:
+			out = append(out, entries[i])
+		}
+	}
+	return out
+}
+
+// buildFeed renders entries as an Atom document. titles maps plan paths to
+// their current titles; plans that no longer exist use their filename.
+func buildFeed(entries []auditEntry, titles map[string]string, now time.Time) ([]byte, error) {
+	feed := atomFeed{
+		Title:   "planc: plan changes",
+		ID:      "urn:planc:feed",
+		Updated: now.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: "planc"},
+	}
+	if len(entries) > 0 {
+		feed.Updated = entries[0].Time.UTC().Format(time.RFC3339)
+	}
+	for _, e := range entries {
+		title := titles[e.File]
+		if title == "" {
+			title = filepath.Base(e.File)
+		}
+		heading := "New plan: " + title
+		if eventKind(e.Event) == eventStatusChanged {
+			heading = fmt.Sprintf("%s → %s", title, exportStatus(e.To))
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   heading,
+			ID:      fmt.Sprintf("urn:planc:%s:%d:%s", e.Event, e.Time.UnixNano(), url.PathEscape(e.File)),
+			Updated: e.Time.UTC().Format(time.RFC3339),
+			Link:    atomLink{Href: (&url.URL{Scheme: "file", Path: filepath.ToSlash(e.File)}).String()},
+			Summary: fmt.Sprintf("%s — %s", e.describe(), contractHome(e.File)),
+		})
+	}
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// feedTitles maps the paths of all to their titles, for buildFeed.
+func feedTitles(all []plan) map[string]string {
+	titles := make(map[string]string, len(all))
+	for _, p := range all {
+		titles[p.path()] = p.title
+	}
+	return titles
+}
+
+func runFeed(cfg config, args []string) int {
+	fs := flag.NewFlagSet("feed", flag.ContinueOnError)
+	limit := fs.Int("n", feedLimit, "number of entries")
+	output := fs.String("o", "", "write the feed to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc feed [-n N] [-o FILE]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	path, err := auditLogPath()
+	if err != nil {
+		return cliError("feed: %v", err)
+	}
+	entries, err := readAuditLog(path)
+	if err != nil {
+		return cliError("feed: %v", err)
+	}
+	all, _ := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	data, err := buildFeed(feedEntries(entries, *limit), feedTitles(all), time.Now())
+	if err != nil {
+		return cliError("feed: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.WriteFile(expandHome(*output), data, 0644); err != nil {
+		return cliError("feed: %v", err)
+	}
+	return 0
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildFeed(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	entries := []auditEntry{
+		{Time: t0, Event: string(eventPlanCreated), File: "/p/auth.md"},
+		{Time: t0.Add(time.Hour), Event: string(eventLabelsChanged), File: "/p/auth.md", Field: "labels", To: "x"},
+		{Time: t0.Add(2 * time.Hour), Event: string(eventStatusChanged), File: "/p/auth.md", Field: "status", From: "reviewed", To: "done"},
+		{Time: t0.Add(3 * time.Hour), Event: string(eventStatusChanged), File: "/p/gone.md", Field: "status", To: "active"},
+	}
+	recent := feedEntries(entries, 2)
+	if len(recent) != 2 || recent[0].File != "/p/gone.md" {
+		t.Fatalf("feedEntries = %+v, want the two newest creations/status changes", recent)
+	}
+
+	data, err := buildFeed(feedEntries(entries, feedLimit), map[string]string{"/p/auth.md": "Auth rewrite"}, t0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed := string(data)
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		"<updated>2026-03-01T12:00:00Z</updated>",
+		"<title>gone.md → active</title>",
+		"<title>Auth rewrite → done</title>",
+		"<title>New plan: Auth rewrite</title>",
+		`<link href="file:///p/auth.md"></link>`,
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("feed missing %q:\n%s", want, feed)
+		}
+	}
+	if strings.Contains(feed, "labels") {
+		t.Error("label changes should not be in the feed")
+	}
+}
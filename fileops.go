@@ -0,0 +1,580 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── File Operations ─────────────────────────────────────────────────────────
+//
+// Rename, move, duplicate, fork, and archive act on whole plan files. The file
+// work is done by the plans package; each operation here has a planStore
+// method that rescans and reports a planFileMsg.
+
+// archiveDirName is the subdirectory archived plans are moved into. Scanning
+// doesn't descend into subdirectories, so archived plans leave the list.
+const archiveDirName = plans.ArchiveDir
+
+// planFileName normalizes a user-supplied plan name to a bare .md filename.
+func planFileName(name string) (string, error) {
+	return plans.FileName(name)
+}
+
+// renamePlanFile gives p a new filename in its directory and returns the new path.
+func renamePlanFile(p plan, name string) (string, error) {
+	return plans.Rename(p.path(), name)
+}
+
+// movePlanFile moves p into dir, keeping its filename.
+func movePlanFile(p plan, dir string) (string, error) {
+	return plans.Move(p.path(), dir)
+}
+
+// forkPlanFile copies p to name as a fresh plan: no status or history,
+// labels kept.
+func forkPlanFile(p plan, name string, now time.Time) (string, error) {
+	return plans.Fork(p.path(), name, now)
+}
+
+// archivePlanFile moves p into the archive subdirectory of its directory.
+func archivePlanFile(p plan) (string, error) {
+	return plans.Archive(p.path())
+}
+
+// duplicatePlanFile copies p to name-copy.md (or name-copy-N.md) next to it.
+// The copy gets its own created: time so it sorts as a new plan.
+func duplicatePlanFile(p plan, now time.Time) (string, error) {
+	return plans.Duplicate(p.path(), now)
+}
+
+// planFileCmd runs a file operation on p, rescans, and reports the result as
+// a kind event. The message is built from the resulting path; select says
+// whether to select it.
+func planFileCmd(agentDir, projectGlob string, p plan, kind eventKind, op func() (string, error), message func(path string) string, selectPath bool) tea.Cmd {
+	return func() tea.Msg {
+		path, err := op()
+		if err != nil {
+			return errMsg{err}
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		msg := planFileMsg{plans: plans, message: message(path), event: planEvent{kind: kind, path: path, from: p.path(), to: path}}
+		if selectPath {
+			msg.path = path
+		}
+		return msg
+	}
+}
+
+// renamePlan renames p and points the plans that link to it, or name it in
+// supersedes:/superseded_by:, at the new filename.
+func renamePlan(agentDir, projectGlob string, p plan, name string) tea.Cmd {
+	var updated, failed int
+	return planFileCmd(agentDir, projectGlob, p, eventPlanRenamed,
+		func() (string, error) {
+			path, err := renamePlanFile(p, name)
+			if err != nil || path == p.path() {
+				return path, err
+			}
+			if all, err := scanAllPlans(agentDir, projectGlob); err == nil {
+				updated, failed = relinkPlans(all, p.file, filepath.Base(path))
+			}
+			return path, nil
+		},
+		func(path string) string {
+			return "Renamed: " + p.file + " → " + filepath.Base(path) + relinkMessage(updated, failed)
+		},
+		true)
+}
+
+// linksTo reports whether p refers to the plan file named file, by a body
+// link or its supersedes:/superseded_by:/blocked_by: keys.
+func linksTo(p plan, file string) bool {
+	name := planRefName(file)
+	blocks := func(ref string) bool { return planRefName(ref) == name }
+	return slices.Contains(p.links, name) || planRefName(p.supersedes) == name || planRefName(p.supersededBy) == name ||
+		slices.ContainsFunc(p.blockedBy, blocks)
+}
+
+// relinkPlans rewrites the references to oldFile in every plan that has one
+// to name newFile, returning how many plans were updated and how many
+// couldn't be.
+func relinkPlans(all []plan, oldFile, newFile string) (updated, failed int) {
+	for _, p := range all {
+		if !linksTo(p, oldFile) {
+			continue
+		}
+		if err := relinkPlanFile(p, oldFile, newFile); err != nil {
+			logger.Error("relink failed", "path", p.path(), "err", err)
+			failed++
+			continue
+		}
+		updated++
+	}
+	return updated, failed
+}
+
+func relinkPlanFile(p plan, oldFile, newFile string) error {
+	data, err := os.ReadFile(p.path())
+	if err != nil {
+		return err
+	}
+	_, body, _ := plans.SplitFrontmatter(string(data))
+	if newBody := plans.RenameLinks(body, oldFile, newFile); newBody != body {
+		if err := plans.WriteBody(p.path(), newBody); err != nil {
+			return err
+		}
+	}
+	updates := make(map[string]string)
+	if planRefName(p.supersedes) == planRefName(oldFile) {
+		updates["supersedes"] = newFile
+	}
+	if planRefName(p.supersededBy) == planRefName(oldFile) {
+		updates["superseded_by"] = newFile
+	}
+	if refs, ok := renameBlocker(p.blockedBy, oldFile, newFile); ok {
+		updates["blocked_by"] = strings.Join(refs, ", ")
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return setFrontmatter(p.path(), updates)
+}
+
+// relinkMessage describes relinkPlans' work for the rename notification.
+func relinkMessage(updated, failed int) string {
+	var msg string
+	if updated == 1 {
+		msg = " · updated links in 1 plan"
+	} else if updated > 1 {
+		msg = fmt.Sprintf(" · updated links in %d plans", updated)
+	}
+	if failed > 0 {
+		msg += fmt.Sprintf(" · %d not updated", failed)
+	}
+	return msg
+}
+
+func movePlan(agentDir, projectGlob string, p plan, dir string) tea.Cmd {
+	return planFileCmd(agentDir, projectGlob, p, eventPlanMoved,
+		func() (string, error) { return movePlanFile(p, dir) },
+		func(path string) string { return "Moved: " + p.file + " → " + contractHome(filepath.Dir(path)) },
+		true)
+}
+
+func duplicatePlan(agentDir, projectGlob string, p plan) tea.Cmd {
+	return planFileCmd(agentDir, projectGlob, p, eventPlanDuplicated,
+		func() (string, error) { return duplicatePlanFile(p, time.Now()) },
+		func(path string) string { return "Duplicated: " + filepath.Base(path) },
+		true)
+}
+
+func forkPlan(agentDir, projectGlob string, p plan, name string) tea.Cmd {
+	return planFileCmd(agentDir, projectGlob, p, eventPlanDuplicated,
+		func() (string, error) { return forkPlanFile(p, name, time.Now()) },
+		func(path string) string { return "Forked: " + p.file + " → " + filepath.Base(path) },
+		true)
+}
+
+func archivePlan(agentDir, projectGlob string, p plan) tea.Cmd {
+	return planFileCmd(agentDir, projectGlob, p, eventPlanArchived,
+		func() (string, error) { return archivePlanFile(p) },
+		func(string) string { return "Archived: " + p.file },
+		false)
+}
+
+// ─── Rename Modal ────────────────────────────────────────────────────────────
+//
+// r renames the selected plan's file. Plans that link to it by filename or
+// [[wikilink]], or name it in supersedes:/superseded_by:, are updated to the
+// new name (see relinkPlans).
+
+func (m *model) openRenameModal() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	m.renaming = true
+	m.titleInput.SetValue(strings.TrimSuffix(item.file, filepath.Ext(item.file)))
+	m.titleInput.CursorEnd()
+	m.titleInput.Focus()
+	return textinput.Blink
+}
+
+func (m model) handleRenameModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.renaming = false
+		m.titleInput.Blur()
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.renaming = false
+		m.titleInput.Blur()
+		item, ok := m.list.SelectedItem().(plan)
+		if !ok {
+			return m, nil, true
+		}
+		name := strings.TrimSpace(m.titleInput.Value())
+		if file, err := planFileName(name); err == nil && file == item.file {
+			return m, nil, true
+		}
+		return m, m.store.renamePlan(item, name), true
+	}
+	var cmd tea.Cmd
+	m.titleInput, cmd = m.titleInput.Update(msg)
+	return m, cmd, true
+}
+
+// followRename moves the per-plan state keyed by path from a renamed plan's
+// old path to its new one, so the selection and render choices survive it.
+func (m *model) followRename(from, to string) {
+	for _, set := range []map[string]bool{m.selected, m.renderLarge} {
+		if set[from] {
+			delete(set, from)
+			set[to] = true
+		}
+	}
+}
+
+func (m model) renderRenameModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Rename")) + "\n")
+	ext := ".md"
+	if item, ok := m.list.SelectedItem().(plan); ok {
+		b.WriteString(dimStyle.Render(item.file) + "\n")
+		ext = filepath.Ext(item.file)
+	}
+	b.WriteString("\n" + m.titleInput.View() + dimStyle.Render(ext) + "\n\n")
+	b.WriteString(dimStyle.Render(tr("enter rename and update links · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
+
+// ─── Fork Modal ──────────────────────────────────────────────────────────────
+//
+// f copies the selected plan to a new file to start a follow-up iteration
+// from (see plans.Fork). The name defaults to the next -vN version.
+
+var versionSuffixRegex = regexp.MustCompile(`^(.*)-v(\d+)$`)
+
+// nextVersionName returns the name after stem in a -v2, -v3… series.
+func nextVersionName(stem string) string {
+	if m := versionSuffixRegex.FindStringSubmatch(stem); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		return fmt.Sprintf("%s-v%d", m[1], n+1)
+	}
+	return stem + "-v2"
+}
+
+func (m *model) openForkModal() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	m.forking = true
+	m.titleInput.SetValue(nextVersionName(strings.TrimSuffix(item.file, filepath.Ext(item.file))))
+	m.titleInput.CursorEnd()
+	m.titleInput.Focus()
+	return textinput.Blink
+}
+
+func (m model) handleForkModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.forking = false
+		m.titleInput.Blur()
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.forking = false
+		m.titleInput.Blur()
+		item, ok := m.list.SelectedItem().(plan)
+		if !ok {
+			return m, nil, true
+		}
+		return m, m.store.forkPlan(item, strings.TrimSpace(m.titleInput.Value())), true
+	}
+	var cmd tea.Cmd
+	m.titleInput, cmd = m.titleInput.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) renderForkModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Fork")) + "\n")
+	ext := ".md"
+	if item, ok := m.list.SelectedItem().(plan); ok {
+		b.WriteString(dimStyle.Render(trf("copy of %s", item.file)) + "\n")
+		ext = filepath.Ext(item.file)
+	}
+	b.WriteString("\n" + m.titleInput.View() + dimStyle.Render(ext) + "\n\n")
+	b.WriteString(dimStyle.Render(tr("status cleared, labels kept · enter create · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
+
+// ─── Move Modal ──────────────────────────────────────────────────────────────
+//
+// w moves the selected plan's file to another known plan directory: the agent
+// directory, its subdirectories with recursive_scan, the project directories
+// matched by project_plans_glob, and any other directory holding plans.
+
+type moveState struct {
+	active bool
+	dirs   []string // target directories, in sortSources order
+	cursor int
+}
+
+// planDirs returns the directories that hold plans or may: those of the
+// loaded plans, plus (outside demo mode) the agent directory, its scanned
+// subdirectories, and the project directories, agent directory first.
+func (m model) planDirs() []string {
+	dirs := planSources(*m.planSource(), m.dir)
+	if !m.demo.active {
+		known := append([]string{m.dir}, agentSubDirs(m.dir)...)
+		for _, d := range append(known, resolveProjectDirs(m.cfg.ProjectPlanGlob)...) {
+			if !slices.Contains(dirs, d) {
+				dirs = append(dirs, d)
+			}
+		}
+		sortSources(dirs, m.dir)
+	}
+	return dirs
+}
+
+// moveTargets returns the directories p can be moved to.
+func (m model) moveTargets(p plan) []string {
+	return slices.DeleteFunc(m.planDirs(), func(d string) bool { return d == p.dir })
+}
+
+func (m *model) openMoveModal() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	dirs := m.moveTargets(item)
+	if len(dirs) == 0 {
+		return m.setNotification(tr("No other plan directories"), statusTimeout)
+	}
+	m.move = moveState{active: true, dirs: dirs}
+	return nil
+}
+
+func (m model) handleMoveKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Move), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.move.active = false
+	case msg.String() == "j" || msg.String() == "down":
+		if m.move.cursor < len(m.move.dirs)-1 {
+			m.move.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.move.cursor > 0 {
+			m.move.cursor--
+		}
+	case msg.Type == tea.KeyEnter:
+		m.move.active = false
+		item, ok := m.list.SelectedItem().(plan)
+		if !ok || m.move.cursor >= len(m.move.dirs) {
+			return m, nil, true
+		}
+		return m, m.store.movePlan(item, m.move.dirs[m.move.cursor]), true
+	}
+	return m, nil, true
+}
+
+func (m model) renderMoveModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := min(m.width-4, 80)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Move to…")) + "\n")
+	if item, ok := m.list.SelectedItem().(plan); ok {
+		b.WriteString(dimStyle.Render(truncateForWidth(contractHome(item.path()), contentW)) + "\n")
+	}
+	b.WriteString("\n")
+	for i, d := range m.move.dirs {
+		name := sourceName(d, m.dir)
+		if name == "" {
+			name = "agent"
+		}
+		path := dimStyle.Render(truncateForWidth(contractHome(d), max(contentW-lipgloss.Width(name)-4, 10)))
+		if i == m.move.cursor {
+			b.WriteString(accentStyle.Render("> "+name) + "  " + path + "\n")
+		} else {
+			b.WriteString("  " + name + "  " + path + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("enter move · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
+
+// ─── demoStore ───────────────────────────────────────────────────────────────
+
+// demoRelocate returns plans with p replaced by moved, or an error if another
+// demo plan already has moved's path. Content follows the new filename.
+func (s demoStore) demoRelocate(p, moved plan) ([]plan, error) {
+	plans := *s.plans
+	if moved.path() != p.path() && slices.ContainsFunc(plans, func(dp plan) bool { return dp.path() == moved.path() }) {
+		return nil, fmt.Errorf("%s already exists", moved.file)
+	}
+	updated := slices.Clone(plans)
+	for i := range updated {
+		if updated[i].path() == p.path() {
+			updated[i] = moved
+		}
+	}
+	if moved.file != p.file {
+		s.content[moved.file] = s.content[p.file]
+		delete(s.content, p.file)
+	}
+	return updated, nil
+}
+
+func (s demoStore) renamePlan(p plan, name string) tea.Cmd {
+	file, err := planFileName(name)
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	moved := p
+	moved.file = file
+	updated, err := s.demoRelocate(p, moved)
+	var relinked int
+	if err == nil && file != p.file {
+		for i, dp := range updated {
+			if !linksTo(dp, p.file) {
+				continue
+			}
+			relinked++
+			s.content[dp.file] = plans.RenameLinks(s.content[dp.file], p.file, file)
+			updated[i].links = bodyLinks(s.content[dp.file])
+			if planRefName(dp.supersedes) == planRefName(p.file) {
+				updated[i].supersedes = file
+			}
+			if planRefName(dp.supersededBy) == planRefName(p.file) {
+				updated[i].supersededBy = file
+			}
+			updated[i].blockedBy, _ = renameBlocker(dp.blockedBy, p.file, file)
+		}
+		linkPlans(updated)
+	}
+	return func() tea.Msg {
+		if err != nil {
+			return errMsg{err}
+		}
+		return planFileMsg{plans: updated, path: moved.path(), message: "Renamed: " + p.file + " → " + file + relinkMessage(relinked, 0),
+			event: planEvent{kind: eventPlanRenamed, path: moved.path(), from: p.path(), to: moved.path()}}
+	}
+}
+
+func (s demoStore) movePlan(p plan, dir string) tea.Cmd {
+	moved := p
+	moved.dir = dir
+	updated, err := s.demoRelocate(p, moved)
+	return func() tea.Msg {
+		if err != nil {
+			return errMsg{err}
+		}
+		return planFileMsg{plans: updated, path: moved.path(), message: "Moved: " + p.file + " → " + contractHome(dir),
+			event: planEvent{kind: eventPlanMoved, path: moved.path(), from: p.path(), to: moved.path()}}
+	}
+}
+
+func (s demoStore) duplicatePlan(p plan) tea.Cmd {
+	plans := *s.plans
+	stem := strings.TrimSuffix(p.file, ".md") + "-copy"
+	file := stem + ".md"
+	for n := 2; slices.ContainsFunc(plans, func(dp plan) bool { return dp.dir == p.dir && dp.file == file }); n++ {
+		file = fmt.Sprintf("%s-%d.md", stem, n)
+	}
+	s.content[file] = s.content[p.file]
+	dup := p
+	dup.file = file
+	dup.created = time.Now()
+	dup.backlinks = nil
+	return func() tea.Msg {
+		updated := append(slices.Clone(plans), dup)
+		return planFileMsg{plans: updated, path: dup.path(), message: "Duplicated: " + file,
+			event: planEvent{kind: eventPlanDuplicated, path: dup.path(), from: p.path(), to: dup.path()}}
+	}
+}
+
+func (s demoStore) forkPlan(p plan, name string) tea.Cmd {
+	file, err := planFileName(name)
+	if err == nil && slices.ContainsFunc(*s.plans, func(dp plan) bool { return dp.dir == p.dir && dp.file == file }) {
+		err = fmt.Errorf("%s already exists", file)
+	}
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	plans := *s.plans
+	s.content[file] = s.content[p.file]
+	fork := p
+	fork.file = file
+	fork.created = time.Now()
+	fork.status, fork.history = "", nil
+	fork.supersedes, fork.supersededBy = "", ""
+	fork.backlinks = nil
+	for _, k := range []string{"status", "history", "supersedes", "superseded_by"} {
+		fork.fields = withField(fork.fields, k, "")
+	}
+	return func() tea.Msg {
+		updated := append(slices.Clone(plans), fork)
+		return planFileMsg{plans: updated, path: fork.path(), message: "Forked: " + p.file + " → " + file,
+			event: planEvent{kind: eventPlanDuplicated, path: fork.path(), from: p.path(), to: fork.path()}}
+	}
+}
+
+func (s demoStore) archivePlan(p plan) tea.Cmd {
+	plans := *s.plans
+	return func() tea.Msg {
+		var remaining []plan
+		for _, dp := range plans {
+			if dp.path() != p.path() {
+				remaining = append(remaining, dp)
+			}
+		}
+		archived := filepath.Join(p.dir, archiveDirName, p.file)
+		return planFileMsg{plans: remaining, message: "Archived: " + p.file,
+			event: planEvent{kind: eventPlanArchived, path: archived, from: p.path(), to: archived}}
+	}
+}
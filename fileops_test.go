@@ -0,0 +1,327 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPlanFileName(t *testing.T) {
+	tests := []struct {
+		name, want string
+		wantErr    bool
+	}{
+		{"auth", "auth.md", false},
+		{" auth.md ", "auth.md", false},
+		{"", "", true},
+		{"..", "", true},
+		{"a/b", "", true},
+	}
+	for _, tt := range tests {
+		got, err := planFileName(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("planFileName(%q) = %q, %v; want %q, err=%v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// storeFixture builds a store holding a.md and b.md in dir.
+type storeFixture func(t *testing.T, dir string) planStore
+
+func diskFixture(t *testing.T, dir string) planStore {
+	for _, f := range []string{"a.md", "b.md"} {
+		content := "---\nstatus: active\n---\n# " + f + "\n"
+		if err := os.WriteFile(filepath.Join(dir, f), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return diskStore{agentDir: dir}
+}
+
+func demoFixture(t *testing.T, dir string) planStore {
+	plans := []plan{
+		{dir: dir, file: "a.md", title: "a.md", status: "active"},
+		{dir: dir, file: "b.md", title: "b.md", status: "active"},
+	}
+	content := map[string]string{"a.md": "# a.md\n", "b.md": "# b.md\n"}
+	return demoStore{plans: &plans, content: content}
+}
+
+func TestPlanStoreFileOps(t *testing.T) {
+	stores := []struct {
+		name    string
+		fixture storeFixture
+	}{
+		{"disk", diskFixture},
+		{"demo", demoFixture},
+	}
+	tests := []struct {
+		name     string
+		op       func(s planStore, p plan, dir string) any
+		wantFile string // base name of the selected path, or "" if none
+		wantErr  string
+		gone     bool // a.md should be absent from the list
+		wantLen  int
+	}{
+		{
+			name:     "rename",
+			op:       func(s planStore, p plan, _ string) any { return s.renamePlan(p, "renamed")() },
+			wantFile: "renamed.md", gone: true, wantLen: 2,
+		},
+		{
+			name:    "rename onto existing",
+			op:      func(s planStore, p plan, _ string) any { return s.renamePlan(p, "b")() },
+			wantErr: "already exists",
+		},
+		{
+			name:    "rename invalid",
+			op:      func(s planStore, p plan, _ string) any { return s.renamePlan(p, "x/y")() },
+			wantErr: "invalid plan name",
+		},
+		{
+			name:     "duplicate",
+			op:       func(s planStore, p plan, _ string) any { return s.duplicatePlan(p)() },
+			wantFile: "a-copy.md", wantLen: 3,
+		},
+		{
+			name:     "fork",
+			op:       func(s planStore, p plan, _ string) any { return s.forkPlan(p, "a-v2")() },
+			wantFile: "a-v2.md", wantLen: 3,
+		},
+		{
+			name:    "fork onto existing",
+			op:      func(s planStore, p plan, _ string) any { return s.forkPlan(p, "b")() },
+			wantErr: "already exists",
+		},
+		{
+			name: "archive",
+			op:   func(s planStore, p plan, _ string) any { return s.archivePlan(p)() },
+			gone: true, wantLen: 1,
+		},
+		{
+			name:     "move",
+			op:       func(s planStore, p plan, dir string) any { return s.movePlan(p, filepath.Join(dir, "elsewhere"))() },
+			wantFile: "a.md",
+		},
+	}
+	for _, st := range stores {
+		for _, tt := range tests {
+			t.Run(st.name+"/"+tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				s := st.fixture(t, dir)
+				a := plan{dir: dir, file: "a.md", title: "a.md", status: "active"}
+
+				got := tt.op(s, a, dir)
+				if tt.wantErr != "" {
+					e, ok := got.(errMsg)
+					if !ok || !strings.Contains(e.err.Error(), tt.wantErr) {
+						t.Fatalf("got %#v, want error containing %q", got, tt.wantErr)
+					}
+					return
+				}
+				msg, ok := got.(planFileMsg)
+				if !ok {
+					t.Fatalf("got %#v, want planFileMsg", got)
+				}
+				if filepath.Base(msg.path) != tt.wantFile && !(tt.wantFile == "" && msg.path == "") {
+					t.Errorf("path = %q, want base %q", msg.path, tt.wantFile)
+				}
+				if msg.message == "" {
+					t.Error("missing notification message")
+				}
+				if tt.name == "move" {
+					if filepath.Dir(msg.path) != filepath.Join(dir, "elsewhere") {
+						t.Errorf("moved to %q", msg.path)
+					}
+					return
+				}
+				if len(msg.plans) != tt.wantLen {
+					t.Errorf("got %d plans, want %d", len(msg.plans), tt.wantLen)
+				}
+				hasA := slices.ContainsFunc(msg.plans, func(p plan) bool { return p.path() == a.path() })
+				if hasA == tt.gone {
+					t.Errorf("a.md present = %v, want %v", hasA, !tt.gone)
+				}
+				if tt.wantFile != "" && !slices.ContainsFunc(msg.plans, func(p plan) bool { return p.path() == msg.path }) {
+					t.Errorf("selected path %q not in list", msg.path)
+				}
+			})
+		}
+	}
+}
+
+func TestDuplicatePlanFileStampsCreated(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "a.md")
+	if err := os.WriteFile(src, []byte("---\nstatus: active\ncreated: 2020-01-01T00:00:00Z\n---\n# A\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	p := plan{dir: dir, file: "a.md"}
+
+	first, err := duplicatePlanFile(p, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := duplicatePlanFile(p, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(first) != "a-copy.md" || filepath.Base(second) != "a-copy-2.md" {
+		t.Errorf("copies = %s, %s", first, second)
+	}
+	data, _ := os.ReadFile(first)
+	fm, body := parseFrontmatter(string(data))
+	if fm["created"] != "2026-03-04T10:00:00Z" || fm["status"] != "active" {
+		t.Errorf("frontmatter = %v", fm)
+	}
+	if body != "# A\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestArchivePlanFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("# A\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := archivePlanFile(plan{dir: dir, file: "a.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst != filepath.Join(dir, archiveDirName, "a.md") {
+		t.Errorf("dst = %s", dst)
+	}
+	if _, err := os.Stat(dst); err != nil {
+		t.Errorf("archived file missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.md")); !os.IsNotExist(err) {
+		t.Errorf("original still present: %v", err)
+	}
+}
+
+func TestRenameUpdatesLinks(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "otter.md"), "---\nstatus: active\n---\n# Auth\n")
+	writeFile(t, filepath.Join(dir, "api.md"), "---\nstatus: active\n---\n# API\n\nDepends on [[otter]].\n")
+	writeFile(t, filepath.Join(dir, "old.md"), "---\nstatus: done\nsuperseded_by: otter.md\n---\n# Old\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	i := slices.IndexFunc(m.list.Items(), func(item list.Item) bool { return item.(plan).file == "otter.md" })
+	m.list.Select(i)
+	m.selected[filepath.Join(dir, "otter.md")] = true
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = m2.(model)
+	if !m.renaming || m.titleInput.Value() != "otter" {
+		t.Fatalf("r should open the rename prompt with the name, got %v %q", m.renaming, m.titleInput.Value())
+	}
+	m.titleInput.SetValue("auth-rollout")
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	msg := cmd()
+	fm, ok := msg.(planFileMsg)
+	if !ok {
+		t.Fatalf("got %#v, want planFileMsg", msg)
+	}
+	if !strings.Contains(fm.message, "updated links in 2 plans") {
+		t.Errorf("message = %q", fm.message)
+	}
+	m2, _ = m.Update(msg)
+	m = m2.(model)
+	if !m.selected[filepath.Join(dir, "auth-rollout.md")] || len(m.selected) != 1 {
+		t.Errorf("selection should follow the rename: %v", m.selected)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "api.md")); !strings.Contains(string(data), "[[auth-rollout]]") {
+		t.Errorf("api.md link not updated:\n%s", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "old.md")); !strings.Contains(string(data), "superseded_by: auth-rollout.md") {
+		t.Errorf("old.md superseded_by not updated:\n%s", data)
+	}
+}
+
+func TestRenameUpdatesBlockedBy(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "otter.md"), "---\nstatus: active\n---\n# Auth\n")
+	writeFile(t, filepath.Join(dir, "api.md"), "---\nblocked_by: otter, infra.md\n---\n# API\n")
+	writeFile(t, filepath.Join(dir, "ui.md"), "---\nblocked_by: Otter.md\n---\n# UI\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated, failed := relinkPlans(all, "otter.md", "auth-rollout.md"); updated != 2 || failed != 0 {
+		t.Errorf("relinkPlans = %d, %d; want 2, 0", updated, failed)
+	}
+	for file, want := range map[string]string{"api.md": "blocked_by: auth-rollout, infra.md\n", "ui.md": "blocked_by: auth-rollout.md\n"} {
+		if data, _ := os.ReadFile(filepath.Join(dir, file)); !strings.Contains(string(data), want) {
+			t.Errorf("%s blocked_by not updated:\n%s", file, data)
+		}
+	}
+
+	demo := []plan{{dir: "/demo", file: "otter.md"}, {dir: "/demo", file: "api.md", blockedBy: []string{"otter", "infra.md"}}}
+	s := demoStore{plans: &demo, content: map[string]string{}}
+	msg, ok := s.renamePlan(demo[0], "auth-rollout")().(planFileMsg)
+	if !ok {
+		t.Fatal("demo rename failed")
+	}
+	for _, p := range msg.plans {
+		if p.file == "api.md" && !slices.Equal(p.blockedBy, []string{"auth-rollout", "infra.md"}) {
+			t.Errorf("demo blocked_by = %q", p.blockedBy)
+		}
+	}
+}
+
+func TestNextVersionName(t *testing.T) {
+	for stem, want := range map[string]string{"auth": "auth-v2", "auth-v2": "auth-v3", "v2-api-v9": "v2-api-v10"} {
+		if got := nextVersionName(stem); got != want {
+			t.Errorf("nextVersionName(%q) = %q, want %q", stem, got, want)
+		}
+	}
+}
+
+func TestMoveModal(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(t.TempDir(), "app", "plans")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "auth.md"), "---\nstatus: active\n---\n# Auth\n")
+	cfg := newDefaultConfig()
+	cfg.ProjectPlanGlob = project
+	all, err := scanAllPlans(dir, cfg.ProjectPlanGlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, cfg, nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = m2.(model)
+	if !m.move.active || !slices.Equal(m.move.dirs, []string{project}) {
+		t.Fatalf("w should list the project dir, got %v %v", m.move.active, m.move.dirs)
+	}
+	if out := m.View(); !strings.Contains(out, "app") {
+		t.Errorf("modal should name the project:\n%s", out)
+	}
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	msg, ok := cmd().(planFileMsg)
+	if !ok || msg.path != filepath.Join(project, "auth.md") {
+		t.Fatalf("got %#v", msg)
+	}
+	if _, err := os.Stat(filepath.Join(project, "auth.md")); err != nil {
+		t.Errorf("plan not moved: %v", err)
+	}
+}
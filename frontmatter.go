@@ -0,0 +1,53 @@
+package main
+
+import (
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Frontmatter ─────────────────────────────────────────────────────────────
+//
+// Parsing and line-preserving edits live in the plans package. These
+// shorthands keep call sites terse and avoid clashing with the many local
+// variables named plans.
+
+// labelsAsList makes YAML labels default to a block sequence. Set from
+// config.LabelsAsList.
+var labelsAsList = &plans.LabelsAsList
+
+func parseFrontmatter(content string) (map[string]string, string) {
+	return plans.ParseFrontmatter(content)
+}
+
+func splitFrontmatter(content string) (front []string, body, delim string) {
+	return plans.SplitFrontmatter(content)
+}
+
+func editFrontmatter(front []string, updates map[string]string, delim string) []string {
+	return plans.EditFrontmatter(front, updates, delim)
+}
+
+func joinFrontmatter(front []string, body, delim string) string {
+	return plans.JoinFrontmatter(front, body, delim)
+}
+
+func restoreEncoding(original, result string) string {
+	return plans.RestoreEncoding(original, result)
+}
+
+func setFrontmatter(path string, updates map[string]string) error {
+	return plans.SetFrontmatter(path, updates)
+}
+
+func parseFrontmatterTime(v string) (time.Time, bool) {
+	return plans.ParseTime(v)
+}
+
+func parseLabels(s string) []string {
+	return plans.ParseLabels(s)
+}
+
+func labelsString(labels []string) string {
+	return plans.FormatLabels(labels)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Field getters ───────────────────────────────────────────────────────────
+//
+// `planc title|status|labels|comments-count PLAN...` print one field per plan,
+// a line each in argument order, for shell prompts and status bars that
+// shouldn't have to parse planc list --json. `planc status` without plans
+// prints how many plans have each status, e.g. "3 active".
+
+func runTitle(cfg config, args []string) int {
+	return runGetter(cfg, "title", args, func(p plans.Plan) string { return p.Title })
+}
+
+func runStatus(cfg config, args []string) int {
+	return runGetter(cfg, "status", args, func(p plans.Plan) string { return exportStatus(p.Status) })
+}
+
+func runLabels(cfg config, args []string) int {
+	return runGetter(cfg, "labels", args, func(p plans.Plan) string { return labelsString(p.Labels) })
+}
+
+func runCommentsCount(cfg config, args []string) int {
+	return runGetter(cfg, "comments-count", args, func(p plans.Plan) string { return strconv.Itoa(p.OpenComments) })
+}
+
+// runGetter prints get for each plan named in args.
+func runGetter(cfg config, name string, args []string, get func(plans.Plan) string) int {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		if name == "status" {
+			fmt.Fprintln(fs.Output(), "Usage: planc status [PLAN...]")
+			fmt.Fprintln(fs.Output(), "Prints each plan's status, or with no plans the number of plans in each status.")
+			return
+		}
+		fmt.Fprintf(fs.Output(), "Usage: planc %s PLAN...\n", name)
+		if name == "comments-count" {
+			fmt.Fprintln(fs.Output(), "Prints the number of open (unresolved) comments in each plan.")
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 && name != "status" {
+		fs.Usage()
+		return 2
+	}
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("%s: %v", name, err)
+	}
+	if fs.NArg() == 0 {
+		counts := statusCounts(all)
+		for _, opt := range statusOptions() {
+			fmt.Printf("%d %s\n", counts[opt.status], opt.label)
+		}
+		return 0
+	}
+	values, err := planFieldValues(all, fs.Args(), get)
+	if err != nil {
+		return cliError("%s: %v", name, err)
+	}
+	for _, v := range values {
+		fmt.Println(v)
+	}
+	return 0
+}
+
+// planFieldValues returns get for each plan named in args, in order.
+func planFieldValues(all []plan, args []string, get func(plans.Plan) string) ([]string, error) {
+	paths, err := resolvePlanPaths(all, args)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(paths))
+	for _, path := range paths {
+		p, _, err := plans.Read(path)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, get(p))
+	}
+	return values, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jakebf/planc/plans"
+)
+
+func TestPlanFieldValues(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "auth.md"), "---\nstatus: active\nlabels: api, infra\n---\n# Auth\n\n> **[comment]:** why?\n\n> **[resolved]:** done\n")
+	writeFile(t, filepath.Join(dir, "notes.md"), "Just text.\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		get  func(plans.Plan) string
+		want string
+	}{
+		{func(p plans.Plan) string { return p.Title }, "Auth,notes"},
+		{func(p plans.Plan) string { return exportStatus(p.Status) }, "active,new"},
+		{func(p plans.Plan) string { return labelsString(p.Labels) }, "api, infra,"},
+		{func(p plans.Plan) string { return strconv.Itoa(p.OpenComments) }, "1,0"},
+	} {
+		got, err := planFieldValues(all, []string{"auth", "notes.md"}, tt.get)
+		if err != nil || strings.Join(got, ",") != tt.want {
+			t.Errorf("values = %q, %v; want %q", got, err, tt.want)
+		}
+	}
+	if _, err := planFieldValues(all, []string{"missing"}, func(p plans.Plan) string { return p.Title }); err == nil {
+		t.Error("missing plan resolved")
+	}
+}
+
+func TestRunGetterUsage(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	if code := runTitle(cfg, nil); code != 2 {
+		t.Errorf("planc title without plans: exit code %d", code)
+	}
+	if code := runStatus(cfg, nil); code != 0 {
+		t.Errorf("planc status without plans: exit code %d", code)
+	}
+}
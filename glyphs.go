@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Glyphs ──────────────────────────────────────────────────────────────────
+//
+// The status icons and the comment and selection marks come from a glyph set
+// the glyphs config field can override, e.g. nerd-font icons:
+//
+//	"glyphs": {"active": "", "done": "", "comment": " "}
+//
+// Status and selection glyphs are padded to the widest of them, so
+// multi-cell glyphs keep list rows and modals aligned.
+
+// glyphSet holds the glyphs in use. Keys match the config field.
+type glyphSet struct {
+	new, reviewed, active, done string
+	comment                     string
+	selected, unselected        string
+	width                       int // display width status and selection glyphs are padded to
+}
+
+func defaultGlyphs() glyphSet {
+	return glyphSet{
+		new: "·", reviewed: "○", active: "●", done: "✓",
+		comment:  "💬",
+		selected: "✓", unselected: "·",
+		width: 1,
+	}
+}
+
+var glyphs atomic.Pointer[glyphSet]
+
+// setGlyphs applies config overrides over the defaults. Unknown keys and
+// empty values are ignored. Icons from the statuses field count toward the
+// padded width (see setStatuses).
+func setGlyphs(overrides map[string]string) {
+	g := defaultGlyphs()
+	fields := map[string]*string{
+		"new": &g.new, "reviewed": &g.reviewed, "active": &g.active, "done": &g.done,
+		"comment": &g.comment, "selected": &g.selected, "unselected": &g.unselected,
+	}
+	for k, v := range overrides {
+		if f, ok := fields[k]; ok && v != "" {
+			*f = v
+		}
+	}
+	for _, s := range []string{g.new, g.reviewed, g.active, g.done, g.selected, g.unselected} {
+		g.width = max(g.width, lipgloss.Width(s))
+	}
+	for _, opt := range statusOptions() {
+		g.width = max(g.width, lipgloss.Width(opt.icon))
+	}
+	glyphs.Store(&g)
+}
+
+func currentGlyphs() glyphSet {
+	if g := glyphs.Load(); g != nil {
+		return *g
+	}
+	return defaultGlyphs()
+}
+
+// pad right-pads a glyph to the set's width.
+func (g glyphSet) pad(s string) string {
+	if w := lipgloss.Width(s); w < g.width {
+		return s + strings.Repeat(" ", g.width-w)
+	}
+	return s
+}
+
+// statusIcon returns the padded glyph for a status.
+func statusIcon(s string) string {
+	g := currentGlyphs()
+	return g.pad(statusIconFor(s, g))
+}
+
+// styledStatusIcon returns a status's glyph in its color.
+func styledStatusIcon(s string) string {
+	return statusStyle(s).Render(statusIcon(s))
+}
+
+// selectIcon returns the padded selection mark.
+func selectIcon(marked bool) string {
+	g := currentGlyphs()
+	if marked {
+		return g.pad(g.selected)
+	}
+	return g.pad(g.unselected)
+}
+
+// commentIcon returns the comment glyph.
+func commentIcon() string {
+	return currentGlyphs().comment
+}
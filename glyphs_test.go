@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSetGlyphsPadsToWidest(t *testing.T) {
+	t.Cleanup(func() { setGlyphs(nil) })
+	setGlyphs(map[string]string{"active": "[a]", "done": "", "bogus": "x"})
+
+	if got := statusIcon("active"); got != "[a]" {
+		t.Errorf("active = %q", got)
+	}
+	if got := statusIcon("done"); got != "✓  " {
+		t.Errorf("an empty override keeps the default, padded: %q", got)
+	}
+	if got := selectIcon(false); got != "·  " {
+		t.Errorf("unselected = %q", got)
+	}
+
+	setGlyphs(nil)
+	if got := statusIcon("reviewed"); got != "○" {
+		t.Errorf("defaults = %q", got)
+	}
+}
+
+func TestCustomGlyphsInList(t *testing.T) {
+	t.Cleanup(func() { setGlyphs(nil) })
+	setGlyphs(map[string]string{"active": "A>", "reviewed": "R>", "new": "N>", "done": "D>"})
+	m := testModel()
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = m2.(model)
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "A> ") || strings.Contains(view, "●") {
+		t.Errorf("list should draw the configured glyphs:\n%s", view)
+	}
+
+	// Selection marks are padded to the status glyph width.
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = m2.(model)
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "✓  ") {
+		t.Errorf("selection mark should be padded:\n%s", view)
+	}
+}
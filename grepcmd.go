@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ─── planc grep ──────────────────────────────────────────────────────────────
+//
+// `planc grep PATTERN` searches plan bodies, which the TUI's / filter
+// doesn't, and prints one tab-separated line per match: file, line number,
+// the heading the line is under, and the line. Line numbers count from the
+// top of the file, frontmatter included, so they work with `$EDITOR +N`.
+// Like grep, it exits 1 when nothing matches.
+
+// grepMatch is one matching line in `planc grep --json`.
+type grepMatch struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Heading string `json:"heading"`
+	Text    string `json:"text"`
+}
+
+var headingPattern = regexp.MustCompile(`^#{1,6}\s+(.*\S)`)
+
+// grepPlan returns the lines of a plan file's body that match re.
+func grepPlan(path, content string, re *regexp.Regexp) []grepMatch {
+	_, body := parseFrontmatter(content)
+	offset := 0
+	if strings.HasSuffix(content, body) {
+		offset = strings.Count(content[:len(content)-len(body)], "\n")
+	}
+	var out []grepMatch
+	heading := ""
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		} else if m := headingPattern.FindStringSubmatch(trimmed); m != nil && !inFence {
+			heading = m[1]
+		}
+		if re.MatchString(line) {
+			out = append(out, grepMatch{File: path, Line: offset + i + 1, Heading: heading, Text: strings.TrimRight(line, "\r")})
+		}
+	}
+	return out
+}
+
+func writeGrepTSV(w io.Writer, matches []grepMatch) {
+	clean := strings.NewReplacer("\t", " ")
+	for _, m := range matches {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", m.File, m.Line, clean.Replace(m.Heading), clean.Replace(m.Text))
+	}
+}
+
+func runGrep(cfg config, args []string) int {
+	fs := flag.NewFlagSet("grep", flag.ContinueOnError)
+	ignoreCase := fs.Bool("i", false, "ignore case")
+	fixed := fs.Bool("F", false, "match PATTERN as plain text, not a regular expression")
+	asJSON := fs.Bool("json", false, "print a JSON array of matches")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc grep [-i] [-F] [--json] PATTERN")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	pattern := fs.Arg(0)
+	if *fixed {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if *ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return cliError("grep: %v", err)
+	}
+
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("grep: %v", err)
+	}
+	sortPlans(plans)
+	matches := []grepMatch{}
+	for _, p := range plans {
+		data, err := os.ReadFile(p.path())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "planc: grep: %v\n", err)
+			continue
+		}
+		matches = append(matches, grepPlan(p.path(), string(data), re)...)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(matches); err != nil {
+			return cliError("grep: %v", err)
+		}
+	} else {
+		writeGrepTSV(os.Stdout, matches)
+	}
+	if len(matches) == 0 {
+		return 1
+	}
+	return 0
+}
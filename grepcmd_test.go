@@ -0,0 +1,44 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestGrepPlan(t *testing.T) {
+	content := "---\nstatus: active\nnote: retry\n---\n# Plan\n\n## Rollout\n\nRetry with backoff.\n\n```sh\n# retry loop\n```\n\n## Cleanup\n\nretry later\n"
+	got := grepPlan("/p/a.md", content, regexp.MustCompile("(?i)retry"))
+	want := []grepMatch{
+		{"/p/a.md", 9, "Rollout", "Retry with backoff."},
+		{"/p/a.md", 12, "Rollout", "# retry loop"}, // a comment in code, not a heading
+		{"/p/a.md", 17, "Cleanup", "retry later"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("matches = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunGrep(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	writeFile(t, filepath.Join(cfg.PlansDir, "a.md"), "# A\n\nUse a.b for routing.\n")
+
+	if code := runGrep(cfg, []string{"-F", "a.b"}); code != 0 {
+		t.Errorf("exit code %d", code)
+	}
+	if code := runGrep(cfg, []string{"-F", "a*b"}); code != 1 {
+		t.Errorf("no match: exit code %d, want 1", code)
+	}
+	if code := runGrep(cfg, []string{"("}); code != 1 {
+		t.Errorf("bad pattern: exit code %d, want 1", code)
+	}
+	if code := runGrep(cfg, nil); code != 2 {
+		t.Errorf("no pattern: exit code %d, want 2", code)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Grouped View ────────────────────────────────────────────────────────────
+//
+// G groups the list under header rows by status, label, or source. Enter on a
+// header collapses the group to that row; collapse state lasts the session.
+
+// groupModes is the G cycle order; "" is the flat list.
+var groupModes = []string{"", "status", "label", "source"}
+
+// groupHeader is a list row heading one group. It has no plan, so the
+// existing SelectedItem().(plan) checks treat it as "nothing selected".
+type groupHeader struct {
+	name      string
+	count     int
+	collapsed bool
+}
+
+func (h groupHeader) FilterValue() string { return "" }
+
+// groupName is the group p belongs to under mode.
+func groupName(p plan, mode, agentDir string) string {
+	switch mode {
+	case "status":
+		return statusLabel(p.status)
+	case "label":
+		if len(p.labels) == 0 {
+			return "unlabeled"
+		}
+		return p.labels[0]
+	case "source":
+		if name := sourceName(p.dir, agentDir); name != "" {
+			return name
+		}
+		return "agent"
+	}
+	return ""
+}
+
+// groupItems lays plans out under a header per group, keeping the plans'
+// order within each group. Status groups follow statusRank; other groups are
+// alphabetical, with the catch-all group last. Collapsed groups keep only
+// their header.
+func groupItems(plans []plan, mode, agentDir string, collapsed map[string]bool) []list.Item {
+	if mode == "" {
+		return plansToItems(plans)
+	}
+	groups := make(map[string][]plan)
+	var names []string
+	for _, p := range plans {
+		name := groupName(p, mode, agentDir)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], p)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		if mode == "status" {
+			return statusRank(names[i]) < statusRank(names[j])
+		}
+		ci, cj := names[i] == "unlabeled" || names[i] == "agent", names[j] == "unlabeled" || names[j] == "agent"
+		if ci != cj {
+			return cj
+		}
+		return names[i] < names[j]
+	})
+	var items []list.Item
+	for _, name := range names {
+		closed := collapsed[mode+":"+name]
+		items = append(items, groupHeader{name: name, count: len(groups[name]), collapsed: closed})
+		if !closed {
+			items = append(items, plansToItems(groups[name])...)
+		}
+	}
+	return items
+}
+
+// listItems builds list rows for visible plans in the current group mode.
+func (m model) listItems(plans []plan) []list.Item {
+	return groupItems(markBlocked(plans, *m.planSource()), m.groupBy, m.dir, m.collapsedGroups)
+}
+
+// setListItems shows plans in the list. The semantic search filter gets the
+// same rows, so it can look plans up by path.
+func (m *model) setListItems(plans []plan) {
+	items := m.listItems(plans)
+	if m.semantic != nil {
+		m.list.Filter = m.semantic.filterFor(items)
+	}
+	m.list.SetItems(items)
+}
+
+// cycleGroupMode moves to the next group mode and rebuilds the list, keeping
+// the cursor on the same plan.
+func (m *model) cycleGroupMode() {
+	prev := m.selectedFile()
+	for i, mode := range groupModes {
+		if mode == m.groupBy {
+			m.groupBy = groupModes[(i+1)%len(groupModes)]
+			break
+		}
+	}
+	m.setListItems(m.visiblePlans())
+	m.selectFile(prev)
+	m.restoreTitle()
+}
+
+// toggleGroup collapses or expands the group whose header is under the
+// cursor. Returns false if the cursor isn't on a header.
+func (m *model) toggleGroup() bool {
+	h, ok := m.list.SelectedItem().(groupHeader)
+	if !ok {
+		return false
+	}
+	k := m.groupBy + ":" + h.name
+	m.collapsedGroups[k] = !m.collapsedGroups[k]
+	idx := m.list.Index()
+	m.setListItems(m.visiblePlans())
+	m.list.Select(idx)
+	return true
+}
+
+// renderGroupHeader draws a header row: a disclosure arrow, the group name,
+// and its plan count.
+func renderGroupHeader(w io.Writer, h groupHeader, cursor bool) {
+	bar := normalBar
+	if cursor {
+		bar = selectedBar
+	}
+	arrow := "▾"
+	if h.collapsed {
+		arrow = "▸"
+	}
+	name := lipgloss.NewStyle().Bold(true).Render(h.name)
+	fmt.Fprintf(w, "%s%s %s %s", bar, dateStyle.Render(arrow), name, dateStyle.Render(fmt.Sprintf("%d", h.count)))
+}
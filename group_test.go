@@ -0,0 +1,92 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// itemNames renders list items as "#name" for headers and the file for plans.
+func itemNames(items []list.Item) []string {
+	var out []string
+	for _, it := range items {
+		switch v := it.(type) {
+		case groupHeader:
+			out = append(out, "#"+v.name)
+		case plan:
+			out = append(out, v.file)
+		}
+	}
+	return out
+}
+
+func TestGroupItemsByStatus(t *testing.T) {
+	plans := []plan{
+		{file: "d.md", status: "done"},
+		{file: "a1.md", status: "active"},
+		{file: "n.md"},
+		{file: "a2.md", status: "active"},
+	}
+	items := groupItems(plans, "status", "", map[string]bool{})
+	want := []string{"#active", "a1.md", "a2.md", "#new", "n.md", "#done", "d.md"}
+	if names := itemNames(items); !slices.Equal(names, want) {
+		t.Fatalf("items = %v, want %v", names, want)
+	}
+	if h := items[0].(groupHeader); h.count != 2 {
+		t.Errorf("active count = %d, want 2", h.count)
+	}
+
+	items = groupItems(plans, "status", "", map[string]bool{"status:active": true})
+	if h, ok := items[0].(groupHeader); !ok || !h.collapsed {
+		t.Fatalf("first item should be a collapsed header, got %v", items[0])
+	}
+	if _, ok := items[1].(groupHeader); !ok {
+		t.Errorf("collapsed group should hide its plans, got %v", items[1])
+	}
+}
+
+func TestGroupItemsByLabelPutsUnlabeledLast(t *testing.T) {
+	plans := []plan{
+		{file: "x.md"},
+		{file: "w.md", labels: []string{"web"}},
+		{file: "a.md", labels: []string{"api", "web"}},
+	}
+	items := groupItems(plans, "label", "", map[string]bool{})
+	want := []string{"#api", "a.md", "#web", "w.md", "#unlabeled", "x.md"}
+	if names := itemNames(items); !slices.Equal(names, want) {
+		t.Errorf("items = %v, want %v", names, want)
+	}
+	if got := groupItems(plans, "", "", nil); len(got) != len(plans) {
+		t.Errorf("flat mode should have no headers, got %d items", len(got))
+	}
+}
+
+func TestGroupCollapseWithEnter(t *testing.T) {
+	m := testModel()
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = m2.(model)
+	if m.groupBy != "status" {
+		t.Fatalf("G should group by status, got %q", m.groupBy)
+	}
+	before := len(m.list.Items())
+	m.list.Select(0)
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.comment.active {
+		t.Fatal("enter on a header should not open comment mode")
+	}
+	if h, ok := m.list.SelectedItem().(groupHeader); !ok || !h.collapsed || h.name != "active" {
+		t.Fatalf("selected = %v, want collapsed active header", m.list.SelectedItem())
+	}
+	if after := len(m.list.Items()); after != before-2 {
+		t.Errorf("items after collapse = %d, want %d", after, before-2)
+	}
+
+	// Revealing a plan in a collapsed group expands it.
+	m.revealPlan(m.allPlans[0].path())
+	if p, ok := m.list.SelectedItem().(plan); !ok || p.file != m.allPlans[0].file {
+		t.Errorf("revealPlan should expand the group and select the plan, got %v", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Status History ──────────────────────────────────────────────────────────
+//
+// Every status change appends a "<time> <from> -> <to>" entry to the plan's
+// history: frontmatter list (see plans.StatusUpdates). H shows the history of
+// the selected plan with the time spent in each status, and its cycle time:
+// from first going active to last going done.
+
+// withStatus returns p with status set and the change added to its history,
+// matching what plans.StatusUpdates writes.
+func withStatus(p plan, status string, at time.Time) plan {
+	if p.status != status {
+		p.history = append(p.history[:len(p.history):len(p.history)], plans.StatusChange{At: at, From: p.status, To: status})
+	}
+	p.status = status
+	return p
+}
+
+// cycleTime returns the time from p's first change to active (see isActive)
+// to its last change to a done status after that, or false if it hasn't made both.
+func cycleTime(history []plans.StatusChange) (time.Duration, bool) {
+	var start, end time.Time
+	for _, c := range history {
+		switch {
+		case isActive(c.To) && start.IsZero():
+			start = c.At
+		case isDone(c.To) && !start.IsZero():
+			end = c.At
+		}
+	}
+	if start.IsZero() || end.IsZero() {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
+
+// formatSpan renders d compactly: "45m", "5h", "3d 4h", "12d".
+func formatSpan(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 7*24*time.Hour:
+		days := int(d.Hours()) / 24
+		if h := int(d.Hours()) % 24; h > 0 {
+			return fmt.Sprintf("%dd %dh", days, h)
+		}
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dd", int(d.Hours())/24)
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+func (m *model) openHistory() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	if len(item.history) == 0 {
+		return m.setNotification(tr("No status changes recorded"), statusTimeout)
+	}
+	m.showHistory = true
+	return nil
+}
+
+func (m model) handleHistoryKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.History), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.showHistory = false
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderHistoryModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return ""
+	}
+	modalW := min(m.width-4, 80)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Status History")) + "\n")
+	b.WriteString(dimStyle.Render(truncateForWidth(item.title, contentW)) + "\n\n")
+	prev := item.created
+	for _, c := range item.history {
+		line := c.At.Local().Format("2006-01-02 15:04") + "  " +
+			statusIcon(c.From) + " " + statusLabel(c.From) + " → " + statusIcon(c.To) + " " + statusLabel(c.To)
+		if !prev.IsZero() && c.At.After(prev) {
+			line += dimStyle.Render("  " + trf("after %s", formatSpan(c.At.Sub(prev))))
+		}
+		b.WriteString(line + "\n")
+		prev = c.At
+	}
+	if n := len(item.history); n > 0 && item.history[n-1].To == item.status && !isDone(item.status) {
+		b.WriteString(dimStyle.Render(trf("%s for %s", statusLabel(item.status), formatSpan(time.Since(item.history[n-1].At)))) + "\n")
+	}
+	if d, ok := cycleTime(item.history); ok {
+		b.WriteString("\n" + accentStyle.Render(tr("Cycle time")) + " " + formatSpan(d) + dimStyle.Render(tr(" (active → done)")) + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("esc/H close")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
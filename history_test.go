@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/jakebf/planc/plans"
+)
+
+func TestCycleTime(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	history := []plans.StatusChange{
+		{At: day(1), From: "", To: "reviewed"},
+		{At: day(2), From: "reviewed", To: "active"},
+		{At: day(4), From: "active", To: "done"},
+		{At: day(5), From: "done", To: "active"},
+		{At: day(8), From: "active", To: "done"},
+	}
+	if d, ok := cycleTime(history); !ok || d != 6*24*time.Hour {
+		t.Errorf("cycleTime = %v, %v", d, ok)
+	}
+	if _, ok := cycleTime(history[:2]); ok {
+		t.Error("a plan that isn't done has no cycle time")
+	}
+	for d, want := range map[time.Duration]string{
+		45 * time.Minute:       "45m",
+		30 * time.Hour:         "30h",
+		(3*24 + 4) * time.Hour: "3d 4h",
+		12 * 24 * time.Hour:    "12d",
+	} {
+		if got := formatSpan(d); got != want {
+			t.Errorf("formatSpan(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestStatusHistoryView(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	writeFile(t, path, "---\nstatus: reviewed\ncreated: 2026-03-01T09:00:00Z\n---\n# Auth\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+
+	for _, status := range []string{"active", "done"} {
+		item := m.list.SelectedItem().(plan)
+		m2, _ = m.Update(setPlanStatus(item, status)())
+		m = m2.(model)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = m2.(model)
+	if !m.showHistory {
+		t.Fatal("H should open the status history")
+	}
+	out := ansi.Strip(m.View())
+	for _, want := range []string{"reviewed → ", "active → ", "Cycle time"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history view missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// ─── Localization ────────────────────────────────────────────────────────────
+//
+// User-facing strings go through tr, gettext style: the English text is the
+// key, and a locale file maps it to a translation. Locale files are flat JSON
+// objects named after the locale (es.json, pt_BR.json); planc ships some in
+// locales/, and a file of the same name in locales/ next to config.json
+// overrides or extends them. The locale comes from the locale config field,
+// else LC_ALL, LC_MESSAGES, or LANG. Missing strings stay English.
+
+const localesDirName = "locales"
+
+//go:embed locales/*.json
+var bundledLocales embed.FS
+
+var catalog atomic.Pointer[map[string]string]
+
+// tr returns the translation of s in the current locale, or s.
+func tr(s string) string {
+	c := catalog.Load()
+	if c == nil {
+		setLocale("")
+		c = catalog.Load()
+	}
+	if t, ok := (*c)[s]; ok && t != "" {
+		return t
+	}
+	return s
+}
+
+// trf translates a format string, then formats it.
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// envLocale returns the locale the environment asks for, as gettext
+// resolves it.
+func envLocale() string {
+	for _, v := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if s := os.Getenv(v); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// localeCandidates returns the locale file names to try for a locale, most
+// specific first: "de_DE.UTF-8@euro" tries de_DE, then de. English and the C
+// locale need no file.
+func localeCandidates(locale string) []string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.ReplaceAll(locale, "-", "_")
+	lang, _, _ := strings.Cut(locale, "_")
+	lang = strings.ToLower(lang)
+	if lang == "" || lang == "c" || lang == "posix" || lang == "en" {
+		return nil
+	}
+	if locale != lang {
+		return []string{locale, lang}
+	}
+	return []string{lang}
+}
+
+// loadCatalog merges the bundled and user locale files for locale. Less
+// specific files are applied first so a region file only needs the strings
+// that differ.
+func loadCatalog(locale, userDir string) map[string]string {
+	out := make(map[string]string)
+	names := localeCandidates(locale)
+	for i := len(names) - 1; i >= 0; i-- {
+		if data, err := bundledLocales.ReadFile("locales/" + names[i] + ".json"); err == nil {
+			mergeLocale(out, data)
+		}
+		if userDir != "" {
+			if data, err := os.ReadFile(filepath.Join(userDir, names[i]+".json")); err == nil {
+				mergeLocale(out, data)
+			}
+		}
+	}
+	return out
+}
+
+// mergeLocale adds a locale file's entries to c. A malformed file is
+// skipped rather than failing startup.
+func mergeLocale(c map[string]string, data []byte) {
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return
+	}
+	for k, v := range entries {
+		c[k] = v
+	}
+}
+
+// setLocale loads the catalog for the configured locale, or the
+// environment's when it's empty.
+func setLocale(locale string) {
+	if locale == "" {
+		locale = envLocale()
+	}
+	var userDir string
+	if path, err := configPath(); err == nil {
+		userDir = filepath.Join(filepath.Dir(path), localesDirName)
+	}
+	c := loadCatalog(locale, userDir)
+	catalog.Store(&c)
+}
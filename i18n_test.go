@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLocaleCandidates(t *testing.T) {
+	tests := map[string][]string{
+		"de_DE.UTF-8":      {"de_DE", "de"},
+		"pt-BR":            {"pt_BR", "pt"},
+		"es":               {"es"},
+		"ca_ES.UTF-8@euro": {"ca_ES", "ca"},
+		"en_US.UTF-8":      nil,
+		"C.UTF-8":          nil,
+		"POSIX":            nil,
+		"":                 nil,
+	}
+	for in, want := range tests {
+		if got := localeCandidates(in); !slices.Equal(got, want) {
+			t.Errorf("localeCandidates(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestLoadCatalogUserOverrides(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "es.json"), []byte(`{"quit": "cerrar", "Extra": "Más"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "es_MX.json"), []byte(`{"search": "búsqueda"}`), 0o644)
+
+	c := loadCatalog("es_MX.UTF-8", dir)
+	if c["quit"] != "cerrar" {
+		t.Errorf("user file should override the bundled one: %q", c["quit"])
+	}
+	if c["Extra"] != "Más" || c["search"] != "búsqueda" {
+		t.Errorf("user and region entries should be merged: %v", c)
+	}
+	if c["help"] != "ayuda" {
+		t.Errorf("bundled entries should remain: %q", c["help"])
+	}
+
+	if c := loadCatalog("fr_FR", dir); len(c) != 0 {
+		t.Errorf("unknown locale should be empty: %v", c)
+	}
+}
+
+func TestTrFallsBackToEnglish(t *testing.T) {
+	t.Cleanup(func() { setLocale("C") })
+	setLocale("es")
+	if got := tr("quit"); got != "salir" {
+		t.Errorf("tr(quit) = %q", got)
+	}
+	if got := tr("not a known string"); got != "not a known string" {
+		t.Errorf("missing strings should stay English: %q", got)
+	}
+	if got := trf("Deleted: %s", "a.md"); got != "Eliminado: a.md" {
+		t.Errorf("trf = %q", got)
+	}
+
+	setLocale("C")
+	if got := tr("quit"); got != "quit" {
+		t.Errorf("C locale should be English: %q", got)
+	}
+}
+
+// Bundled translations must keep the format verbs of their keys, or trf
+// would print garbage.
+func TestBundledLocalesKeepVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z]`)
+	files, err := bundledLocales.ReadDir("locales")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no bundled locales: %v", err)
+	}
+	for _, f := range files {
+		data, _ := bundledLocales.ReadFile("locales/" + f.Name())
+		var entries map[string]string
+		if err := json.Unmarshal(data, &entries); err != nil {
+			t.Fatalf("%s: %v", f.Name(), err)
+		}
+		for k, v := range entries {
+			if !slices.Equal(verbs.FindAllString(k, -1), verbs.FindAllString(v, -1)) {
+				t.Errorf("%s: %q → %q changes format verbs", f.Name(), k, v)
+			}
+		}
+	}
+}
+
+func TestLocalizedHelp(t *testing.T) {
+	t.Cleanup(func() { setLocale("C") })
+	setLocale("es")
+	m := testModel()
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = m2.(model)
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = m2.(model)
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "Atajos de teclado") || !strings.Contains(view, "salir") {
+		t.Errorf("help should be translated:\n%s", view)
+	}
+}
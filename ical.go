@@ -0,0 +1,133 @@
+package main
+
+import (
+	"crypto/sha1"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ─── planc ical ──────────────────────────────────────────────────────────────
+//
+// `planc ical` writes an iCalendar (.ics) file with an all-day event on the
+// due: date of every unfinished plan, so deadlines show up in a regular
+// calendar app (subscribe to the file, or import it). --alarm adds a
+// reminder that long before each deadline. due: is set with d (see due.go)
+// and accepts the same formats as created:.
+
+// dueEvent is one plan deadline.
+type dueEvent struct {
+	plan plan
+	due  time.Time
+}
+
+// dueEvents returns the deadlines of unfinished plans.
+func dueEvents(plans []plan) []dueEvent {
+	var events []dueEvent
+	for _, p := range plans {
+		if !isDone(p.status) && !p.due.IsZero() {
+			events = append(events, dueEvent{plan: p, due: p.due})
+		}
+	}
+	return events
+}
+
+// icsEscape escapes a TEXT value (RFC 5545 §3.3.11).
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// icsFold folds a content line to 75 octets, without splitting a UTF-8
+// sequence (RFC 5545 §3.1).
+func icsFold(line string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
+
+// buildICS renders the events as a VCALENDAR. alarm, if positive, adds a
+// display reminder that long before each deadline.
+func buildICS(events []dueEvent, alarm time.Duration, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//planc//plan deadlines//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Plan deadlines",
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, ev := range events {
+		day := ev.due.Format("20060102")
+		next := ev.due.AddDate(0, 0, 1).Format("20060102")
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%x@planc", sha1.Sum([]byte(ev.plan.path()))),
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+day,
+			"DTEND;VALUE=DATE:"+next,
+			"SUMMARY:"+icsEscape("Due: "+ev.plan.title),
+			"DESCRIPTION:"+icsEscape(fmt.Sprintf("Status: %s\n%s", exportStatus(ev.plan.status), contractHome(ev.plan.path()))),
+		)
+		if alarm > 0 {
+			lines = append(lines,
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"DESCRIPTION:"+icsEscape("Plan due: "+ev.plan.title),
+				fmt.Sprintf("TRIGGER:-PT%dM", int(alarm.Minutes())),
+				"END:VALARM",
+			)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(icsFold(l) + "\r\n")
+	}
+	return b.String()
+}
+
+func runICal(cfg config, args []string) int {
+	fs := flag.NewFlagSet("ical", flag.ContinueOnError)
+	alarm := fs.String("alarm", "", "remind this long before each deadline (e.g. 1d, 2h)")
+	output := fs.String("o", "", "write the calendar to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc ical [--alarm 1d] [-o FILE]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	var before time.Duration
+	if *alarm != "" {
+		d, ok := parsePeriod(*alarm)
+		if !ok {
+			return cliError("ical: invalid --alarm %q (use 1d, 2h, ...)", *alarm)
+		}
+		before = d
+	}
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("ical: %v", err)
+	}
+	ics := buildICS(dueEvents(plans), before, time.Now())
+	if *output == "" {
+		fmt.Print(ics)
+		return 0
+	}
+	if err := os.WriteFile(expandHome(*output), []byte(ics), 0644); err != nil {
+		return cliError("ical: %v", err)
+	}
+	return 0
+}
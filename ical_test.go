@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDueEventsAndICS(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\ndue: 2026-03-20\n---\n# Launch, finally\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "---\nstatus: done\ndue: 2026-03-01\n---\n# Shipped\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "# No deadline\n")
+	plans, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := dueEvents(plans)
+	if len(events) != 1 || events[0].plan.file != "a.md" {
+		t.Fatalf("dueEvents = %+v, want only a.md", events)
+	}
+
+	ics := buildICS(events, 24*time.Hour, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART;VALUE=DATE:20260320\r\n",
+		"DTEND;VALUE=DATE:20260321\r\n",
+		`SUMMARY:Due: Launch\, finally` + "\r\n",
+		"TRIGGER:-PT1440M\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ics missing %q:\n%s", want, ics)
+		}
+	}
+}
+
+func TestICSFold(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := icsFold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("line of %d octets: %q", len(part), part)
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Error("unfolding should restore the line")
+	}
+}
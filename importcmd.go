@@ -0,0 +1,237 @@
+package main
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── planc import ────────────────────────────────────────────────────────────
+//
+// `planc import PATH...` copies plans into the agent plans directory, e.g. to
+// consolidate plans from several machines. A PATH is a plan, a directory of
+// plans, or a .zip of them. Plans whose content is already in planc (or
+// earlier in the same import) are skipped, legacy frontmatter is migrated as
+// planc migrate would, and created: is pinned to the source's modification
+// time so imported plans sort where they belong rather than as brand new. A
+// name that is taken gets a -2, -3, ... suffix.
+
+// maxImportSize bounds a single plan read from a zip.
+const maxImportSize = 10 << 20
+
+// importSource is a plan file found in an import PATH.
+type importSource struct {
+	name     string // where it came from, for messages: a path, or zip:entry
+	file     string // base filename
+	content  string
+	modified time.Time
+}
+
+// readImportSources returns the plans in arg: the file itself, the .md files
+// directly inside a directory, or the .md files anywhere in a zip (except
+// private notes).
+func readImportSources(arg string) ([]importSource, error) {
+	info, err := os.Stat(arg)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(arg), ".zip") && !info.IsDir() {
+		return readZipSources(arg)
+	}
+	files := []string{arg}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(arg, "*.md")); err != nil {
+			return nil, err
+		}
+	}
+	var out []importSource
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fi, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, importSource{name: file, file: filepath.Base(file), content: string(data), modified: fi.ModTime()})
+	}
+	return out, nil
+}
+
+func readZipSources(zipPath string) ([]importSource, error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var out []importSource
+	for _, f := range r.File {
+		dir, file := path.Split(f.Name)
+		dir = "/" + dir
+		if f.FileInfo().IsDir() || !strings.HasSuffix(file, ".md") ||
+			strings.Contains(dir, "/"+plans.NotesDir+"/") || strings.Contains(dir, "/__MACOSX/") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxImportSize+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		if len(data) > maxImportSize {
+			return nil, fmt.Errorf("%s: larger than %s", f.Name, formatSize(maxImportSize))
+		}
+		out = append(out, importSource{name: zipPath + ":" + f.Name, file: file, content: string(data), modified: f.Modified})
+	}
+	return out, nil
+}
+
+// normalizeImport migrates content's legacy frontmatter and pins created:,
+// returning the new content and a description of each change.
+func normalizeImport(content string, modified time.Time) (string, []string) {
+	fm, _ := parseFrontmatter(content)
+	updates, changes := legacyUpdates(fm)
+	if fm["created"] == "" && fm["updated"] == "" && !modified.IsZero() {
+		updates["created"] = modified.Format(time.RFC3339)
+	}
+	if len(updates) == 0 {
+		return content, changes
+	}
+	front, body, delim := splitFrontmatter(content)
+	return plans.RestoreEncoding(content, joinFrontmatter(editFrontmatter(front, updates, delim), body, delim)), changes
+}
+
+// importResult is what happened to one importSource.
+type importResult struct {
+	src       importSource
+	path      string   // where it was (or would be) written
+	duplicate string   // the existing plan with the same content, if skipped
+	changes   []string // frontmatter migrations
+}
+
+// importPlans copies sources into dir unless dryRun, skipping any whose
+// content matches a plan in existing or an earlier source.
+func importPlans(dir string, existing []plan, sources []importSource, dryRun bool) ([]importResult, error) {
+	seen := make(map[[32]byte]string) // content hash → plan path
+	for _, p := range existing {
+		if data, err := os.ReadFile(p.path()); err == nil {
+			seen[sha256.Sum256(data)] = p.path()
+		}
+	}
+	taken := make(map[string]bool)
+	var results []importResult
+	for _, src := range sources {
+		content, changes := normalizeImport(src.content, src.modified)
+		raw, normalized := sha256.Sum256([]byte(src.content)), sha256.Sum256([]byte(content))
+		if dup, ok := seen[raw]; ok {
+			results = append(results, importResult{src: src, duplicate: dup})
+			continue
+		}
+		if dup, ok := seen[normalized]; ok {
+			results = append(results, importResult{src: src, duplicate: dup})
+			continue
+		}
+		dst := importPath(dir, src.file, taken)
+		taken[dst] = true
+		seen[raw], seen[normalized] = dst, dst
+		if !dryRun {
+			if err := os.WriteFile(dst, []byte(content), 0644); err != nil {
+				return results, err
+			}
+			if !src.modified.IsZero() {
+				os.Chtimes(dst, src.modified, src.modified)
+			}
+		}
+		results = append(results, importResult{src: src, path: dst, changes: changes})
+	}
+	return results, nil
+}
+
+// importPath returns dir/file, or dir/name-N.md if that exists or was taken
+// earlier in this import.
+func importPath(dir, file string, taken map[string]bool) string {
+	slug := strings.TrimSuffix(file, ".md")
+	dst := filepath.Join(dir, slug+".md")
+	for n := 2; ; n++ {
+		if _, err := os.Stat(dst); os.IsNotExist(err) && !taken[dst] {
+			return dst
+		}
+		dst = filepath.Join(dir, fmt.Sprintf("%s-%d.md", slug, n))
+	}
+}
+
+func runImport(cfg config, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "list what would be imported without copying")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc import [--dry-run] PATH...")
+		fmt.Fprintln(fs.Output(), "Copies plans (files, directories, or .zip files) into the plans directory, skipping duplicates.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	var sources []importSource
+	for _, arg := range fs.Args() {
+		found, err := readImportSources(arg)
+		if err != nil {
+			return cliError("import: %v", err)
+		}
+		sources = append(sources, found...)
+	}
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("import: %v", err)
+	}
+	if !*dryRun {
+		if err := os.MkdirAll(cfg.PlansDir, 0755); err != nil {
+			return cliError("import: %v", err)
+		}
+	}
+	results, err := importPlans(cfg.PlansDir, all, sources, *dryRun)
+
+	auditPath, perr := auditLogPath()
+	imported, skipped := 0, 0
+	for _, r := range results {
+		if r.duplicate != "" {
+			skipped++
+			fmt.Printf("skipped %s: same as %s\n", contractHome(r.src.name), contractHome(r.duplicate))
+			continue
+		}
+		imported++
+		line := fmt.Sprintf("%s → %s", contractHome(r.src.name), contractHome(r.path))
+		if len(r.changes) > 0 {
+			line += " (" + strings.Join(r.changes, ", ") + ")"
+		}
+		fmt.Println(line)
+		if !*dryRun && perr == nil {
+			auditLog{auditPath}.record(planEvent{kind: eventPlanCreated, path: r.path, at: time.Now()})
+		}
+	}
+	verb := "imported"
+	if *dryRun {
+		verb = "would be imported"
+	}
+	fmt.Fprintf(os.Stderr, "%d plans %s, %d duplicates skipped\n", imported, verb, skipped)
+	if err != nil {
+		return cliError("import: %v", err)
+	}
+	return 0
+}
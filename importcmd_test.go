@@ -0,0 +1,87 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+func TestImportPlans(t *testing.T) {
+	plansDir, other := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(plansDir, "auth.md"), "# Auth\n")
+	writeFile(t, filepath.Join(other, "auth.md"), "# Auth, the laptop copy\n")
+	writeFile(t, filepath.Join(other, "same.md"), "# Auth\n")
+	writeFile(t, filepath.Join(other, "legacy.md"), "---\nstatus: pending\nproject: Atlas\n---\n# Legacy\n")
+	old := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	os.Chtimes(filepath.Join(other, "legacy.md"), old, old)
+
+	// A zip holding another copy of legacy.md, which is a duplicate once
+	// migrated, and private notes, which aren't plans.
+	zipPath := filepath.Join(t.TempDir(), "plans.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"plans/legacy.md":                        "---\nstatus: reviewed\nlabels: atlas\ncreated: 2025-06-01T12:00:00Z\n---\n# Legacy\n",
+		"plans/" + plans.NotesDir + "/legacy.md": "private\n",
+	} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	f.Close()
+
+	var sources []importSource
+	for _, arg := range []string{other, zipPath} {
+		found, err := readImportSources(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources = append(sources, found...)
+	}
+	if len(sources) != 4 {
+		t.Fatalf("sources = %d, want 4", len(sources))
+	}
+	existing, _ := scanAllPlans(plansDir, "")
+
+	results, err := importPlans(plansDir, existing, sources, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(plansDir); len(entries) != 1 {
+		t.Fatalf("dry run wrote files: %d entries", len(entries))
+	}
+
+	results, err = importPlans(plansDir, existing, sources, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range results {
+		if r.duplicate != "" {
+			got = append(got, filepath.Base(r.src.name)+" dup of "+filepath.Base(r.duplicate))
+		} else {
+			got = append(got, filepath.Base(r.src.name)+" → "+filepath.Base(r.path))
+		}
+	}
+	want := "auth.md → auth-2.md, legacy.md → legacy.md, same.md dup of auth.md, legacy.md dup of legacy.md"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("results = %s\nwant %s", strings.Join(got, ", "), want)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(plansDir, "legacy.md"))
+	fm, _ := parseFrontmatter(string(data))
+	if fm["status"] != "reviewed" || fm["labels"] != "atlas" || fm["project"] != "" || fm["created"] != "2025-06-01T12:00:00Z" {
+		t.Errorf("imported frontmatter = %v", fm)
+	}
+	if info, _ := os.Stat(filepath.Join(plansDir, "legacy.md")); !info.ModTime().Equal(old) {
+		t.Errorf("modified = %v, want the source's %v", info.ModTime(), old)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── planc lint ──────────────────────────────────────────────────────────────
+//
+// `planc lint [PATH...]` checks plans for CI, e.g. to gate pull requests that
+// add to a repo's plans/ directory. It never starts the TUI or the setup
+// wizard and prints nothing when every plan passes; each problem is printed
+// as file:line: message, and the exit status is 1 if there were any. A PATH
+// is a plan or a directory of plans; with none, the configured plans are
+// checked. The checks:
+//
+//   - frontmatter is closed and its status is one of the configured
+//     statuses (new, reviewed, active, or done by default; see statusOptions)
+//   - labels aren't repeated (labels are compared ignoring case)
+//   - the plan has a title: or a # heading
+//   - comment blockquotes are in the form planc reads:
+//     > **[comment]:** text (or [resolved])
+//   - the plan has the lint_sections headings, when configured
+
+// lintProblem is one problem in a plan; Line counts from 1.
+type lintProblem struct {
+	File    string
+	Line    int
+	Message string
+}
+
+// looseComment matches lines that look like they were meant as comments.
+var looseComment = regexp.MustCompile(`(?i)^>\s*\**\s*\[(comment|resolved)\]`)
+
+// lintContent checks a plan file's content against the rules above.
+func lintContent(path, content string, sections []string) []lintProblem {
+	var out []lintProblem
+	add := func(line int, format string, args ...any) {
+		out = append(out, lintProblem{File: path, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+	front, body, delim := splitFrontmatter(content)
+	bodyStart := 0 // lines before the body
+	if delim != "" {
+		bodyStart = len(front) + 2
+	} else if first, _, _ := strings.Cut(strings.TrimPrefix(content, "\uFEFF"), "\n"); slices.Contains([]string{plans.YAMLDelim, plans.TOMLDelim}, strings.TrimSpace(first)) {
+		add(1, "frontmatter opened with %s is never closed", strings.TrimSpace(first))
+	}
+	sep := ":"
+	if delim == plans.TOMLDelim {
+		sep = "="
+	}
+	// keyLine returns the line of a frontmatter key, or 1 if it isn't there.
+	keyLine := func(key string) int {
+		for i, l := range front {
+			if k, _, ok := strings.Cut(strings.TrimSpace(l), sep); ok && strings.TrimSpace(k) == key {
+				return i + 2
+			}
+		}
+		return 1
+	}
+
+	fm, _ := parseFrontmatter(content)
+	if status := fm["status"]; status == "pending" {
+		add(keyLine("status"), "legacy status pending; run planc migrate to make it reviewed")
+	} else if _, ok := statusByName(status); status != "" && !ok {
+		add(keyLine("status"), "unknown status %q (%s)", status, statusChoices())
+	}
+	var seen []string
+	for _, l := range strings.Split(fm["labels"], ",") {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l == "" {
+			continue
+		}
+		if slices.Contains(seen, l) {
+			add(keyLine("labels"), "duplicate label %q", l)
+		}
+		seen = append(seen, l)
+	}
+	if _, untitled := planTitle(fm, body, filepath.Base(path)); untitled {
+		add(bodyStart+1, "no title: field or # heading")
+	}
+
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && looseComment.MatchString(trimmed) && !commentRegex.MatchString(trimmed) {
+			add(bodyStart+i+1, "malformed comment; write > **[comment]:** text")
+		}
+	}
+
+	if len(sections) > 0 {
+		for _, s := range lintPlan(plan{headings: bodyHeadings(body)}, sections) {
+			add(bodyStart+1, "missing section %q (lint_sections)", s)
+		}
+	}
+	return out
+}
+
+// lintPaths expands PATH arguments into plan files: directories contribute
+// the .md files directly inside them.
+func lintPaths(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.md"))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+func writeLintProblems(w io.Writer, problems []lintProblem) {
+	for _, p := range problems {
+		fmt.Fprintf(w, "%s:%d: %s\n", p.File, p.Line, p.Message)
+	}
+}
+
+func runLint(cfg config, args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc lint [PATH...]")
+		fmt.Fprintln(fs.Output(), "Checks plans (files or directories; default: the configured plans) and exits 1 on problems.")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	var paths []string
+	if fs.NArg() > 0 {
+		var err error
+		if paths, err = lintPaths(fs.Args()); err != nil {
+			return cliError("lint: %v", err)
+		}
+	} else {
+		all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+		if err != nil {
+			return cliError("lint: %v", err)
+		}
+		sortPlans(all)
+		for _, p := range all {
+			paths = append(paths, p.path())
+		}
+	}
+
+	var problems []lintProblem
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, lintProblem{File: path, Line: 1, Message: err.Error()})
+			continue
+		}
+		problems = append(problems, lintContent(path, string(data), cfg.LintSections)...)
+	}
+	writeLintProblems(os.Stdout, problems)
+	if len(problems) > 0 {
+		return 1
+	}
+	return 0
+}
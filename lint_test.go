@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintContent(t *testing.T) {
+	tests := []struct {
+		name, content string
+		want          []string // "line: message prefix"
+	}{
+		{"clean", "---\nstatus: active\nlabels: api, infra\n---\n# Auth\n\n> **[comment]:** why?\n\n> **[resolved]:** done\n", nil},
+		{"unknown status", "---\ntitle: Auth\nstatus: shipped\n---\nbody\n", []string{`3: unknown status "shipped"`}},
+		{"pending", "---\nstatus: pending\n---\n# Auth\n", []string{"2: legacy status pending"}},
+		{"duplicate labels", "---\nlabels: [api, infra, API]\n---\n# Auth\n", []string{`2: duplicate label "api"`}},
+		{"no title", "---\nstatus: new\n---\nJust text.\n", []string{"4: no title"}},
+		{"unclosed", "---\nstatus: active\n# Auth\n", []string{"1: frontmatter opened with --- is never closed"}},
+		{"malformed comments", "# Auth\n\n> [comment]: no bold\n> **[Comment]:** capital\n> **[comment]:**\n\n```\n> [comment]: in a fence\n```\n",
+			[]string{"3: malformed comment", "4: malformed comment", "5: malformed comment"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, p := range lintContent("plan.md", tt.content, nil) {
+			got = append(got, fmt.Sprintf("%d: %s", p.Line, p.Message))
+		}
+		ok := len(got) == len(tt.want)
+		for i := 0; ok && i < len(got); i++ {
+			ok = strings.HasPrefix(got[i], tt.want[i])
+		}
+		if !ok {
+			t.Errorf("%s: problems %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	problems := lintContent("plan.md", "# Auth\n\n## Context\n", []string{"Context", "Testing"})
+	if len(problems) != 1 || problems[0].Message != `missing section "Testing" (lint_sections)` {
+		t.Errorf("sections: %+v", problems)
+	}
+}
+
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "good.md"), "---\nstatus: done\n---\n# Good\n")
+	if code := runLint(newDefaultConfig(), []string{dir}); code != 0 {
+		t.Errorf("clean dir: exit code %d", code)
+	}
+	writeFile(t, filepath.Join(dir, "bad.md"), "---\nstatus: wip\n---\n# Bad\n")
+	if code := runLint(newDefaultConfig(), []string{dir}); code != 1 {
+		t.Errorf("bad plan: exit code %d", code)
+	}
+	if code := runLint(newDefaultConfig(), []string{filepath.Join(dir, "good.md")}); code != 0 {
+		t.Errorf("one good file: exit code %d", code)
+	}
+	if code := runLint(newDefaultConfig(), []string{filepath.Join(dir, "missing.md")}); code != 1 {
+		t.Errorf("missing path: exit code %d", code)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ─── planc list ──────────────────────────────────────────────────────────────
+//
+// `planc list` prints every plan in list order, one per line, as
+// tab-separated file, title, status, labels, created, and modified, for fzf,
+// cut, and awk. --json prints the same fields as an array for jq. --status
+// and --label narrow the list; done plans are included unless filtered out.
+
+// listEntry is one plan in `planc list --json`.
+type listEntry struct {
+	File     string    `json:"file"`
+	Title    string    `json:"title"`
+	Status   string    `json:"status"`
+	Labels   []string  `json:"labels"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+}
+
+func newListEntry(p plan) listEntry {
+	labels := p.labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return listEntry{
+		File:     p.path(),
+		Title:    p.title,
+		Status:   exportStatus(p.status),
+		Labels:   labels,
+		Created:  p.created,
+		Modified: p.modified,
+	}
+}
+
+// matchPlans keeps plans with one of statuses (any if empty) that carry
+// label (any if empty).
+func matchPlans(plans []plan, statuses []string, label string) []plan {
+	var out []plan
+	for _, p := range plans {
+		if len(statuses) > 0 && !slices.Contains(statuses, exportStatus(p.status)) {
+			continue
+		}
+		if label != "" && !slices.Contains(p.labels, label) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// writeListTSV writes one tab-separated line per plan. Tabs and newlines in
+// titles are replaced so every plan stays on one line.
+func writeListTSV(w io.Writer, plans []plan) {
+	clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	for _, p := range plans {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			p.path(), clean.Replace(p.title), exportStatus(p.status), strings.Join(p.labels, ","),
+			p.created.Format(time.RFC3339), p.modified.Format(time.RFC3339))
+	}
+}
+
+func writeListJSON(w io.Writer, plans []plan) error {
+	entries := make([]listEntry, len(plans))
+	for i, p := range plans {
+		entries[i] = newListEntry(p)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+func runList(cfg config, args []string) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print a JSON array instead of tab-separated lines")
+	status := fs.String("status", "", "only plans with these statuses, comma-separated ("+strings.Join(statusNames(), ", ")+")")
+	label := fs.String("label", "", "only plans with this label")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc list [--json] [--status LIST] [--label LABEL]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	var statuses []string
+	for _, s := range strings.Split(*status, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses = append(statuses, s)
+		}
+	}
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("list: %v", err)
+	}
+	plans = matchPlans(plans, statuses, *label)
+	sortPlans(plans)
+	if *asJSON {
+		if err := writeListJSON(os.Stdout, plans); err != nil {
+			return cliError("list: %v", err)
+		}
+		return 0
+	}
+	writeListTSV(os.Stdout, plans)
+	return 0
+}
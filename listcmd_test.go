@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestListOutput(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\nstatus: active\nlabels: api, auth\n---\n# Auth\tv2\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "# Plain\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "---\nstatus: done\nlabels: api\n---\n# Shipped\n")
+	plans, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortPlans(plans)
+
+	var tsv bytes.Buffer
+	writeListTSV(&tsv, plans)
+	lines := strings.Split(strings.TrimSuffix(tsv.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("lines = %q", lines)
+	}
+	for _, l := range lines {
+		cols := strings.Split(l, "\t")
+		if len(cols) != 6 {
+			t.Fatalf("want 6 columns: %q", l)
+		}
+		if filepath.Base(cols[0]) == "a.md" && (cols[1] != "Auth v2" || cols[2] != "active" || cols[3] != "api,auth") {
+			t.Errorf("a.md = %q", cols)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeListJSON(&out, plans); err != nil {
+		t.Fatal(err)
+	}
+	var entries []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("entries = %v", entries)
+	}
+	for _, e := range entries {
+		if filepath.Base(e["file"].(string)) == "b.md" {
+			if e["status"] != "new" || e["labels"] == nil || e["created"] == "" {
+				t.Errorf("b.md = %v", e)
+			}
+		}
+	}
+}
+
+func TestMatchPlans(t *testing.T) {
+	plans := testPlans()
+	statuses := func(ps []plan) []string {
+		var out []string
+		for _, p := range ps {
+			out = append(out, p.status)
+		}
+		return out
+	}
+	if got := statuses(matchPlans(plans, []string{"active", "reviewed"}, "")); !slices.Equal(got, []string{"active", "active", "reviewed"}) {
+		t.Errorf("status filter = %v", got)
+	}
+	if got := matchPlans(plans, nil, "orion"); len(got) != 1 || got[0].status != "done" {
+		t.Errorf("label filter = %v", got)
+	}
+	if got := matchPlans(plans, []string{"new"}, ""); len(got) != 0 {
+		t.Errorf("no test plan is new: %v", got)
+	}
+}
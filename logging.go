@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// ─── Debug Logging ───────────────────────────────────────────────────────────
+//
+// --log-file PATH (or $PLANC_LOG) writes JSON lines for watcher events, scans,
+// renders, command launches, and errors. The TUI runs in the alt screen, so a
+// log file is the only place failures that flash by in the status bar (or
+// never reach it) can be read back for a bug report. Without a log file,
+// logger discards everything.
+
+// logEnv names the environment variable that sets the log file when
+// --log-file isn't given.
+const logEnv = "PLANC_LOG"
+
+var logger = slog.New(slog.DiscardHandler)
+
+// extractLogFlag removes --log-file PATH / --log-file=PATH from args and
+// returns the path (falling back to $PLANC_LOG) and the remaining args. ok is
+// false if --log-file is missing its path.
+func extractLogFlag(args []string) (path string, rest []string, ok bool) {
+	path = os.Getenv(logEnv)
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if v, found := strings.CutPrefix(a, "--log-file="); found {
+			path = v
+			continue
+		}
+		if a == "--log-file" {
+			if i+1 >= len(args) {
+				return "", nil, false
+			}
+			path = args[i+1]
+			i++
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return path, rest, true
+}
+
+// openLog points logger at path, appending, and returns a func that closes
+// the file. An empty path leaves logging off.
+func openLog(path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+	f, err := os.OpenFile(expandHome(path), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger.Info("start", "version", getVersion(), "pid", os.Getpid())
+	return func() { f.Close() }, nil
+}
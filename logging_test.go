@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExtractLogFlag(t *testing.T) {
+	t.Setenv(logEnv, "")
+	tests := []struct {
+		args     []string
+		wantPath string
+		wantRest []string
+		wantOK   bool
+	}{
+		{[]string{"--demo"}, "", []string{"--demo"}, true},
+		{[]string{"--log-file", "/tmp/p.log", "--demo"}, "/tmp/p.log", []string{"--demo"}, true},
+		{[]string{"migrate", "--log-file=/tmp/p.log", "--dry-run"}, "/tmp/p.log", []string{"migrate", "--dry-run"}, true},
+		{[]string{"--log-file"}, "", nil, false},
+	}
+	for _, tt := range tests {
+		path, rest, ok := extractLogFlag(tt.args)
+		if path != tt.wantPath || !slices.Equal(rest, tt.wantRest) || ok != tt.wantOK {
+			t.Errorf("extractLogFlag(%q) = %q, %q, %v", tt.args, path, rest, ok)
+		}
+	}
+
+	t.Setenv(logEnv, "/tmp/env.log")
+	if path, _, _ := extractLogFlag(nil); path != "/tmp/env.log" {
+		t.Errorf("$%s fallback = %q", logEnv, path)
+	}
+}
+
+func TestOpenLogWritesJSONLines(t *testing.T) {
+	defer func(l *slog.Logger) { logger = l }(logger)
+	path := filepath.Join(t.TempDir(), "planc.log")
+	closeLog, err := openLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Error("watch failed", "dir", "/plans", "err", os.ErrPermission)
+	closeLog()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines: %q", len(lines), data)
+	}
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["msg"] != "watch failed" || rec["dir"] != "/plans" || rec["level"] != "ERROR" {
+		t.Errorf("record = %v", rec)
+	}
+}
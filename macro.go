@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Keyboard Macros ─────────────────────────────────────────────────────────
+//
+// Q starts recording keys and Q again stops; @ asks how many times to replay
+// them. A macro is any key sequence, e.g. "l, toggle two labels, enter, 1,
+// j" relabels a plan, marks it reviewed, and moves on, so replaying it 20
+// times handles the next 20 plans. Replayed keys go through Update one after
+// another; the writes they start land afterwards, as with typing quickly.
+// The macro lasts until planc exits or another is recorded.
+
+const maxMacroRepeat = 1000
+
+type macroState struct {
+	recording bool
+	keys      []tea.KeyMsg
+	consumed  bool // the current key was Q or @ itself; don't record it
+	replaying bool
+	prompting bool
+	count     textinput.Model
+}
+
+// handleMacroKey handles Q and @ outside text input. It reports false for
+// other keys.
+func (m model) handleMacroKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if m.macro.replaying {
+		return m, nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.MacroRecord):
+		m.macro.consumed = true
+		if m.macro.recording {
+			m.macro.recording = false
+			return m, m.setNotification(trf("Macro recorded: %d keys · @ to replay", len(m.macro.keys)), statusTimeout), true
+		}
+		m.macro.recording = true
+		m.macro.keys = nil
+		return m, m.setNotification(tr("Recording macro · Q to stop"), statusTimeout), true
+	case key.Matches(msg, m.keys.MacroPlay):
+		m.macro.consumed = true
+		switch {
+		case m.macro.recording:
+			return m, m.setNotification(tr("Stop recording with Q first"), statusTimeout), true
+		case len(m.macro.keys) == 0:
+			return m, m.setNotification(tr("No macro recorded · Q to record one"), statusTimeout), true
+		}
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.CharLimit = 4
+		ti.Placeholder = "1"
+		m.macro.count = ti
+		m.macro.prompting = true
+		return m, m.macro.count.Focus(), true
+	}
+	return m, nil, false
+}
+
+func (m model) handleMacroPrompt(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.macro.prompting = false
+		return m, nil, true
+	case tea.KeyEnter:
+		m.macro.prompting = false
+		times := 1
+		if v := strings.TrimSpace(m.macro.count.Value()); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return m, m.setNotification(trf("Not a count: %s", v), statusTimeout), true
+			}
+			times = min(n, maxMacroRepeat)
+		}
+		return m.replayMacro(times)
+	}
+	if len(msg.Runes) > 0 && (msg.Runes[0] < '0' || msg.Runes[0] > '9') {
+		return m, nil, true
+	}
+	var cmd tea.Cmd
+	m.macro.count, cmd = m.macro.count.Update(msg)
+	return m, cmd, true
+}
+
+// replayMacro feeds the recorded keys through Update times times and
+// batches the commands they return.
+func (m model) replayMacro(times int) (model, tea.Cmd, bool) {
+	keys := m.macro.keys
+	m.macro.replaying = true
+	var cmds []tea.Cmd
+	for range times {
+		for _, k := range keys {
+			next, cmd := m.Update(k)
+			m = next.(model)
+			cmds = append(cmds, cmd)
+		}
+	}
+	m.macro.replaying = false
+	cmds = append(cmds, m.setNotification(trf("Replayed macro %d×", times), statusTimeout))
+	return m, tea.Batch(cmds...), true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+// macroIndicator is shown at the left of the status bar while recording.
+func (m model) macroIndicator() string {
+	if !m.macro.recording {
+		return ""
+	}
+	style := lipgloss.NewStyle().Bold(true).Foreground(colorYellow)
+	return " " + style.Render(fmt.Sprintf("● REC %d", len(m.macro.keys)))
+}
+
+func (m model) renderMacroPrompt() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(trf("Replay macro (%d keys)", len(m.macro.keys))) + "\n")
+	b.WriteString(tr("Times: ") + m.macro.count.View() + "\n\n")
+	b.WriteString(dimStyle.Render(tr("enter replay · esc cancel")))
+
+	overlay := helpBoxStyle.Width(40).Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func press(t *testing.T, m model, keys ...string) model {
+	t.Helper()
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m2, _ := m.Update(msg)
+		m = m2.(model)
+	}
+	return m
+}
+
+func TestMacroRecordAndReplay(t *testing.T) {
+	m := testModel()
+
+	m = press(t, m, "Q")
+	if !m.macro.recording {
+		t.Fatal("Q should start recording")
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "● REC") {
+		t.Error("the status bar should show the recording indicator")
+	}
+	m = press(t, m, "x", "j", "Q")
+	if m.macro.recording || len(m.macro.keys) != 2 {
+		t.Fatalf("recorded %d keys (recording %v), want x and j", len(m.macro.keys), m.macro.recording)
+	}
+	if len(m.selected) != 1 || m.list.Index() != 1 {
+		t.Fatalf("recording should still run the keys: %d selected, index %d", len(m.selected), m.list.Index())
+	}
+
+	m = press(t, m, "@")
+	if !m.macro.prompting {
+		t.Fatal("@ should ask for a count")
+	}
+	m = press(t, m, "a", "2") // non-digits are ignored
+	if got := m.macro.count.Value(); got != "2" {
+		t.Fatalf("count = %q", got)
+	}
+	m = press(t, m, "enter")
+	if m.macro.prompting {
+		t.Fatal("enter should close the prompt")
+	}
+	if len(m.selected) != 3 || m.list.Index() != 2 { // three visible plans; the last j stays put
+		t.Errorf("after replaying twice: %d selected, index %d; want 3, 2", len(m.selected), m.list.Index())
+	}
+	if len(m.macro.keys) != 2 {
+		t.Errorf("replay shouldn't change the macro: %d keys", len(m.macro.keys))
+	}
+}
+
+func TestMacroReplayNeedsMacro(t *testing.T) {
+	m := testModel()
+	m = press(t, m, "@")
+	if m.macro.prompting {
+		t.Error("@ without a macro shouldn't prompt")
+	}
+
+	// @ while recording doesn't replay and isn't recorded.
+	m = press(t, m, "Q", "j", "@", "Q")
+	if m.macro.prompting || len(m.macro.keys) != 1 {
+		t.Errorf("keys = %d, prompting %v", len(m.macro.keys), m.macro.prompting)
+	}
+
+	m = press(t, m, "@", "esc")
+	if m.macro.prompting || m.list.Index() != 1 {
+		t.Errorf("esc should cancel without replaying (index %d)", m.list.Index())
+	}
+}
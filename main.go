@@ -3,8 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fsnotify/fsnotify"
@@ -23,16 +26,54 @@ func getVersion() string {
 }
 
 func main() {
+	logPath, args, ok := extractLogFlag(os.Args[1:])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "--log-file requires a path\n")
+		os.Exit(1)
+	}
+	cfgPath, args, ok := extractConfigFlag(args)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "--config requires a path\n")
+		os.Exit(1)
+	}
+	if cfgPath != "" {
+		if abs, err := filepath.Abs(expandHome(cfgPath)); err == nil {
+			cfgPath = abs
+		}
+		os.Setenv(configEnv, cfgPath) // inherited by planc --setup, which , runs
+	}
+	os.Args = append(os.Args[:1], args...)
+	closeLog, err := openLog(logPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening log file: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeLog()
+
 	if len(os.Args) > 1 && (os.Args[1] == "--help" || os.Args[1] == "-h") {
+		applyConfigGlobals(loadConfigRaw()) // for the configured statuses
 		fmt.Println("planc — a tiny TUI for browsing and annotating AI agent plans")
 		fmt.Println()
-		fmt.Println("Usage: planc [flags]")
+		fmt.Println("Usage: planc [flags] [[open] PLAN] | planc - | planc <command> [args]")
+		fmt.Println()
+		fmt.Println("PLAN, a path or a plan's file name, starts with that plan selected;")
+		fmt.Println("a planc://plan/<file>#heading link also opens comment mode at the heading.")
+		fmt.Println("planc - opens markdown piped to stdin in a one-off session and prints")
+		fmt.Println("the temp file holding your edits on exit.")
 		fmt.Println()
 		fmt.Println("Flags:")
 		fmt.Println("  --help, -h    Show this help")
 		fmt.Println("  --version     Print version")
 		fmt.Println("  --setup       Re-run first-time configuration")
 		fmt.Println("  --demo        Launch with demo data")
+		fmt.Println("  --archived    Browse archived plans (see planc archive)")
+		fmt.Println("  --label L     Start with the list filtered to label L")
+		fmt.Println("  --status S    Start with the list filtered to status S (" + statusList() + ")")
+		fmt.Println("  --config P    Use config file P instead of the default; or set $" + configEnv)
+		fmt.Println("  --log-file P  Append debug logs (JSON lines) to P; or set $" + logEnv)
+		fmt.Println()
+		fmt.Println("Commands:")
+		printSubcommands()
 		return
 	}
 
@@ -41,24 +82,99 @@ func main() {
 		return
 	}
 
-	if len(os.Args) > 1 && strings.HasPrefix(os.Args[1], "-") &&
-		os.Args[1] != "--setup" && os.Args[1] != "--demo" {
+	if code, ok := runSubcommand(os.Args[1:]); ok {
+		closeLog()
+		os.Exit(code)
+	}
+
+	startLabel, startStatus, args, err := extractStartFilters(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nRun planc --help for usage.\n", err)
+		os.Exit(1)
+	}
+	os.Args = append(os.Args[:1], args...)
+
+	// Hidden: --demo-size N launches demo mode with N synthetic plans for
+	// performance testing.
+	demoSize := 0
+	if len(os.Args) > 1 && (os.Args[1] == "--demo-size" || strings.HasPrefix(os.Args[1], "--demo-size=")) {
+		arg, ok := strings.CutPrefix(os.Args[1], "--demo-size=")
+		if !ok && len(os.Args) > 2 {
+			arg = os.Args[2]
+		}
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 {
+			fmt.Fprintf(os.Stderr, "--demo-size requires a positive number\n")
+			os.Exit(1)
+		}
+		demoSize = n
+	}
+
+	stdinMode := len(os.Args) == 2 && os.Args[1] == "-"
+	archivedMode := len(os.Args) == 2 && os.Args[1] == "--archived"
+	if len(os.Args) > 1 && strings.HasPrefix(os.Args[1], "-") && !stdinMode && !archivedMode &&
+		os.Args[1] != "--setup" && os.Args[1] != "--demo" && demoSize == 0 {
 		fmt.Fprintf(os.Stderr, "unknown flag: %s\nRun planc --help for usage.\n", os.Args[1])
 		os.Exit(1)
 	}
 
+	// planc PLAN and planc open PLAN start with PLAN selected.
+	var openArg, openHeading string
+	if args := os.Args[1:]; len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		if args[0] == "open" {
+			args = args[1:]
+		}
+		if len(args) != 1 {
+			fmt.Fprintf(os.Stderr, "Usage: planc [open] PLAN\nRun planc --help for usage.\n")
+			os.Exit(1)
+		}
+		openArg = args[0]
+		if isPlanURL(openArg) {
+			if openArg, openHeading, err = parsePlanURL(openArg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
 	if len(os.Args) > 1 && os.Args[1] == "--setup" {
 		path, err := configPath()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		runSetup(path, loadConfigRaw(), nil) // loadConfigRaw avoids triggering first-time setup
+		cfg := loadConfigRaw() // avoids triggering first-time setup
+		setLocale(cfg.Locale)
+		runSetup(path, cfg, nil)
 		return
 	}
 
 	cfg := loadConfig()
+	applyConfigGlobals(cfg)
+	// --status is checked against the configured statuses.
+	if err := checkStartStatus(startStatus); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nRun planc --help for usage.\n", err)
+		os.Exit(1)
+	}
 	dir := cfg.PlansDir
+	var stdinPath string
+	if stdinMode {
+		stdinPath, err = readStdinPlan(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		dir = filepath.Dir(stdinPath)
+		cfg.ProjectPlanGlob = ""
+		scratchSession.Store(true)
+	}
+	if archivedMode && dir != "" {
+		dir = filepath.Join(dir, archiveDirName)
+		if cfg.ProjectPlanGlob != "" {
+			cfg.ProjectPlanGlob = filepath.Join(cfg.ProjectPlanGlob, archiveDirName)
+		}
+		scratchSession.Store(true)
+	}
 	if dir == "" {
 		fmt.Fprintf(os.Stderr, "Error: could not determine plans directory (is $HOME set?)\n")
 		os.Exit(1)
@@ -78,31 +194,135 @@ func main() {
 		os.Exit(1)
 	}
 
-	projectDirs := resolveProjectDirs(cfg.ProjectPlanGlob)
+	var openPath string
+	if openArg != "" {
+		openPath, err = openPlanPath(plans, openArg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	projectDirs := append(agentSubDirs(dir), resolveProjectDirs(cfg.ProjectPlanGlob)...)
 
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
+		logger.Error("watcher start failed", "err", err)
 		fmt.Fprintf(os.Stderr, "Warning: could not start file watcher: %v\n", err)
 	} else {
 		defer watcher.Close()
 		if err := watcher.Add(dir); err != nil {
+			logger.Error("watch failed", "dir", dir, "err", err)
 			fmt.Fprintf(os.Stderr, "Warning: could not watch directory: %v\n", err)
 		}
 		for _, d := range projectDirs {
 			if err := watcher.Add(d); err != nil {
+				logger.Error("watch failed", "dir", d, "err", err)
 				fmt.Fprintf(os.Stderr, "Warning: could not watch directory %s: %v\n", d, err)
 			}
 		}
+		logger.Debug("watching", "dirs", 1+len(projectDirs))
 	}
 
 	m := newModel(plans, dir, cfg, watcher)
 	m.projectDirs = projectDirs
-	if len(os.Args) > 1 && os.Args[1] == "--demo" {
+	if path, err := auditLogPath(); err == nil {
+		m.auditPath = path
+		m.events.subscribe(auditLog{path}.record)
+	}
+	if startLabel != "" || startStatus != "" {
+		m.applyStartFilters(startLabel, startStatus)
+	}
+	if demoSize > 0 {
+		m.demo.size = demoSize
+		m.enterDemoMode()
+	} else if len(os.Args) > 1 && os.Args[1] == "--demo" {
 		m.enterDemoMode()
+	} else if openPath != "" {
+		m.revealPlan(openPath)
+		if openHeading != "" {
+			if line, ok := headingLine(openPath, openHeading); ok {
+				m.link = &planLink{path: openPath, line: line}
+			} else {
+				m.notification = trf("No heading %q in %s", openHeading, filepath.Base(openPath))
+			}
+		}
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if stdinMode {
+		opts = append(opts, tea.WithInputTTY()) // stdin is the plan
+	}
+	rec := &crashRecorder{}
+	p := tea.NewProgram(crashGuard{model: m, rec: rec}, opts...)
+	_, err = p.Run()
+	if stdinPath != "" {
+		fmt.Println(stdinPath)
+	}
+	if rec.crashed() {
+		logger.Error("panic", "report", rec.report(time.Now()))
+		path, werr := rec.write(crashDir(), time.Now())
+		if werr != nil {
+			fmt.Fprintf(os.Stderr, "planc crashed, and the crash report could not be saved: %v\n", werr)
+		} else {
+			fmt.Fprintf(os.Stderr, "planc crashed. A crash report was saved to %s\nPlease attach it to a bug report: https://github.com/jakebf/planc/issues\n", path)
+		}
+		closeLog()
+		os.Exit(2)
 	}
-	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	if _, err := p.Run(); err != nil {
+	if err != nil {
+		logger.Error("program exited", "err", err)
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// extractStartFilters removes --label and --status (as --flag V or
+// --flag=V) from args, returning their values and the remaining args.
+func extractStartFilters(args []string) (label, status string, rest []string, err error) {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		name, value, hasValue := strings.Cut(a, "=")
+		if name != "--label" && name != "--status" {
+			rest = append(rest, a)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", "", nil, fmt.Errorf("%s requires a value", name)
+			}
+			value = args[i+1]
+			i++
+		}
+		if name == "--label" {
+			label = value
+		} else {
+			status = value
+		}
+	}
+	return label, status, rest, nil
+}
+
+// checkStartStatus reports an error unless status is empty or one of the
+// configured statuses.
+func checkStartStatus(status string) error {
+	if _, ok := statusByName(status); status != "" && !ok {
+		return fmt.Errorf("unknown status %q (%s)", status, statusChoices())
+	}
+	return nil
+}
+
+// openPlanPath returns the path of the plan named by arg (see
+// resolvePlanPaths). A file outside the plans directories isn't in the list,
+// so it can't be opened.
+func openPlanPath(all []plan, arg string) (string, error) {
+	paths, err := resolvePlanPaths(all, []string{arg})
+	if err != nil {
+		return "", err
+	}
+	for _, p := range all {
+		if abs, err := filepath.Abs(p.path()); err == nil && abs == paths[0] {
+			return p.path(), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not in the plans directory or a project's plans", arg)
+}
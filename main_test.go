@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenPlanPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rate-limits.md")
+	writeFile(t, path, "# Rate limits\n")
+	outside := filepath.Join(t.TempDir(), "notes.md")
+	writeFile(t, outside, "# Notes\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, arg := range []string{"rate-limits", "rate-limits.md", path} {
+		if got, err := openPlanPath(all, arg); err != nil || got != path {
+			t.Errorf("openPlanPath(%q) = %q, %v", arg, got, err)
+		}
+	}
+	if _, err := openPlanPath(all, outside); err == nil {
+		t.Error("a file outside the plans directories should fail")
+	}
+	if _, err := openPlanPath(all, "missing"); err == nil {
+		t.Error("an unknown plan should fail")
+	}
+}
+
+func TestExtractStartFilters(t *testing.T) {
+	label, status, rest, err := extractStartFilters([]string{"--label", "fittrack", "--status=active", "auth"})
+	if err != nil || label != "fittrack" || status != "active" || len(rest) != 1 || rest[0] != "auth" {
+		t.Errorf("got %q %q %q %v", label, status, rest, err)
+	}
+	if _, _, _, err := extractStartFilters([]string{"--label"}); err == nil {
+		t.Error("--label without a value accepted")
+	}
+}
+
+func TestCheckStartStatus(t *testing.T) {
+	if err := checkStartStatus("shipped"); err == nil {
+		t.Error("unknown status accepted")
+	}
+	useStatuses(t, []statusDef{{Name: "backlog"}, {Name: "shipped"}})
+	if err := checkStartStatus("shipped"); err != nil {
+		t.Errorf("a configured status should be accepted: %v", err)
+	}
+}
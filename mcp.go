@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ─── planc mcp ───────────────────────────────────────────────────────────────
+//
+// `planc mcp` serves the Model Context Protocol on stdin/stdout (JSON-RPC 2.0,
+// one message per line), so a coding agent can read plans and their review
+// comments and update them itself. Register it with the agent, e.g.
+// `claude mcp add planc -- planc mcp`. The tools reuse the CLI's plan lookup
+// and writes: plans are rescanned on every call, and changes go to the audit
+// log like edits made in the TUI, which picks them up through its watcher.
+
+// mcpProtocolVersions are the protocol revisions the server speaks, newest
+// first. The tools use nothing later revisions changed, so all three work.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type mcpResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mcpTool is a tool the server offers. call returns the text shown to the
+// agent; an error is reported as a failed tool call, not a protocol error.
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	call        func(s *mcpServer, args json.RawMessage) (string, error)
+}
+
+// mcpSchema builds a tool's input schema from its properties (name →
+// description); all are strings.
+func mcpSchema(required []string, props ...[2]string) map[string]any {
+	properties := make(map[string]any)
+	for _, p := range props {
+		properties[p[0]] = map[string]any{"type": "string", "description": p[1]}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+var mcpPlanArg = [2]string{"plan", "The plan's path or file name (with or without .md)"}
+
+// mcpTools returns the tools the server offers. A function rather than a
+// var so the status descriptions follow the configured vocabulary.
+func mcpTools() []mcpTool {
+	return []mcpTool{
+		{
+			Name:        "list_plans",
+			Description: "List plans with their path, title, status (" + strings.Join(statusNames(), ", ") + "), labels, and dates.",
+			InputSchema: mcpSchema(nil,
+				[2]string{"status", "Only plans with these statuses, comma-separated"},
+				[2]string{"label", "Only plans with this label"}),
+			call: (*mcpServer).listPlans,
+		},
+		{
+			Name:        "get_plan",
+			Description: "Read a plan: its status and labels, the review comments left on it (each under the heading it belongs to), and its markdown body.",
+			InputSchema: mcpSchema([]string{"plan"}, mcpPlanArg),
+			call:        (*mcpServer).getPlan,
+		},
+		{
+			Name:        "set_status",
+			Description: "Set a plan's status: " + statusList() + ".",
+			InputSchema: mcpSchema([]string{"plan", "status"}, mcpPlanArg,
+				[2]string{"status", statusList()}),
+			call: (*mcpServer).setStatus,
+		},
+		{
+			Name:        "add_comment",
+			Description: "Add a review comment to a plan, under a heading (the first heading if none is given).",
+			InputSchema: mcpSchema([]string{"plan", "text"}, mcpPlanArg,
+				[2]string{"text", "The comment"},
+				[2]string{"heading", "Text of the heading to comment under"}),
+			call: (*mcpServer).addComment,
+		},
+	}
+}
+
+type mcpServer struct {
+	cfg       config
+	auditPath string // "" skips the audit log
+	now       func() time.Time
+}
+
+// serve answers requests from r on w until r ends.
+func (s *mcpServer) serve(r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 16<<20)
+	enc := json.NewEncoder(w)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var req mcpRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			if err := enc.Encode(mcpResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &mcpError{rpcParseError, err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(req.ID) == 0 {
+			continue // a notification, e.g. notifications/initialized
+		}
+		result, rpcErr := s.handle(req)
+		if err := enc.Encode(mcpResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func (s *mcpServer) handle(req mcpRequest) (any, *mcpError) {
+	logger.Debug("mcp request", "method", req.Method)
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		// Agree to the client's version if it's one we speak, else offer
+		// our newest and let the client decide.
+		version := mcpProtocolVersions[0]
+		if slices.Contains(mcpProtocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "planc", "version": getVersion()},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": mcpTools()}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &mcpError{rpcInvalidParams, err.Error()}
+		}
+		for _, t := range mcpTools() {
+			if t.Name != params.Name {
+				continue
+			}
+			if len(params.Arguments) == 0 {
+				params.Arguments = json.RawMessage("{}")
+			}
+			text, err := t.call(s, params.Arguments)
+			if err != nil {
+				logger.Info("mcp tool failed", "tool", t.Name, "err", err)
+				return mcpToolResult(err.Error(), true), nil
+			}
+			return mcpToolResult(text, false), nil
+		}
+		return nil, &mcpError{rpcInvalidParams, fmt.Sprintf("unknown tool %q", params.Name)}
+	}
+	return nil, &mcpError{rpcMethodNotFound, fmt.Sprintf("unknown method %q", req.Method)}
+}
+
+func mcpToolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// plan scans the plans and finds the one named by arg.
+func (s *mcpServer) plan(arg string) (plan, error) {
+	if arg == "" {
+		return plan{}, errors.New("plan is required")
+	}
+	all, err := scanAllPlans(s.cfg.PlansDir, s.cfg.ProjectPlanGlob)
+	if err != nil {
+		return plan{}, err
+	}
+	path, err := openPlanPath(all, arg)
+	if err != nil {
+		return plan{}, err
+	}
+	for _, p := range all {
+		if p.path() == path {
+			return p, nil
+		}
+	}
+	return plan{}, fmt.Errorf("no plan %q", arg)
+}
+
+// record sends ev to the audit log.
+func (s *mcpServer) record(ev planEvent) {
+	if s.auditPath == "" {
+		return
+	}
+	ev.at = s.now()
+	auditLog{s.auditPath}.record(ev)
+}
+
+func mcpJSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	return string(data), err
+}
+
+func (s *mcpServer) listPlans(args json.RawMessage) (string, error) {
+	var in struct{ Status, Label string }
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", err
+	}
+	var statuses []string
+	for _, st := range strings.Split(in.Status, ",") {
+		if st = strings.TrimSpace(st); st != "" {
+			statuses = append(statuses, st)
+		}
+	}
+	all, err := scanAllPlans(s.cfg.PlansDir, s.cfg.ProjectPlanGlob)
+	if err != nil {
+		return "", err
+	}
+	matched := matchPlans(all, statuses, in.Label)
+	sortPlans(matched)
+	entries := make([]listEntry, len(matched))
+	for i, p := range matched {
+		entries[i] = newListEntry(p)
+	}
+	return mcpJSON(entries)
+}
+
+func (s *mcpServer) getPlan(args json.RawMessage) (string, error) {
+	var in struct{ Plan string }
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", err
+	}
+	p, err := s.plan(in.Plan)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(p.path())
+	if err != nil {
+		return "", err
+	}
+	_, body := parseFrontmatter(string(data))
+	return mcpJSON(newPlanDocument(p, body))
+}
+
+func (s *mcpServer) setStatus(args json.RawMessage) (string, error) {
+	var in struct{ Plan, Status string }
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", err
+	}
+	status, ok := statusByName(in.Status)
+	if !ok {
+		return "", fmt.Errorf("unknown status %q (%s)", in.Status, statusChoices())
+	}
+	p, err := s.plan(in.Plan)
+	if err != nil {
+		return "", err
+	}
+	events, err := setPlans([]string{p.path()}, true, status, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, ev := range events {
+		s.record(ev)
+	}
+	return fmt.Sprintf("%s is %s", p.title, exportStatus(status)), nil
+}
+
+func (s *mcpServer) addComment(args json.RawMessage) (string, error) {
+	var in struct{ Plan, Text, Heading string }
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", err
+	}
+	p, err := s.plan(in.Plan)
+	if err != nil {
+		return "", err
+	}
+	ev, heading, err := addPlanComment(p, in.Heading, in.Text)
+	if err != nil {
+		return "", err
+	}
+	s.record(ev)
+	return fmt.Sprintf("Added a comment under %q", heading), nil
+}
+
+// addPlanComment adds text as a review comment under the plan's first heading
+// matching heading, ignoring case, or its first heading if heading is "". It
+// returns the heading's text.
+func addPlanComment(p plan, heading, text string) (planEvent, string, error) {
+	// A comment is one line of the plan.
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return planEvent{}, "", errors.New("text is required")
+	}
+	data, err := os.ReadFile(p.path())
+	if err != nil {
+		return planEvent{}, "", err
+	}
+	_, body := parseFrontmatter(string(data))
+	target := -1
+	var headings []string
+	for _, e := range extractToc(body) {
+		if e.isComment {
+			continue
+		}
+		headings = append(headings, e.text)
+		if target < 0 && (heading == "" || strings.EqualFold(e.text, strings.TrimSpace(heading))) {
+			target = e.rawLine
+			heading = e.text
+		}
+	}
+	switch {
+	case len(headings) == 0:
+		return planEvent{}, "", errors.New("the plan has no headings to comment under")
+	case target < 0:
+		return planEvent{}, "", fmt.Errorf("no heading %q; the plan's headings are: %s", heading, strings.Join(headings, "; "))
+	}
+	if err := writeCommentBody(p.path(), injectComment(body, target, text)); err != nil {
+		return planEvent{}, "", err
+	}
+	return planEvent{kind: eventCommentAdded, path: p.path(), to: text}, heading, nil
+}
+
+func runMCP(cfg config, args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc mcp")
+		fmt.Fprintln(fs.Output(), "Serves the Model Context Protocol on stdin/stdout, e.g. claude mcp add planc -- planc mcp")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	s := &mcpServer{cfg: cfg, now: time.Now}
+	if path, err := auditLogPath(); err == nil {
+		s.auditPath = path
+	}
+	if err := s.serve(os.Stdin, os.Stdout); err != nil {
+		return cliError("mcp: %v", err)
+	}
+	return 0
+}
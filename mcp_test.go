@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mcpCall runs one request through a server and returns its response.
+func mcpCall(t *testing.T, s *mcpServer, method string, params any) mcpResponse {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := s.serve(bytes.NewReader(data), &out); err != nil {
+		t.Fatal(err)
+	}
+	var resp mcpResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("response %q: %v", out.String(), err)
+	}
+	return resp
+}
+
+// mcpToolText calls a tool and returns its text and whether it failed.
+func mcpToolText(t *testing.T, s *mcpServer, tool string, args map[string]string) (string, bool) {
+	t.Helper()
+	resp := mcpCall(t, s, "tools/call", map[string]any{"name": tool, "arguments": args})
+	if resp.Error != nil {
+		t.Fatalf("%s: %v", tool, resp.Error.Message)
+	}
+	result := resp.Result.(map[string]any)
+	content := result["content"].([]any)[0].(map[string]any)
+	return content["text"].(string), result["isError"].(bool)
+}
+
+func testMCPServer(t *testing.T) (*mcpServer, string) {
+	t.Helper()
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	path := filepath.Join(cfg.PlansDir, "rate-limits.md")
+	writeFile(t, path, "---\nstatus: reviewed\nlabels: api\n---\n# Rate limits\n\n## Rollout\n\n> **[comment]:** Needs a flag\n\nShip it.\n")
+	writeFile(t, filepath.Join(cfg.PlansDir, "other.md"), "# Other\n")
+	s := &mcpServer{cfg: cfg, auditPath: filepath.Join(t.TempDir(), auditFileName), now: time.Now}
+	return s, path
+}
+
+func TestMCPProtocol(t *testing.T) {
+	s, _ := testMCPServer(t)
+
+	resp := mcpCall(t, s, "initialize", map[string]any{"protocolVersion": "2025-03-26"})
+	result := resp.Result.(map[string]any)
+	if result["protocolVersion"] != "2025-03-26" || result["serverInfo"].(map[string]any)["name"] != "planc" {
+		t.Errorf("initialize = %v", result)
+	}
+
+	for _, v := range []string{"2099-01-01", ""} {
+		resp := mcpCall(t, s, "initialize", map[string]any{"protocolVersion": v})
+		if got := resp.Result.(map[string]any)["protocolVersion"]; got != mcpProtocolVersions[0] {
+			t.Errorf("initialize with %q = %v, want the newest supported version", v, got)
+		}
+	}
+
+	resp = mcpCall(t, s, "tools/list", nil)
+	var names []string
+	for _, tool := range resp.Result.(map[string]any)["tools"].([]any) {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	if got := strings.Join(names, ","); got != "list_plans,get_plan,set_status,add_comment" {
+		t.Errorf("tools = %s", got)
+	}
+
+	if resp := mcpCall(t, s, "resources/list", nil); resp.Error == nil || resp.Error.Code != rpcMethodNotFound {
+		t.Errorf("unknown method = %+v", resp)
+	}
+
+	// Notifications get no response; bad JSON gets a parse error.
+	var out bytes.Buffer
+	in := `{"jsonrpc":"2.0","method":"notifications/initialized"}` + "\n" + "{oops\n"
+	if err := s.serve(strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "-32700") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestMCPTools(t *testing.T) {
+	s, path := testMCPServer(t)
+
+	text, isErr := mcpToolText(t, s, "list_plans", map[string]string{"status": "reviewed"})
+	var entries []listEntry
+	if err := json.Unmarshal([]byte(text), &entries); isErr || err != nil || len(entries) != 1 || entries[0].File != path {
+		t.Errorf("list_plans = %s", text)
+	}
+
+	text, _ = mcpToolText(t, s, "get_plan", map[string]string{"plan": "rate-limits"})
+	var got struct {
+		Status   string
+		Comments []planComment
+		Body     string
+	}
+	if err := json.Unmarshal([]byte(text), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "reviewed" || len(got.Comments) != 1 || got.Comments[0] != (planComment{Heading: "Rollout", Text: "Needs a flag"}) || !strings.Contains(got.Body, "Ship it.") {
+		t.Errorf("get_plan = %s", text)
+	}
+
+	if _, isErr := mcpToolText(t, s, "set_status", map[string]string{"plan": "rate-limits", "status": "done"}); isErr {
+		t.Error("set_status failed")
+	}
+	if _, isErr := mcpToolText(t, s, "set_status", map[string]string{"plan": "rate-limits", "status": "shipped"}); !isErr {
+		t.Error("an unknown status should fail")
+	}
+
+	if _, isErr := mcpToolText(t, s, "add_comment", map[string]string{"plan": "rate-limits", "heading": "rollout", "text": "Canary first"}); isErr {
+		t.Error("add_comment failed")
+	}
+	if text, isErr := mcpToolText(t, s, "add_comment", map[string]string{"plan": "rate-limits", "heading": "Testing", "text": "x"}); !isErr || !strings.Contains(text, "Rollout") {
+		t.Errorf("a missing heading should fail and list the headings: %s", text)
+	}
+	if _, isErr := mcpToolText(t, s, "get_plan", map[string]string{"plan": "missing"}); !isErr {
+		t.Error("an unknown plan should fail")
+	}
+
+	data, _ := os.ReadFile(path)
+	fm, body := parseFrontmatter(string(data))
+	if fm["status"] != "done" || !strings.Contains(body, "## Rollout\n\n> **[comment]:** Canary first\n") {
+		t.Errorf("plan = %q", data)
+	}
+	entriesLogged, err := readAuditLog(s.auditPath)
+	if err != nil || len(entriesLogged) != 2 || entriesLogged[1].Event != string(eventCommentAdded) {
+		t.Errorf("audit = %+v, %v", entriesLogged, err)
+	}
+}
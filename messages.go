@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 // ─── Messages ────────────────────────────────────────────────────────────────
 //
 // All messages are internal to the Update loop. Async tea.Cmd functions
@@ -22,9 +24,18 @@ type labelsUpdatedMsg struct {
 	plan plan
 }
 
+// titleUpdatedMsg reports a changed title: override; override is "" when it
+// was cleared and the plan fell back to its heading.
+type titleUpdatedMsg struct {
+	plan     plan
+	override string
+}
+
 // reloadMsg replaces the full plan list after a delete or external rescan.
+// events lists the deletions, if any.
 type reloadMsg struct {
-	plans []plan
+	plans  []plan
+	events []planEvent
 }
 
 // fileChangedMsg is sent by the fsnotify watcher after debounce.
@@ -57,6 +68,12 @@ type copiedClearMsg struct {
 	id int
 }
 
+// planCopiedMsg reports that a plan was copied to the clipboard (as rich text
+// or a prompt).
+type planCopiedMsg struct {
+	path string
+}
+
 type changedSpinExpiredMsg struct {
 	id int
 }
@@ -65,16 +82,44 @@ type editorLaunchedMsg struct{}
 
 type labelFlashMsg struct{}
 
+// labelSuggestionsMsg delivers suggested labels for an unlabeled plan.
+type labelSuggestionsMsg struct {
+	file   string
+	labels []string
+}
+
+// planFileMsg delivers the rescanned plan list after a file-level change
+// (create, rename, move, duplicate, archive). path is the plan to select,
+// or "" if the plan left the list. event describes the change.
+type planFileMsg struct {
+	plans   []plan
+	path    string
+	message string
+	event   planEvent
+}
+
 type errMsg struct {
 	err error
 }
 
+// plansDirMsg reports whether the agent plans directory is available.
+type plansDirMsg struct {
+	err error
+}
+
+// plansDirRetryMsg asks for another check of an unavailable plans directory.
+type plansDirRetryMsg struct {
+	id int
+}
+
 // batchDoneMsg is returned by batch status/label operations with the full
 // updated plan list and a summary message for the status bar.
 type batchDoneMsg struct {
 	plans   []plan
 	files   []string
 	message string
+	events  []planEvent       // reported changes; nil means diff plans against the list
+	content map[string]string // demo mode: rewritten bodies by filename, applied to demo.content in Update
 }
 
 type updateAvailableMsg struct {
@@ -92,9 +137,105 @@ type commentContentMsg struct {
 	toc                     []tocEntry
 }
 
+// commentSavedMsg reports a rewritten plan body; event describes the
+// comment change that produced it.
 type commentSavedMsg struct {
 	file, rawBody, rendered string
 	toc                     []tocEntry
+	event                   planEvent
+}
+
+// tasksLoadedMsg carries a plan's body for task mode.
+type tasksLoadedMsg struct {
+	path, body string
+}
+
+// tasksSavedMsg reports a plan body rewritten by checking or unchecking a
+// task; event describes the change.
+type tasksSavedMsg struct {
+	path, body string
+	event      planEvent
+}
+
+// screenshotSavedMsg reports where a screenshot was written.
+type screenshotSavedMsg struct {
+	path string // .html path; the .ans file sits next to it
+}
+
+// exportedMsg reports where a combined export was written.
+type exportedMsg struct {
+	path  string
+	count int
+}
+
+// remindersMsg delivers the startup reminders (see reminders.go).
+type remindersMsg struct {
+	items []reminder
+}
+
+// notesLoadedMsg delivers a plan's private notes.
+type notesLoadedMsg struct {
+	path  string
+	notes string
+	err   error
+}
+
+// notesSavedMsg reports that a plan's private notes were written.
+type notesSavedMsg struct {
+	path string
+}
+
+// agentStartedMsg reports a background agent launch; wait blocks until it
+// exits.
+type agentStartedMsg struct {
+	path string
+	run  agentRun
+	wait func() error
+}
+
+// agentExitedMsg reports that a plan's background agent exited.
+type agentExitedMsg struct {
+	path string
+	err  error
+}
+
+// agentOutputMsg delivers the tail of a plan's agent log for the L modal.
+type agentOutputMsg struct {
+	path     string
+	seq      int
+	lines    []string
+	modified time.Time
+	err      error
+}
+
+// agentOutputTickMsg refreshes the L modal while it's open.
+type agentOutputTickMsg struct {
+	seq int
+}
+
+// activityLoadedMsg delivers audit log entries, newest first.
+type activityLoadedMsg struct {
+	entries []auditEntry
+	err     error
+}
+
+// todosLoadedMsg delivers open task items collected from active plans.
+type todosLoadedMsg struct {
+	items []todoItem
+}
+
+// semanticIndexedMsg reports the result of an embedding index build.
+type semanticIndexedMsg struct {
+	count int
+	err   error
+}
+
+// semanticQueryMsg is sent once typing in the search pauses on term (done
+// false), and again when its embedding has been fetched (done true).
+type semanticQueryMsg struct {
+	term string
+	done bool
+	err  error
 }
 
 type startupUpdateMsg struct {
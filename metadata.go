@@ -0,0 +1,268 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Metadata ────────────────────────────────────────────────────────────────
+//
+// Every frontmatter key is kept in plan.fields, including ones planc has no
+// use for itself (branch:, pr:, owner:). m opens a panel listing the selected
+// plan's keys, where a adds one, enter edits a value, and d removes a key; h
+// there toggles a header block at the top of the preview showing them all
+// (show_metadata in config.json).
+
+// maxMetadataLines caps the preview header block.
+const maxMetadataLines = 3
+
+// metadataKeys returns the keys of fields, sorted.
+func metadataKeys(fields map[string]string) []string {
+	return slices.Sorted(maps.Keys(fields))
+}
+
+// withField returns a copy of fields with k set to v, or removed when v is
+// empty, matching how frontmatter writes treat empty values.
+func withField(fields map[string]string, k, v string) map[string]string {
+	out := maps.Clone(fields)
+	if out == nil {
+		out = make(map[string]string)
+	}
+	if v == "" {
+		delete(out, k)
+	} else {
+		out[k] = v
+	}
+	return out
+}
+
+// metadataHeader packs fields as "key value" pairs into at most
+// maxMetadataLines lines of width, or returns "" when there are none.
+func metadataHeader(fields map[string]string, width int) string {
+	keyStyle := lipgloss.NewStyle().Foreground(colorDim)
+	var lines []string
+	line, lineW := " ", 1
+	keys := metadataKeys(fields)
+	for i, k := range keys {
+		pair := truncateForWidth(k+": "+strings.ReplaceAll(fields[k], "\n", " "), max(width-2, 10))
+		w := lipgloss.Width(pair)
+		if lineW > 1 && lineW+2+w > width {
+			if len(lines) == maxMetadataLines-1 {
+				line += keyStyle.Render(trf("  +%d more", len(keys)-i))
+				break
+			}
+			lines = append(lines, line)
+			line, lineW = " ", 1
+		}
+		if lineW > 1 {
+			line += "  "
+			lineW += 2
+		}
+		k, v, _ := strings.Cut(pair, ": ")
+		line += keyStyle.Render(k+":") + " " + v
+		lineW += w
+	}
+	if lineW == 1 {
+		return ""
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+type metadataState struct {
+	active    bool
+	path      string // the plan's path
+	cursor    int
+	prompting bool   // the input is open
+	editKey   string // key whose value is being edited, or "" when adding
+	input     textinput.Model
+	err       string
+}
+
+// metadataFields returns the frontmatter of the plan the panel shows.
+func (m model) metadataFields() map[string]string {
+	for _, p := range *m.planSource() {
+		if p.path() == m.metadata.path {
+			return p.fields
+		}
+	}
+	return nil
+}
+
+func (m *model) openMetadata() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.CharLimit = 200
+	ti.Width = 50
+	m.metadata = metadataState{active: true, path: item.path(), input: ti}
+	return nil
+}
+
+// promptMetadata opens the input to edit key's value, or to add a key=value
+// pair when key is "".
+func (m *model) promptMetadata(key string) tea.Cmd {
+	m.metadata.prompting = true
+	m.metadata.editKey = key
+	m.metadata.err = ""
+	m.metadata.input.Placeholder = ""
+	m.metadata.input.SetValue("")
+	if key == "" {
+		m.metadata.input.Placeholder = "key=value"
+	} else {
+		m.metadata.input.SetValue(m.metadataFields()[key])
+	}
+	m.metadata.input.CursorEnd()
+	return m.metadata.input.Focus()
+}
+
+// toggleMetadataHeader shows or hides the preview's metadata block and saves
+// the choice, outside demo mode.
+func (m *model) toggleMetadataHeader() {
+	m.cfg.ShowMetadata = !m.cfg.ShowMetadata
+	if !m.demo.active {
+		if path, err := configPath(); err == nil {
+			saveConfig(path, m.cfg)
+		}
+	}
+}
+
+func (m model) handleMetadataKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if key.Matches(msg, m.keys.ForceQuit) {
+		return m, tea.Quit, true
+	}
+	if m.metadata.prompting {
+		return m.handleMetadataInput(msg)
+	}
+	keys := metadataKeys(m.metadataFields())
+	switch {
+	case key.Matches(msg, m.keys.Metadata), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.metadata.active = false
+	case msg.String() == "j" || msg.String() == "down":
+		if m.metadata.cursor < len(keys)-1 {
+			m.metadata.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.metadata.cursor > 0 {
+			m.metadata.cursor--
+		}
+	case msg.String() == "a":
+		return m, m.promptMetadata(""), true
+	case msg.String() == "e" || msg.Type == tea.KeyEnter:
+		if m.metadata.cursor < len(keys) {
+			return m, m.promptMetadata(keys[m.metadata.cursor]), true
+		}
+	case msg.String() == "d":
+		if m.metadata.cursor < len(keys) {
+			k := keys[m.metadata.cursor]
+			if m.metadata.cursor == len(keys)-1 && m.metadata.cursor > 0 {
+				m.metadata.cursor--
+			}
+			return m, m.store.batchSetField([]string{m.metadata.path}, k, ""), true
+		}
+	case msg.String() == "h":
+		m.toggleMetadataHeader()
+	}
+	return m, nil, true
+}
+
+func (m model) handleMetadataInput(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.metadata.prompting = false
+		m.metadata.input.Blur()
+		return m, nil, true
+	case tea.KeyEnter:
+		k, v := m.metadata.editKey, strings.TrimSpace(m.metadata.input.Value())
+		if k == "" {
+			var err error
+			if k, v, err = parseSetCommand(v); err != nil {
+				m.metadata.err = err.Error()
+				return m, nil, true
+			}
+		}
+		m.metadata.prompting = false
+		m.metadata.input.Blur()
+		if keys := metadataKeys(withField(m.metadataFields(), k, v)); v != "" {
+			m.metadata.cursor = max(slices.Index(keys, k), 0)
+		}
+		return m, m.store.batchSetField([]string{m.metadata.path}, k, v), true
+	}
+	m.metadata.err = ""
+	var cmd tea.Cmd
+	m.metadata.input, cmd = m.metadata.input.Update(msg)
+	return m, cmd, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderMetadataModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+	warnStyle := lipgloss.NewStyle().Foreground(colorYellow)
+
+	modalW := min(m.width-4, 80)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	fields := m.metadataFields()
+	keys := metadataKeys(fields)
+	keyW := 0
+	for _, k := range keys {
+		keyW = max(keyW, lipgloss.Width(k))
+	}
+	keyW = min(keyW, contentW/3)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Metadata")) + "\n")
+	b.WriteString(dimStyle.Render(truncateForWidth(contractHome(m.metadata.path), contentW)) + "\n\n")
+	if len(keys) == 0 {
+		b.WriteString(dimStyle.Render(tr("No frontmatter")) + "\n")
+	}
+	for i, k := range keys {
+		name := truncateForWidth(k, keyW)
+		name += strings.Repeat(" ", keyW-lipgloss.Width(name))
+		v := fields[k]
+		if m.metadata.prompting && m.metadata.editKey == k {
+			v = m.metadata.input.View()
+		} else {
+			v = truncateForWidth(strings.ReplaceAll(v, "\n", " "), contentW-keyW-4)
+		}
+		if i == m.metadata.cursor && !m.metadata.prompting {
+			b.WriteString(accentStyle.Render("> "+name) + "  " + v + "\n")
+		} else {
+			b.WriteString("  " + dimStyle.Render(name) + "  " + v + "\n")
+		}
+	}
+	if m.metadata.prompting && m.metadata.editKey == "" {
+		b.WriteString("\n" + tr("add ") + m.metadata.input.View() + "\n")
+	}
+	if m.metadata.err != "" {
+		b.WriteString(warnStyle.Render(m.metadata.err) + "\n")
+	}
+
+	header := tr("h show in preview")
+	if m.cfg.ShowMetadata {
+		header = tr("h hide from preview")
+	}
+	hint := tr("a add · enter edit · d remove · ") + header + tr(" · esc close")
+	if m.metadata.prompting {
+		hint = tr("enter save · empty value removes · esc cancel")
+	}
+	b.WriteString("\n" + dimStyle.Render(hint))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestMetadataHeader(t *testing.T) {
+	fields := map[string]string{"status": "active", "branch": "feat/auth", "pr": "142", "owner": "sam"}
+	got := ansi.Strip(metadataHeader(fields, 80))
+	if got != " branch: feat/auth  owner: sam  pr: 142  status: active" {
+		t.Errorf("header = %q", got)
+	}
+	got = ansi.Strip(metadataHeader(fields, 18))
+	lines := strings.Split(got, "\n")
+	if len(lines) != maxMetadataLines || !strings.HasSuffix(lines[2], "+1 more") {
+		t.Errorf("narrow header should cap at %d lines:\n%s", maxMetadataLines, got)
+	}
+	if metadataHeader(nil, 80) != "" {
+		t.Error("no fields should render no header")
+	}
+}
+
+func TestMetadataPanel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	writeFile(t, path, "---\nstatus: active\nbranch: feat/auth\npr: 142\n---\n# Auth\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+
+	send := func(msg tea.KeyMsg) tea.Cmd {
+		t.Helper()
+		m2, cmd := m.Update(msg)
+		m = m2.(model)
+		return cmd
+	}
+	apply := func(cmd tea.Cmd) {
+		t.Helper()
+		msg, ok := cmd().(batchDoneMsg)
+		if !ok {
+			t.Fatalf("got %T, want batchDoneMsg", msg)
+		}
+		m2, _ := m.Update(msg)
+		m = m2.(model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	send(runes("m"))
+	if !m.metadata.active {
+		t.Fatal("m should open the metadata panel")
+	}
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "branch  feat/auth") {
+		t.Errorf("panel should list the fields:\n%s", out)
+	}
+
+	// Add a field.
+	send(runes("a"))
+	send(runes("owner=sam"))
+	apply(send(tea.KeyMsg{Type: tea.KeyEnter}))
+	// Edit the first one (branch).
+	m.metadata.cursor = 0
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.metadata.prompting || m.metadata.input.Value() != "feat/auth" {
+		t.Fatalf("enter should edit branch, input %q", m.metadata.input.Value())
+	}
+	m.metadata.input.SetValue("feat/auth-v2")
+	apply(send(tea.KeyMsg{Type: tea.KeyEnter}))
+	// Remove pr. The first write also added created:.
+	m.metadata.cursor = slices.Index(metadataKeys(m.metadataFields()), "pr")
+	apply(send(runes("d")))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{"owner: sam", "branch: feat/auth-v2"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("file missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "pr:") {
+		t.Errorf("pr should be removed:\n%s", content)
+	}
+
+	send(runes("h"))
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.metadata.active || !m.cfg.ShowMetadata {
+		t.Fatal("h should turn on the preview header and esc close the panel")
+	}
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "owner: sam") {
+		t.Errorf("preview should show the metadata header:\n%s", out)
+	}
+}
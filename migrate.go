@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ─── planc migrate ───────────────────────────────────────────────────────────
+//
+// Legacy fields are normally migrated lazily, when a plan is next edited.
+// `planc migrate` rewrites every plan on disk in one pass; --dry-run only
+// reports what would change.
+
+// legacyUpdates returns the frontmatter updates that migrate fm's legacy
+// fields, with a human description of each.
+func legacyUpdates(fm map[string]string) (map[string]string, []string) {
+	updates := make(map[string]string)
+	var changes []string
+	if project := fm["project"]; project != "" {
+		labels := parseLabels(fm["labels"])
+		if !hasLabel(labels, strings.ToLower(project)) {
+			labels = parseLabels(labelsString(append(labels, project)))
+		}
+		updates["labels"] = labelsString(labels)
+		updates["project"] = ""
+		changes = append(changes, fmt.Sprintf("project %q → labels", project))
+	}
+	if fm["status"] == "pending" {
+		updates["status"] = "reviewed"
+		changes = append(changes, "pending → reviewed")
+	}
+	return updates, changes
+}
+
+// migratePlans migrates every plan under agentDir and projectGlob, writing a
+// line per changed file to w. Returns the number of files changed (or that
+// would change, with dryRun) and failed.
+func migratePlans(agentDir, projectGlob string, dryRun bool, w io.Writer) (changed, failed int, err error) {
+	plans, err := scanAllPlans(agentDir, projectGlob)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, p := range plans {
+		data, err := os.ReadFile(p.path())
+		if err != nil {
+			failed++
+			continue
+		}
+		fm, _ := parseFrontmatter(string(data))
+		updates, changes := legacyUpdates(fm)
+		if len(changes) == 0 {
+			continue
+		}
+		if !dryRun {
+			if err := setFrontmatter(p.path(), updates); err != nil {
+				fmt.Fprintf(w, "%s: %v\n", contractHome(p.path()), err)
+				failed++
+				continue
+			}
+		}
+		changed++
+		fmt.Fprintf(w, "%s: %s\n", contractHome(p.path()), strings.Join(changes, ", "))
+	}
+	return changed, failed, nil
+}
+
+func runMigrate(cfg config, args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "report changes without writing files")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc migrate [--dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	changed, failed, err := migratePlans(cfg.PlansDir, cfg.ProjectPlanGlob, *dryRun, os.Stdout)
+	if err != nil {
+		return cliError("migrate: %v", err)
+	}
+	verb := "migrated"
+	if *dryRun {
+		verb = "would be migrated"
+	}
+	fmt.Printf("%d plans %s", changed, verb)
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Println()
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
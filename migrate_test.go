@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLegacyUpdates(t *testing.T) {
+	updates, changes := legacyUpdates(map[string]string{"project": "Atlas", "labels": "api", "status": "pending"})
+	if updates["labels"] != "api, atlas" || updates["project"] != "" || updates["status"] != "reviewed" {
+		t.Errorf("updates = %v", updates)
+	}
+	if len(changes) != 2 {
+		t.Errorf("changes = %v", changes)
+	}
+	if _, changes := legacyUpdates(map[string]string{"status": "active", "labels": "api"}); len(changes) != 0 {
+		t.Errorf("current frontmatter should need no migration, got %v", changes)
+	}
+}
+
+func TestMigratePlans(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "legacy.md")
+	current := filepath.Join(dir, "current.md")
+	writeFile(t, legacy, "---\nstatus: pending\nproject: atlas\n---\n# Legacy\n")
+	writeFile(t, current, "---\nstatus: active\n---\n# Current\n")
+
+	var out bytes.Buffer
+	changed, failed, err := migratePlans(dir, "", true, &out)
+	if err != nil || changed != 1 || failed != 0 {
+		t.Fatalf("dry run: changed=%d failed=%d err=%v", changed, failed, err)
+	}
+	if !strings.Contains(out.String(), "legacy.md") || !strings.Contains(out.String(), "pending → reviewed") {
+		t.Errorf("dry run report = %q", out.String())
+	}
+	if data, _ := os.ReadFile(legacy); !strings.Contains(string(data), "pending") {
+		t.Error("dry run should not modify files")
+	}
+
+	out.Reset()
+	if changed, _, _ := migratePlans(dir, "", false, &out); changed != 1 {
+		t.Errorf("changed = %d, want 1", changed)
+	}
+	data, _ := os.ReadFile(legacy)
+	fm, _ := parseFrontmatter(string(data))
+	if fm["status"] != "reviewed" || fm["labels"] != "atlas" || fm["project"] != "" {
+		t.Errorf("migrated frontmatter = %v", fm)
+	}
+	if changed, _, _ := migratePlans(dir, "", false, &out); changed != 0 {
+		t.Errorf("second run changed %d plans, want 0", changed)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -27,18 +28,31 @@ type keyMap struct {
 	Navigate    key.Binding
 	SwitchPane  key.Binding
 	OpenStatus  key.Binding
+	Priority    key.Binding
 	CycleStatus key.Binding
-	SetStatus   key.Binding // 0-3 direct status set (display-only binding)
+	SetStatus   key.Binding // 0-9 direct status set, one key per status
 	Undo        key.Binding
 	ToggleDone  key.Binding
+	ToggleDate  key.Binding
+	Sort        key.Binding
 	Labels      key.Binding
 	Delete      key.Binding
+	Archive     key.Binding
 	Primary     key.Binding
 	Editor      key.Binding
 	Filter      key.Binding
 	CopyFile    key.Binding
-	PrevLabel key.Binding
-	NextLabel key.Binding
+	CopyRich    key.Binding
+	CopyPrompt  key.Binding
+	PrevLabel   key.Binding
+	NextLabel   key.Binding
+	PrevSource  key.Binding
+	NextSource  key.Binding
+	PrevOwner   key.Binding
+	NextOwner   key.Binding
+	FollowUp    key.Binding
+	Group       key.Binding
+	Render      key.Binding
 	Select      key.Binding
 	SelectAll   key.Binding
 	View        key.Binding
@@ -46,6 +60,34 @@ type keyMap struct {
 	ScrollUp    key.Binding
 	Help        key.Binding
 	Settings    key.Binding
+	Screenshot  key.Binding
+	GenTitle    key.Binding
+	SetTitle    key.Binding
+	Rename      key.Binding
+	Fork        key.Binding
+	Move        key.Binding
+	SetField    key.Binding
+	Due         key.Binding
+	NewPlan     key.Binding
+	Capture     key.Binding
+	Info        key.Binding
+	Todo        key.Binding
+	Tasks       key.Binding
+	Related     key.Binding
+	Newer       key.Binding
+	Older       key.Binding
+	Supersede   key.Binding
+	Blocker     key.Binding
+	Dedupe      key.Binding
+	Trash       key.Binding
+	Metadata    key.Binding
+	History     key.Binding
+	Activity    key.Binding
+	AgentOutput key.Binding
+	MacroRecord key.Binding
+	MacroPlay   key.Binding
+	Notes       key.Binding
+	Export      key.Binding
 	Quit        key.Binding
 	ForceQuit   key.Binding
 	Demo        key.Binding
@@ -53,31 +95,72 @@ type keyMap struct {
 
 func newKeyMap(cfg config) keyMap {
 	return keyMap{
-		Navigate:    key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "navigate / scroll")),
-		SwitchPane:  key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab", "switch pane")),
-		OpenStatus:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "status")),
-		CycleStatus: key.NewBinding(key.WithKeys("~"), key.WithHelp("~", "cycle status")),
-		SetStatus:   key.NewBinding(key.WithKeys("0", "1", "2", "3"), key.WithHelp("0-3", "set status")),
-		Undo:        key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo status")),
-		ToggleDone:  key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "toggle done plans")),
-		Labels:      key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "labels")),
-		Delete:      key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "delete plan")),
+		Navigate:    key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", tr("navigate / scroll"))),
+		SwitchPane:  key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab", tr("switch pane"))),
+		OpenStatus:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", tr("status"))),
+		Priority:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", tr("priority"))),
+		CycleStatus: key.NewBinding(key.WithKeys("~"), key.WithHelp("~", tr("cycle status"))),
+		SetStatus:   setStatusBinding(),
+		Undo:        key.NewBinding(key.WithKeys("u"), key.WithHelp("u", tr("undo status"))),
+		ToggleDone:  key.NewBinding(key.WithKeys("a"), key.WithHelp("a", tr("toggle done plans"))),
+		ToggleDate:  key.NewBinding(key.WithKeys("M"), key.WithHelp("M", tr("created/modified dates"))),
+		Sort:        key.NewBinding(key.WithKeys("O"), key.WithHelp("O", tr("sort order"))),
+		Labels:      key.NewBinding(key.WithKeys("l"), key.WithHelp("l", tr("labels"))),
+		Delete:      key.NewBinding(key.WithKeys("#"), key.WithHelp("#", tr("delete plan"))),
+		Trash:       key.NewBinding(key.WithKeys("X"), key.WithHelp("X", tr("trash"))),
+		Archive:     key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", tr("archive done plans"))),
 		Primary:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", commandLabel(cfg.Primary))),
 		Editor:      key.NewBinding(key.WithKeys("e"), key.WithHelp("e", commandLabel(cfg.Editor))),
-		Filter:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-		CopyFile:    key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "copy path")),
-		PrevLabel: key.NewBinding(key.WithKeys("["), key.WithHelp("[/]", "cycle label filter")),
-		NextLabel: key.NewBinding(key.WithKeys("]")),
-		View:        key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "view")),
-		Select:      key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "select")),
+		Filter:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", tr("search"))),
+		CopyFile:    key.NewBinding(key.WithKeys("C"), key.WithHelp("C", tr("copy path"))),
+		CopyRich:    key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", tr("copy as rich text"))),
+		CopyPrompt:  key.NewBinding(key.WithKeys("P"), key.WithHelp("P", tr("copy as agent prompt"))),
+		PrevLabel:   key.NewBinding(key.WithKeys("["), key.WithHelp("[/]", tr("cycle label filter"))),
+		NextLabel:   key.NewBinding(key.WithKeys("]")),
+		PrevSource:  key.NewBinding(key.WithKeys("{"), key.WithHelp("{/}", tr("cycle source filter"))),
+		NextSource:  key.NewBinding(key.WithKeys("}")),
+		PrevOwner:   key.NewBinding(key.WithKeys("("), key.WithHelp("(/)", tr("cycle owner filter"))),
+		NextOwner:   key.NewBinding(key.WithKeys(")")),
+		FollowUp:    key.NewBinding(key.WithKeys("F"), key.WithHelp("F", tr("needs follow-up"))),
+		Group:       key.NewBinding(key.WithKeys("G"), key.WithHelp("G", tr("group by status/label/source"))),
+		Render:      key.NewBinding(key.WithKeys("v"), key.WithHelp("v", tr("render large plan"))),
+		View:        key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("view"))),
+		Select:      key.NewBinding(key.WithKeys("x"), key.WithHelp("x", tr("select"))),
 		SelectAll:   key.NewBinding(key.WithKeys("a")),
-		ScrollDown:  key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "page down")),
-		ScrollUp:    key.NewBinding(key.WithKeys("B"), key.WithHelp("B", "page up")),
-		Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
-		Settings:    key.NewBinding(key.WithKeys(","), key.WithHelp(",", "settings")),
-		Quit:        key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+		ScrollDown:  key.NewBinding(key.WithKeys(" "), key.WithHelp("space", tr("page down"))),
+		ScrollUp:    key.NewBinding(key.WithKeys("B"), key.WithHelp("B", tr("page up"))),
+		Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", tr("help"))),
+		Settings:    key.NewBinding(key.WithKeys(","), key.WithHelp(",", tr("settings"))),
+		Screenshot:  key.NewBinding(key.WithKeys("S"), key.WithHelp("S", tr("save screenshot"))),
+		GenTitle:    key.NewBinding(key.WithKeys("T"), key.WithHelp("T", tr("generate title"))),
+		SetTitle:    key.NewBinding(key.WithKeys("R"), key.WithHelp("R", tr("set title"))),
+		Rename:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", tr("rename file"))),
+		Fork:        key.NewBinding(key.WithKeys("f"), key.WithHelp("f", tr("fork plan"))),
+		Move:        key.NewBinding(key.WithKeys("w"), key.WithHelp("w", tr("move to…"))),
+		SetField:    key.NewBinding(key.WithKeys(":"), key.WithHelp(":", tr("set field"))),
+		Due:         key.NewBinding(key.WithKeys("d"), key.WithHelp("d", tr("due date"))),
+		NewPlan:     key.NewBinding(key.WithKeys("N"), key.WithHelp("N", tr("new plan"))),
+		Capture:     key.NewBinding(key.WithKeys("I"), key.WithHelp("I", tr("capture URL/clipboard as plan"))),
+		Info:        key.NewBinding(key.WithKeys("i"), key.WithHelp("i", tr("plan info"))),
+		Todo:        key.NewBinding(key.WithKeys("t"), key.WithHelp("t", tr("action items"))),
+		Related:     key.NewBinding(key.WithKeys("b"), key.WithHelp("b", tr("related plans"))),
+		Newer:       key.NewBinding(key.WithKeys(">"), key.WithHelp(">/<", tr("newer/older version"))),
+		Older:       key.NewBinding(key.WithKeys("<")),
+		Supersede:   key.NewBinding(key.WithKeys("V"), key.WithHelp("V", tr("supersede a plan"))),
+		Blocker:     key.NewBinding(key.WithKeys("J"), key.WithHelp("J", tr("jump to blocking plan"))),
+		Dedupe:      key.NewBinding(key.WithKeys("="), key.WithHelp("=", tr("find duplicate plans"))),
+		Metadata:    key.NewBinding(key.WithKeys("m"), key.WithHelp("m", tr("frontmatter metadata"))),
+		History:     key.NewBinding(key.WithKeys("H"), key.WithHelp("H", tr("status history"))),
+		Tasks:       key.NewBinding(key.WithKeys("x"), key.WithHelp("x", tr("check off tasks (preview)"))),
+		Activity:    key.NewBinding(key.WithKeys("A"), key.WithHelp("A", tr("activity log"))),
+		AgentOutput: key.NewBinding(key.WithKeys("L"), key.WithHelp("L", tr("agent output"))),
+		MacroRecord: key.NewBinding(key.WithKeys("Q"), key.WithHelp("Q", tr("record macro"))),
+		MacroPlay:   key.NewBinding(key.WithKeys("@"), key.WithHelp("@", tr("replay macro"))),
+		Notes:       key.NewBinding(key.WithKeys("n"), key.WithHelp("n", tr("private notes"))),
+		Export:      key.NewBinding(key.WithKeys("E"), key.WithHelp("E", tr("export plans"))),
+		Quit:        key.NewBinding(key.WithKeys("q"), key.WithHelp("q", tr("quit"))),
 		ForceQuit:   key.NewBinding(key.WithKeys("ctrl+c")),
-		Demo:        key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "demo mode")),
+		Demo:        key.NewBinding(key.WithKeys("D"), key.WithHelp("D", tr("demo mode"))),
 	}
 }
 
@@ -88,9 +171,9 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		// Essentials
-		{k.View, k.Editor, k.Primary, k.CopyFile, k.OpenStatus, k.Labels, k.Select, k.ToggleDone, k.Filter, k.PrevLabel},
+		{k.View, k.Editor, k.Primary, k.CopyFile, k.CopyRich, k.CopyPrompt, k.OpenStatus, k.Priority, k.Labels, k.Info, k.Metadata, k.History, k.Notes, k.Todo, k.Tasks, k.Related, k.Newer, k.Blocker, k.Select, k.ToggleDone, k.Filter, k.PrevLabel, k.PrevSource, k.PrevOwner, k.FollowUp, k.Group},
 		// Power user
-		{k.Navigate, k.SwitchPane, k.ScrollDown, k.ScrollUp, k.CycleStatus, k.SetStatus, k.Undo, k.Delete, k.Settings, k.Quit},
+		{k.Navigate, k.SwitchPane, k.ScrollDown, k.ScrollUp, k.CycleStatus, k.SetStatus, k.Undo, k.ToggleDate, k.Sort, k.Activity, k.AgentOutput, k.GenTitle, k.SetTitle, k.Rename, k.Fork, k.Move, k.SetField, k.Due, k.Supersede, k.Dedupe, k.NewPlan, k.Capture, k.MacroRecord, k.MacroPlay, k.Render, k.Delete, k.Trash, k.Archive, k.Export, k.Screenshot, k.Settings, k.Quit},
 	}
 }
 
@@ -100,6 +183,7 @@ const statusTimeout = 3 * time.Second
 
 type demoState struct {
 	active  bool
+	size    int // >0 generates this many synthetic plans instead of the curated set
 	plans   []plan
 	content map[string]string
 }
@@ -133,25 +217,35 @@ type model struct {
 	refreshing   map[string]bool   // files being re-rendered due to external change
 	previewWidth int               // cached width for invalidation on resize
 	prerendered  bool              // true after first render pass
+	renderLarge  map[string]bool   // large plans the user chose to render (v)
 	glamourStyle string            // "dark" or "light" based on terminal background
 
 	// Plan data
-	allPlans    []plan
-	dir         string // primary agent plans directory
-	projectDirs []string
-	cfg         config
-	installed     time.Time // first-run timestamp; controls unset-plan visibility
-	store         planStore
-	watcher       *fsnotify.Watcher
-	showDone      bool
-	labelFilter string
+	allPlans        []plan
+	dir             string   // primary agent plans directory
+	projectDirs     []string // watched besides dir: its subdirectories (recursive_scan) and project dirs
+	cfg             config
+	installed       time.Time // first-run timestamp; controls unset-plan visibility
+	store           planStore
+	events          *eventBus      // plan lifecycle events; see events.go
+	semantic        *semanticIndex // nil unless embedding_url is configured
+	watcher         *fsnotify.Watcher
+	showDone        bool
+	labelFilter     string
+	sourceFilter    string          // plan directory the list is restricted to ("" = all)
+	ownerFilter     string          // owner: the list is restricted to ("" = all)
+	followUpFilter  bool            // only plans with open (unresolved) comments
+	statusFilter    string          // status name the list is restricted to, from --status ("" = all)
+	groupBy         string          // group mode: "", "status", "label", or "source"
+	collapsedGroups map[string]bool // "mode:name" → collapsed, for this session
 
 	// Cursor and selection
-	prevIndex    int             // tracks cursor changes to trigger preview updates
-	selected     map[string]bool // files toggled with 'x' for batch operations
-	changedFiles map[string]bool // files recently changed externally (spinner on badge)
+	prevIndex       int             // tracks cursor changes to trigger preview updates
+	selected        map[string]bool // files toggled with 'x' for batch operations
+	changedFiles    map[string]bool // files recently changed externally (spinner on badge)
 	changedSpinID   int
-	changedSpinView *string // shared with delegate for spinner frame
+	changedSpinView *string         // shared with delegate for spinner frame
+	display         *displayOptions // shared with delegate for config-driven rendering
 
 	// Modals and transient state
 	confirmDelete    bool
@@ -162,13 +256,39 @@ type model struct {
 	settingLabels  bool
 	labelInput     textinput.Model
 	labelChoices   []string        // all known labels
+	labelSuggested map[string]bool // suggested labels for an unlabeled plan (tab accepts)
 	labelToggled   map[string]bool // tracks which labels are toggled (on = all have it)
 	labelMixed     map[string]bool // tracks mixed state in batch mode (some but not all)
 	labelCursor    int
-	labelBatchMode bool            // true when multiple plans selected
-	labelDirty     bool            // true when user has toggled/added a label
-	labelFlashIdx  int             // index flashing after enter toggle (-1 = none)
-	labelFlashTick int             // remaining flash ticks
+	labelBatchMode bool // true when multiple plans selected
+	labelDirty     bool // true when user has toggled/added a label
+	labelFlashIdx  int  // index flashing after enter toggle (-1 = none)
+	labelFlashTick int  // remaining flash ticks
+
+	// Title modal
+	settingTitle bool
+	titleInput   textinput.Model
+
+	// Rename and fork prompts (reuse titleInput)
+	renaming bool
+	forking  bool
+
+	// Set-field prompt
+	settingField bool
+	fieldInput   textinput.Model
+	fieldFiles   []string // plans the prompt applies to
+	fieldErr     string
+
+	// Due date prompt
+	settingDue bool
+	dueInput   textinput.Model
+	dueFiles   []string // plans the prompt applies to
+	dueErr     string
+
+	// New-plan prompt (reuses titleInput)
+	creatingPlan   bool
+	newPlanDirPath string // directory the plan will be created in
+	newPlanFlow    templateFlow
 
 	// Inline feedback
 	undoFiles      map[string]string // filename → new status (shown inline on plan row during undo window)
@@ -183,6 +303,69 @@ type model struct {
 	settingStatus     bool
 	statusModalCursor int
 
+	// Sort menu
+	settingSort bool
+	sortCursor  int // index into sortPresets
+
+	// Priority modal
+	settingPriority bool
+	priorityCursor  int // index into priorityOptions
+
+	// Info modal
+	showInfo   bool
+	infoCursor int // selected backlink in the info modal
+
+	// Action items view
+	todo todoState
+
+	// Task mode (x in the preview pane)
+	taskMode taskModeState
+
+	// Related plans panel
+	related relatedState
+
+	// Supersede picker (V)
+	supersede supersedeState
+
+	// Move-to modal (w)
+	move moveState
+
+	// Duplicate plans view (=)
+	dedupe dedupeState
+
+	// Trash view (X)
+	trash trashState
+
+	// Metadata panel (m)
+	metadata metadataState
+
+	// Status history view (H)
+	showHistory bool
+
+	// Agent plans directory availability
+	plansDir plansDirState
+
+	// planc:// link section to open at the first window size (see deeplink.go)
+	link *planLink
+
+	// Plans awaiting an export format choice (see openExportPrompt)
+	exportPlans []plan
+
+	// Done plans awaiting confirmation to archive (see openArchivePrompt)
+	archivePlans []plan
+
+	// Activity view (audit log)
+	activity  activityState
+	notes     notesState
+	reminders remindersState
+	auditPath string // audit log file, "" if there is no data directory
+
+	// Background agent runs (primary_mode "background"), by plan path
+	agentRuns   map[string]agentRun
+	agentOutput agentOutputState
+
+	macro macroState
+
 	// Sub-states
 	clod            clodState
 	demo            demoState
@@ -200,29 +383,48 @@ func (m *model) planSource() *[]plan {
 }
 
 func (m model) visiblePlans() []plan {
+	return m.filteredPlans(m.showDone)
+}
+
+// filteredPlans applies the current label and source filters, with done and
+// untouched plans shown or hidden per showDone.
+func (m model) filteredPlans(showDone bool) []plan {
+	var plans []plan
 	if m.demo.active {
 		// Use a fake installed time so unset-status plans with recent
 		// modified times are visible, just like in real usage.
 		fakeInstalled := time.Now().Add(-48 * time.Hour)
-		return filterPlans(m.demo.plans, m.showDone, m.keepFiles(), m.labelFilter, fakeInstalled)
+		plans = filterSource(filterPlans(m.demo.plans, showDone, m.keepFiles(), m.labelFilter, fakeInstalled), m.sourceFilter)
+	} else {
+		plans = filterSource(filterPlans(m.allPlans, showDone, m.keepFiles(), m.labelFilter, m.installed), m.sourceFilter)
+	}
+	if m.followUpFilter {
+		plans = filterFollowUp(plans)
 	}
-	return filterPlans(m.allPlans, m.showDone, m.keepFiles(), m.labelFilter, m.installed)
+	plans = filterOwner(plans, m.ownerFilter)
+	if m.statusFilter != "" {
+		plans = filterStatus(plans, m.statusFilter)
+	}
+	return plans
 }
 
-// syncHasComments updates the hasComments flag on the plan matching planPath
-// in both allPlans and the visible list. Called after comment edits so the
-// 💬 indicator in the list view stays in sync.
-func (m *model) syncHasComments(planPath string, has bool) {
+// syncComments updates the comment counts on the plan matching planPath in
+// both allPlans and the visible list from its current body. Called after
+// comment edits so the 💬 indicator in the list view stays in sync.
+func (m *model) syncComments(planPath, body string) {
+	total, open := countComments(body)
 	plans := m.planSource()
 	for i, p := range *plans {
 		if p.path() == planPath {
-			(*plans)[i].hasComments = has
+			(*plans)[i].hasComments = total > 0
+			(*plans)[i].openComments = open
 			break
 		}
 	}
 	for i, item := range m.list.Items() {
 		if p, ok := item.(plan); ok && p.path() == planPath {
-			p.hasComments = has
+			p.hasComments = total > 0
+			p.openComments = open
 			m.list.SetItem(i, p)
 			break
 		}
@@ -240,9 +442,11 @@ func newModel(plans []plan, dir string, cfg config, watcher *fsnotify.Watcher) m
 	}
 	sortPlans(plans)
 	var spinView string
-	delegate := planDelegate{agentDir: dir, selected: sel, changed: chg, undoFiles: uf, copiedFiles: cf, spinnerView: &spinView}
+	display := &displayOptions{showTokens: cfg.ShowTokens, readingTime: cfg.ShowReadingTime, ageColors: cfg.AgeColors, lintSections: cfg.LintSections}
+	delegate := planDelegate{agentDir: dir, display: display, selected: sel, changed: chg, undoFiles: uf, copiedFiles: cf, spinnerView: &spinView}
 	visible := filterPlans(plans, cfg.ShowAll, nil, "", installed)
-	l := list.New(plansToItems(visible), delegate, 0, 0)
+	items := plansToItems(markBlocked(visible, plans))
+	l := list.New(items, delegate, 0, 0)
 	l.Title = "Planc Active · All"
 	l.SetShowStatusBar(false)
 	l.SetShowHelp(false)
@@ -250,6 +454,10 @@ func newModel(plans []plan, dir string, cfg config, watcher *fsnotify.Watcher) m
 	l.Styles.TitleBar = lipgloss.NewStyle().Padding(0, 1, 1, 2)
 	l.KeyMap.Quit.SetKeys("q") // don't quit on esc
 	l.FilterInput.Prompt = "Search: "
+	semantic := newSemanticIndex(cfg)
+	if semantic != nil {
+		l.Filter = semantic.filterFor(items)
+	}
 
 	keys := newKeyMap(cfg)
 
@@ -271,6 +479,23 @@ func newModel(plans []plan, dir string, cfg config, watcher *fsnotify.Watcher) m
 	li.CharLimit = 50
 	li.Width = 30
 
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.CharLimit = 120
+	ti.Width = 50
+
+	fi := textinput.New()
+	fi.Prompt = ""
+	fi.Placeholder = "key=value"
+	fi.CharLimit = 200
+	fi.Width = 40
+
+	di := textinput.New()
+	di.Prompt = ""
+	di.Placeholder = "2026-03-20, fri, +3d"
+	di.CharLimit = 40
+	di.Width = 30
+
 	ci := textinput.New()
 	ci.Prompt = "comment: "
 	ci.CharLimit = 200
@@ -291,10 +516,15 @@ func newModel(plans []plan, dir string, cfg config, watcher *fsnotify.Watcher) m
 		focused:         listPane,
 		prevIndex:       -1,
 		previewCache:    make(map[string]string),
+		refreshing:      make(map[string]bool),
 		changedFiles:    chg,
 		changedSpinView: &spinView,
+		display:         display,
 		undoFiles:       uf,
 		copiedFiles:     cf,
+		collapsedGroups: make(map[string]bool),
+		renderLarge:     make(map[string]bool),
+		agentRuns:       make(map[string]agentRun),
 		watcher:         watcher,
 		allPlans:        plans,
 		showDone:        cfg.ShowAll,
@@ -303,9 +533,14 @@ func newModel(plans []plan, dir string, cfg config, watcher *fsnotify.Watcher) m
 		installed:       installed,
 		selected:        sel,
 		store:           diskStore{agentDir: dir, projectGlob: cfg.ProjectPlanGlob},
+		events:          newEventBus(),
+		semantic:        semantic,
 		glamourStyle:    style,
 		status:          statusBarState{spinner: s},
 		labelInput:      li,
+		titleInput:      ti,
+		fieldInput:      fi,
+		dueInput:        di,
 		comment:         commentState{commentInput: ci},
 		releaseNotes:    releaseNotesState{viewport: rnvp},
 	}
@@ -320,6 +555,15 @@ func (m model) Init() tea.Cmd {
 		if cmd := startupUpdateCmd(getVersion()); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		if files := untitledFiles(m.allPlans); m.cfg.AutoTitle && len(files) > 0 {
+			cmds = append(cmds, m.cmdGenerateTitles(files))
+		}
+		if m.semantic != nil {
+			cmds = append(cmds, m.semantic.build(m.allPlans))
+		}
+		if after := remindAfter(m.cfg); after > 0 && m.link == nil {
+			cmds = append(cmds, loadReminders(m.allPlans, after, time.Now()))
+		}
 	}
 	if len(cmds) == 0 {
 		return nil
@@ -388,11 +632,13 @@ func (m *model) restoreTitle() {
 	tab := lipgloss.NewStyle().Bold(true)
 	ghost := lipgloss.NewStyle().Foreground(colorDim)
 
+	active := fmt.Sprintf("Active %d", len(m.filteredPlans(false)))
+	all := fmt.Sprintf("All %d", len(m.filteredPlans(true)))
 	var tabs string
 	if m.showDone {
-		tabs = ghost.Render("a ") + ghost.Render("Active") + ghost.Render(" · ") + tab.Render("All")
+		tabs = ghost.Render("a ") + ghost.Render(active) + ghost.Render(" · ") + tab.Render(all)
 	} else {
-		tabs = ghost.Render("a ") + tab.Render("Active") + ghost.Render(" · ") + ghost.Render("All")
+		tabs = ghost.Render("a ") + tab.Render(active) + ghost.Render(" · ") + ghost.Render(all)
 	}
 	tabsW := lipgloss.Width(tabs)
 
@@ -408,9 +654,35 @@ func (m *model) restoreTitle() {
 			}
 		}
 	}
+	if keys := currentSortKeys(); len(keys) > 0 || sortByModified.Load() {
+		left += " " + ghost.Render(sortDescription(keys))
+	}
+	if m.sourceFilter != "" {
+		name := sourceName(m.sourceFilter, m.dir)
+		if name == "" {
+			name = "agent"
+		}
+		left += " " + ghost.Render(name+"/")
+	}
+	if m.statusFilter != "" {
+		status, _ := statusByName(m.statusFilter)
+		left += " " + statusStyle(status).Render(m.statusFilter)
+	}
 	if m.labelFilter != "" {
 		left += " " + labelColor(m.labelFilter).Render(m.labelFilter)
 	}
+	if m.ownerFilter != "" {
+		left += " " + lipgloss.NewStyle().Bold(true).Render("@"+m.ownerFilter)
+	}
+	if m.groupBy != "" {
+		left += " " + ghost.Render("by "+m.groupBy)
+	}
+	if m.followUpFilter {
+		left += " " + lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render(commentIcon()+" follow-up")
+	}
+	if total := totalEstimate(m.viewPlans()); total > 0 {
+		left += " " + ghost.Render("~"+formatEstimate(total)+" of work")
+	}
 	if m.list.IsFiltered() {
 		filterText := m.list.FilterValue()
 		if filterText != "" {
@@ -451,6 +723,120 @@ func (m *model) selectFile(path string) {
 	}
 }
 
+// listIndex returns the index of the plan at path in the list items.
+func (m model) listIndex(path string) (int, bool) {
+	for i, item := range m.list.Items() {
+		if p, ok := item.(plan); ok && p.path() == path {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// applyStartFilters restricts the list to a label and/or status name, as
+// given by planc --label and --status. A status filter shows done and
+// untouched plans too, since it already says which plans to show.
+func (m *model) applyStartFilters(label, status string) {
+	m.labelFilter = label
+	m.statusFilter = status
+	if status != "" {
+		m.showDone = true
+		m.updateHelpKeys()
+	}
+	m.setListItems(m.visiblePlans())
+	m.restoreTitle()
+}
+
+// revealPlan selects the plan at path, expanding its group and clearing the
+// search, filters, and done-hiding as needed to make it visible.
+func (m *model) revealPlan(path string) {
+	if m.list.IsFiltered() || m.list.SettingFilter() {
+		m.list.ResetFilter()
+	}
+	if m.groupBy != "" {
+		for _, p := range *m.planSource() {
+			if p.path() == path {
+				delete(m.collapsedGroups, m.groupBy+":"+groupName(p, m.groupBy, m.dir))
+				m.setListItems(m.visiblePlans())
+				break
+			}
+		}
+	}
+	if _, inList := m.listIndex(path); !inList {
+		m.labelFilter = ""
+		m.sourceFilter = ""
+		m.ownerFilter = ""
+		m.followUpFilter = false
+		m.statusFilter = ""
+		m.setListItems(m.visiblePlans())
+		if _, inList = m.listIndex(path); !inList {
+			m.showDone = true
+			m.setListItems(m.visiblePlans())
+		}
+		m.restoreTitle()
+	}
+	m.selectFile(path)
+}
+
+// toggleDateMode flips between created and modified dates, saves the choice,
+// and re-sorts. Returns true if dates are now modification times.
+func (m *model) toggleDateMode() bool {
+	byModified := !sortByModified.Load()
+	sortByModified.Store(byModified)
+	if !m.demo.active {
+		m.cfg.SortByModified = byModified
+		if path, err := configPath(); err == nil {
+			saveConfig(path, m.cfg)
+		}
+	}
+	m.resortPlans()
+	return byModified
+}
+
+// resortPlans re-sorts the plan list after the date mode changes, keeping the
+// cursor on the same plan.
+func (m *model) resortPlans() {
+	prev := m.selectedFile()
+	sortPlans(*m.planSource())
+	m.setListItems(m.visiblePlans())
+	m.selectFile(prev)
+	m.restoreTitle()
+}
+
+// cycleSourceFilter moves the source filter to the next (or previous) plan
+// directory that has visible plans, wrapping through "all sources". Returns
+// nil when there is only one source.
+func (m *model) cycleSourceFilter(forward bool) tea.Cmd {
+	sources := planSources(*m.planSource(), m.dir)
+	if len(sources) < 2 {
+		return nil
+	}
+	cycle := append([]string{""}, sources...)
+	idx := slices.Index(cycle, m.sourceFilter)
+	for range cycle {
+		if forward {
+			idx = (idx + 1) % len(cycle)
+		} else {
+			idx = (idx - 1 + len(cycle)) % len(cycle)
+		}
+		m.sourceFilter = cycle[idx]
+		if visible := m.visiblePlans(); len(visible) > 0 || m.sourceFilter == "" {
+			m.restoreTitle()
+			m.setListItems(visible)
+			m.list.ResetSelected()
+			m.prevIndex = 0
+			if file := m.selectedFile(); file != "" {
+				if content, ok := m.previewCache[file]; ok {
+					m.viewport.SetContent(content)
+					m.viewport.GotoTop()
+				}
+			}
+			return m.renderWindow()
+		}
+	}
+	return nil
+}
+
 func (m model) cmdSetStatus(p plan, status string) tea.Cmd {
 	return m.store.setStatus(p, status)
 }
@@ -471,6 +857,21 @@ func (m model) cmdBatchUpdateLabels(files []string, add []string, remove []strin
 	return m.store.batchUpdateLabels(files, add, remove)
 }
 
+func (m model) cmdGenerateTitles(files []string) tea.Cmd {
+	return m.store.generateTitles(files)
+}
+
+// untitledFiles returns paths of plans whose title fell back to the filename.
+func untitledFiles(plans []plan) []string {
+	var files []string
+	for _, p := range plans {
+		if p.untitled {
+			files = append(files, p.path())
+		}
+	}
+	return files
+}
+
 // pruneSelection removes selected files that are no longer in the visible list.
 func (m *model) pruneSelection() {
 	visible := make(map[string]bool)
@@ -496,12 +897,22 @@ func (m model) cmdLoadComment(planPath string) tea.Cmd {
 	return loadCommentMode(planPath, m.glamourStyle, m.previewW())
 }
 
-// cmdSaveComment returns the appropriate saveComment command for the current mode.
-func (m model) cmdSaveComment(newBody string) tea.Cmd {
+// cmdSaveComment returns the appropriate saveComment command for the current
+// mode. ev describes the comment change; its path is filled in here.
+func (m model) cmdSaveComment(newBody string, ev planEvent) tea.Cmd {
+	save := saveComment(m.comment.planFile, newBody, m.glamourStyle, m.previewW())
 	if m.demo.active {
-		return saveCommentDemo(m.comment.planFile, newBody, m.demo.content, m.glamourStyle, m.previewW())
+		save = saveCommentDemo(m.comment.planFile, newBody, m.demo.content, m.glamourStyle, m.previewW())
+	}
+	ev.path = m.comment.planFile
+	return func() tea.Msg {
+		msg := save()
+		if saved, ok := msg.(commentSavedMsg); ok {
+			saved.event = ev
+			return saved
+		}
+		return msg
 	}
-	return saveComment(m.comment.planFile, newBody, m.glamourStyle, m.previewW())
 }
 
 func (m model) selectedFiles() []string {
@@ -582,6 +993,13 @@ func (m model) renderWindow() tea.Cmd {
 		if _, cached := m.previewCache[p.path()]; cached {
 			continue
 		}
+		if isLargePlan(p) && !m.renderLarge[p.path()] {
+			// Render on demand (v); neighbors are skipped entirely.
+			if i == idx {
+				cmds = append(cmds, largePlanPlaceholder(p))
+			}
+			continue
+		}
 		if m.demo.active {
 			md, ok := m.demo.content[p.file]
 			if !ok {
@@ -608,7 +1026,7 @@ func (m model) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.notification = ""
 			return m, tea.Batch(
 				m.cmdDelete(item),
-				m.setNotification("Deleted: "+item.file, 3*time.Second),
+				m.setNotification(trf("Moved to trash: %s (X to restore)", item.file), 3*time.Second),
 			)
 		}
 	case "n", "esc":
@@ -627,28 +1045,6 @@ func (m model) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// statusOptions maps cursor index to status values for the status modal.
-var statusOptions = []struct {
-	key    string
-	icon   string
-	label  string
-	status string
-}{
-	{"0", "·", "new", ""},
-	{"1", "○", "reviewed", "reviewed"},
-	{"2", "●", "active", "active"},
-	{"3", "✓", "done", "done"},
-}
-
-func statusCursorForStatus(s string) int {
-	for i, opt := range statusOptions {
-		if opt.status == s {
-			return i
-		}
-	}
-	return 0
-}
-
 func (m model) handleStatusModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 	switch {
 	case key.Matches(msg, m.keys.ForceQuit):
@@ -658,21 +1054,13 @@ func (m model) handleStatusModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		return m, nil, true
 	case msg.Type == tea.KeyEnter:
 		m.settingStatus = false
-		return m, m.applyStatus(statusOptions[m.statusModalCursor].status), true
-	case msg.String() == "0":
-		m.settingStatus = false
-		return m, m.applyStatus(""), true
-	case msg.String() == "1":
-		m.settingStatus = false
-		return m, m.applyStatus("reviewed"), true
-	case msg.String() == "2":
-		m.settingStatus = false
-		return m, m.applyStatus("active"), true
-	case msg.String() == "3":
+		return m, m.applyStatus(statusOptions()[m.statusModalCursor].status), true
+	case key.Matches(msg, m.keys.SetStatus):
 		m.settingStatus = false
-		return m, m.applyStatus("done"), true
+		status, _ := statusForKey(msg.String())
+		return m, m.applyStatus(status), true
 	case msg.String() == "j" || msg.String() == "down":
-		if m.statusModalCursor < len(statusOptions)-1 {
+		if m.statusModalCursor < len(statusOptions())-1 {
 			m.statusModalCursor++
 		}
 		return m, nil, true
@@ -699,12 +1087,79 @@ func (m model) applyStatus(status string) tea.Cmd {
 	return nil
 }
 
+func (m model) handleInfoModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Info), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.showInfo = false
+	case msg.String() == "r":
+		if item, ok := m.list.SelectedItem().(plan); ok && len(item.problems) > 0 {
+			m.showInfo = false
+			return m, m.store.repairPlan(item), true
+		}
+	case msg.Type == tea.KeyEnter:
+		m.showInfo = false
+		refs := m.infoBacklinks()
+		if m.infoCursor < len(refs) {
+			cmd := m.jumpToPlan(refs[m.infoCursor].path())
+			return m, cmd, true
+		}
+	case msg.String() == "j" || msg.String() == "down":
+		if m.infoCursor < len(m.infoBacklinks())-1 {
+			m.infoCursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.infoCursor > 0 {
+			m.infoCursor--
+		}
+	}
+	return m, nil, true
+}
+
+// infoBacklinks returns the plans referencing the selected plan, in list order.
+func (m model) infoBacklinks() []plan {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok || len(item.backlinks) == 0 {
+		return nil
+	}
+	refs := make(map[string]bool, len(item.backlinks))
+	for _, path := range item.backlinks {
+		refs[path] = true
+	}
+	var out []plan
+	for _, p := range *m.planSource() {
+		if refs[p.path()] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// jumpToPlan selects the plan at path. In comment mode it reopens comment mode
+// on that plan.
+func (m *model) jumpToPlan(path string) tea.Cmd {
+	m.revealPlan(path)
+	if !m.comment.active {
+		return nil
+	}
+	m.prevIndex = m.list.Index()
+	m.comment.planFile = path
+	m.comment.cursor = 0
+	m.comment.editing = false
+	return m.cmdLoadComment(path)
+}
+
 // ─── Label Modal ─────────────────────────────────────────────────────────────
 
-func (m *model) openLabelModal(batchMode bool) {
+// openLabelModal shows the label modal for the selected plan or, in batch
+// mode, for all selected plans. For a single unlabeled plan it also returns a
+// command that computes label suggestions in the background.
+func (m *model) openLabelModal(batchMode bool) tea.Cmd {
 	m.settingLabels = true
 	m.labelBatchMode = batchMode
 	m.labelChoices = recentLabels(*m.planSource())
+	m.labelSuggested = nil
 	m.labelToggled = make(map[string]bool)
 	m.labelMixed = make(map[string]bool)
 	m.labelDirty = false
@@ -739,6 +1194,53 @@ func (m *model) openLabelModal(batchMode bool) {
 	m.labelFlashTick = 0
 	m.labelInput.SetValue("")
 	m.labelInput.Focus()
+
+	if item, ok := m.list.SelectedItem().(plan); ok && !batchMode && len(item.labels) == 0 {
+		return tea.Batch(textinput.Blink, m.cmdSuggestLabels(item))
+	}
+	return textinput.Blink
+}
+
+// cmdSuggestLabels reads the plan body (from disk or demo content) and
+// computes label suggestions off the Update loop.
+func (m model) cmdSuggestLabels(p plan) tea.Cmd {
+	plans := *m.planSource()
+	agentDir := m.dir
+	if m.demo.active {
+		body := m.demo.content[p.file]
+		return func() tea.Msg {
+			return labelSuggestionsMsg{file: p.path(), labels: suggestLabels(p, body, plans, agentDir)}
+		}
+	}
+	return func() tea.Msg {
+		data, err := os.ReadFile(p.path())
+		if err != nil {
+			return nil
+		}
+		_, body := parseFrontmatter(string(data))
+		return labelSuggestionsMsg{file: p.path(), labels: suggestLabels(p, body, plans, agentDir)}
+	}
+}
+
+// applyLabelSuggestions moves suggested labels to the top of the modal's
+// choices so they are visible and ready for one-key acceptance.
+func (m *model) applyLabelSuggestions(labels []string) {
+	if len(labels) == 0 {
+		return
+	}
+	m.labelSuggested = make(map[string]bool)
+	choices := make([]string, 0, len(m.labelChoices)+len(labels))
+	for _, l := range labels {
+		m.labelSuggested[l] = true
+		choices = append(choices, l)
+	}
+	for _, l := range m.labelChoices {
+		if !m.labelSuggested[l] {
+			choices = append(choices, l)
+		}
+	}
+	m.labelChoices = choices
+	m.labelCursor = 0
 }
 
 // filteredLabelChoices returns label choices filtered by the current input.
@@ -770,6 +1272,14 @@ func (m model) handleLabelModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			return m, m.applyLabelChanges(), true
 		}
 		return m, nil, true
+	case msg.Type == tea.KeyTab && len(m.labelSuggested) > 0:
+		// Accept all suggestions (plus anything already toggled) and close
+		for l := range m.labelSuggested {
+			m.labelToggled[l] = true
+		}
+		m.labelDirty = true
+		m.settingLabels = false
+		return m, m.applyLabelChanges(), true
 	case msg.Type == tea.KeyEnter:
 		filtered := m.filteredLabelChoices()
 		filter := strings.ToLower(strings.TrimSpace(m.labelInput.Value()))
@@ -954,8 +1464,10 @@ func (m model) handleCommentEditKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 
 		entry := m.comment.toc[m.comment.editTarget]
 		var newBody string
+		ev := planEvent{kind: eventCommentAdded, to: text}
 		if m.comment.editExisting {
 			newBody = replaceComment(m.comment.rawBody, entry.rawLine, text)
+			ev = planEvent{kind: eventCommentEdited, from: entry.text, to: text}
 		} else {
 			newBody = injectComment(m.comment.rawBody, entry.rawLine, text)
 			// Move cursor to the newly inserted comment (appears after the heading)
@@ -963,7 +1475,7 @@ func (m model) handleCommentEditKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		}
 
 		m.comment.commentInput.SetValue("")
-		return m, m.cmdSaveComment(newBody), true
+		return m, m.cmdSaveComment(newBody, ev), true
 	default:
 		var cmd tea.Cmd
 		m.comment.commentInput, cmd = m.comment.commentInput.Update(msg)
@@ -979,6 +1491,13 @@ func (m model) commentNextFile(delta int) (model, tea.Cmd, bool) {
 	}
 	idx := m.list.Index()
 	newIdx := idx + delta
+	// Step over group headers.
+	for newIdx >= 0 && newIdx < len(items) {
+		if _, ok := items[newIdx].(plan); ok {
+			break
+		}
+		newIdx += delta
+	}
 	if newIdx < 0 || newIdx >= len(items) {
 		return m, nil, true
 	}
@@ -1009,7 +1528,7 @@ func (m model) handleCommentKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 
 	// Exit comment mode
 	case msg.Type == tea.KeyEsc:
-		m.syncHasComments(m.comment.planFile, bodyHasComments(m.comment.rawBody))
+		m.syncComments(m.comment.planFile, m.comment.rawBody)
 		m.comment.active = false
 		m.comment.toc = nil
 		delete(m.previewCache, m.comment.planFile)
@@ -1039,8 +1558,14 @@ func (m model) handleCommentKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		return m, nil, true
 	case key.Matches(msg, m.keys.Labels):
 		if _, ok := m.list.SelectedItem().(plan); ok {
-			m.openLabelModal(false)
-			return m, textinput.Blink, true
+			cmd := m.openLabelModal(false)
+			return m, cmd, true
+		}
+		return m, nil, true
+	case key.Matches(msg, m.keys.Info):
+		if _, ok := m.list.SelectedItem().(plan); ok {
+			m.showInfo = true
+			m.infoCursor = 0
 		}
 		return m, nil, true
 
@@ -1052,7 +1577,7 @@ func (m model) handleCommentKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 
 	// Editor — exit comment mode and let key fall through
 	case key.Matches(msg, m.keys.Editor):
-		m.syncHasComments(m.comment.planFile, bodyHasComments(m.comment.rawBody))
+		m.syncComments(m.comment.planFile, m.comment.rawBody)
 		m.comment.active = false
 		m.comment.toc = nil
 		delete(m.previewCache, m.comment.planFile)
@@ -1109,7 +1634,21 @@ func (m model) handleCommentKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				return m, nil, true
 			}
 			newBody := removeComment(m.comment.rawBody, entry.rawLine)
-			return m, m.cmdSaveComment(newBody), true
+			return m, m.cmdSaveComment(newBody, planEvent{kind: eventCommentDeleted, from: entry.text}), true
+		case msg.String() == "r":
+			if len(m.comment.toc) == 0 {
+				return m, nil, true
+			}
+			entry := m.comment.toc[m.comment.cursor]
+			if !entry.isComment {
+				return m, nil, true
+			}
+			newBody := setCommentResolved(m.comment.rawBody, entry.rawLine, !entry.resolved)
+			kind := eventCommentResolved
+			if entry.resolved {
+				kind = eventCommentReopened
+			}
+			return m, m.cmdSaveComment(newBody, planEvent{kind: kind, to: entry.text}), true
 		case msg.String() == "right":
 			m.focused = previewPane
 			return m, nil, true
@@ -1135,29 +1674,31 @@ func (m model) handleSelectMode(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		m.settingStatus = true
 		m.statusModalCursor = statusCursorForStatus(first.status)
 		return m, nil, true
+	case key.Matches(msg, m.keys.Priority):
+		m.openPriorityModal(m.firstSelectedPlan().priority)
+		return m, nil, true
 	case key.Matches(msg, m.keys.CycleStatus):
 		first := m.firstSelectedPlan()
-		target := nextStatus[first.status]
-		if target == "" {
-			target = "reviewed"
-		}
-		files := m.selectedFiles()
-		return m, m.cmdBatchSetStatus(files, target), true
-	case msg.String() == "0":
 		files := m.selectedFiles()
-		return m, m.cmdBatchSetStatus(files, ""), true
-	case msg.String() == "1":
+		return m, m.cmdBatchSetStatus(files, nextStatus(first.status)), true
+	case key.Matches(msg, m.keys.SetStatus):
+		status, _ := statusForKey(msg.String())
 		files := m.selectedFiles()
-		return m, m.cmdBatchSetStatus(files, "reviewed"), true
-	case msg.String() == "2":
-		files := m.selectedFiles()
-		return m, m.cmdBatchSetStatus(files, "active"), true
-	case msg.String() == "3":
-		files := m.selectedFiles()
-		return m, m.cmdBatchSetStatus(files, "done"), true
+		return m, m.cmdBatchSetStatus(files, status), true
 	case key.Matches(msg, m.keys.Labels):
-		m.openLabelModal(true)
-		return m, textinput.Blink, true
+		cmd := m.openLabelModal(true)
+		return m, cmd, true
+	case key.Matches(msg, m.keys.GenTitle):
+		return m, m.cmdGenerateTitles(m.selectedFiles()), true
+	case key.Matches(msg, m.keys.SetField):
+		cmd := m.openFieldModal(m.selectedFiles())
+		return m, cmd, true
+	case key.Matches(msg, m.keys.Due):
+		cmd := m.openDueModal(m.selectedFiles(), m.firstSelectedPlan().due)
+		return m, cmd, true
+	case key.Matches(msg, m.keys.Export):
+		m.openExportPrompt()
+		return m, nil, true
 	case msg.String() == "a":
 		for _, item := range m.list.Items() {
 			if p, ok := item.(plan); ok {
@@ -1197,21 +1738,48 @@ func (m model) handleSelectMode(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 
 // ─── Key Handling ─────────────────────────────────────────────────────────────
 
+// modalOpen reports whether a modal or prompt owns the keyboard. A new modal
+// adds its state here so global keys (scrolling, the demo toggle) can't leak
+// through it.
+func (m model) modalOpen() bool {
+	return m.confirmDelete || m.settingStatus || m.settingSort || m.settingPriority ||
+		m.settingLabels || m.settingTitle || m.renaming || m.forking || m.settingField ||
+		m.settingDue || m.creatingPlan || m.showInfo || m.showHistory ||
+		m.todo.active || m.taskMode.active || m.related.active || m.supersede.active ||
+		m.move.active || m.dedupe.active || m.trash.active || m.metadata.active ||
+		m.activity.active || m.notes.active || m.reminders.active || m.agentOutput.active ||
+		m.macro.prompting || m.exportPlans != nil || m.archivePlans != nil
+}
+
+// textInputFocused reports whether keys are going to a text input, where the
+// global keys (settings, screenshot, scrolling, demo) are typed instead.
+func (m model) textInputFocused() bool {
+	return m.comment.editing || m.notes.active || m.settingLabels || m.settingTitle ||
+		m.renaming || m.forking || m.settingField || m.settingDue || m.creatingPlan ||
+		m.supersede.active || m.metadata.prompting || m.macro.prompting || m.list.SettingFilter()
+}
+
 // handleKeyMsg processes keyboard input, returning handled=true for keys that
 // should short-circuit Update (modals, commands, etc.) and handled=false for
 // keys that should fall through to list.Update for default navigation/search.
 func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 	// Settings — accessible from anywhere except text input modes
-	if key.Matches(msg, m.keys.Settings) && !m.comment.editing && !m.settingLabels && !m.clod.active && !m.list.SettingFilter() {
+	if key.Matches(msg, m.keys.Settings) && !m.textInputFocused() && !m.clod.active {
 		m.help.ShowAll = false
 		m.confirmDelete = false
 		m.settingLabels = false
 		m.settingStatus = false
+		m.showInfo = false
+		m.todo.active = false
+		m.activity.active = false
+		m.reminders.active = false
+		m.agentOutput.active = false
 		exe, err := os.Executable()
 		if err != nil {
 			return m, func() tea.Msg { return errMsg{fmt.Errorf("could not find executable: %w", err)} }, true
 		}
 		c := exec.Command(exe, "--setup")
+		logger.Info("launch", "args", c.Args)
 		return m, tea.ExecProcess(c, func(err error) tea.Msg {
 			if err != nil {
 				return errMsg{fmt.Errorf("setup failed: %w", err)}
@@ -1220,6 +1788,11 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		}), true
 	}
 
+	// Screenshot — capture the frame as it looks right now, modals included
+	if key.Matches(msg, m.keys.Screenshot) && !m.textInputFocused() && !m.clod.active {
+		return m, saveScreenshot(m.View(), screenshotDir(), time.Now()), true
+	}
+
 	// Clod fake AI screen — swallows all input when active
 	if m.clod.active {
 		return m.handleClodKey(msg)
@@ -1252,7 +1825,7 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 	}
 
 	// Space / shift+space — scroll preview regardless of pane focus
-	if !m.help.ShowAll && !m.confirmDelete && !m.settingStatus && !m.settingLabels && !m.list.SettingFilter() && !m.comment.editing {
+	if !m.help.ShowAll && !m.modalOpen() && !m.textInputFocused() {
 		switch {
 		case key.Matches(msg, m.keys.ScrollDown):
 			m.viewport.HalfViewDown()
@@ -1264,7 +1837,7 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 	}
 
 	// Demo toggle — accessible from any pane, blocked during modals/filters/comment mode
-	if key.Matches(msg, m.keys.Demo) && !m.comment.active && !m.list.SettingFilter() && !m.list.IsFiltered() && !m.confirmDelete && !m.settingStatus && !m.settingLabels {
+	if key.Matches(msg, m.keys.Demo) && !m.comment.active && !m.textInputFocused() && !m.list.IsFiltered() && !m.modalOpen() {
 		if m.demo.active {
 			m.exitDemoMode()
 			return m, m.renderWindow(), true
@@ -1284,16 +1857,91 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 
+	if m.todo.active {
+		return m.handleTodoKey(msg)
+	}
+	if m.taskMode.active {
+		return m.handleTaskKey(msg)
+	}
+	if m.related.active {
+		return m.handleRelatedKey(msg)
+	}
+	if m.supersede.active {
+		return m.handleSupersedeKey(msg)
+	}
+	if m.move.active {
+		return m.handleMoveKey(msg)
+	}
+	if m.dedupe.active {
+		return m.handleDedupeKey(msg)
+	}
+	if m.trash.active {
+		return m.handleTrashKey(msg)
+	}
+	if m.metadata.active {
+		return m.handleMetadataKey(msg)
+	}
+	if m.showHistory {
+		return m.handleHistoryKey(msg)
+	}
+	if m.activity.active {
+		return m.handleActivityKey(msg)
+	}
+	if m.notes.active {
+		return m.handleNotesKey(msg)
+	}
+	if m.reminders.active {
+		return m.handleRemindersKey(msg)
+	}
+	if m.agentOutput.active {
+		return m.handleAgentOutputKey(msg)
+	}
+	if m.macro.prompting {
+		return m.handleMacroPrompt(msg)
+	}
+	if m.showInfo {
+		return m.handleInfoModal(msg)
+	}
 	if m.settingLabels {
 		return m.handleLabelModal(msg)
 	}
+	if m.settingTitle {
+		return m.handleTitleModal(msg)
+	}
+	if m.renaming {
+		return m.handleRenameModal(msg)
+	}
+	if m.forking {
+		return m.handleForkModal(msg)
+	}
+	if m.settingField {
+		return m.handleFieldModal(msg)
+	}
+	if m.settingDue {
+		return m.handleDueModal(msg)
+	}
+	if m.creatingPlan {
+		return m.handleNewPlanModal(msg)
+	}
 	if m.settingStatus {
 		return m.handleStatusModal(msg)
 	}
+	if m.settingSort {
+		return m.handleSortModal(msg)
+	}
+	if m.settingPriority {
+		return m.handlePriorityModal(msg)
+	}
 	if m.confirmDelete {
 		mod, cmd := m.handleDeleteConfirm(msg)
 		return mod.(model), cmd, true
 	}
+	if m.exportPlans != nil {
+		return m.handleExportPrompt(msg)
+	}
+	if m.archivePlans != nil {
+		return m.handleArchiveConfirm(msg)
+	}
 
 	// Comment mode — after modals/help/scroll so those work naturally
 	if m.comment.active {
@@ -1304,17 +1952,31 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		m = mod // apply model changes (e.g. exiting comment mode for editor)
 	}
 
-
 	filtering := m.list.SettingFilter()
 
+	// Q / @ — record and replay keyboard macros
+	if !filtering {
+		if mod, cmd, handled := m.handleMacroKey(msg); handled {
+			return mod, cmd, true
+		}
+	}
+
+	// r — check the plans directory again while it's unavailable
+	if msg.String() == "r" && !filtering && m.plansDir.err != nil && !m.demo.active {
+		return m, checkPlansDir(m.dir), true
+	}
+
 	if len(m.selected) > 0 {
 		if mod, cmd, handled := m.handleSelectMode(msg); handled {
 			return mod, cmd, true
 		}
 	}
 
-	// Enter / o — view mode (from either pane)
+	// Enter / o — view mode (from either pane); on a group header, collapse
 	if (msg.Type == tea.KeyEnter || msg.String() == "o") && !filtering {
+		if m.toggleGroup() {
+			return m, nil, true
+		}
 		if item, ok := m.list.SelectedItem().(plan); ok {
 			m.comment.active = true
 			m.comment.planFile = item.path()
@@ -1340,6 +2002,18 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		case key.Matches(msg, m.keys.SwitchPane):
 			m.focused = listPane
 			return m, nil, true
+		case key.Matches(msg, m.keys.Tasks):
+			return m, m.openTaskMode(), true
+		case key.Matches(msg, m.keys.Related):
+			return m, m.openRelated(), true
+		case key.Matches(msg, m.keys.Metadata):
+			return m, m.openMetadata(), true
+		case key.Matches(msg, m.keys.History):
+			return m, m.openHistory(), true
+		case key.Matches(msg, m.keys.Newer):
+			return m, m.walkChain(true), true
+		case key.Matches(msg, m.keys.Older):
+			return m, m.walkChain(false), true
 		case key.Matches(msg, m.keys.Help):
 			m.help.ShowAll = true
 			return m, nil, true
@@ -1370,9 +2044,13 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			return m, nil, true
 		}
 	case msg.String() == "esc":
-		if !filtering && (m.showDone || m.labelFilter != "") {
+		if !filtering && (m.showDone || m.labelFilter != "" || m.sourceFilter != "" || m.ownerFilter != "" || m.followUpFilter || m.statusFilter != "") {
 			m.showDone = false
 			m.labelFilter = ""
+			m.sourceFilter = ""
+			m.ownerFilter = ""
+			m.followUpFilter = false
+			m.statusFilter = ""
 			if !m.demo.active && m.cfg.ShowAll {
 				m.cfg.ShowAll = false
 				if path, err := configPath(); err == nil {
@@ -1380,7 +2058,7 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				}
 			}
 			visible := m.visiblePlans()
-			m.list.SetItems(plansToItems(visible))
+			m.setListItems(visible)
 			m.list.ResetSelected()
 			m.restoreTitle()
 			return m, nil, true
@@ -1393,20 +2071,23 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				return m, nil, true
 			}
 		}
+	case key.Matches(msg, m.keys.Priority):
+		if !filtering {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				m.openPriorityModal(item.priority)
+				return m, nil, true
+			}
+		}
 	case key.Matches(msg, m.keys.CycleStatus):
 		if !filtering {
 			if item, ok := m.list.SelectedItem().(plan); ok {
-				status := nextStatus[item.status]
-				if status == "" {
-					status = "reviewed"
-				}
-				return m, m.cmdSetStatus(item, status), true
+				return m, m.cmdSetStatus(item, nextStatus(item.status)), true
 			}
 		}
-	case msg.String() == "0" || msg.String() == "1" || msg.String() == "2" || msg.String() == "3":
+	case key.Matches(msg, m.keys.SetStatus):
 		if !filtering {
 			if item, ok := m.list.SelectedItem().(plan); ok {
-				status := map[string]string{"0": "", "1": "reviewed", "2": "active", "3": "done"}[msg.String()]
+				status, _ := statusForKey(msg.String())
 				if item.status == status {
 					return m, nil, true
 				}
@@ -1431,7 +2112,7 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				}
 			}
 			visible := m.visiblePlans()
-			m.list.SetItems(plansToItems(visible))
+			m.setListItems(visible)
 			m.list.ResetSelected()
 			m.restoreTitle()
 			if file := m.selectedFile(); file != "" {
@@ -1442,6 +2123,48 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			}
 			return m, nil, true
 		}
+	case key.Matches(msg, m.keys.Render):
+		if !filtering {
+			if item, ok := m.list.SelectedItem().(plan); ok && isLargePlan(item) && !m.renderLarge[item.path()] {
+				m.renderLarge[item.path()] = true
+				delete(m.previewCache, item.path())
+				cmd := m.setNotification("Rendering "+item.file+"…", statusTimeout)
+				return m, tea.Batch(cmd, m.renderWindow()), true
+			}
+		}
+	case key.Matches(msg, m.keys.Group):
+		if !filtering {
+			m.cycleGroupMode()
+			return m, m.renderWindow(), true
+		}
+	case key.Matches(msg, m.keys.FollowUp):
+		if !filtering {
+			m.followUpFilter = !m.followUpFilter
+			visible := m.visiblePlans()
+			m.setListItems(visible)
+			m.list.ResetSelected()
+			m.prevIndex = 0
+			m.restoreTitle()
+			if m.followUpFilter && len(visible) == 0 {
+				cmd := m.setNotification(tr("No plans with open comments"), statusTimeout)
+				return m, cmd, true
+			}
+			return m, m.renderWindow(), true
+		}
+	case key.Matches(msg, m.keys.ToggleDate):
+		if !filtering {
+			notice := "Dates: created"
+			if m.toggleDateMode() {
+				notice = "Dates: modified"
+			}
+			cmd := m.setNotification(notice, statusTimeout)
+			return m, cmd, true
+		}
+	case key.Matches(msg, m.keys.Sort):
+		if !filtering {
+			m.openSortModal()
+			return m, nil, true
+		}
 	case key.Matches(msg, m.keys.NextLabel), key.Matches(msg, m.keys.PrevLabel):
 		if !filtering {
 			labels := recentLabels(*m.planSource())
@@ -1483,7 +2206,7 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 					visible := m.visiblePlans()
 					if len(visible) > 0 || m.labelFilter == "" {
 						m.restoreTitle()
-						m.list.SetItems(plansToItems(visible))
+						m.setListItems(visible)
 						m.list.ResetSelected()
 						m.prevIndex = 0
 						// Update viewport to show the new first item
@@ -1498,13 +2221,146 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				}
 			}
 		}
+	case key.Matches(msg, m.keys.NextSource), key.Matches(msg, m.keys.PrevSource):
+		if !filtering {
+			if cmd := m.cycleSourceFilter(key.Matches(msg, m.keys.NextSource)); cmd != nil {
+				return m, cmd, true
+			}
+		}
+	case key.Matches(msg, m.keys.NextOwner), key.Matches(msg, m.keys.PrevOwner):
+		if !filtering {
+			if cmd := m.cycleOwnerFilter(key.Matches(msg, m.keys.NextOwner)); cmd != nil {
+				return m, cmd, true
+			}
+		}
 	case key.Matches(msg, m.keys.Labels):
 		if !filtering {
 			if _, ok := m.list.SelectedItem().(plan); ok {
-				m.openLabelModal(false)
-				return m, textinput.Blink, true
+				cmd := m.openLabelModal(false)
+				return m, cmd, true
+			}
+		}
+	case key.Matches(msg, m.keys.Todo):
+		if !filtering {
+			cmd := m.openTodos()
+			return m, cmd, true
+		}
+	case key.Matches(msg, m.keys.Activity):
+		if !filtering {
+			cmd := m.openActivity()
+			return m, cmd, true
+		}
+	case key.Matches(msg, m.keys.Notes):
+		if !filtering && !m.demo.active {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				return m, m.openNotes(item), true
+			}
+		}
+	case key.Matches(msg, m.keys.AgentOutput):
+		if !filtering && !m.demo.active {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				return m, m.openAgentOutput(item), true
+			}
+		}
+	case key.Matches(msg, m.keys.Export):
+		if !filtering && m.openExportPrompt() {
+			return m, nil, true
+		}
+	case key.Matches(msg, m.keys.Info):
+		if !filtering {
+			if _, ok := m.list.SelectedItem().(plan); ok {
+				m.showInfo = true
+				m.infoCursor = 0
+				return m, nil, true
+			}
+		}
+	case key.Matches(msg, m.keys.GenTitle):
+		if !filtering {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				if !item.untitled {
+					return m, m.setNotification(trf("Already titled: %s", item.title), statusTimeout), true
+				}
+				return m, m.cmdGenerateTitles([]string{item.path()}), true
+			}
+		}
+	case key.Matches(msg, m.keys.SetField):
+		if !filtering {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				cmd := m.openFieldModal([]string{item.path()})
+				return m, cmd, true
+			}
+		}
+	case key.Matches(msg, m.keys.Related):
+		if !filtering {
+			return m, m.openRelated(), true
+		}
+	case key.Matches(msg, m.keys.Newer):
+		if !filtering {
+			return m, m.walkChain(true), true
+		}
+	case key.Matches(msg, m.keys.Older):
+		if !filtering {
+			return m, m.walkChain(false), true
+		}
+	case key.Matches(msg, m.keys.Supersede):
+		if !filtering {
+			return m, m.openSupersede(), true
+		}
+	case key.Matches(msg, m.keys.Blocker):
+		if !filtering {
+			return m, m.jumpToBlocker(), true
+		}
+	case key.Matches(msg, m.keys.Dedupe):
+		if !filtering {
+			return m, m.openDedupe(), true
+		}
+	case key.Matches(msg, m.keys.Metadata):
+		if !filtering {
+			return m, m.openMetadata(), true
+		}
+	case key.Matches(msg, m.keys.History):
+		if !filtering {
+			return m, m.openHistory(), true
+		}
+	case key.Matches(msg, m.keys.Due):
+		if !filtering {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				cmd := m.openDueModal([]string{item.path()}, item.due)
+				return m, cmd, true
 			}
 		}
+	case key.Matches(msg, m.keys.NewPlan):
+		if !filtering {
+			cmd := m.openNewPlanModal()
+			return m, cmd, true
+		}
+	case key.Matches(msg, m.keys.Capture):
+		if !filtering && !m.demo.active {
+			m.notification = "Capturing clipboard…"
+			return m, capturePlan(m.dir, m.cfg.ProjectPlanGlob, m.newPlanDir()), true
+		}
+	case key.Matches(msg, m.keys.SetTitle):
+		if !filtering {
+			if cmd := m.openTitleModal(); cmd != nil {
+				return m, cmd, true
+			}
+		}
+	case key.Matches(msg, m.keys.Rename):
+		if !filtering {
+			if cmd := m.openRenameModal(); cmd != nil {
+				return m, cmd, true
+			}
+		}
+	case key.Matches(msg, m.keys.Fork):
+		if !filtering {
+			if cmd := m.openForkModal(); cmd != nil {
+				return m, cmd, true
+			}
+		}
+	case key.Matches(msg, m.keys.Move):
+		if !filtering {
+			return m, m.openMoveModal(), true
+		}
 	case key.Matches(msg, m.keys.Delete):
 		if !filtering {
 			if item, ok := m.list.SelectedItem().(plan); ok {
@@ -1513,6 +2369,18 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				return m, nil, true
 			}
 		}
+	case key.Matches(msg, m.keys.Trash):
+		if !filtering && !m.demo.active {
+			return m, m.openTrash(), true
+		}
+	case key.Matches(msg, m.keys.Archive):
+		if !filtering && !m.demo.active && !scratchSession.Load() {
+			if !m.openArchivePrompt() {
+				days := int(archiveAfter(m.cfg).Hours() / 24)
+				return m, m.setNotification(trf("No done plans untouched for %d days", days), statusTimeout), true
+			}
+			return m, nil, true
+		}
 	case key.Matches(msg, m.keys.CopyFile):
 		if !filtering && !m.demo.active {
 			if item, ok := m.list.SelectedItem().(plan); ok {
@@ -1528,6 +2396,18 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				}), true
 			}
 		}
+	case key.Matches(msg, m.keys.CopyRich):
+		if !filtering && !m.demo.active {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				return m, copyPlanRichText(item.path()), true
+			}
+		}
+	case key.Matches(msg, m.keys.CopyPrompt):
+		if !filtering && !m.demo.active {
+			if item, ok := m.list.SelectedItem().(plan); ok {
+				return m, copyPlanPrompt(m.cfg.PromptTemplate, item), true
+			}
+		}
 	case key.Matches(msg, m.keys.Select):
 		if !filtering {
 			if item, ok := m.list.SelectedItem().(plan); ok {
@@ -1565,6 +2445,9 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				if isEditor && effectiveEditorMode(m.cfg) == "background" {
 					return m, runBackgroundEditor(args), true
 				}
+				if !isEditor && m.cfg.PrimaryMode == "background" {
+					return m, startAgentRun(args, item.path()), true
+				}
 				c := shellCommand(args...)
 				agentDir := m.dir
 				projectGlob := m.cfg.ProjectPlanGlob
@@ -1587,10 +2470,22 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if src, ok := msg.(eventSource); ok {
+		events := src.planEvents(*m.planSource())
+		for i := range events {
+			events[i].demo = m.demo.active
+		}
+		m.events.publish(events...)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		mod, cmd, handled := m.handleKeyMsg(msg)
 		m = mod // Always apply model changes (e.g. select toggle)
+		if m.macro.recording && !m.macro.consumed {
+			m.macro.keys = append(m.macro.keys, msg)
+		}
+		m.macro.consumed = false
 		if handled {
 			return m, cmd
 		}
@@ -1662,6 +2557,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.applyLayout()
 		m.restoreTitle()
 		m.refreshReleaseNotesView()
+		if m.notes.active {
+			w, h := m.notesSize()
+			m.notes.input.SetWidth(w)
+			m.notes.input.SetHeight(h)
+		}
 
 		innerPreviewW := m.previewW()
 		if !m.prerendered || m.previewWidth != innerPreviewW {
@@ -1670,6 +2570,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.previewCache = make(map[string]string)
 			cmds = append(cmds, m.renderWindow())
 		}
+		if m.link != nil {
+			cmds = append(cmds, m.openLinkTarget())
+		}
 
 	case planContentMsg:
 		isRefresh := m.refreshing[msg.file]
@@ -1699,14 +2602,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		visible := m.visiblePlans()
-		m.list.SetItems(plansToItems(visible))
+		m.setListItems(visible)
 		m.selectFile(msg.newPlan.path())
 		// Inline indicator on the affected row (replaces date)
-		statusLabel := msg.newPlan.status
-		if statusLabel == "" {
-			statusLabel = "new"
-		}
-		m.undoFiles[msg.newPlan.path()] = statusLabel
+		m.undoFiles[msg.newPlan.path()] = statusLabel(msg.newPlan.status)
 		m.undoID++
 		undoID := m.undoID
 		return m, tea.Batch(
@@ -1727,7 +2626,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		visible := m.visiblePlans()
-		m.list.SetItems(plansToItems(visible))
+		m.setListItems(visible)
 		m.selectFile(msg.plan.path())
 		label := strings.Join(msg.plan.labels, ", ")
 		if label == "" {
@@ -1735,13 +2634,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.setNotification("Labels: "+label, statusTimeout)
 
+	case titleUpdatedMsg:
+		plans := m.planSource()
+		for i, p := range *plans {
+			if p.path() == msg.plan.path() {
+				updated := msg.plan
+				updated.modified = time.Now()
+				(*plans)[i] = updated
+				break
+			}
+		}
+		visible := m.visiblePlans()
+		m.setListItems(visible)
+		m.selectFile(msg.plan.path())
+		delete(m.previewCache, msg.plan.path())
+		cmds = append(cmds, m.renderWindow())
+		if msg.override == "" {
+			cmds = append(cmds, m.setNotification(tr("Title override cleared"), statusTimeout))
+		} else {
+			cmds = append(cmds, m.setNotification("Title: "+msg.override, statusTimeout))
+		}
+		return m, tea.Batch(cmds...)
+
 	case batchDoneMsg:
 		plans := m.planSource()
 		*plans = msg.plans
+		if m.demo.active {
+			for file, body := range msg.content {
+				m.demo.content[file] = body
+			}
+		}
 		sortPlans(*plans)
 		m.batchKeepFiles = msg.files
 		visible := m.visiblePlans()
-		m.list.SetItems(plansToItems(visible))
+		m.setListItems(visible)
 		m.previewCache = make(map[string]string)
 		m.prerendered = true
 		cmds = append(cmds, m.renderWindow())
@@ -1754,12 +2680,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		clear(m.selected)
 		return m, tea.Batch(cmds...)
 
+	case planFileMsg:
+		if msg.event.kind == eventPlanRenamed {
+			m.followRename(msg.event.from, msg.event.to)
+		} else {
+			clear(m.selected)
+		}
+		plans := m.planSource()
+		*plans = msg.plans
+		sortPlans(*plans)
+		m.setListItems(m.visiblePlans())
+		m.previewCache = make(map[string]string)
+		m.prerendered = true
+		if msg.path != "" {
+			m.revealPlan(msg.path)
+		}
+		cmds = append(cmds, m.renderWindow())
+		cmds = append(cmds, m.setNotification(msg.message, statusTimeout))
+		return m, tea.Batch(cmds...)
+
 	case batchLingerExpiredMsg:
 		if len(m.batchKeepFiles) > 0 && msg.id == m.batchLingerID {
 			m.batchKeepFiles = nil
 			visible := m.visiblePlans()
 			idx := m.list.Index()
-			m.list.SetItems(plansToItems(visible))
+			m.setListItems(visible)
 			if idx >= len(visible) && len(visible) > 0 {
 				m.list.Select(len(visible) - 1)
 			}
@@ -1773,7 +2718,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			clear(m.undoFiles)
 			visible := m.visiblePlans()
 			idx := m.list.Index()
-			m.list.SetItems(plansToItems(visible))
+			m.setListItems(visible)
 			if idx >= len(visible) && len(visible) > 0 {
 				m.list.Select(len(visible) - 1)
 			}
@@ -1798,14 +2743,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Re-scan plans from disk and re-render nearby previews.
 		// Preserves cursor position and scroll offset for refreshed files.
 		if !m.demo.active {
+			if err := statPlansDir(m.dir); err != nil {
+				// Keep the last-known list rather than rescanning an empty one.
+				cmds = append(cmds, m.setPlansDirErr(err))
+				if m.watcher != nil {
+					cmds = append(cmds, watchDir(m.watcher))
+				}
+				return m, tea.Batch(cmds...)
+			}
 			prevFile := m.selectedFile()
 			clear(m.selected)
 			plans, err := scanAllPlans(m.dir, m.cfg.ProjectPlanGlob)
 			if err == nil {
 				m.allPlans = plans
 				sortPlans(m.allPlans)
-				visible := filterPlans(plans, m.showDone, m.keepFiles(), m.labelFilter, m.installed)
-				m.list.SetItems(plansToItems(visible))
+				visible := m.visiblePlans()
+				m.setListItems(visible)
 				m.selectFile(prevFile)
 				m.refreshing = make(map[string]bool)
 				items := m.list.Items()
@@ -1822,6 +2775,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 				cmds = append(cmds, m.renderWindow())
+				if m.semantic != nil {
+					cmds = append(cmds, m.semantic.build(plans))
+				}
 
 				if len(msg.files) > 0 {
 					// Only show "Updated:" for files that still exist (not deleted).
@@ -1867,13 +2823,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case duplicatesMsg:
+		return m, m.showDuplicates(msg)
+
+	case trashLoadedMsg:
+		return m, m.showTrash(msg)
+
+	case trashPurgedMsg:
+		return m, m.setNotification(trf("Purged %d plans", msg.n), statusTimeout)
+
 	case reloadMsg:
 		clear(m.selected)
 		plans := m.planSource()
 		*plans = msg.plans
 		sortPlans(*plans)
 		visible := m.visiblePlans()
-		m.list.SetItems(plansToItems(visible))
+		m.setListItems(visible)
 		m.previewCache = make(map[string]string)
 		m.prerendered = true
 		if len(visible) == 0 {
@@ -1885,11 +2850,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case configUpdatedMsg:
 		clear(m.selected)
 		cfg := loadConfig()
+		if scratchSession.Load() {
+			cfg.PlansDir, cfg.ProjectPlanGlob = m.dir, m.cfg.ProjectPlanGlob
+		}
 		oldGlob := m.cfg.ProjectPlanGlob
+		oldSortByModified, oldKeys := sortByModified.Load(), currentSortKeys()
+		oldRecursive, oldFiles := recursiveScan.Load(), currentPlanFiles()
 		m.cfg = cfg
+		applyConfigGlobals(cfg) // before newKeyMap, which translates help text and binds the status keys
 		m.keys = newKeyMap(cfg)
-		// Re-scan if plans dir or project glob changed
-		if cfg.PlansDir != m.dir || cfg.ProjectPlanGlob != oldGlob {
+		m.semantic = newSemanticIndex(cfg)
+		if m.semantic != nil {
+			m.list.Filter = m.semantic.filterFor(m.list.Items())
+			cmds = append(cmds, m.semantic.build(m.allPlans))
+		} else {
+			m.list.Filter = list.DefaultFilter
+		}
+		m.display.showTokens = cfg.ShowTokens
+		m.display.readingTime = cfg.ShowReadingTime
+		m.display.ageColors = cfg.AgeColors
+		m.display.lintSections = cfg.LintSections
+		if oldSortByModified != cfg.SortByModified || !slices.Equal(oldKeys, currentSortKeys()) {
+			m.resortPlans()
+		}
+		// Re-scan if plans dir, project glob, recursive scanning, or plan files changed
+		recursiveChanged := oldRecursive != cfg.RecursiveScan
+		filesChanged := !slices.Equal(oldFiles, currentPlanFiles())
+		if cfg.PlansDir != m.dir || cfg.ProjectPlanGlob != oldGlob || recursiveChanged || filesChanged {
 			plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
 			if err == nil {
 				// Update watcher for agent dir change
@@ -1898,7 +2885,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.dir = cfg.PlansDir
 					if m.watcher != nil {
 						_ = m.watcher.Remove(oldDir)
-						_ = m.watcher.Add(m.dir)
+						if err := m.watcher.Add(m.dir); err != nil {
+							logger.Error("watch failed", "dir", m.dir, "err", err)
+						}
 					}
 				}
 				// Update watchers for project dirs
@@ -1906,16 +2895,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					for _, d := range m.projectDirs {
 						_ = m.watcher.Remove(d)
 					}
-					m.projectDirs = resolveProjectDirs(cfg.ProjectPlanGlob)
+					m.projectDirs = append(agentSubDirs(m.dir), resolveProjectDirs(cfg.ProjectPlanGlob)...)
 					for _, d := range m.projectDirs {
-						_ = m.watcher.Add(d)
+						if err := m.watcher.Add(d); err != nil {
+							logger.Error("watch failed", "dir", d, "err", err)
+						}
 					}
 				}
 				m.allPlans = plans
 				sortPlans(m.allPlans)
 				m.store = diskStore{agentDir: m.dir, projectGlob: cfg.ProjectPlanGlob}
-				visible := filterPlans(plans, m.showDone, m.keepFiles(), m.labelFilter, m.installed)
-				m.list.SetItems(plansToItems(visible))
+				visible := m.visiblePlans()
+				m.setListItems(visible)
 				m.previewCache = make(map[string]string)
 				cmds = append(cmds, m.renderWindow())
 			} else {
@@ -1945,12 +2936,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case planCopiedMsg:
+		clear(m.copiedFiles)
+		m.copiedFiles[msg.path] = true
+		m.copiedID++
+		id := m.copiedID
+		return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+			return copiedClearMsg{id: id}
+		})
+
 	case copiedClearMsg:
 		if msg.id == m.copiedID {
 			clear(m.copiedFiles)
 		}
 		return m, nil
 
+	case labelSuggestionsMsg:
+		if m.settingLabels && !m.labelBatchMode && msg.file == m.selectedFile() && m.labelInput.Value() == "" {
+			m.applyLabelSuggestions(msg.labels)
+		}
+		return m, nil
+
 	case labelFlashMsg:
 		if m.labelFlashTick > 0 {
 			m.labelFlashTick--
@@ -1994,14 +3000,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.comment.toc = msg.toc
 			m.comment.rawBody = msg.rawBody
 			m.viewport.SetContent(msg.rendered)
+			if m.comment.jumpLine > 0 {
+				m.comment.cursor = tocIndexForLine(msg.toc, m.comment.jumpLine)
+				m.comment.jumpLine = 0
+			}
 			if len(msg.toc) > 0 {
-				m.scrollToTocEntry(msg.toc[0])
+				m.scrollToTocEntry(msg.toc[m.comment.cursor])
 			}
 			// Also update the preview cache
 			m.previewCache[msg.file] = msg.rendered
 		}
 		return m, nil
 
+	case tasksLoadedMsg:
+		m.taskMode = taskModeState{active: true}
+		m.setTasks(msg.path, msg.body)
+		if len(m.taskMode.items) == 0 {
+			m.taskMode.active = false
+			return m, m.setNotification(tr("No tasks in this plan"), statusTimeout)
+		}
+		m.scrollToTask()
+		return m, nil
+
+	case tasksSavedMsg:
+		return m, m.handleTasksSaved(msg)
+
 	case commentSavedMsg:
 		if msg.file == m.comment.planFile && m.comment.active {
 			m.comment.toc = msg.toc
@@ -2020,30 +3043,151 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Update preview cache
 			m.previewCache[msg.file] = msg.rendered
 			// Re-evaluate comment icon in the plan list
-			m.syncHasComments(msg.file, bodyHasComments(msg.rawBody))
+			m.syncComments(msg.file, msg.rawBody)
 		}
 		return m, nil
 
 	case editorLaunchedMsg:
-		return m, m.setNotification("Editor opened", 2*time.Second)
+		return m, m.setNotification(tr("Editor opened"), 2*time.Second)
+
+	case agentStartedMsg:
+		m.agentRuns[msg.path] = msg.run
+		return m, tea.Batch(waitAgentRun(msg.path, msg.wait),
+			m.setNotification(trf("Agent started: %s · L for output", filepath.Base(msg.path)), statusTimeout))
+
+	case agentExitedMsg:
+		run := m.agentRuns[msg.path]
+		run.ended, run.err = time.Now(), msg.err
+		m.agentRuns[msg.path] = run
+		text := trf("Agent finished: %s", filepath.Base(msg.path))
+		if msg.err != nil {
+			text = trf("Agent failed: %s (%v)", filepath.Base(msg.path), msg.err)
+		}
+		return m, m.setNotification(text, statusTimeout)
+
+	case agentOutputMsg:
+		if !m.agentOutput.active || msg.seq != m.agentOutput.seq {
+			return m, nil
+		}
+		if m.agentOutput.scroll > 0 { // hold a scrolled-back view in place
+			m.agentOutput.scroll += max(len(msg.lines)-len(m.agentOutput.lines), 0)
+		}
+		m.agentOutput.loading = false
+		m.agentOutput.lines, m.agentOutput.modified, m.agentOutput.err = msg.lines, msg.modified, msg.err
+		m.agentOutput.scroll = min(m.agentOutput.scroll, max(len(msg.lines)-m.agentOutputHeight(), 0))
+		return m, agentOutputTick(msg.seq)
+
+	case agentOutputTickMsg:
+		if !m.agentOutput.active || msg.seq != m.agentOutput.seq {
+			return m, nil
+		}
+		return m, loadAgentOutput(m.agentOutput.path, msg.seq)
+
+	case activityLoadedMsg:
+		if m.activity.active {
+			m.activity.loading = false
+			m.activity.entries = msg.entries
+			m.activity.err = msg.err
+			m.activity.cursor = 0
+		}
+		return m, nil
+
+	case remindersMsg:
+		if len(msg.items) > 0 && !m.demo.active {
+			m.reminders = remindersState{active: true, items: msg.items}
+		}
+		return m, nil
+
+	case notesLoadedMsg:
+		if m.notes.active && msg.path == m.notes.path {
+			if msg.err != nil {
+				m.notes.active = false
+				return m, func() tea.Msg { return errMsg{fmt.Errorf("notes: %w", msg.err)} }
+			}
+			m.notes.loading = false
+			m.notes.saved = msg.notes
+			m.notes.input.SetValue(msg.notes)
+		}
+		return m, nil
+
+	case notesSavedMsg:
+		return m, m.setNotification(tr("Notes saved"), statusTimeout)
+
+	case todosLoadedMsg:
+		if m.todo.active {
+			m.todo.loading = false
+			m.todo.items = msg.items
+			m.todo.cursor = 0
+		}
+		return m, nil
+
+	case semanticIndexedMsg:
+		if msg.err != nil {
+			return m, m.setNotification("Semantic index: "+msg.err.Error(), statusTimeout)
+		}
+		return m, nil
+
+	case semanticQueryMsg:
+		// Drop terms the search has moved on from.
+		searching := m.list.SettingFilter() || m.list.IsFiltered()
+		if m.semantic == nil || !searching || msg.term != m.list.FilterValue() || msg.err != nil {
+			return m, nil
+		}
+		if !msg.done {
+			return m, m.semantic.fetchQuery(msg.term)
+		}
+		// Re-run the filter now that it can add semantic matches.
+		return m, m.list.SetItems(m.list.Items())
+
+	case screenshotSavedMsg:
+		return m, m.setNotification(trf("Saved: %s", contractHome(msg.path)), statusTimeout)
+
+	case exportedMsg:
+		label := "1 plan"
+		if msg.count != 1 {
+			label = fmt.Sprintf("%d plans", msg.count)
+		}
+		return m, m.setNotification(fmt.Sprintf("Exported %s: %s", label, contractHome(msg.path)), statusTimeout)
 
 	case errMsg:
-		return m, m.setNotification(fmt.Sprintf("Error: %v", msg.err), statusTimeout)
+		logger.Error("error", "err", msg.err)
+		if m.demo.active {
+			return m, m.setNotification(fmt.Sprintf("Error: %v", msg.err), statusTimeout)
+		}
+		if m.plansDir.err != nil {
+			return m, nil // the banner already explains; don't pile on
+		}
+		return m, tea.Batch(
+			m.setNotification(fmt.Sprintf("Error: %v", msg.err), statusTimeout),
+			checkPlansDir(m.dir),
+		)
+
+	case plansDirMsg:
+		return m, m.setPlansDirErr(msg.err)
+
+	case plansDirRetryMsg:
+		if msg.id != m.plansDir.retryID || m.plansDir.err == nil {
+			return m, nil
+		}
+		return m, checkPlansDir(m.dir)
 	}
 
 	// Search: temporarily show all plans so filter matches across done/hidden items.
 	// On search exit (esc or empty filter), restore the active visibility filter.
 	wasSearching := m.list.SettingFilter() || m.list.IsFiltered()
 	if kmsg, isKey := msg.(tea.KeyMsg); isKey && !wasSearching && key.Matches(kmsg, m.keys.Filter) {
-		m.list.SetItems(plansToItems(*m.planSource()))
+		m.setListItems(*m.planSource())
 	}
 
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
 	cmds = append(cmds, cmd)
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.semantic != nil && m.list.SettingFilter() {
+		cmds = append(cmds, m.semantic.debounceQuery(m.list.FilterValue()))
+	}
 
 	if isSearching := m.list.SettingFilter() || m.list.IsFiltered(); wasSearching && !isSearching {
-		m.list.SetItems(plansToItems(m.visiblePlans()))
+		m.setListItems(m.visiblePlans())
 	}
 
 	m.restoreTitle()
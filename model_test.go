@@ -8,6 +8,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/jakebf/planc/plans"
 )
 
 func testPlans() []plan {
@@ -510,3 +512,434 @@ func TestStartupUpdateMessageUpdatesModelState(t *testing.T) {
 		t.Fatalf("releaseNotes state not applied: on=%v ver=%q", m.releaseNotes.on, m.releaseNotes.version)
 	}
 }
+
+func TestSyntheticDemoMode(t *testing.T) {
+	m := testModel()
+	m.demo.size = 500
+	m.enterDemoMode()
+	if len(m.demo.plans) != 500 {
+		t.Fatalf("demo plans = %d, want 500", len(m.demo.plans))
+	}
+	seen := make(map[string]bool)
+	for _, p := range m.demo.plans {
+		if seen[p.file] {
+			t.Fatalf("duplicate synthetic file %q", p.file)
+		}
+		seen[p.file] = true
+		if _, ok := m.demo.content[p.file]; !ok {
+			t.Fatalf("missing content for %q", p.file)
+		}
+	}
+	if len(m.list.Items()) == 0 {
+		t.Error("expected some synthetic plans to be visible")
+	}
+
+	// Toggling demo off and on again regenerates the same dataset size.
+	m.exitDemoMode()
+	m.enterDemoMode()
+	if len(m.demo.plans) != 500 {
+		t.Errorf("re-entered demo plans = %d, want 500", len(m.demo.plans))
+	}
+}
+
+func TestLabelSuggestionsTabAccepts(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "plan-a.md"), "---\nlabels: search\n---\n# Search ranking\n")
+	writeFile(t, filepath.Join(dir, "plan-b.md"), "---\nstatus: active\n---\n# Tune search ranking weights\n")
+
+	plans, err := scanPlans(dir)
+	if err != nil {
+		t.Fatalf("scanPlans: %v", err)
+	}
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = m2.(model)
+	m.selectFile(filepath.Join(dir, "plan-b.md"))
+
+	m.openLabelModal(false)
+	sugg := m.cmdSuggestLabels(m.list.SelectedItem().(plan))()
+	m2, _ = m.Update(sugg)
+	m = m2.(model)
+	if !m.labelSuggested["search"] || m.labelChoices[0] != "search" {
+		t.Fatalf("expected search suggested first, got suggested=%v choices=%v", m.labelSuggested, m.labelChoices)
+	}
+
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = m2.(model)
+	if m.settingLabels {
+		t.Fatal("tab should accept suggestions and close the modal")
+	}
+	updated, ok := cmd().(labelsUpdatedMsg)
+	if !ok || len(updated.plan.labels) != 1 || updated.plan.labels[0] != "search" {
+		t.Fatalf("expected labels [search], got %#v", cmd())
+	}
+}
+
+func TestInfoModalShowsChecklist(t *testing.T) {
+	m := testModel()
+	m.cfg.LintSections = []string{"Rollout"}
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = m2.(model)
+	if !m.showInfo {
+		t.Fatal("i should open the info modal")
+	}
+	view := m.View()
+	for _, want := range []string{"Checklist", "✗ Rollout", "Created"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("info modal missing %q", want)
+		}
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m2.(model).showInfo {
+		t.Error("esc should close the info modal")
+	}
+}
+
+func TestTodoViewJumpsToPlan(t *testing.T) {
+	m := testModel()
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = m2.(model)
+	if !m.todo.active || !m.todo.loading {
+		t.Fatal("t should open the action items view in loading state")
+	}
+	target := m.list.Items()[2].(plan)
+	m2, _ = m.Update(todosLoadedMsg{items: []todoItem{
+		{planPath: target.path(), planTitle: target.title, heading: "Setup", text: "install deps", rawLine: 7},
+	}})
+	m = m2.(model)
+	if view := m.View(); !strings.Contains(view, "install deps") {
+		t.Error("action items view should list the task")
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.todo.active {
+		t.Error("enter should close the action items view")
+	}
+	if !m.comment.active || m.comment.planFile != target.path() {
+		t.Errorf("enter should open %s in comment mode, got active=%v file=%q", target.path(), m.comment.active, m.comment.planFile)
+	}
+	if m.comment.jumpLine != 7 {
+		t.Errorf("jumpLine = %d, want 7", m.comment.jumpLine)
+	}
+}
+
+func TestInfoModalBacklinkJump(t *testing.T) {
+	plans := testPlans()
+	plans[1].links = []string{"humming-marinating-narwhal"}
+	linkPlans(plans)
+	m := newModel(plans, "/tmp/test-plans", newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = m2.(model)
+	view := m.View()
+	if !strings.Contains(view, "Referenced by") || !strings.Contains(view, plans[1].title) {
+		t.Fatal("info modal should list the referencing plan")
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.showInfo {
+		t.Error("enter should close the info modal")
+	}
+	if p, ok := m.list.SelectedItem().(plan); !ok || p.file != plans[1].file {
+		t.Errorf("enter should select the referencing plan, got %v", m.list.SelectedItem())
+	}
+}
+
+func TestTitleModalSetsOverride(t *testing.T) {
+	m := testModel()
+	m.store = demoStore{plans: &m.allPlans, content: map[string]string{}}
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = m2.(model)
+	if !m.settingTitle {
+		t.Fatal("R should open the title modal")
+	}
+	m.titleInput.SetValue("Short")
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.settingTitle {
+		t.Error("enter should close the title modal")
+	}
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	if p, ok := m.list.SelectedItem().(plan); !ok || p.title != "Short" {
+		t.Errorf("selected title = %v", m.list.SelectedItem())
+	}
+}
+
+func TestNewPlanModalCreatesAndSelects(t *testing.T) {
+	m := testModel()
+	m.store = demoStore{plans: &m.allPlans, content: map[string]string{}}
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	m = m2.(model)
+	if !m.creatingPlan {
+		t.Fatal("N should open the new-plan prompt")
+	}
+	m.titleInput.SetValue("Brand new idea")
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.creatingPlan {
+		t.Error("enter should close the prompt")
+	}
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	if p, ok := m.list.SelectedItem().(plan); !ok || p.file != "brand-new-idea.md" {
+		t.Errorf("selected = %v", m.list.SelectedItem())
+	}
+}
+
+func TestSourceCycleRestrictsList(t *testing.T) {
+	plans := testPlans()
+	for i := range plans {
+		plans[i].dir = "/tmp/test-plans"
+	}
+	plans[1].dir = "/code/api/plans"
+	m := newModel(plans, "/tmp/test-plans", newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	all := len(m.list.Items())
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("}")})
+	m = m2.(model)
+	if m.sourceFilter != "/tmp/test-plans" || len(m.list.Items()) != all-1 {
+		t.Errorf("first source: filter=%q items=%d", m.sourceFilter, len(m.list.Items()))
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("}")})
+	m = m2.(model)
+	if m.sourceFilter != "/code/api/plans" || len(m.list.Items()) != 1 {
+		t.Errorf("second source: filter=%q items=%d", m.sourceFilter, len(m.list.Items()))
+	}
+	if !strings.Contains(m.list.Title, "api/") {
+		t.Errorf("title should name the source: %q", m.list.Title)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = m2.(model)
+	if m.sourceFilter != "" || len(m.list.Items()) != all {
+		t.Errorf("esc should clear the source filter: filter=%q items=%d", m.sourceFilter, len(m.list.Items()))
+	}
+}
+
+func TestToggleDateModeResortsAndSaves(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Cleanup(func() { sortByModified.Store(false) })
+	m := testModel()
+	// Make the oldest visible plan the most recently modified.
+	for i := range m.allPlans {
+		m.allPlans[i].modified = m.allPlans[i].created
+	}
+	m.allPlans[2].modified = time.Now()
+	stale := m.allPlans[2].file
+	m.list.SetItems(plansToItems(m.visiblePlans()))
+
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m = m2.(model)
+	if !sortByModified.Load() || !m.cfg.SortByModified {
+		t.Fatal("M should switch to modified dates and record it in config")
+	}
+	if p := m.list.Items()[0].(plan); p.file != stale {
+		t.Errorf("first item = %s, want %s", p.file, stale)
+	}
+	if !strings.Contains(m.list.Title, "modified") {
+		t.Errorf("title should show the date mode: %q", m.list.Title)
+	}
+}
+
+func TestAgeTint(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	tests := []struct {
+		name   string
+		p      plan
+		tinted bool
+	}{
+		{"fresh", plan{status: "active", modified: now.Add(-3 * day)}, false},
+		{"weeks", plan{status: "active", modified: now.Add(-20 * day)}, true},
+		{"months", plan{status: "reviewed", modified: now.Add(-90 * day)}, true},
+		{"done", plan{status: "done", modified: now.Add(-90 * day)}, false},
+		{"no mtime", plan{status: "active"}, false},
+	}
+	for _, tt := range tests {
+		if _, ok := ageTint(tt.p, now); ok != tt.tinted {
+			t.Errorf("%s: tinted = %v, want %v", tt.name, ok, tt.tinted)
+		}
+	}
+	months, _ := ageTint(plan{modified: now.Add(-90 * day)}, now)
+	weeks, _ := ageTint(plan{modified: now.Add(-20 * day)}, now)
+	if months.GetForeground() == weeks.GetForeground() {
+		t.Error("neglected plans should use a different color than stale ones")
+	}
+}
+
+func TestTitleShowsTabCounts(t *testing.T) {
+	m := testModel()
+	m.restoreTitle()
+	if !strings.Contains(m.list.Title, "Active 3") || !strings.Contains(m.list.Title, "All 4") {
+		t.Errorf("title = %q, want Active 3 · All 4", m.list.Title)
+	}
+	m.labelFilter = "kokua"
+	m.restoreTitle()
+	if !strings.Contains(m.list.Title, "Active 1") || !strings.Contains(m.list.Title, "All 1") {
+		t.Errorf("label-filtered title = %q, want Active 1 · All 1", m.list.Title)
+	}
+}
+
+func TestStatusCountsInHelp(t *testing.T) {
+	counts := statusCounts(testPlans())
+	if counts["active"] != 2 || counts["reviewed"] != 1 || counts["done"] != 1 || counts[""] != 0 {
+		t.Errorf("counts = %v", counts)
+	}
+	m := testModel()
+	m.help.ShowAll = true
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "2 active") {
+		t.Errorf("help should list status counts:\n%s", out)
+	}
+}
+
+func TestTaskProgressShown(t *testing.T) {
+	m := testModel()
+	m.allPlans[0].tasks, m.allPlans[0].tasksDone, m.allPlans[0].tokens = 9, 4, 1200
+	m.list.SetItems(plansToItems(m.visiblePlans()))
+	m.list.Select(0)
+	out := ansi.Strip(m.View())
+	if !strings.Contains(out, "4/9 ") || !strings.Contains(out, "4/9 tasks · ") {
+		t.Errorf("task progress missing from the list row or preview title:\n%s", out)
+	}
+	if got := taskProgress(m.allPlans[1]); got != "" {
+		t.Errorf("plan without tasks: progress %q", got)
+	}
+}
+
+func TestFollowUpFilter(t *testing.T) {
+	m := testModel()
+	m.allPlans[1].hasComments = true
+	m.allPlans[1].openComments = 2
+	m.allPlans[2].hasComments = true // resolved comments only
+	m.list.SetItems(plansToItems(m.visiblePlans()))
+
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = m2.(model)
+	if items := m.list.Items(); len(items) != 1 || items[0].(plan).file != m.allPlans[1].file {
+		t.Fatalf("follow-up filter items = %v", items)
+	}
+	if !strings.Contains(m.list.Title, "follow-up") {
+		t.Errorf("title should show the filter: %q", m.list.Title)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = m2.(model)
+	if m.followUpFilter || len(m.list.Items()) != 3 {
+		t.Errorf("F again should clear the filter, items = %d", len(m.list.Items()))
+	}
+}
+
+func TestStartFilters(t *testing.T) {
+	m := testModel()
+	m.applyStartFilters("", "done")
+	if items := m.list.Items(); len(items) != 1 || items[0].(plan).file != "calm-drifting-whale.md" {
+		t.Fatalf("--status done items = %v", items)
+	}
+	if !strings.Contains(m.list.Title, "done") {
+		t.Errorf("title should show the status filter: %q", m.list.Title)
+	}
+	m.applyStartFilters("pulse", "active")
+	if items := m.list.Items(); len(items) != 1 || items[0].(plan).file != "deep-crunching-sprout.md" {
+		t.Fatalf("--label pulse --status active items = %v", items)
+	}
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = m2.(model)
+	if m.statusFilter != "" || m.labelFilter != "" || len(m.list.Items()) != 3 {
+		t.Errorf("esc should clear the filters, items = %d", len(m.list.Items()))
+	}
+}
+
+func TestLargePlanRendersOnDemand(t *testing.T) {
+	m := testModel()
+	dir := t.TempDir()
+	p := m.allPlans[0]
+	p.dir = dir
+	p.size = largePlanSize + 1
+	if err := os.WriteFile(p.path(), []byte("# Huge\n\nBody text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.allPlans[0] = p
+	m.list.SetItems(m.listItems(m.visiblePlans()))
+	m.selectFile(p.path())
+	m.previewCache = make(map[string]string)
+
+	execCmd(t, &m, m.renderWindow())
+	if got := m.previewCache[p.path()]; !strings.Contains(got, "Large plan") {
+		t.Fatalf("large plan should get a placeholder, got %q", got)
+	}
+
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = m2.(model)
+	if !m.renderLarge[p.path()] {
+		t.Fatal("v should allow rendering the large plan")
+	}
+	// Run the render directly; the returned batch also holds the notification timer.
+	execCmd(t, &m, m.renderWindow())
+	if got := ansi.Strip(m.previewCache[p.path()]); !strings.Contains(got, "Body text") {
+		t.Errorf("after v the preview should be rendered, got %q", got)
+	}
+}
+
+func TestDemoToggleBlockedByModals(t *testing.T) {
+	m := testModel()
+	m.trash = trashState{active: true, items: []plans.TrashedPlan{{File: "a.md"}}}
+	if !m.modalOpen() {
+		t.Fatal("the trash view should count as a modal")
+	}
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	if m2.(model).demo.active {
+		t.Error("D shouldn't toggle demo mode while a modal is open")
+	}
+}
+
+func TestDemoGenerateTitlesAppliesInUpdate(t *testing.T) {
+	m := testModel()
+	m.enterDemoMode()
+	m.demo.plans = []plan{{dir: "/demo", file: "untitled.md", title: "untitled", untitled: true}}
+	m.demo.content = map[string]string{"untitled.md": "Plan the storage rework.\n"}
+	store := demoStore{plans: &m.demo.plans, content: m.demo.content}
+
+	msg := store.generateTitles([]string{"/demo/untitled.md"})().(batchDoneMsg)
+	if !strings.HasPrefix(msg.content["untitled.md"], "# ") {
+		t.Fatalf("msg content = %q", msg.content)
+	}
+	if m.demo.content["untitled.md"] != "Plan the storage rework.\n" {
+		t.Error("the cmd shouldn't write demo content off the UI goroutine")
+	}
+	m2, _ := m.Update(msg)
+	if got := m2.(model).demo.content["untitled.md"]; got != msg.content["untitled.md"] {
+		t.Errorf("Update should apply the titled body, got %q", got)
+	}
+}
+
+func TestGlobalKeysTypedIntoTextInputs(t *testing.T) {
+	typeKeys := func(m model) model {
+		for _, r := range "S," {
+			m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = m2.(model)
+		}
+		return m
+	}
+
+	m := testModel()
+	m.openSupersede()
+	if got := typeKeys(m).supersede.input.Value(); got != "S," {
+		t.Errorf("supersede filter = %q, want S,", got)
+	}
+
+	m = testModel()
+	m.openMetadata()
+	m.promptMetadata("")
+	if got := typeKeys(m).metadata.input.Value(); got != "S," {
+		t.Errorf("metadata input = %q, want S,", got)
+	}
+
+	m = testModel()
+	m.macro.prompting = true
+	if !m.textInputFocused() {
+		t.Error("the macro count prompt should count as text input")
+	}
+}
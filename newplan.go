@@ -0,0 +1,256 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── New Plans ───────────────────────────────────────────────────────────────
+//
+// N creates a plan in the selected plan's directory (or the agent plans
+// directory), from a template when there are any (see templates.go). A plans directory can hold a .planc.json declaring default
+// frontmatter and a body template for plans created there:
+//
+//	{"defaults": {"labels": "api", "owner": "sam"}, "template": "template.md"}
+
+const dirConfigName = ".planc.json"
+
+// dirConfig is the per-directory config read from .planc.json.
+type dirConfig struct {
+	Defaults map[string]string `json:"defaults,omitempty"` // frontmatter for new plans
+	Template string            `json:"template,omitempty"` // body template path, relative to the directory
+}
+
+// loadDirConfig reads dir's .planc.json. A missing file yields a zero config.
+func loadDirConfig(dir string) (dirConfig, error) {
+	var dc dirConfig
+	data, err := os.ReadFile(filepath.Join(dir, dirConfigName))
+	if os.IsNotExist(err) {
+		return dc, nil
+	}
+	if err != nil {
+		return dc, err
+	}
+	if err := json.Unmarshal(data, &dc); err != nil {
+		return dc, fmt.Errorf("%s: %w", filepath.Join(dir, dirConfigName), err)
+	}
+	return dc, nil
+}
+
+// defaultPlanBody is used when a directory has no template.
+const defaultPlanBody = "# {{title}}\n\n"
+
+// expandTemplate fills {{title}} and {{date}} in a plan body template.
+func expandTemplate(tmpl, title string, now time.Time) string {
+	return strings.NewReplacer(
+		"{{title}}", title,
+		"{{date}}", now.Format("2006-01-02"),
+	).Replace(tmpl)
+}
+
+var slugStrip = regexp.MustCompile(`[^a-z0-9]+`)
+
+// planSlug turns a title into a filename stem: lowercase words joined by
+// dashes, at most six words.
+func planSlug(title string) string {
+	words := strings.Fields(slugStrip.ReplaceAllString(strings.ToLower(title), " "))
+	if len(words) > 6 {
+		words = words[:6]
+	}
+	if len(words) == 0 {
+		return "plan"
+	}
+	return strings.Join(words, "-")
+}
+
+// Word lists for randomPlanName, in the style of Claude Code's plan names.
+var (
+	nameAdjectives = []string{"bright", "calm", "deep", "eager", "gentle", "humming", "lively", "quiet", "rapid", "shiny", "steady", "swift", "tidy", "vivid", "witty", "zesty"}
+	nameGerunds    = []string{"baking", "crunching", "dancing", "drifting", "floating", "gliding", "juggling", "marinating", "pondering", "roaming", "sailing", "sketching", "spinning", "tinkering", "wandering", "weaving"}
+	nameNouns      = []string{"badger", "comet", "falcon", "heron", "lantern", "maple", "narwhal", "otter", "pebble", "quokka", "sparrow", "sprout", "tortoise", "walrus", "whale", "willow"}
+)
+
+// randomPlanName returns a three-word filename stem like Claude Code's, e.g.
+// humming-marinating-narwhal.
+func randomPlanName(r *rand.Rand) string {
+	pick := func(words []string) string { return words[r.IntN(len(words))] }
+	return pick(nameAdjectives) + "-" + pick(nameGerunds) + "-" + pick(nameNouns)
+}
+
+// uniquePlanPath returns dir/slug.md, or dir/slug-N.md if that exists.
+func uniquePlanPath(dir, slug string) string {
+	return plans.UniquePath(dir, slug)
+}
+
+// newPlanContent renders a new plan file: the directory's defaults overlaid
+// with fields (which win), plus a created: stamp, over body or, if body is
+// empty, the directory's template.
+func newPlanContent(dc dirConfig, dir, title string, fields map[string]string, body string, now time.Time) (string, error) {
+	if body == "" {
+		tmpl := defaultPlanBody
+		if dc.Template != "" {
+			tmplPath := dc.Template
+			if !filepath.IsAbs(tmplPath) {
+				tmplPath = filepath.Join(dir, tmplPath)
+			}
+			data, err := os.ReadFile(expandHome(tmplPath))
+			if err != nil {
+				return "", fmt.Errorf("template: %w", err)
+			}
+			tmpl = string(data)
+		}
+		body = expandTemplate(tmpl, title, now)
+	}
+
+	fm := make(map[string]string, len(dc.Defaults)+len(fields)+1)
+	for k, v := range dc.Defaults {
+		fm[k] = v
+	}
+	for k, v := range fields {
+		fm[k] = v
+	}
+	if fm["created"] == "" {
+		fm["created"] = now.Format(time.RFC3339)
+	}
+	if labels, ok := fm["labels"]; ok {
+		fm["labels"] = labelsString(parseLabels(labels))
+	}
+	front := editFrontmatter(nil, fm, plans.YAMLDelim)
+	return joinFrontmatter(front, body, plans.YAMLDelim), nil
+}
+
+// createPlanFile writes a new plan for title in dir and returns its path.
+func createPlanFile(dir, title string, fields map[string]string, now time.Time) (string, error) {
+	return writeNewPlan(dir, title, fields, "", now)
+}
+
+// writeNewPlan writes a new plan with the given body (the directory's
+// template if empty) in dir and returns its path.
+func writeNewPlan(dir, title string, fields map[string]string, body string, now time.Time) (string, error) {
+	return writeNewPlanAs(dir, planSlug(title), title, fields, body, now)
+}
+
+// writeNewPlanAs is writeNewPlan with the filename stem given.
+func writeNewPlanAs(dir, stem, title string, fields map[string]string, body string, now time.Time) (string, error) {
+	dc, err := loadDirConfig(dir)
+	if err != nil {
+		return "", err
+	}
+	content, err := newPlanContent(dc, dir, title, fields, body, now)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := uniquePlanPath(dir, stem)
+	lastSelfWrite.Store(time.Now().UnixMilli())
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func createPlan(agentDir, projectGlob, dir, title string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := createPlanFile(dir, title, nil, time.Now())
+		if err != nil {
+			return errMsg{fmt.Errorf("new plan: %w", err)}
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return planFileMsg{plans: plans, path: path, message: "Created: " + filepath.Base(path), event: planEvent{kind: eventPlanCreated, path: path, to: title}}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// newPlanDir is where N creates plans: the selected plan's directory, so
+// project defaults apply, or the agent plans directory.
+func (m model) newPlanDir() string {
+	if item, ok := m.list.SelectedItem().(plan); ok && item.dir != "" {
+		return item.dir
+	}
+	return m.dir
+}
+
+func (m *model) openNewPlanModal() tea.Cmd {
+	m.creatingPlan = true
+	m.newPlanDirPath = m.newPlanDir()
+	m.startTemplateFlow()
+	m.titleInput.SetValue("")
+	m.titleInput.Focus()
+	return textinput.Blink
+}
+
+func (m model) handleNewPlanModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case m.newPlanFlow.picking:
+		return m.handleTemplatePicker(msg)
+	case msg.Type == tea.KeyEsc:
+		m.creatingPlan = false
+		m.titleInput.Blur()
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		title := strings.TrimSpace(m.titleInput.Value())
+		if title == "" && m.newPlanFlow.currentVar() == "title" {
+			return m, nil, true
+		}
+		if m.newPlanFlow.tmpl != nil {
+			return m, m.templateInputDone(title), true
+		}
+		m.creatingPlan = false
+		m.titleInput.Blur()
+		return m, m.store.createPlan(m.newPlanDirPath, title), true
+	}
+	var cmd tea.Cmd
+	m.titleInput, cmd = m.titleInput.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) renderNewPlanModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("New plan")) + "\n")
+	b.WriteString(dimStyle.Render("in "+contractHome(m.newPlanDirPath)+"/") + "\n\n")
+	f := m.newPlanFlow
+	switch {
+	case f.picking:
+		b.WriteString(m.renderTemplatePicker())
+	case f.tmpl != nil:
+		name := f.currentVar()
+		b.WriteString(dimStyle.Render(fmt.Sprintf("Template %s · %d of %d", f.tmpl.name, f.step+1, len(f.vars))) + "\n")
+		b.WriteString(strings.ToUpper(name[:1]) + name[1:] + ": " + m.titleInput.View() + "\n\n")
+		if f.step == len(f.vars)-1 {
+			b.WriteString(dimStyle.Render(tr("enter create · esc cancel")))
+		} else {
+			b.WriteString(dimStyle.Render(tr("enter next · esc cancel")))
+		}
+	default:
+		b.WriteString("Title: " + m.titleInput.View() + "\n\n")
+		b.WriteString(dimStyle.Render(tr("enter create · esc cancel")))
+	}
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
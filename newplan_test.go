@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanSlug(t *testing.T) {
+	tests := []struct{ title, want string }{
+		{"Add OAuth login", "add-oauth-login"},
+		{"  Fix: the (flaky) test!  ", "fix-the-flaky-test"},
+		{"one two three four five six seven", "one-two-three-four-five-six"},
+		{"???", "plan"},
+	}
+	for _, tt := range tests {
+		if got := planSlug(tt.title); got != tt.want {
+			t.Errorf("planSlug(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestCreatePlanFileAppliesDirDefaults(t *testing.T) {
+	dir := t.TempDir()
+	cfg := `{"defaults": {"labels": "api, auth", "owner": "sam", "status": "reviewed"}, "template": "tmpl.md"}`
+	if err := os.WriteFile(filepath.Join(dir, dirConfigName), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tmpl.md"), []byte("# {{title}}\n\nOpened {{date}}.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	path, err := createPlanFile(dir, "Rate limiting", map[string]string{"status": "active"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "rate-limiting.md" {
+		t.Errorf("path = %s", path)
+	}
+	data, _ := os.ReadFile(path)
+	fm, body := parseFrontmatter(string(data))
+	if fm["status"] != "active" {
+		t.Errorf("explicit status should win, got %q", fm["status"])
+	}
+	if fm["labels"] != "api, auth" || fm["owner"] != "sam" {
+		t.Errorf("defaults not applied: %v", fm)
+	}
+	if fm["created"] != "2026-03-04T10:00:00Z" {
+		t.Errorf("created = %q", fm["created"])
+	}
+	if body != "# Rate limiting\n\nOpened 2026-03-04.\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	again, err := createPlanFile(dir, "Rate limiting", nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(again) != "rate-limiting-2.md" {
+		t.Errorf("second plan path = %s", again)
+	}
+}
+
+func TestCreatePlanFileWithoutDirConfig(t *testing.T) {
+	dir := t.TempDir()
+	path, err := createPlanFile(dir, "Plain", nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "created:") || !strings.Contains(string(data), "# Plain\n") {
+		t.Errorf("content = %q", data)
+	}
+}
+
+func TestCreatePlanFileBadDirConfig(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, dirConfigName), []byte("{"), 0644)
+	if _, err := createPlanFile(dir, "X", nil, time.Now()); err == nil {
+		t.Error("expected an error for malformed .planc.json")
+	}
+}
+
+func TestRandomPlanName(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	name := randomPlanName(r)
+	parts := strings.Split(name, "-")
+	if len(parts) != 3 || !slices.Contains(nameAdjectives, parts[0]) || !slices.Contains(nameGerunds, parts[1]) || !slices.Contains(nameNouns, parts[2]) {
+		t.Errorf("name = %q", name)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Private Notes ───────────────────────────────────────────────────────────
+//
+// n opens the highlighted plan's private notes: thoughts about a plan that
+// shouldn't land in an agent's context. They're kept in a sidecar file
+// (.planc/notes/<file>.md next to the plan, see plans.NotesDir) and edited in
+// place; ctrl+s saves, esc discards.
+
+type notesState struct {
+	active  bool
+	loading bool
+	path    string // plan the notes belong to
+	title   string
+	saved   string // notes as last read or written
+	input   textarea.Model
+}
+
+func loadNotes(path string) tea.Cmd {
+	return func() tea.Msg {
+		notes, err := plans.ReadNotes(path)
+		return notesLoadedMsg{path: path, notes: strings.TrimRight(notes, "\n"), err: err}
+	}
+}
+
+func saveNotes(path, notes string) tea.Cmd {
+	return func() tea.Msg {
+		if err := plans.WriteNotes(path, notes); err != nil {
+			return errMsg{fmt.Errorf("notes: %w", err)}
+		}
+		return notesSavedMsg{path: path}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// notesSize returns the textarea size for the current window.
+func (m model) notesSize() (w, h int) {
+	w = min(m.width-4, 100) - 8 // helpBoxStyle borders + padding
+	h = m.height - 12
+	return max(w, 20), max(h, 3)
+}
+
+func (m *model) openNotes(p plan) tea.Cmd {
+	input := textarea.New()
+	input.ShowLineNumbers = false
+	input.Prompt = ""
+	input.Placeholder = tr("Private notes (never written into the plan)")
+	input.CharLimit = 0
+	w, h := m.notesSize()
+	input.SetWidth(w)
+	input.SetHeight(h)
+	m.notes = notesState{active: true, loading: true, path: p.path(), title: p.title, input: input}
+	return tea.Batch(loadNotes(p.path()), m.notes.input.Focus())
+}
+
+func (m model) handleNotesKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.notes.active = false
+		if m.notes.input.Value() != m.notes.saved {
+			return m, m.setNotification(tr("Notes discarded"), statusTimeout), true
+		}
+		return m, nil, true
+	case msg.Type == tea.KeyCtrlS:
+		if m.notes.loading {
+			return m, nil, true
+		}
+		m.notes.active = false
+		if m.notes.input.Value() == m.notes.saved {
+			return m, nil, true
+		}
+		return m, saveNotes(m.notes.path, m.notes.input.Value()), true
+	}
+	if m.notes.loading {
+		return m, nil, true
+	}
+	var cmd tea.Cmd
+	m.notes.input, cmd = m.notes.input.Update(msg)
+	return m, cmd, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderNotesModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	w, _ := m.notesSize()
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(trf("Notes: %s", truncateForWidth(m.notes.title, w-7))) + "\n")
+	b.WriteString(dimStyle.Render(trf("Private — kept in %s, not in the plan", plans.NotesDir)) + "\n\n")
+	if m.notes.loading {
+		b.WriteString(dimStyle.Render(tr("Loading...")) + "\n")
+	} else {
+		b.WriteString(m.notes.input.View() + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("ctrl+s save · esc discard")))
+
+	overlay := helpBoxStyle.Width(w + 6).Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
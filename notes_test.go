@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jakebf/planc/plans"
+)
+
+func TestNotesModalSavesSidecar(t *testing.T) {
+	dir := t.TempDir()
+	ps := testPlans()
+	for i := range ps {
+		ps[i].dir = dir
+	}
+	m := newModel(ps, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	target := m.list.SelectedItem().(plan)
+	if err := plans.WriteNotes(target.path(), "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = m2.(model)
+	if !m.notes.active {
+		t.Fatal("n should open the notes modal")
+	}
+	execCmd(t, &m, cmd)
+	if m.notes.loading || m.notes.input.Value() != "first" {
+		t.Fatalf("notes = %q (loading %v)", m.notes.input.Value(), m.notes.loading)
+	}
+	if view := m.View(); !strings.Contains(view, "Notes: "+target.title) {
+		t.Error("notes modal should show the plan title")
+	}
+
+	// Keys go to the textarea, not the list.
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" and q")})
+	m = m2.(model)
+	m2, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = m2.(model)
+	if m.notes.active || cmd == nil {
+		t.Fatal("ctrl+s should close the modal and save")
+	}
+	if _, ok := cmd().(notesSavedMsg); !ok {
+		t.Fatal("expected notesSavedMsg")
+	}
+	if got, _ := plans.ReadNotes(target.path()); got != "first and q\n" {
+		t.Errorf("notes = %q", got)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Owners ──────────────────────────────────────────────────────────────────
+//
+// The owner: frontmatter key (or assignee:, as other tools write it) names
+// who a plan belongs to in a shared plan directory. Rows show it as @name,
+// and (/) cycle an owner filter like [/] cycle the label filter, so each
+// teammate can see just their plans.
+
+// planOwner returns the owner named by frontmatter fields, without a
+// leading @, or "".
+func planOwner(fields map[string]string) string {
+	v := fields["owner"]
+	if strings.TrimSpace(v) == "" {
+		v = fields["assignee"]
+	}
+	return strings.TrimPrefix(strings.TrimSpace(v), "@")
+}
+
+// planOwners returns the distinct owners of plans, sorted. Owners differing
+// only in case count once, as filterOwner matches them alike.
+func planOwners(plans []plan) []string {
+	var owners []string
+	for _, p := range plans {
+		same := func(o string) bool { return strings.EqualFold(o, p.owner) }
+		if p.owner != "" && !slices.ContainsFunc(owners, same) {
+			owners = append(owners, p.owner)
+		}
+	}
+	slices.Sort(owners)
+	return owners
+}
+
+// filterOwner keeps plans owned by owner; an empty owner keeps everything.
+func filterOwner(plans []plan, owner string) []plan {
+	if owner == "" {
+		return plans
+	}
+	var filtered []plan
+	for _, p := range plans {
+		if strings.EqualFold(p.owner, owner) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// ownerBadge renders p's owner for the list row, or "".
+func ownerBadge(p plan) string {
+	if p.owner == "" {
+		return ""
+	}
+	return dateStyle.Render("@" + p.owner)
+}
+
+// cycleOwnerFilter moves the owner filter to the next (or previous) owner
+// with visible plans, wrapping through "all".
+func (m *model) cycleOwnerFilter(forward bool) tea.Cmd {
+	owners := planOwners(*m.planSource())
+	if len(owners) == 0 {
+		return nil
+	}
+	cycle := append([]string{""}, owners...)
+	idx := slices.Index(cycle, m.ownerFilter)
+	for range cycle {
+		if forward {
+			idx = (idx + 1) % len(cycle)
+		} else {
+			idx = (idx - 1 + len(cycle)) % len(cycle)
+		}
+		m.ownerFilter = cycle[idx]
+		if visible := m.visiblePlans(); len(visible) > 0 || m.ownerFilter == "" {
+			m.restoreTitle()
+			m.setListItems(visible)
+			m.list.ResetSelected()
+			m.prevIndex = 0
+			if file := m.selectedFile(); file != "" {
+				if content, ok := m.previewCache[file]; ok {
+					m.viewport.SetContent(content)
+					m.viewport.GotoTop()
+				}
+			}
+			return m.renderWindow()
+		}
+	}
+	return nil
+}
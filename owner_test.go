@@ -0,0 +1,63 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPlanOwner(t *testing.T) {
+	for _, tt := range []struct {
+		fields map[string]string
+		want   string
+	}{
+		{map[string]string{"owner": "alice"}, "alice"},
+		{map[string]string{"owner": " @alice "}, "alice"},
+		{map[string]string{"assignee": "@bob"}, "bob"},
+		{map[string]string{"owner": "alice", "assignee": "bob"}, "alice"},
+		{map[string]string{}, ""},
+	} {
+		if got := planOwner(tt.fields); got != tt.want {
+			t.Errorf("planOwner(%v) = %q, want %q", tt.fields, got, tt.want)
+		}
+	}
+	plans := []plan{{owner: "bob"}, {owner: "alice"}, {owner: "Alice"}, {}}
+	if got := planOwners(plans); !slices.Equal(got, []string{"alice", "bob"}) {
+		t.Errorf("planOwners = %v", got)
+	}
+	if got := filterOwner(plans, "alice"); len(got) != 2 {
+		t.Errorf("filterOwner(alice) kept %d plans, want 2", len(got))
+	}
+}
+
+func TestOwnerFilterCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\nstatus: active\nowner: alice\n---\n# Alpha\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "---\nstatus: active\nassignee: \"@bob\"\n---\n# Beta\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "---\nstatus: active\n---\n# Gamma\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m2, _ = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(")")})
+	mm := m2.(model)
+	if mm.ownerFilter != "alice" {
+		t.Fatalf("ownerFilter = %q, want alice", mm.ownerFilter)
+	}
+	if n := len(mm.list.Items()); n != 1 {
+		t.Errorf("list shows %d plans, want 1", n)
+	}
+	if out := ansi.Strip(mm.View()); !strings.Contains(out, "@alice") || strings.Contains(out, "Gamma") {
+		t.Errorf("list should show only alice's plan:\n%s", out)
+	}
+	m2, _ = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("(")})
+	if f := m2.(model).ownerFilter; f != "" {
+		t.Errorf("( should cycle back to all owners, got %q", f)
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/bmatcuk/doublestar/v4"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jakebf/planc/plans"
 )
 
 // ─── Types ───────────────────────────────────────────────────────────────────
@@ -23,6 +27,19 @@ type planStore interface {
 	setLabels(p plan, labels []string) tea.Cmd
 	batchSetStatus(files []string, status string) tea.Cmd
 	batchUpdateLabels(files []string, add []string, remove []string) tea.Cmd
+	generateTitles(files []string) tea.Cmd
+	setTitle(p plan, title string) tea.Cmd
+	repairPlan(p plan) tea.Cmd
+	batchSetField(files []string, key, value string) tea.Cmd
+	createPlan(dir, title string) tea.Cmd
+	renamePlan(p plan, name string) tea.Cmd
+	movePlan(p plan, dir string) tea.Cmd
+	duplicatePlan(p plan) tea.Cmd
+	forkPlan(p plan, name string) tea.Cmd
+	archivePlan(p plan) tea.Cmd
+	supersedePlan(newer, older plan) tea.Cmd
+	supersedeDuplicates(groups []duplicateGroup) tea.Cmd
+	deletePlans(ps []plan) tea.Cmd
 }
 
 type pane int
@@ -33,41 +50,42 @@ const (
 )
 
 type plan struct {
-	dir         string    // directory containing this plan file
-	status      string    // from frontmatter, or "" (unset)
-	project     string    // from frontmatter, or "" (deprecated; use labels)
-	labels      []string  // from frontmatter, or migrated from project
-	title       string    // from first # heading
-	created     time.Time // file birth time
-	modified    time.Time // file modification time
-	file        string    // base filename
-	hasComments bool      // true if body contains comment blockquotes
+	dir          string               // directory containing this plan file
+	status       string               // from frontmatter, or "" (unset)
+	project      string               // from frontmatter, or "" (deprecated; use labels)
+	labels       []string             // from frontmatter, or migrated from project
+	priority     string               // frontmatter priority: high, medium, or low, or ""
+	due          time.Time            // frontmatter due:, or zero
+	estimate     string               // frontmatter estimate: as written (see parseEstimate), or ""
+	owner        string               // frontmatter owner: (or assignee:), without a leading @, or ""
+	supersededBy string               // frontmatter superseded_by: (a plan filename), or ""
+	supersedes   string               // frontmatter supersedes: (a plan filename), or ""
+	blockedBy    []string             // frontmatter blocked_by: plan filenames (see blocked.go)
+	blocked      bool                 // a blocked_by: plan isn't done yet; set by listItems
+	title        string               // from frontmatter title:, else first # heading
+	created      time.Time            // frontmatter created: (or updated:), else file birth time
+	modified     time.Time            // file modification time
+	file         string               // base filename
+	hasComments  bool                 // true if body contains comment blockquotes
+	openComments int                  // comments not yet marked [resolved]
+	tasks        int                  // task items ("- [ ]" and "- [x]") in the body
+	tasksDone    int                  // checked task items
+	untitled     bool                 // true if title fell back to the filename (no # heading)
+	size         int                  // file size in bytes
+	tokens       int                  // approximate LLM token count (see estimateTokens)
+	words        int                  // body word count (see plans.CountWords)
+	headings     []string             // text of every heading in the body, for lint checks
+	links        []string             // plan names referenced in the body (see bodyLinks)
+	backlinks    []string             // paths of plans that reference this one (see linkPlans)
+	problems     []fieldProblem       // frontmatter validation failures (see validateFrontmatter)
+	fields       map[string]string    // every frontmatter field, flattened to strings
+	history      []plans.StatusChange // status changes, oldest first (see history.go)
 }
 
 func (p plan) path() string {
 	return filepath.Join(p.dir, p.file)
 }
 
-var nextStatus = map[string]string{
-	"":         "reviewed",
-	"reviewed": "active",
-	"active":   "done",
-	"done":     "reviewed",
-}
-
-func statusIcon(s string) string {
-	switch s {
-	case "active":
-		return "●"
-	case "reviewed":
-		return "○"
-	case "done":
-		return "✓"
-	default:
-		return "·"
-	}
-}
-
 func (p plan) Title() string {
 	if len(p.labels) > 0 {
 		return fmt.Sprintf("%s %s: %s", statusIcon(p.status), strings.Join(p.labels, ","), p.title)
@@ -85,40 +103,64 @@ func (p plan) FilterValue() string {
 
 // ─── Plan Scanning ───────────────────────────────────────────────────────────
 
-// parseFrontmatter extracts YAML frontmatter key-value pairs from content.
-// Returns the fields and the body (everything after the closing ---).
-func parseFrontmatter(content string) (fields map[string]string, body string) {
-	fields = make(map[string]string)
-	content = strings.ReplaceAll(content, "\r\n", "\n")
-	content = strings.ReplaceAll(content, "\r", "\n")
-	lines := strings.Split(content, "\n")
-	if len(lines) < 2 || lines[0] != "---" {
-		return fields, content
-	}
-	closing := -1
-	for i := 1; i < len(lines); i++ {
-		if lines[i] == "---" {
-			closing = i
-			break
-		}
-	}
-	if closing < 0 {
-		return fields, content
-	}
-	for _, line := range lines[1:closing] {
-		k, v, ok := strings.Cut(line, ":")
-		if ok {
-			k = strings.TrimSpace(k)
-			v = strings.TrimSpace(v)
-			// Empty values are intentionally dropped: this pairs with
-			// setFrontmatter's convention of deleting keys set to "".
-			if k != "" && v != "" {
-				fields[k] = v
-			}
-		}
+// estimateTokens approximates how many LLM tokens text will consume (see
+// plans.EstimateTokens).
+func estimateTokens(text string) int {
+	return plans.EstimateTokens(text)
+}
+
+// countWords counts the words in a plan body (see plans.CountWords).
+func countWords(body string) int {
+	return plans.CountWords(body)
+}
+
+// formatTokens renders a token count compactly: "~850", "~1.2k", "~120k".
+func formatTokens(n int) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprintf("~%d", n)
+	case n < 100000:
+		return fmt.Sprintf("~%.1fk", float64(n)/1000)
+	default:
+		return fmt.Sprintf("~%dk", n/1000)
+	}
+}
+
+// wordsPerMinute is the reading speed behind readingTime.
+const wordsPerMinute = 230
+
+// readingTime estimates how long a body of n words takes to read, in
+// minutes: "~6 min", never less than "~1 min".
+func readingTime(n int) string {
+	return fmt.Sprintf("~%d min", max((n+wordsPerMinute/2)/wordsPerMinute, 1))
+}
+
+// formatWords renders a word count compactly: "850 words", "2.3k words".
+func formatWords(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d words", n)
+	}
+	return fmt.Sprintf("%.1fk words", float64(n)/1000)
+}
+
+// largePlanSize is the file size above which a plan's preview is not
+// rendered until asked for, since glamour can take seconds on huge files.
+const largePlanSize = 512 * 1024
+
+func isLargePlan(p plan) bool {
+	return p.size > largePlanSize
+}
+
+// formatSize renders a byte count as B, KB, or MB.
+func formatSize(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
 	}
-	body = strings.Join(lines[closing+1:], "\n")
-	return fields, body
 }
 
 // parseHeader returns the text of the first # heading, skipping frontmatter.
@@ -127,165 +169,268 @@ func parseHeader(content string) string {
 	return headerFromBody(body)
 }
 
+// planTitle picks a plan's display title: the frontmatter title: override,
+// else the first # heading, else the filename. untitled reports the last case.
+func planTitle(fm map[string]string, body, file string) (title string, untitled bool) {
+	return plans.Title(fm, body, file)
+}
+
+// withTitleHeading returns body with its first # heading replaced by title,
+// or with a # title heading prepended if it has none. Used to show a
+// frontmatter title: override at the top of the preview.
+func withTitleHeading(body, title string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "# ") {
+			lines[i] = "# " + title
+			return strings.Join(lines, "\n")
+		}
+	}
+	return "# " + title + "\n\n" + strings.TrimLeft(body, "\n")
+}
+
 // headerFromBody returns the text of the first # heading in body text.
 func headerFromBody(body string) string {
+	return plans.Heading(body)
+}
+
+// deriveTitle builds a short human title from the first prose paragraph of
+// body, for plans that have no # heading. Markdown markers are stripped and
+// the result is cut at the first sentence or ~60 characters on a word
+// boundary. Returns "" if the body has no usable prose.
+func deriveTitle(body string) string {
+	inFence := false
+	var para []string
 	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "# ") {
-			return strings.TrimSpace(line[2:])
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if trimmed == "" {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ">") ||
+			strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, "---") {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		trimmed = strings.TrimLeft(trimmed, "-*+ ")
+		if trimmed != "" {
+			para = append(para, trimmed)
+		}
+	}
+	text := strings.Join(para, " ")
+	text = strings.NewReplacer("**", "", "__", "", "`", "", "*", "", "[", "", "]", "").Replace(text)
+	if i := strings.IndexAny(text, ".!?"); i > 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSpace(text)
+	const maxLen = 60
+	if utf8.RuneCountInString(text) > maxLen {
+		runes := []rune(text)
+		cut := string(runes[:maxLen])
+		if j := strings.LastIndex(cut, " "); j > maxLen/2 {
+			cut = cut[:j]
 		}
+		text = strings.TrimRight(cut, " ,;:") + "…"
 	}
-	return ""
+	return text
 }
 
-// scanPlans reads all .md files in dir and builds a plan list from
-// frontmatter, headings, and file creation times. Sorted by created descending.
+// bodyHeadings returns the text of every markdown heading in body, in order.
+func bodyHeadings(body string) []string {
+	var headings []string
+	for _, e := range extractToc(body) {
+		if !e.isComment {
+			headings = append(headings, e.text)
+		}
+	}
+	return headings
+}
+
+// lintPlan returns the checklist sections that p has no heading for. A section
+// is satisfied by any heading containing it, case-insensitively, so "Testing"
+// matches "## Testing strategy".
+func lintPlan(p plan, checklist []string) []string {
+	var missing []string
+	for _, want := range checklist {
+		w := strings.ToLower(strings.TrimSpace(want))
+		if w == "" {
+			continue
+		}
+		found := false
+		for _, h := range p.headings {
+			if strings.Contains(strings.ToLower(h), w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+var (
+	wikilinkRegex = regexp.MustCompile(`\[\[([^\]|#]+)`)
+	mdFileRegex   = regexp.MustCompile(`([\w.-]+)\.md\b`)
+)
+
+// planRefName normalizes a plan reference (filename or wikilink target) to the
+// key used for link matching: lowercase base name without the .md extension.
+func planRefName(ref string) string {
+	ref = strings.TrimSpace(filepath.Base(ref))
+	return strings.ToLower(strings.TrimSuffix(ref, ".md"))
+}
+
+// bodyLinks returns the distinct plan names body refers to, either as
+// [[wikilinks]] or as bare/relative .md filenames.
+func bodyLinks(body string) []string {
+	seen := make(map[string]bool)
+	var links []string
+	add := func(ref string) {
+		if name := planRefName(ref); name != "" && !seen[name] {
+			seen[name] = true
+			links = append(links, name)
+		}
+	}
+	for _, m := range wikilinkRegex.FindAllStringSubmatch(body, -1) {
+		add(m[1])
+	}
+	for _, m := range mdFileRegex.FindAllStringSubmatch(body, -1) {
+		add(m[1])
+	}
+	return links
+}
+
+// linkPlans fills in each plan's backlinks from the other plans' links.
+// Self-references are ignored.
+func linkPlans(plans []plan) {
+	byName := make(map[string][]int, len(plans))
+	for i := range plans {
+		plans[i].backlinks = nil
+		name := planRefName(plans[i].file)
+		byName[name] = append(byName[name], i)
+	}
+	for _, src := range plans {
+		for _, l := range src.links {
+			for _, i := range byName[l] {
+				if plans[i].path() != src.path() {
+					plans[i].backlinks = append(plans[i].backlinks, src.path())
+				}
+			}
+		}
+	}
+}
+
+// scanPlans reads all plan files in dir via plans.Read and adds the
+// body-derived fields the UI needs. Sorted by sortPlans.
 func scanPlans(dir string) ([]plan, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
-	var plans []plan
+	var result []plan
 	for _, e := range entries {
-		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
-			continue
-		}
-		path := filepath.Join(dir, e.Name())
-		data, err := os.ReadFile(path)
-		if err != nil {
+		if e.IsDir() || !isPlanFile(e.Name()) {
 			continue
 		}
-		info, err := e.Info()
+		lp, body, err := plans.Read(filepath.Join(dir, e.Name()))
 		if err != nil {
 			continue
 		}
-		fm, body := parseFrontmatter(string(data))
-		title := headerFromBody(body)
-		if title == "" {
-			title = strings.TrimSuffix(e.Name(), ".md")
-		}
-		labels := parseLabels(fm["labels"])
-		project := fm["project"]
-		// Backward compat: migrate project → labels
-		if len(labels) == 0 && project != "" {
-			labels = []string{project}
-		}
-		// Backward compat: migrate pending → reviewed
-		status := fm["status"]
-		if status == "pending" {
-			status = "reviewed"
-		}
-		plans = append(plans, plan{
-			dir:         dir,
-			status:      status,
-			project:     project,
-			labels:      labels,
-			title:       title,
-			created:     fileCreatedTime(path, info.ModTime()),
-			modified:    info.ModTime(),
-			file:        e.Name(),
-			hasComments: bodyHasComments(body),
+		result = append(result, plan{
+			dir:          dir,
+			status:       lp.Status,
+			project:      lp.Project,
+			labels:       lp.Labels,
+			priority:     parsePriority(lp.Fields["priority"]),
+			due:          parseDue(lp.Fields["due"]),
+			estimate:     strings.TrimSpace(lp.Fields["estimate"]),
+			owner:        planOwner(lp.Fields),
+			supersededBy: strings.TrimSpace(lp.Fields["superseded_by"]),
+			supersedes:   strings.TrimSpace(lp.Fields["supersedes"]),
+			blockedBy:    parseBlockedBy(lp.Fields["blocked_by"]),
+			title:        lp.Title,
+			created:      lp.Created,
+			modified:     lp.Modified,
+			file:         lp.File,
+			hasComments:  lp.Comments > 0,
+			openComments: lp.OpenComments,
+			tasks:        lp.Tasks,
+			tasksDone:    lp.TasksDone,
+			untitled:     lp.Untitled,
+			size:         lp.Size,
+			tokens:       lp.Tokens,
+			words:        lp.Words,
+			headings:     bodyHeadings(body),
+			links:        bodyLinks(body),
+			problems:     validateFrontmatter(lp.Fields),
+			fields:       lp.Fields,
+			history:      plans.ParseHistory(lp.Fields["history"]),
 		})
 	}
-	sortPlans(plans)
-	return plans, nil
+	sortPlans(result)
+	return result, nil
 }
 
-// skipDirs lists directory names that are typically very large and
-// will never contain user plan files. Skipping them during glob
-// resolution avoids walking hundreds of thousands of entries
-// (e.g. node_modules trees) that make startup unacceptably slow.
-var skipDirs = map[string]bool{
-	"node_modules":    true,
-	".git":            true,
-	".hg":             true,
-	".svn":            true,
-	".venv":           true,
-	"venv":            true,
-	"__pycache__":     true,
-	".cache":          true,
-	".next":           true,
-	".nuxt":           true,
-	".output":         true,
-	".angular":        true,
-	".gradle":         true,
-	".cargo":          true,
-	".npm":            true,
-	".pnpm":           true,
-	".tox":            true,
-	".mypy_cache":     true,
-	".pytest_cache":   true,
-	".generated":      true,
-	"target":          true,
-	"dist":            true,
-	"build":           true,
-	"coverage":        true,
-	".turbo":          true,
-	".parcel-cache":   true,
-	".docusaurus":     true,
-}
-
-// resolveProjectDirs expands a glob pattern (supporting **) and returns
-// matching directories. Uses filepath.WalkDir from the static prefix of
-// the pattern, skipping known heavy directories for performance.
+// resolveProjectDirs returns the directories matched by the
+// project_plans_glob pattern (see plans.ProjectDirs).
 func resolveProjectDirs(glob string) []string {
-	if glob == "" {
-		return nil
-	}
-	glob = expandHome(glob)
+	return plans.ProjectDirs(glob)
+}
 
-	base := globBase(glob)
-	if _, err := os.Stat(base); err != nil {
-		return nil
-	}
+// recursiveScan makes scans include plans in subdirectories of the agent
+// plans directory. Set from config.RecursiveScan.
+var recursiveScan atomic.Bool
 
-	var dirs []string
-	filepath.WalkDir(base, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return filepath.SkipDir
-		}
-		if !d.IsDir() {
-			return nil
-		}
-		if path != base && skipDirs[d.Name()] {
-			return filepath.SkipDir
-		}
-		matched, _ := doublestar.PathMatch(glob, path)
-		if matched {
-			dirs = append(dirs, path)
-		}
+// agentSubDirs returns the subdirectories of agentDir scanned for plans (see
+// plans.SubDirs), or nil when recursive scanning is off.
+func agentSubDirs(agentDir string) []string {
+	if !recursiveScan.Load() || agentDir == "" {
 		return nil
-	})
-	return dirs
+	}
+	return plans.SubDirs(agentDir)
 }
 
-// globBase returns the longest directory prefix of a glob pattern
-// that contains no wildcard characters (* ? [ {).
-func globBase(pattern string) string {
-	for i, c := range pattern {
-		if c == '*' || c == '?' || c == '[' || c == '{' {
-			dir := pattern[:i]
-			if j := strings.LastIndex(dir, string(filepath.Separator)); j >= 0 {
-				return pattern[:j]
-			}
-			return "."
-		}
+// agentSubpath returns dir relative to agentDir ("api", "client/web") when
+// it is below it, or "".
+func agentSubpath(dir, agentDir string) string {
+	if dir == "" || agentDir == "" {
+		return ""
 	}
-	return pattern
+	rel, err := filepath.Rel(agentDir, dir)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return filepath.ToSlash(rel)
 }
 
-// scanAllPlans scans the agent plans dir and any project dirs matched by glob.
-// Plans are deduplicated by full path and sorted by creation time descending.
+// scanAllPlans scans the agent plans dir (and its subdirectories, with
+// recursiveScan) and any project dirs matched by glob. Plans are
+// deduplicated by full path and sorted by creation time descending.
 func scanAllPlans(agentDir string, projectGlob string) ([]plan, error) {
+	start := time.Now()
 	plans, err := scanPlans(agentDir)
 	if err != nil && !os.IsNotExist(err) {
+		logger.Error("scan failed", "dir", agentDir, "err", err)
 		return nil, err
 	}
 	seen := make(map[string]bool)
 	for _, p := range plans {
 		seen[p.path()] = true
 	}
-	for _, dir := range resolveProjectDirs(projectGlob) {
+	for _, dir := range append(agentSubDirs(agentDir), resolveProjectDirs(projectGlob)...) {
 		dirPlans, err := scanPlans(dir)
 		if err != nil {
 			continue
@@ -298,94 +443,41 @@ func scanAllPlans(agentDir string, projectGlob string) ([]plan, error) {
 		}
 	}
 	sortPlans(plans)
+	linkPlans(plans)
+	logger.Debug("scan", "agent_dir", agentDir, "glob", projectGlob, "plans", len(plans), "duration", time.Since(start))
 	return plans, nil
 }
 
-func sortPlans(plans []plan) {
-	sort.Slice(plans, func(i, j int) bool {
-		return plans[i].created.After(plans[j].created)
-	})
+// planFiles holds the plan_files config patterns; nil means *.md.
+var planFiles atomic.Pointer[[]string]
+
+func setPlanFiles(patterns []string) {
+	planFiles.Store(&patterns)
 }
 
-// parseLabels splits a comma-separated labels string, normalizes to lowercase,
-// and returns them sorted alphabetically.
-func parseLabels(s string) []string {
-	if s == "" {
-		return nil
-	}
-	parts := strings.Split(s, ",")
-	var labels []string
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		p = strings.ToLower(p)
-		if p != "" {
-			labels = append(labels, p)
-		}
+func currentPlanFiles() []string {
+	if p := planFiles.Load(); p != nil {
+		return *p
 	}
-	sort.Strings(labels)
-	return labels
+	return nil
 }
 
-// labelsString joins labels with ", " for frontmatter serialization.
-func labelsString(labels []string) string {
-	return strings.Join(labels, ", ")
+// isPlanFile reports whether path names a plan file under plan_files. Both
+// scanning and the file watchers filter with it.
+func isPlanFile(path string) bool {
+	return plans.MatchFile(path, currentPlanFiles())
 }
 
-// setFrontmatter merges the given fields into the file's YAML frontmatter.
-// Fields with empty values are removed. If no fields remain, frontmatter is stripped.
-// Unknown keys are preserved.
-func setFrontmatter(filePath string, updates map[string]string) error {
-	info, err := os.Stat(filePath)
-	if err != nil {
-		return err
-	}
-	perm := info.Mode().Perm()
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return err
-	}
-	existing, body := parseFrontmatter(string(data))
-	for k, v := range updates {
-		if v == "" {
-			delete(existing, k)
-		} else {
-			existing[k] = v
-		}
-	}
-	var result string
-	if len(existing) > 0 {
-		var buf strings.Builder
-		buf.WriteString("---\n")
-		written := make(map[string]bool)
-		for _, key := range []string{"status", "labels", "project"} {
-			if v, ok := existing[key]; ok && v != "" {
-				fmt.Fprintf(&buf, "%s: %s\n", key, v)
-				written[key] = true
-			}
-		}
-		// Preserve unknown keys in sorted order
-		var extra []string
-		for k := range existing {
-			if !written[k] {
-				extra = append(extra, k)
-			}
-		}
-		sort.Strings(extra)
-		for _, k := range extra {
-			if v := existing[k]; v != "" {
-				fmt.Fprintf(&buf, "%s: %s\n", k, v)
-			}
-		}
-		buf.WriteString("---\n")
-		result = buf.String() + body
-	} else {
-		result = body
+// sortByModified makes the list's date column and order use modification
+// time instead of creation time. Set from config and toggled with M.
+var sortByModified atomic.Bool
+
+// planDate is the date the list shows and sorts by.
+func planDate(p plan) time.Time {
+	if sortByModified.Load() {
+		return p.modified
 	}
-	// Use os.WriteFile (truncate + write) instead of atomic rename to preserve
-	// the file's birth time on Linux. Atomic rename creates a new inode which
-	// resets btime, causing the plan to jump to the top of the created-sort list.
-	lastSelfWrite.Store(time.Now().UnixMilli())
-	return os.WriteFile(filePath, []byte(result), perm)
+	return p.created
 }
 
 // recentLabels returns deduplicated label names from plans, most frequent first.
@@ -417,13 +509,189 @@ func recentLabels(plans []plan) []string {
 	return result
 }
 
+// statusCounts tallies plans by status ("" for new).
+func statusCounts(plans []plan) map[string]int {
+	counts := make(map[string]int)
+	for _, p := range plans {
+		counts[p.status]++
+	}
+	return counts
+}
+
+// filterFollowUp keeps plans with open comments an agent hasn't addressed.
+func filterFollowUp(plans []plan) []plan {
+	var filtered []plan
+	for _, p := range plans {
+		if p.openComments > 0 {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// filterStatus keeps plans with the named status (see statusByName).
+func filterStatus(plans []plan, name string) []plan {
+	status, _ := statusByName(name)
+	var filtered []plan
+	for _, p := range plans {
+		if p.status == status {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// genericDirNames are plan directory names that say nothing about the source,
+// so sourceName looks past them to the enclosing project.
+var genericDirNames = map[string]bool{
+	"plans": true, ".plans": true, "planning": true, "docs": true, "doc": true, ".claude": true,
+}
+
+// sourceName is a short name for the directory a plan came from: "" for the
+// agent plans directory, the relative path for its subdirectories, otherwise
+// the nearest non-generic directory name (~/code/api/docs/plans → "api").
+func sourceName(dir, agentDir string) string {
+	if dir == "" || dir == agentDir {
+		return ""
+	}
+	if sub := agentSubpath(dir, agentDir); sub != "" {
+		return sub
+	}
+	d := dir
+	for genericDirNames[filepath.Base(d)] && filepath.Dir(d) != d {
+		d = filepath.Dir(d)
+	}
+	if name := filepath.Base(d); name != string(filepath.Separator) && name != "." {
+		return name
+	}
+	return filepath.Base(dir)
+}
+
+// planSources returns the distinct plan directories, in sortSources order.
+func planSources(plans []plan, agentDir string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, p := range plans {
+		if !seen[p.dir] {
+			seen[p.dir] = true
+			dirs = append(dirs, p.dir)
+		}
+	}
+	sortSources(dirs, agentDir)
+	return dirs
+}
+
+// sortSources orders plan directories agent directory first, then by source
+// name.
+func sortSources(dirs []string, agentDir string) {
+	sort.Slice(dirs, func(i, j int) bool {
+		ni, nj := sourceName(dirs[i], agentDir), sourceName(dirs[j], agentDir)
+		if ni != nj {
+			return ni < nj
+		}
+		return dirs[i] < dirs[j]
+	})
+}
+
+// filterSource keeps plans from dir; an empty dir keeps everything.
+func filterSource(plans []plan, dir string) []plan {
+	if dir == "" {
+		return plans
+	}
+	var filtered []plan
+	for _, p := range plans {
+		if p.dir == dir {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// suggestStopwords are common title words that say nothing about a plan's topic.
+var suggestStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"plan": true, "add": true, "update": true, "fix": true, "support": true, "implement": true,
+}
+
+// titleWords returns the lowercase words of s that are long enough and
+// distinctive enough to compare plans by topic.
+func titleWords(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) > 2 && !suggestStopwords[w] {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// suggestLabels proposes up to three labels for p, which has no labels yet.
+// Candidates come from the project directory name (project plans only),
+// known labels mentioned in the title or body, and the labels of plans whose
+// titles share words with p. Higher-confidence sources score higher.
+func suggestLabels(p plan, body string, plans []plan, agentDir string) []string {
+	scores := make(map[string]int)
+	if p.dir != "" && agentDir != "" && p.dir != agentDir {
+		if project := strings.ToLower(filepath.Base(filepath.Dir(p.dir))); project != "" && project != "." && project != string(filepath.Separator) {
+			scores[project] += 3
+		}
+	}
+
+	titleSet := titleWords(p.title)
+	bodySet := titleWords(body)
+	for _, l := range recentLabels(plans) {
+		if titleSet[l] {
+			scores[l] += 2
+		} else if bodySet[l] {
+			scores[l]++
+		}
+	}
+
+	for _, other := range plans {
+		if other.path() == p.path() || len(other.labels) == 0 {
+			continue
+		}
+		shared := 0
+		for w := range titleWords(other.title) {
+			if titleSet[w] {
+				shared++
+			}
+		}
+		for _, l := range other.labels {
+			scores[l] += shared
+		}
+	}
+
+	for _, l := range p.labels {
+		delete(scores, l)
+	}
+	var out []string
+	for l, sc := range scores {
+		if sc > 0 {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if scores[out[i]] != scores[out[j]] {
+			return scores[out[i]] > scores[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	if len(out) > 3 {
+		out = out[:3]
+	}
+	return out
+}
+
 func filterPlans(plans []plan, showDone bool, keepFiles map[string]bool, labelFilter string, installed time.Time) []plan {
 	var filtered []plan
 	for _, p := range plans {
 		if labelFilter != "" && !hasLabel(p.labels, labelFilter) {
 			continue
 		}
-		if !showDone && p.status == "done" && !keepFiles[p.path()] {
+		if !showDone && isDone(p.status) && !keepFiles[p.path()] {
 			continue
 		}
 		if !showDone && p.status == "" && !keepFiles[p.path()] {
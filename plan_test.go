@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -157,8 +158,8 @@ func TestSetFrontmatter(t *testing.T) {
 	}
 	data, _ := os.ReadFile(path)
 	content := string(data)
-	if !strings.HasPrefix(content, "---\nstatus: active\n---\n") {
-		t.Errorf("expected frontmatter with status, got:\n%s", content)
+	if !strings.HasPrefix(content, "---\nstatus: active\ncreated: ") {
+		t.Errorf("expected frontmatter with status and created, got:\n%s", content)
 	}
 	if !strings.Contains(content, "# My Plan") {
 		t.Error("body content lost after adding frontmatter")
@@ -318,26 +319,6 @@ func TestSetLabelsWritesMigration(t *testing.T) {
 	}
 }
 
-func TestGlobBase(t *testing.T) {
-	tests := []struct {
-		pattern string
-		want    string
-	}{
-		{"/home/jake/code/**/plans", "/home/jake/code"},
-		{"/home/jake/code/*/plans", "/home/jake/code"},
-		{"/home/jake/code/plans", "/home/jake/code/plans"},
-		{"**/plans", "."},
-		{"/a/b/c", "/a/b/c"},
-		{"/a/b[0-9]/c", "/a"},
-	}
-	for _, tt := range tests {
-		got := globBase(tt.pattern)
-		if got != tt.want {
-			t.Errorf("globBase(%q) = %q, want %q", tt.pattern, got, tt.want)
-		}
-	}
-}
-
 func TestResolveProjectDirsSkipsHeavy(t *testing.T) {
 	// Create a temp tree: base/proj/plans, base/proj/node_modules/plans
 	base := t.TempDir()
@@ -396,3 +377,322 @@ func TestRecentLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestEstimateTokens(t *testing.T) {
+	if got := estimateTokens(""); got != 0 {
+		t.Errorf("estimateTokens(\"\") = %d, want 0", got)
+	}
+	// 400 chars of prose ≈ 100 tokens by the char rule.
+	prose := strings.Repeat("abcdefghi ", 40)
+	if got := estimateTokens(prose); got < 90 || got > 110 {
+		t.Errorf("estimateTokens(prose) = %d, want ~100", got)
+	}
+	// Many short words: the word-based floor should win.
+	short := strings.Repeat("a b ", 100)
+	if got := estimateTokens(short); got < 200*4/3 {
+		t.Errorf("estimateTokens(short words) = %d, want >= %d", got, 200*4/3)
+	}
+}
+
+func TestFormatTokensAndSize(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{formatTokens(850), "~850"},
+		{formatTokens(1234), "~1.2k"},
+		{formatTokens(123456), "~123k"},
+		{formatSize(512), "512 B"},
+		{formatSize(4915), "4.8 KB"},
+		{formatSize(3 * 1024 * 1024), "3.0 MB"},
+		{formatWords(850), "850 words"},
+		{formatWords(2345), "2.3k words"},
+		{readingTime(40), "~1 min"},
+		{readingTime(1380), "~6 min"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestSortPlansByModified(t *testing.T) {
+	t.Cleanup(func() { sortByModified.Store(false) })
+	now := time.Now()
+	plans := []plan{
+		{file: "old-busy.md", created: now.Add(-30 * 24 * time.Hour), modified: now},
+		{file: "new-idle.md", created: now.Add(-time.Hour), modified: now.Add(-time.Hour)},
+	}
+	sortPlans(plans)
+	if plans[0].file != "new-idle.md" {
+		t.Errorf("created order: first = %s", plans[0].file)
+	}
+	sortByModified.Store(true)
+	sortPlans(plans)
+	if plans[0].file != "old-busy.md" {
+		t.Errorf("modified order: first = %s", plans[0].file)
+	}
+	if !planDate(plans[0]).Equal(now) {
+		t.Errorf("planDate = %v, want modified time", planDate(plans[0]))
+	}
+}
+
+func TestSourceName(t *testing.T) {
+	agent := "/home/u/.claude/plans"
+	tests := []struct{ dir, want string }{
+		{agent, ""},
+		{"/home/u/code/api/plans", "api"},
+		{"/home/u/code/api/docs/plans", "api"},
+		{"/home/u/code/web/roadmap", "roadmap"},
+		{"/plans", "plans"},
+	}
+	for _, tt := range tests {
+		if got := sourceName(tt.dir, agent); got != tt.want {
+			t.Errorf("sourceName(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestPlanSourcesAndFilter(t *testing.T) {
+	agent := "/a/.claude/plans"
+	plans := []plan{
+		{dir: "/code/web/plans", file: "1.md"},
+		{dir: agent, file: "2.md"},
+		{dir: "/code/api/plans", file: "3.md"},
+		{dir: "/code/web/plans", file: "4.md"},
+	}
+	got := planSources(plans, agent)
+	want := []string{agent, "/code/api/plans", "/code/web/plans"}
+	if !slices.Equal(got, want) {
+		t.Errorf("planSources = %v, want %v", got, want)
+	}
+	if n := len(filterSource(plans, "/code/web/plans")); n != 2 {
+		t.Errorf("filterSource kept %d plans, want 2", n)
+	}
+	if n := len(filterSource(plans, "")); n != 4 {
+		t.Errorf("empty source filter kept %d plans, want 4", n)
+	}
+}
+
+func TestSuggestLabels(t *testing.T) {
+	agentDir := "/home/u/.claude/plans"
+	plans := []plan{
+		{dir: agentDir, file: "a.md", title: "Billing webhook retries", labels: []string{"payments"}},
+		{dir: agentDir, file: "b.md", title: "Search ranking tweaks", labels: []string{"search"}},
+		{dir: agentDir, file: "c.md", title: "Auth token refresh", labels: []string{"auth"}},
+	}
+
+	// Project plan: directory name first, then similar-title labels.
+	p := plan{dir: "/home/u/code/atlas/plans", file: "new.md", title: "Webhook retries for billing"}
+	got := suggestLabels(p, "Nothing relevant here.", plans, agentDir)
+	if len(got) < 2 || got[0] != "atlas" || got[1] != "payments" {
+		t.Errorf("suggestLabels(project) = %v, want [atlas payments ...]", got)
+	}
+
+	// Agent plan: known label mentioned in the body.
+	p = plan{dir: agentDir, file: "new.md", title: "Untitled"}
+	got = suggestLabels(p, "We need to revisit search relevance.", plans, agentDir)
+	if len(got) != 1 || got[0] != "search" {
+		t.Errorf("suggestLabels(body keyword) = %v, want [search]", got)
+	}
+
+	// No signal → no suggestions.
+	got = suggestLabels(plan{dir: agentDir, file: "x.md", title: "Misc"}, "", plans, agentDir)
+	if len(got) != 0 {
+		t.Errorf("suggestLabels(no signal) = %v, want none", got)
+	}
+}
+
+func TestDeriveTitle(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"first sentence", "Migrate the **session store** to Redis. Then clean up.", "Migrate the session store to Redis"},
+		{"skips subheadings and fences", "## Context\n\n```go\nfoo()\n```\n\nAdd retry logic to `fetch`.", "Add retry logic to fetch"},
+		{"list item", "- Rework onboarding flow\n- second", "Rework onboarding flow second"},
+		{"long text truncated on word", strings.Repeat("word ", 30), strings.Repeat("word ", 11) + "word…"},
+		{"no prose", "```\ncode only\n```\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deriveTitle(tt.body); got != tt.want {
+				t.Errorf("deriveTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintPlan(t *testing.T) {
+	body := "# Plan\n\n## Testing strategy\n\nUnit tests.\n\n```md\n## Rollout\n```\n\n## Open questions\n"
+	p := plan{headings: bodyHeadings(body)}
+	got := lintPlan(p, []string{"Testing", "Rollout", "Open Questions", " "})
+	if len(got) != 1 || got[0] != "Rollout" {
+		t.Errorf("lintPlan = %v, want [Rollout] (fenced heading ignored)", got)
+	}
+	if got := lintPlan(p, nil); len(got) != 0 {
+		t.Errorf("lintPlan(no checklist) = %v, want none", got)
+	}
+}
+
+func TestBodyLinks(t *testing.T) {
+	body := "See [[Bright-Sailing-Otter]] and [[calm-drifting-whale|the tracker]].\nAlso ./plans/deep-crunching-sprout.md and calm-drifting-whale.md again."
+	got := bodyLinks(body)
+	want := []string{"bright-sailing-otter", "calm-drifting-whale", "deep-crunching-sprout"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("bodyLinks = %v, want %v", got, want)
+	}
+}
+
+func TestLinkPlans(t *testing.T) {
+	plans := []plan{
+		{dir: "/p", file: "a.md", links: []string{"b", "a"}},
+		{dir: "/p", file: "b.md"},
+		{dir: "/q", file: "c.md", links: []string{"b"}},
+	}
+	linkPlans(plans)
+	if len(plans[0].backlinks) != 0 {
+		t.Errorf("self-reference should not count, got %v", plans[0].backlinks)
+	}
+	if got := plans[1].backlinks; len(got) != 2 || got[0] != "/p/a.md" || got[1] != "/q/c.md" {
+		t.Errorf("b backlinks = %v", got)
+	}
+}
+
+func TestSetFrontmatterRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.md")
+	front := "---\n# managed by hand\nzeta: \"quoted: value\"\nstatus: reviewed # set during triage\ntags:\n  - a\n  - b\nalpha: 1\ncreated: 2026-01-02\n---\n"
+	writeFile(t, path, front+"# Plan\n")
+
+	if err := setFrontmatter(path, map[string]string{"status": "active"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "---\n# managed by hand\nzeta: \"quoted: value\"\nstatus: active # set during triage\ntags:\n  - a\n  - b\nalpha: 1\ncreated: 2026-01-02\n---\n# Plan\n"
+	if string(data) != want {
+		t.Errorf("status update:\ngot:  %q\nwant: %q", data, want)
+	}
+
+	if err := setFrontmatter(path, map[string]string{"tags": "", "labels": "x, y"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	want = "---\n# managed by hand\nzeta: \"quoted: value\"\nstatus: active # set during triage\nalpha: 1\ncreated: 2026-01-02\nlabels: x, y\n---\n# Plan\n"
+	if string(data) != want {
+		t.Errorf("list removal + new key:\ngot:  %q\nwant: %q", data, want)
+	}
+}
+
+func TestWriteCommentBodyPreservesFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.md")
+	front := "---\nzeta: 1\nstatus: active\n# note\ncreated: 2026-01-02\n---\n"
+	writeFile(t, path, front+"# Plan\n")
+	if err := writeCommentBody(path, "# Plan\n\n> **[comment]:** hi\n"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := front + "# Plan\n\n> **[comment]:** hi\n"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+}
+
+func TestScanPlansTitleOverride(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\ntitle: \"Short: name\"\n---\n# A very long generated heading\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "---\ntitle: Untitled fix\n---\nSome prose.\n")
+	plans, err := scanPlans(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]plan{}
+	for _, p := range plans {
+		got[p.file] = p
+	}
+	if got["a.md"].title != "Short: name" {
+		t.Errorf("a.md title = %q", got["a.md"].title)
+	}
+	if got["b.md"].title != "Untitled fix" || got["b.md"].untitled {
+		t.Errorf("b.md = %+v", got["b.md"])
+	}
+}
+
+func TestWithTitleHeading(t *testing.T) {
+	if got := withTitleHeading("intro\n# Old\nbody", "New"); got != "intro\n# New\nbody" {
+		t.Errorf("replace: got %q", got)
+	}
+	if got := withTitleHeading("\nbody", "New"); got != "# New\n\nbody" {
+		t.Errorf("prepend: got %q", got)
+	}
+}
+
+func TestSetPlanTitle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "# Long heading\n")
+	p := plan{dir: dir, file: "p.md", title: "Long heading"}
+
+	msg := setPlanTitle(p, "Short: title")().(titleUpdatedMsg)
+	if msg.plan.title != "Short: title" {
+		t.Errorf("title = %q", msg.plan.title)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "\ntitle: \"Short: title\"\n") {
+		t.Errorf("file = %q", data)
+	}
+
+	msg = setPlanTitle(msg.plan, "")().(titleUpdatedMsg)
+	if msg.plan.title != "Long heading" {
+		t.Errorf("cleared title = %q, want heading", msg.plan.title)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), "title:") || !strings.HasSuffix(string(data), "---\n# Long heading\n") {
+		t.Errorf("file after clear = %q", data)
+	}
+}
+
+func TestPlanCreatedPrefersFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "---\ncreated: 2024-03-05T10:00:00Z\n---\n# A\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "---\nupdated: 2024-03-06\n---\n# B\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "---\ncreated: not a date\n---\n# C\n")
+	plans, err := scanPlans(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]time.Time{}
+	for _, p := range plans {
+		got[p.file] = p.created
+	}
+	if want := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC); !got["a.md"].Equal(want) {
+		t.Errorf("a.md created = %v, want %v", got["a.md"], want)
+	}
+	if want := time.Date(2024, 3, 6, 0, 0, 0, 0, time.Local); !got["b.md"].Equal(want) {
+		t.Errorf("b.md created = %v, want %v", got["b.md"], want)
+	}
+	if got["c.md"].Year() < 2025 {
+		t.Errorf("c.md should fall back to btime, got %v", got["c.md"])
+	}
+}
+
+func TestSetFrontmatterStampsCreatedOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "# P\n")
+	if err := setFrontmatter(path, map[string]string{"status": "active"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	fm, _ := parseFrontmatter(string(data))
+	first := fm["created"]
+	if _, ok := parseFrontmatterTime(first); !ok {
+		t.Fatalf("created = %q, want a timestamp", first)
+	}
+	if err := setFrontmatter(path, map[string]string{"status": "done"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Count(string(data), "created:") != 1 || !strings.Contains(string(data), "created: "+first) {
+		t.Errorf("created should be written once and kept, got %q", data)
+	}
+}
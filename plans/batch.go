@@ -0,0 +1,131 @@
+package plans
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ─── Batch Writes ────────────────────────────────────────────────────────────
+//
+// A batch edit is all-or-nothing. Every file is read and its new content
+// computed before anything is written, each write is read back and compared,
+// and if any step fails the files already written are restored from their
+// original bytes. Readers only ever see a mixed state if a restore itself
+// fails, and BatchError names those files.
+
+// osWriteFile is os.WriteFile; tests replace it to inject failures.
+var osWriteFile = os.WriteFile
+
+// BatchError reports a batch that did not complete. Path is the file whose
+// read, write, or verification failed. Every file in the batch was left as
+// it was except those in Unrestored, which were written and could not be
+// rolled back.
+type BatchError struct {
+	Path       string
+	Err        error
+	Unrestored []string
+}
+
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("%s: %v", filepath.Base(e.Path), e.Err)
+	if len(e.Unrestored) > 0 {
+		names := make([]string, len(e.Unrestored))
+		for i, p := range e.Unrestored {
+			names[i] = filepath.Base(p)
+		}
+		msg += "; could not restore " + strings.Join(names, ", ")
+	}
+	return msg
+}
+
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// Untouched returns the paths of a failed batch that were left unchanged.
+func (e *BatchError) Untouched(paths []string) []string {
+	var out []string
+	for _, p := range paths {
+		if !slices.Contains(e.Unrestored, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// stagedWrite is one file's original and new content.
+type stagedWrite struct {
+	path     string
+	perm     os.FileMode
+	original []byte
+	result   []byte
+}
+
+// BatchSetFrontmatter merges updates(path, fields) into the frontmatter of
+// every file in paths, where fields is that file's current frontmatter, with the
+// same rules as [SetFrontmatter]. Either every file is updated or, on error,
+// none is: the returned *BatchError says which file failed and lists any
+// that could not be restored.
+func BatchSetFrontmatter(paths []string, updates func(path string, fields map[string]string) map[string]string) error {
+	staged := make([]stagedWrite, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return &BatchError{Path: path, Err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return &BatchError{Path: path, Err: err}
+		}
+		fm, _ := ParseFrontmatter(string(data))
+		result := applyFrontmatter(path, info, string(data), updates(path, fm))
+		if result == string(data) {
+			continue
+		}
+		staged = append(staged, stagedWrite{path, info.Mode().Perm(), data, []byte(result)})
+	}
+
+	for i, w := range staged {
+		if err := commit(w); err != nil {
+			return &BatchError{Path: w.path, Err: err, Unrestored: rollback(staged[:i+1])}
+		}
+	}
+	return nil
+}
+
+// commit writes w's new content and reads it back to verify it.
+func commit(w stagedWrite) error {
+	markWrite()
+	if err := osWriteFile(w.path, w.result, w.perm); err != nil {
+		return err
+	}
+	got, err := os.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, w.result) {
+		return errors.New("write verification failed")
+	}
+	return nil
+}
+
+// rollback restores the original content of written files, newest first,
+// and returns the paths it could not restore.
+func rollback(written []stagedWrite) []string {
+	var failed []string
+	for i := len(written) - 1; i >= 0; i-- {
+		w := written[i]
+		markWrite()
+		if err := osWriteFile(w.path, w.original, w.perm); err != nil {
+			// A write that failed outright may have left the file intact.
+			if got, rerr := os.ReadFile(w.path); rerr != nil || !bytes.Equal(got, w.original) {
+				failed = append(failed, w.path)
+			}
+		}
+	}
+	slices.Reverse(failed)
+	return failed
+}
@@ -0,0 +1,111 @@
+package plans
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBatchSetFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md")
+	writeFile(t, a, "# A\n")
+	writeFile(t, b, "---\nlabels: x\n---\n# B\n")
+
+	err := BatchSetFrontmatter([]string{a, b}, func(_ string, fm map[string]string) map[string]string {
+		return LabelUpdates(fm, []string{"y"}, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{a: "y", b: "x, y"} {
+		fm, _ := ParseFrontmatter(readFile(t, path))
+		if fm["labels"] != want {
+			t.Errorf("%s labels = %q, want %q", filepath.Base(path), fm["labels"], want)
+		}
+	}
+}
+
+func TestBatchSetFrontmatterRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	a, b, c := filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md"), filepath.Join(dir, "c.md")
+	for _, p := range []string{a, b, c} {
+		writeFile(t, p, "# Plan\n")
+	}
+	boom := errors.New("disk full")
+	osWriteFile = func(name string, data []byte, perm os.FileMode) error {
+		if name == c {
+			return boom
+		}
+		return os.WriteFile(name, data, perm)
+	}
+	t.Cleanup(func() { osWriteFile = os.WriteFile })
+
+	err := BatchSetFrontmatter([]string{a, b, c}, func(string, map[string]string) map[string]string {
+		return map[string]string{"status": "done"}
+	})
+	var be *BatchError
+	if !errors.As(err, &be) || be.Path != c || !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want BatchError for c.md", err)
+	}
+	if len(be.Unrestored) != 0 {
+		t.Errorf("Unrestored = %v", be.Unrestored)
+	}
+	for _, p := range []string{a, b, c} {
+		if got := readFile(t, p); got != "# Plan\n" {
+			t.Errorf("%s not rolled back: %q", filepath.Base(p), got)
+		}
+	}
+}
+
+func TestBatchSetFrontmatterReportsUnrestored(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md")
+	writeFile(t, a, "# A\n")
+	writeFile(t, b, "# B\n")
+	writes := 0
+	osWriteFile = func(name string, data []byte, perm os.FileMode) error {
+		writes++
+		if writes > 1 { // b's write and every restore fail
+			return errors.New("read-only file system")
+		}
+		return os.WriteFile(name, data, perm)
+	}
+	t.Cleanup(func() { osWriteFile = os.WriteFile })
+
+	err := BatchSetFrontmatter([]string{a, b}, func(string, map[string]string) map[string]string {
+		return map[string]string{"status": "done"}
+	})
+	var be *BatchError
+	if !errors.As(err, &be) {
+		t.Fatalf("err = %v", err)
+	}
+	// b's failed write never changed it, so only a is left modified.
+	if !slices.Equal(be.Unrestored, []string{a}) {
+		t.Errorf("Unrestored = %v", be.Unrestored)
+	}
+	if got := be.Untouched([]string{a, b}); !slices.Equal(got, []string{b}) {
+		t.Errorf("Untouched = %v", got)
+	}
+	if want := "b.md: read-only file system; could not restore a.md"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestBatchSetFrontmatterStagesBeforeWriting(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.md")
+	writeFile(t, a, "# A\n")
+
+	err := BatchSetFrontmatter([]string{a, filepath.Join(dir, "missing.md")}, func(string, map[string]string) map[string]string {
+		return map[string]string{"status": "done"}
+	})
+	if err == nil {
+		t.Fatal("expected error for missing file")
+	}
+	if got := readFile(t, a); got != "# A\n" {
+		t.Errorf("a.md written before staging finished: %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+package plans
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ─── Comments ────────────────────────────────────────────────────────────────
+
+// CommentPattern matches a comment blockquote. The first group is "comment"
+// for an open comment and "resolved" once it has been addressed; the second
+// is the comment text.
+var CommentPattern = regexp.MustCompile(`^>\s*\*\*\[(comment|resolved)\]:\*\*\s*(.+)$`)
+
+// CountComments returns how many comment blockquotes the body has and how
+// many of them are still open (not [resolved]). Fenced code is skipped.
+func CountComments(body string) (total, open int) {
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := CommentPattern.FindStringSubmatch(trimmed); m != nil {
+			total++
+			if m[1] == "comment" {
+				open++
+			}
+		}
+	}
+	return total, open
+}
+
+// InjectComment inserts a comment blockquote after the given heading line.
+func InjectComment(rawBody string, headingLine int, text string) string {
+	lines := strings.Split(rawBody, "\n")
+	if headingLine < 0 || headingLine >= len(lines) {
+		return rawBody
+	}
+
+	comment := fmt.Sprintf("> **[comment]:** %s", text)
+
+	// Insert after the heading line with blank lines for clean formatting
+	var result []string
+	result = append(result, lines[:headingLine+1]...)
+	result = append(result, "")
+	result = append(result, comment)
+	result = append(result, "")
+	if headingLine+1 < len(lines) {
+		// Skip a leading blank line after heading to avoid double blanks
+		rest := lines[headingLine+1:]
+		if len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
+			rest = rest[1:]
+		}
+		result = append(result, rest...)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// RemoveComment removes a comment line and any adjacent blank line.
+func RemoveComment(rawBody string, commentLine int) string {
+	lines := strings.Split(rawBody, "\n")
+	if commentLine < 0 || commentLine >= len(lines) {
+		return rawBody
+	}
+
+	// Remove the comment line
+	var result []string
+	result = append(result, lines[:commentLine]...)
+
+	// Skip trailing blank line if present
+	rest := lines[commentLine+1:]
+	if len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
+		rest = rest[1:]
+	}
+
+	// Also remove preceding blank line if present
+	if len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
+		result = result[:len(result)-1]
+	}
+
+	result = append(result, rest...)
+	return strings.Join(result, "\n")
+}
+
+// SetCommentResolved rewrites a comment's marker to [resolved] or back to
+// [comment], keeping its text.
+func SetCommentResolved(rawBody string, commentLine int, resolved bool) string {
+	lines := strings.Split(rawBody, "\n")
+	if commentLine < 0 || commentLine >= len(lines) {
+		return rawBody
+	}
+	m := CommentPattern.FindStringSubmatch(strings.TrimSpace(lines[commentLine]))
+	if m == nil {
+		return rawBody
+	}
+	marker := "comment"
+	if resolved {
+		marker = "resolved"
+	}
+	lines[commentLine] = fmt.Sprintf("> **[%s]:** %s", marker, m[2])
+	return strings.Join(lines, "\n")
+}
+
+// ReplaceComment replaces the text of an existing comment in-place. An
+// edited comment is open again, even if it had been resolved.
+func ReplaceComment(rawBody string, commentLine int, newText string) string {
+	lines := strings.Split(rawBody, "\n")
+	if commentLine < 0 || commentLine >= len(lines) {
+		return rawBody
+	}
+
+	lines[commentLine] = fmt.Sprintf("> **[comment]:** %s", newText)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,30 @@
+// Package plans reads and writes planc plan files: markdown documents with
+// optional YAML (---) or TOML (+++) frontmatter carrying status, labels, and
+// other fields, and inline review comments written as
+// "> **[comment]:** text" blockquotes.
+//
+// It has no UI dependencies, so editors, bots, and other tools can share
+// planc's parsing and mutation rules:
+//
+//   - Scanning: [Read], [Scan], [ScanAll], [ProjectDirs], [SubDirs],
+//     and [MatchFile] for plan file name patterns
+//   - Frontmatter: [ParseFrontmatter], [SetFrontmatter], and the
+//     line-preserving [SplitFrontmatter]/[EditFrontmatter]/[JoinFrontmatter]
+//   - Comments: [CountComments], [InjectComment], [RemoveComment],
+//     [ReplaceComment], [SetCommentResolved], [WriteBody]
+//   - Length: [EstimateTokens], [CountWords]
+//   - Tasks: [CountTasks] for "- [ ]" checklist progress, [SetTaskDone]
+//   - History: [StatusUpdates], which [SetStatus] uses to append each change
+//     to a history: list, and [ParseHistory]/[FormatHistory]
+//   - Mutations: [SetStatus], [SetLabels], [UpdateLabels], [SetTitle],
+//     [Rename], [Move], [Duplicate], [Fork], [Archive], [Delete], and [RenameLinks]
+//     to point links at a renamed plan
+//   - Trash: [Trash] moves a deleted plan into a [TrashDir] next to it;
+//     [Trashed], [Restore], and [Purge] list, undo, and finish deletions
+//   - Batches: [BatchSetFrontmatter], which writes every file or none
+//   - Notes: [ReadNotes], [WriteNotes], private per-plan notes kept in a
+//     [NotesDir] sidecar that follows the plan when it is renamed or moved
+//
+// Writes keep the file's existing frontmatter layout, BOM, and line endings,
+// and record their time in [LastWrite] so file watchers can ignore them.
+package plans
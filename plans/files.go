@@ -0,0 +1,393 @@
+package plans
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ─── Writes ──────────────────────────────────────────────────────────────────
+
+// LastWrite holds the Unix millisecond time of this package's most recent
+// write to a plan file. File watchers check it to skip events caused by
+// their own process's writes.
+var LastWrite atomic.Int64
+
+func markWrite() {
+	LastWrite.Store(time.Now().UnixMilli())
+}
+
+// SetFrontmatter merges the given fields into the file's YAML or TOML frontmatter.
+// Fields with empty values are removed. If no fields remain, frontmatter is stripped.
+// Only the lines of changed keys are rewritten; key order, comments, quoting,
+// and unknown keys are left byte-for-byte intact. A created: timestamp is
+// added on the first write (see Created).
+func SetFrontmatter(path string, updates map[string]string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	perm := info.Mode().Perm()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	result := applyFrontmatter(path, info, string(data), updates)
+	// Use os.WriteFile (truncate + write) instead of atomic rename to preserve
+	// the file's birth time on Linux. Atomic rename creates a new inode which
+	// resets btime, causing the plan to jump to the top of the created-sort list.
+	markWrite()
+	return os.WriteFile(path, []byte(result), perm)
+}
+
+// applyFrontmatter returns data with updates merged into its frontmatter,
+// stamping created: on the first write. info is the file's current stat.
+func applyFrontmatter(path string, info os.FileInfo, data string, updates map[string]string) string {
+	front, body, delim := SplitFrontmatter(data)
+	fm, _ := ParseFrontmatter(data)
+	updates = firstTouch(path, info, fm, updates)
+	result := JoinFrontmatter(EditFrontmatter(front, updates, delim), body, delim)
+	return RestoreEncoding(data, result)
+}
+
+// firstTouch adds a created: timestamp to updates if the plan, with
+// frontmatter fm, doesn't record one yet. Pinning the creation time on
+// planc's first write keeps sorting stable after copies, checkouts, and
+// rsync, and on filesystems without a birth time.
+func firstTouch(path string, info os.FileInfo, fm, updates map[string]string) map[string]string {
+	if _, ok := updates["created"]; ok || fm["created"] != "" {
+		return updates
+	}
+	stamped := maps.Clone(updates)
+	if stamped == nil {
+		stamped = make(map[string]string, 1)
+	}
+	stamped["created"] = fileCreatedTime(path, info.ModTime()).Format(time.RFC3339)
+	return stamped
+}
+
+// WriteBody replaces the body of the plan file at path, preserving its
+// frontmatter and encoding. Like SetFrontmatter, it adds a created:
+// timestamp on the first write.
+func WriteBody(path, body string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	perm := info.Mode().Perm()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	front, _, delim := SplitFrontmatter(string(data))
+	fm, _ := ParseFrontmatter(string(data))
+	if stamp := firstTouch(path, info, fm, nil); len(stamp) > 0 {
+		front = EditFrontmatter(front, stamp, delim)
+	}
+	result := RestoreEncoding(string(data), JoinFrontmatter(front, body, delim))
+
+	markWrite()
+	return os.WriteFile(path, []byte(result), perm)
+}
+
+// SetStatus writes status: to the plan at path, recording the change in its
+// history: (see StatusUpdates); "" clears it.
+func SetStatus(path, status string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fm, _ := ParseFrontmatter(string(data))
+	return SetFrontmatter(path, StatusUpdates(fm, status, time.Now()))
+}
+
+// SetTitle writes the title: override to the plan at path; "" clears it.
+func SetTitle(path, title string) error {
+	return SetFrontmatter(path, map[string]string{"title": title})
+}
+
+// SetLabels replaces the plan's labels, dropping the legacy project: field.
+func SetLabels(path string, labels []string) error {
+	return SetFrontmatter(path, map[string]string{
+		"labels":  FormatLabels(labels),
+		"project": "", // migrate away from project
+	})
+}
+
+// UpdateLabels adds and removes labels on the plan at path, keeping the rest.
+// A legacy project: value counts as an existing label.
+func UpdateLabels(path string, add, remove []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fm, _ := ParseFrontmatter(string(data))
+	return SetFrontmatter(path, LabelUpdates(fm, add, remove))
+}
+
+// LabelUpdates returns the frontmatter updates that add and remove labels
+// on a plan with the given fields, dropping the legacy project: field.
+func LabelUpdates(fields map[string]string, add, remove []string) map[string]string {
+	existing := ParseLabels(fields["labels"])
+	if len(existing) == 0 && fields["project"] != "" {
+		existing = []string{fields["project"]}
+	}
+	return map[string]string{
+		"labels":  FormatLabels(ApplyLabelChanges(existing, add, remove)),
+		"project": "", // migrate away from project
+	}
+}
+
+// ApplyLabelChanges applies add/remove to existing labels, returning a new slice.
+func ApplyLabelChanges(existing []string, add []string, remove []string) []string {
+	removeSet := make(map[string]bool)
+	for _, r := range remove {
+		removeSet[r] = true
+	}
+	var result []string
+	seen := make(map[string]bool)
+	for _, l := range existing {
+		if !removeSet[l] && !seen[l] {
+			result = append(result, l)
+			seen[l] = true
+		}
+	}
+	for _, a := range add {
+		if !seen[a] {
+			result = append(result, a)
+			seen[a] = true
+		}
+	}
+	return result
+}
+
+// ─── File Operations ─────────────────────────────────────────────────────────
+
+// ArchiveDir is the subdirectory archived plans are moved into. Scan doesn't
+// descend into subdirectories, so archived plans drop out of listings.
+const ArchiveDir = "archive"
+
+// FileName normalizes a user-supplied plan name to a bare .md filename.
+func FileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid plan name %q", name)
+	}
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+	return name, nil
+}
+
+// UniquePath returns dir/slug.md, or dir/slug-N.md if that exists.
+func UniquePath(dir, slug string) string {
+	path := filepath.Join(dir, slug+".md")
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d.md", slug, n))
+	}
+}
+
+// Delete removes the plan at path. A plan that is already gone is not an error.
+func Delete(path string) error {
+	markWrite()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// relocate moves src to dst, refusing to overwrite an existing plan. The
+// plan's private notes move with it.
+func relocate(src, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	markWrite()
+	if err := moveFile(src, dst, true); err != nil {
+		return err
+	}
+	return moveNotes(src, dst)
+}
+
+// moveFile renames src to dst, falling back to copyAcross when they are on
+// different filesystems.
+func moveFile(src, dst string, plan bool) error {
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	return copyAcross(src, dst, plan)
+}
+
+// copyAcross moves src to dst by copying it and removing src. The copy keeps
+// src's permissions and modification time; its birth time can't be kept, so
+// for a plan it is recorded as created: unless the frontmatter already dates
+// the plan.
+func copyAcross(src, dst string, plan bool) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if plan {
+		fm, _ := ParseFrontmatter(string(data))
+		_, created := ParseTime(fm["created"])
+		_, updated := ParseTime(fm["updated"])
+		if !created && !updated {
+			data = []byte(applyFrontmatter(src, info, string(data), map[string]string{}))
+		}
+	}
+	if err := os.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := os.Chtimes(dst, info.ModTime(), info.ModTime()); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+// siblingPath returns the path of file name next to the plan at path. name
+// gets the plan's extension, or .md, if it lacks one.
+func siblingPath(path, name string) (string, error) {
+	file, err := FileName(name)
+	if err != nil {
+		return "", err
+	}
+	if ext := filepath.Ext(path); ext != ".md" && ext != "" && !strings.HasSuffix(strings.TrimSpace(name), ".md") {
+		file = strings.TrimSuffix(file, ".md")
+		if !strings.HasSuffix(file, ext) {
+			file += ext
+		}
+	}
+	return filepath.Join(filepath.Dir(path), file), nil
+}
+
+// Rename gives the plan at path a new filename in its directory and returns
+// the new path. name gets the plan's extension, or .md, if it lacks one.
+func Rename(path, name string) (string, error) {
+	dst, err := siblingPath(path, name)
+	if err != nil {
+		return "", err
+	}
+	if dst == path {
+		return dst, nil
+	}
+	return dst, relocate(path, dst)
+}
+
+var (
+	wikilinkRefRegex = regexp.MustCompile(`\[\[([^\]|#]+)`)
+	mdFileRefRegex   = regexp.MustCompile(`[\w.-]+\.md\b`)
+)
+
+// RenameLinks rewrites the references to plan file oldFile in body to name
+// newFile instead: [[wikilinks]] (keeping any #heading or |alias and the
+// .md-less form) and .md filenames, including relative paths. Names match
+// case-insensitively, as links resolve.
+func RenameLinks(body, oldFile, newFile string) string {
+	oldStem := strings.TrimSuffix(oldFile, ".md")
+	newStem := strings.TrimSuffix(newFile, ".md")
+	body = wikilinkRefRegex.ReplaceAllStringFunc(body, func(link string) string {
+		target := link[2:]
+		dir, base := "", target
+		if i := strings.LastIndexAny(target, `/\`); i >= 0 {
+			dir, base = target[:i+1], target[i+1:]
+		}
+		base = strings.TrimSpace(base)
+		switch {
+		case strings.EqualFold(base, oldFile):
+			return "[[" + dir + newFile
+		case strings.EqualFold(base, oldStem):
+			return "[[" + dir + newStem
+		}
+		return link
+	})
+	return mdFileRefRegex.ReplaceAllStringFunc(body, func(ref string) string {
+		if strings.EqualFold(ref, oldFile) {
+			return newFile
+		}
+		return ref
+	})
+}
+
+// Move moves the plan at path into dir, keeping its filename, and returns the
+// new path. dir may start with ~/.
+func Move(path, dir string) (string, error) {
+	dst := filepath.Join(ExpandHome(dir), filepath.Base(path))
+	if dst == path {
+		return dst, nil
+	}
+	return dst, relocate(path, dst)
+}
+
+// Archive moves the plan at path into the ArchiveDir subdirectory of its
+// directory and returns the new path.
+func Archive(path string) (string, error) {
+	return Move(path, filepath.Join(filepath.Dir(path), ArchiveDir))
+}
+
+// Duplicate copies the plan at path to name-copy.md (or name-copy-N.md) next
+// to it and returns the copy's path. The copy gets created: now so it sorts
+// as a new plan.
+func Duplicate(path string, now time.Time) (string, error) {
+	dst := UniquePath(filepath.Dir(path), strings.TrimSuffix(filepath.Base(path), ".md")+"-copy")
+	if err := copyPlan(path, dst, map[string]string{"created": now.Format(time.RFC3339)}); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// Fork copies the plan at path to name next to it as a new plan to build
+// on, and returns the copy's path. name gets the plan's extension, or .md,
+// if it lacks one. The copy gets created: now; its status:, history:, and
+// supersedes:/superseded_by: are cleared, and labels and other fields kept.
+func Fork(path, name string, now time.Time) (string, error) {
+	dst, err := siblingPath(path, name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("%s already exists", dst)
+	}
+	err = copyPlan(path, dst, map[string]string{
+		"created":       now.Format(time.RFC3339),
+		"status":        "",
+		"history":       "",
+		"supersedes":    "",
+		"superseded_by": "",
+	})
+	if err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// copyPlan writes the plan at path to dst with updates applied to its
+// frontmatter.
+func copyPlan(path, dst string, updates map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	front, body, delim := SplitFrontmatter(string(data))
+	front = EditFrontmatter(front, updates, delim)
+	content := RestoreEncoding(string(data), JoinFrontmatter(front, body, delim))
+	markWrite()
+	return os.WriteFile(dst, []byte(content), 0644)
+}
@@ -0,0 +1,190 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateLabels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p.md")
+	writeFile(t, path, "---\nproject: api\ncreated: 2026-01-02\n---\n# P\n")
+
+	if err := UpdateLabels(path, []string{"ui"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	p, _, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(p.Labels, []string{"api", "ui"}) || p.Project != "" {
+		t.Errorf("labels = %v, project = %q", p.Labels, p.Project)
+	}
+
+	if err := UpdateLabels(path, nil, []string{"api"}); err != nil {
+		t.Fatal(err)
+	}
+	if p, _, _ = Read(path); !slices.Equal(p.Labels, []string{"ui"}) {
+		t.Errorf("after remove: labels = %v", p.Labels)
+	}
+}
+
+func TestSetStatusRecordsWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p.md")
+	writeFile(t, path, "# P\n")
+	before := time.Now().UnixMilli()
+
+	if err := SetStatus(path, "active"); err != nil {
+		t.Fatal(err)
+	}
+	if LastWrite.Load() < before {
+		t.Error("LastWrite not updated")
+	}
+	fm, body := ParseFrontmatter(readFile(t, path))
+	if fm["status"] != "active" || fm["created"] == "" || body != "# P\n" {
+		t.Errorf("fields = %v, body = %q", fm, body)
+	}
+}
+
+func TestWriteBodyPinsCreated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p.md")
+	writeFile(t, path, "# P\n")
+	if err := WriteBody(path, "# P\n\nMore.\n"); err != nil {
+		t.Fatal(err)
+	}
+	fm, body := ParseFrontmatter(readFile(t, path))
+	if _, ok := ParseTime(fm["created"]); !ok || body != "# P\n\nMore.\n" {
+		t.Fatalf("fields = %v, body = %q", fm, body)
+	}
+	// A second write keeps the pinned time.
+	writeFile(t, path, "---\ncreated: 2026-01-02\n---\n# P\n")
+	if err := WriteBody(path, "# Q\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != "---\ncreated: 2026-01-02\n---\n# Q\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFileOperations(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "a.md")
+	writeFile(t, src, "---\nstatus: active\n---\n# A\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "# B\n")
+
+	if _, err := Rename(src, "b"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("rename onto existing plan: err = %v", err)
+	}
+	renamed, err := Rename(src, "c")
+	if err != nil || renamed != filepath.Join(dir, "c.md") {
+		t.Fatalf("Rename = %q, %v", renamed, err)
+	}
+
+	dup, err := Duplicate(renamed, time.Now())
+	if err != nil || filepath.Base(dup) != "c-copy.md" {
+		t.Fatalf("Duplicate = %q, %v", dup, err)
+	}
+
+	moved, err := Move(dup, filepath.Join(dir, "other"))
+	if err != nil || moved != filepath.Join(dir, "other", "c-copy.md") {
+		t.Fatalf("Move = %q, %v", moved, err)
+	}
+
+	archived, err := Archive(renamed)
+	if err != nil || archived != filepath.Join(dir, ArchiveDir, "c.md") {
+		t.Fatalf("Archive = %q, %v", archived, err)
+	}
+	if fm, _ := ParseFrontmatter(readFile(t, archived)); fm["status"] != "active" {
+		t.Errorf("archived frontmatter = %v", fm)
+	}
+
+	if err := Delete(archived); err != nil {
+		t.Fatal(err)
+	}
+	if err := Delete(archived); err != nil {
+		t.Errorf("deleting a missing plan: %v", err)
+	}
+
+	plans, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || plans[0].File != "b.md" {
+		t.Errorf("remaining plans = %+v", plans)
+	}
+}
+
+func TestRenameLinks(t *testing.T) {
+	body := "See [[Auth]], [[auth#Steps|the steps]], [[docs/auth.md]], and ./auth.md.\nNot [[oauth]] or my-auth.md.\n"
+	want := "See [[login]], [[login#Steps|the steps]], [[docs/login.md]], and ./login.md.\nNot [[oauth]] or my-auth.md.\n"
+	if got := RenameLinks(body, "auth.md", "login.md"); got != want {
+		t.Errorf("RenameLinks =\n%s\nwant\n%s", got, want)
+	}
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "notes.markdown")
+	writeFile(t, src, "# Notes\n")
+	if got, err := Rename(src, "ideas"); err != nil || filepath.Base(got) != "ideas.markdown" {
+		t.Errorf("Rename kept extension = %q, %v", got, err)
+	}
+}
+
+func TestFork(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "auth.md")
+	writeFile(t, src, "---\nstatus: done\nlabels: [api, auth]\ncreated: 2026-01-02\nsuperseded_by: x.md\nhistory:\n  - 2026-01-03T00:00:00Z new -> done\n---\n# Auth\n")
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	dst, err := Fork(src, "auth-v2", now)
+	if err != nil || filepath.Base(dst) != "auth-v2.md" {
+		t.Fatalf("Fork = %q, %v", dst, err)
+	}
+	fm, body := ParseFrontmatter(readFile(t, dst))
+	if fm["status"] != "" || fm["history"] != "" || fm["superseded_by"] != "" {
+		t.Errorf("fork should start fresh: %v", fm)
+	}
+	if fm["labels"] != "api, auth" || fm["created"] != "2026-03-04T10:00:00Z" || body != "# Auth\n" {
+		t.Errorf("fork frontmatter = %v, body %q", fm, body)
+	}
+	if _, err := Fork(src, "auth-v2", now); err == nil {
+		t.Error("forking onto an existing plan should fail")
+	}
+}
+
+func TestCopyAcrossKeepsDates(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "a.md")
+	writeFile(t, src, "# A\n")
+	mtime := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(dir, "other", "a.md")
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := copyAcross(src, dst, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("source should be removed: %v", err)
+	}
+	info, err := os.Stat(dst)
+	if err != nil || !info.ModTime().Equal(mtime) {
+		t.Errorf("modification time = %v, %v; want %v", info.ModTime(), err, mtime)
+	}
+	if fm, body := ParseFrontmatter(readFile(t, dst)); fm["created"] == "" || body != "# A\n" {
+		t.Errorf("copy should record created:, got %v %q", fm, body)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,538 @@
+package plans
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ─── Frontmatter ─────────────────────────────────────────────────────────────
+//
+// Plans may carry YAML (---) or TOML (+++) frontmatter. Reads flatten values
+// to strings (lists become "a, b"; see yaml.go for YAML), and writes treat
+// frontmatter as a list of top-level key/value lines, touching only the lines
+// of changed keys so hand-maintained frontmatter keeps its layout.
+
+// Frontmatter delimiters, as returned by SplitFrontmatter.
+const (
+	YAMLDelim = "---"
+	TOMLDelim = "+++"
+)
+
+// utf8BOM is the byte order mark some Windows editors prepend to UTF-8 files.
+const utf8BOM = "\ufeff"
+
+// SplitFrontmatter separates content into its raw frontmatter lines (between
+// the delimiters) and the body. delim is "---" for YAML, "+++" for TOML, or
+// "" if there is no frontmatter. A leading BOM is dropped, line endings are
+// normalized to \n, and delimiters may carry trailing whitespace.
+func SplitFrontmatter(content string) (front []string, body, delim string) {
+	content = strings.TrimPrefix(content, utf8BOM)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+	lines := strings.Split(content, "\n")
+	if len(lines) < 2 {
+		return nil, content, ""
+	}
+	open := strings.TrimRight(lines[0], " \t")
+	if open != YAMLDelim && open != TOMLDelim {
+		return nil, content, ""
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t") == open {
+			return lines[1:i], strings.Join(lines[i+1:], "\n"), open
+		}
+	}
+	return nil, content, ""
+}
+
+// RestoreEncoding gives result (built from \n-normalized text) the BOM and
+// CRLF line endings of original, so writes don't churn Windows-authored files.
+func RestoreEncoding(original, result string) string {
+	if strings.Contains(original, "\r\n") {
+		result = strings.ReplaceAll(result, "\n", "\r\n")
+	}
+	if strings.HasPrefix(original, utf8BOM) {
+		result = utf8BOM + result
+	}
+	return result
+}
+
+// commonIndent returns the whitespace prefix shared by every non-blank line,
+// so frontmatter indented as a whole (e.g. by a tab) still parses.
+func commonIndent(lines []string) string {
+	indent := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			indent, first = lead, false
+			continue
+		}
+		for !strings.HasPrefix(lead, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	return indent
+}
+
+// dedent strips indent from each non-blank line.
+func dedent(lines []string, indent string) []string {
+	if indent == "" {
+		return lines
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			out[i] = line
+		} else {
+			out[i] = strings.TrimPrefix(line, indent)
+		}
+	}
+	return out
+}
+
+// ParseFrontmatter extracts frontmatter key-value pairs from content.
+// Returns the fields and the body (everything after the closing delimiter).
+// YAML goes through a YAML parser (see parseYAML), falling back to the line
+// reader when it isn't valid YAML.
+func ParseFrontmatter(content string) (fields map[string]string, body string) {
+	front, body, delim := SplitFrontmatter(content)
+	front = dedent(front, commonIndent(front))
+	if delim == YAMLDelim {
+		if fields, ok := parseYAML(front); ok {
+			return fields, body
+		}
+	}
+	return parseFrontmatterLines(front, delim), body
+}
+
+// parseFrontmatterLines reads frontmatter one top-level "key: value" line at
+// a time. It reads all TOML, and YAML the YAML parser rejects.
+func parseFrontmatterLines(front []string, delim string) map[string]string {
+	fields := make(map[string]string)
+	for i, line := range front {
+		if delim == TOMLDelim && strings.HasPrefix(strings.TrimSpace(line), "[") {
+			break // keys after a [table] header aren't top-level
+		}
+		k := frontmatterKey(line, delim)
+		if k == "" {
+			continue
+		}
+		v := frontmatterValue(line, delim)
+		switch {
+		case delim == TOMLDelim:
+			v = tomlValue(v)
+		case v == "":
+			v = yamlBlockList(front[i+1:])
+		case strings.HasPrefix(v, "["):
+			v = yamlFlowList(v)
+		default:
+			v = yamlValue(v)
+		}
+		// Empty values are intentionally dropped: this pairs with
+		// SetFrontmatter's convention of deleting keys set to "".
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+func frontmatterSep(delim string) string {
+	if delim == TOMLDelim {
+		return "="
+	}
+	return ":"
+}
+
+// frontmatterKey returns the key of a top-level "key: value" (YAML) or
+// "key = value" (TOML) line, or "" for indented, comment, and blank lines.
+func frontmatterKey(line, delim string) string {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '[' {
+		return ""
+	}
+	k, _, ok := strings.Cut(line, frontmatterSep(delim))
+	if !ok {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(k), `"`)
+}
+
+// frontmatterValue returns the raw, trimmed value part of a key line.
+func frontmatterValue(line, delim string) string {
+	_, v, _ := strings.Cut(line, frontmatterSep(delim))
+	return strings.TrimSpace(v)
+}
+
+// isContinuation reports whether line continues the previous key's value: an
+// indented YAML list item, or a TOML multi-line array element.
+func isContinuation(line, delim string) bool {
+	if line == "" || frontmatterKey(line, delim) != "" {
+		return false
+	}
+	if delim == TOMLDelim {
+		t := strings.TrimSpace(line)
+		return t != "" && !strings.HasPrefix(t, "#") && !strings.HasPrefix(t, "[")
+	}
+	return line[0] == ' ' || line[0] == '\t' || line[0] == '-'
+}
+
+// tomlValue flattens a TOML value to the string form used for YAML values:
+// strings are unquoted, arrays of strings become "a, b", and trailing
+// comments are dropped.
+func tomlValue(v string) string {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, "[") {
+		end := strings.LastIndex(v, "]")
+		if end < 0 {
+			end = len(v)
+		}
+		var items []string
+		for _, part := range strings.Split(v[1:end], ",") {
+			if part = tomlValue(part); part != "" {
+				items = append(items, part)
+			}
+		}
+		return strings.Join(items, ", ")
+	}
+	if strings.HasPrefix(v, `"`) {
+		if end := strings.Index(v[1:], `"`); end >= 0 {
+			if s, err := strconv.Unquote(v[:end+2]); err == nil {
+				return s
+			}
+			return v[1 : end+1]
+		}
+	}
+	if strings.HasPrefix(v, "'") {
+		if end := strings.Index(v[1:], "'"); end >= 0 {
+			return v[1 : end+1]
+		}
+	}
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v)
+}
+
+// yamlValue unquotes a fully quoted YAML scalar; plain scalars lose any
+// trailing comment.
+func yamlValue(v string) string {
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+		if s, err := strconv.Unquote(v); err == nil {
+			return s
+		}
+	}
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = strings.TrimSpace(v[:i]) // trailing comment
+	}
+	return v
+}
+
+// yamlBlockList joins the "- item" lines at the start of lines (a YAML block
+// sequence) with ", ". Returns "" if lines doesn't start with one.
+func yamlBlockList(lines []string) string {
+	var items []string
+	for _, line := range lines {
+		t := strings.TrimSpace(line)
+		if t == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		item, ok := strings.CutPrefix(t, "-")
+		if !ok || !isContinuation(line, YAMLDelim) {
+			break
+		}
+		if item = yamlValue(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// yamlFlowList flattens a YAML flow sequence ("[a, 'b']") to "a, b".
+func yamlFlowList(v string) string {
+	end := strings.LastIndex(v, "]")
+	if end < 0 {
+		end = len(v)
+	}
+	var items []string
+	for _, part := range strings.Split(v[1:end], ",") {
+		if part = yamlValue(strings.TrimSpace(part)); part != "" {
+			items = append(items, part)
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// yamlNeedsQuote reports whether v would not survive as a plain YAML scalar.
+func yamlNeedsQuote(v string) bool {
+	return strings.Contains(v, ": ") || strings.Contains(v, " #") ||
+		strings.ContainsAny(v[:1], "\"'[{&*!|>%@`#") || strings.HasSuffix(v, ":")
+}
+
+// LabelsAsList makes YAML labels default to a block sequence ("- ui") instead
+// of a comma-joined string. Mirrors config.LabelsAsList; a global because
+// SetFrontmatter has many callers that don't carry config.
+var LabelsAsList atomic.Bool
+
+// YAML label styles, chosen per file so existing formatting is kept.
+const (
+	labelsInline = iota // labels: a, b
+	labelsBlock         // labels:\n  - a\n  - b
+	labelsFlow          // labels: [a, b]
+)
+
+// yamlLabelStyle returns the style an existing labels value was written in.
+func yamlLabelStyle(old string) int {
+	switch {
+	case old == "":
+		return labelsBlock
+	case strings.HasPrefix(old, "["):
+		return labelsFlow
+	}
+	return labelsInline
+}
+
+// formatYAMLLabels renders a labels value in the given style.
+func formatYAMLLabels(v string, style int) string {
+	labels := ParseLabels(v)
+	switch style {
+	case labelsBlock:
+		var b strings.Builder
+		b.WriteString("labels:")
+		for _, l := range labels {
+			b.WriteString("\n  - " + l)
+		}
+		return b.String()
+	case labelsFlow:
+		return "labels: [" + strings.Join(labels, ", ") + "]"
+	}
+	return "labels: " + FormatLabels(labels)
+}
+
+// formatFrontmatterLine renders a key line in the given format. YAML values
+// are quoted only when needed, labels follow LabelsAsList, and history is
+// always a block sequence. In TOML, labels and history are written as string
+// arrays and other values as quoted strings.
+func formatFrontmatterLine(k, v, delim string) string {
+	if delim != TOMLDelim {
+		switch k {
+		case "labels":
+			style := labelsInline
+			if LabelsAsList.Load() {
+				style = labelsBlock
+			}
+			return formatYAMLLabels(v, style)
+		case "history":
+			var b strings.Builder
+			b.WriteString("history:")
+			for _, entry := range strings.Split(v, ", ") {
+				if yamlNeedsQuote(entry) {
+					entry = strconv.Quote(entry)
+				}
+				b.WriteString("\n  - " + entry)
+			}
+			return b.String()
+		}
+		if yamlNeedsQuote(v) {
+			v = strconv.Quote(v)
+		}
+		return k + ": " + v
+	}
+	var items []string
+	switch k {
+	case "labels":
+		items = ParseLabels(v)
+	case "history":
+		items = strings.Split(v, ", ")
+	default:
+		return k + " = " + strconv.Quote(v)
+	}
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = strconv.Quote(item)
+	}
+	return k + " = [" + strings.Join(quoted, ", ") + "]"
+}
+
+// inlineComment returns the trailing "# ..." comment of a raw frontmatter
+// value, or "". A # inside a quoted scalar is part of the value, so the
+// search starts after the closing quote.
+func inlineComment(raw string) string {
+	rest := raw
+	if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+		end := closingQuote(raw)
+		if end < 0 {
+			return ""
+		}
+		rest = raw[end+1:]
+	}
+	if i := strings.Index(rest, " #"); i >= 0 {
+		return rest[i+1:]
+	}
+	return ""
+}
+
+// closingQuote returns the index of the quote that closes the scalar raw
+// opens, or -1.
+func closingQuote(raw string) int {
+	q := raw[0]
+	for i := 1; i < len(raw); i++ {
+		switch {
+		case q == '"' && raw[i] == '\\':
+			i++
+		case raw[i] == q && q == '\'' && i+1 < len(raw) && raw[i+1] == '\'':
+			i++ // '' is an escaped quote
+		case raw[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+// EditFrontmatter applies updates to raw frontmatter lines, touching only the
+// lines of changed keys. A key's continuation lines (e.g. a YAML list) belong
+// to it and are replaced along with it. Empty values delete the key. New keys
+// are added after the existing top-level keys: status, labels, project first,
+// then sorted.
+func EditFrontmatter(front []string, updates map[string]string, delim string) []string {
+	indent := commonIndent(front)
+	front = dedent(front, indent)
+	var out []string
+	done := make(map[string]bool)
+	skipping := false
+	tableAt := -1 // TOML: index in out of the first [table] header
+	for _, line := range front {
+		if skipping && isContinuation(line, delim) {
+			continue // continuation of a replaced key
+		}
+		skipping = false
+		if delim == TOMLDelim && tableAt < 0 && strings.HasPrefix(strings.TrimSpace(line), "[") {
+			tableAt = len(out)
+		}
+		k := frontmatterKey(line, delim)
+		v, update := updates[k]
+		if k == "" || !update || tableAt >= 0 {
+			out = append(out, line)
+			continue
+		}
+		skipping = true
+		if done[k] || v == "" {
+			continue // duplicate key or deletion
+		}
+		done[k] = true
+		old := frontmatterValue(line, delim)
+		if k == "labels" && delim != TOMLDelim {
+			out = append(out, formatYAMLLabels(v, yamlLabelStyle(old)))
+			continue
+		}
+		// Keep a trailing inline comment on the replaced line.
+		comment := ""
+		if c := inlineComment(old); c != "" {
+			comment = " " + c
+		}
+		out = append(out, formatFrontmatterLine(k, v, delim)+comment)
+	}
+	var added []string
+	for k, v := range updates {
+		if v != "" && !done[k] {
+			added = append(added, k)
+		}
+	}
+	order := map[string]int{"status": 0, "labels": 1, "project": 2}
+	sort.Slice(added, func(i, j int) bool {
+		oi, iok := order[added[i]]
+		oj, jok := order[added[j]]
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok != jok:
+			return iok
+		}
+		return added[i] < added[j]
+	})
+	var lines []string
+	for _, k := range added {
+		lines = append(lines, formatFrontmatterLine(k, updates[k], delim))
+	}
+	if tableAt >= 0 {
+		out = append(out[:tableAt], append(lines, out[tableAt:]...)...)
+	} else {
+		out = append(out, lines...)
+	}
+	if indent != "" {
+		for i, line := range out {
+			if strings.TrimSpace(line) != "" {
+				out[i] = indent + strings.ReplaceAll(line, "\n", "\n"+indent)
+			}
+		}
+	}
+	return out
+}
+
+// JoinFrontmatter reassembles a file from frontmatter lines and body. The
+// frontmatter block is omitted if it has no non-blank lines. delim defaults to
+// YAML for files that had no frontmatter.
+func JoinFrontmatter(front []string, body, delim string) string {
+	if delim == "" {
+		delim = YAMLDelim
+	}
+	for _, line := range front {
+		if strings.TrimSpace(line) != "" {
+			return delim + "\n" + strings.Join(front, "\n") + "\n" + delim + "\n" + body
+		}
+	}
+	return body
+}
+
+// ParseLabels splits a comma-separated labels string, normalizes to lowercase,
+// and returns them sorted alphabetically.
+func ParseLabels(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	var labels []string
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		p = strings.ToLower(p)
+		if p != "" {
+			labels = append(labels, p)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// FormatLabels joins labels with ", " for frontmatter serialization.
+func FormatLabels(labels []string) string {
+	return strings.Join(labels, ", ")
+}
+
+// timeLayouts are the accepted formats for created:/updated:.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a created:/updated: value. Times without a zone are taken
+// as local.
+func ParseTime(v string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,288 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseTOMLFrontmatter(t *testing.T) {
+	content := "+++\nstatus = \"active\" # triaged\nlabels = [\"api\", 'infra']\ndraft = false\n\n[extra]\nstatus = \"ignored\"\n+++\n# Plan\n"
+	fields, body := ParseFrontmatter(content)
+	if fields["status"] != "active" {
+		t.Errorf("status = %q, want active", fields["status"])
+	}
+	if fields["labels"] != "api, infra" {
+		t.Errorf("labels = %q, want %q", fields["labels"], "api, infra")
+	}
+	if fields["draft"] != "false" {
+		t.Errorf("draft = %q, want false", fields["draft"])
+	}
+	if body != "# Plan\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestSetFrontmatterTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.md")
+	writeFile(t, path, "+++\ntitle = \"Keep me\"\nstatus = \"reviewed\"\ncreated = 2026-01-02\n\n[params]\nx = 1\n+++\n# Plan\n")
+
+	if err := SetFrontmatter(path, map[string]string{"status": "active", "labels": "a, b"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "+++\ntitle = \"Keep me\"\nstatus = \"active\"\ncreated = 2026-01-02\n\nlabels = [\"a\", \"b\"]\n[params]\nx = 1\n+++\n# Plan\n"
+	if string(data) != want {
+		t.Errorf("got:  %q\nwant: %q", data, want)
+	}
+	fields, _ := ParseFrontmatter(string(data))
+	if fields["status"] != "active" || fields["labels"] != "a, b" {
+		t.Errorf("round-trip fields = %v", fields)
+	}
+}
+
+func TestScanTOML(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "toml.md"), "+++\nstatus = \"done\"\nlabels = [\"x\"]\n+++\n# TOML plan\n")
+	plans, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || plans[0].Status != "done" || plans[0].Title != "TOML plan" || !slices.Equal(plans[0].Labels, []string{"x"}) {
+		t.Errorf("plans = %+v", plans)
+	}
+}
+
+func TestParseYAMLListLabels(t *testing.T) {
+	tests := []struct {
+		name, content string
+	}{
+		{"block indented", "---\nlabels:\n  - ui\n  - \"backend\"\nstatus: active\n---\n"},
+		{"block flush", "---\nlabels:\n- ui\n- backend\nstatus: active\n---\n"},
+		{"flow", "---\nlabels: [ui, 'backend']\nstatus: active\n---\n"},
+	}
+	for _, tt := range tests {
+		fields, _ := ParseFrontmatter(tt.content)
+		if fields["labels"] != "ui, backend" || fields["status"] != "active" {
+			t.Errorf("%s: fields = %v", tt.name, fields)
+		}
+	}
+}
+
+func TestParseYAMLValues(t *testing.T) {
+	content := `---
+status: active
+summary: |
+  Move auth to the gateway.
+  Then drop the old middleware.
+goal: >
+  Ship it
+  this sprint
+title: "Auth: move to
+  the gateway"
+github:
+  repo: jakebf/planc
+  pr: 142
+defaults: &d
+  owner: sam
+review:
+  <<: *d
+reviewers:
+  - name: ana
+  - name: li
+branch: ~
+---
+# P
+`
+	fields, body := ParseFrontmatter(content)
+	want := map[string]string{
+		"status":         "active",
+		"summary":        "Move auth to the gateway.\nThen drop the old middleware.",
+		"goal":           "Ship it this sprint",
+		"title":          "Auth: move to the gateway",
+		"github.repo":    "jakebf/planc",
+		"github.pr":      "142",
+		"defaults.owner": "sam",
+		"review.owner":   "sam",
+		"reviewers":      "name: ana, name: li",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("%s = %q, want %q", k, fields[k], v)
+		}
+	}
+	if _, ok := fields["branch"]; ok || len(fields) != len(want) {
+		t.Errorf("fields = %v", fields)
+	}
+	if body != "# P\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestParseInvalidYAMLFallsBack(t *testing.T) {
+	// Not valid YAML (the second ": "), but agents write it.
+	fields, _ := ParseFrontmatter("---\ntitle: Fix: login redirect\nstatus: active\nlabels:\n  - auth\n---\n# P\n")
+	if fields["title"] != "Fix: login redirect" || fields["status"] != "active" || fields["labels"] != "auth" {
+		t.Errorf("fields = %v", fields)
+	}
+}
+
+func TestSetFrontmatterKeepsBlockScalar(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "---\nsummary: |\n  line one\n  line two\nstatus: reviewed\ncreated: 2026-01-02\n---\n# P\n")
+	if err := SetFrontmatter(path, map[string]string{"status": "active"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "---\nsummary: |\n  line one\n  line two\nstatus: active\ncreated: 2026-01-02\n---\n# P\n"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+	if fields, _ := ParseFrontmatter(string(data)); fields["summary"] != "line one\nline two" {
+		t.Errorf("summary = %q", fields["summary"])
+	}
+}
+
+func TestSetFrontmatterKeepsLabelStyle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+
+	writeFile(t, path, "---\nlabels:\n- ui\nstatus: active\ncreated: 2026-01-02\n---\n# P\n")
+	if err := SetFrontmatter(path, map[string]string{"labels": "ui, api"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "---\nlabels:\n  - api\n  - ui\nstatus: active\ncreated: 2026-01-02\n---\n# P\n"; string(data) != want {
+		t.Errorf("block: got %q, want %q", data, want)
+	}
+
+	writeFile(t, path, "---\nlabels: [ui]\ncreated: 2026-01-02\n---\n# P\n")
+	if err := SetFrontmatter(path, map[string]string{"labels": "ui, api"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	if want := "---\nlabels: [api, ui]\ncreated: 2026-01-02\n---\n# P\n"; string(data) != want {
+		t.Errorf("flow: got %q, want %q", data, want)
+	}
+}
+
+func TestLabelsAsListConfig(t *testing.T) {
+	LabelsAsList.Store(true)
+	defer LabelsAsList.Store(false)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "---\ncreated: 2026-01-02\n---\n# P\n")
+	if err := SetFrontmatter(path, map[string]string{"labels": "b, a"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "---\ncreated: 2026-01-02\nlabels:\n  - a\n  - b\n---\n# P\n"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+}
+
+// frontmatterCorpus covers encoding and layout edge cases seen in the wild.
+// Every entry should parse to status=active and labels="a, b".
+var frontmatterCorpus = map[string]string{
+	"plain":            "---\nstatus: active\nlabels: a, b\n---\n# P\n",
+	"bom":              "\ufeff---\nstatus: active\nlabels: a, b\n---\n# P\n",
+	"crlf":             "---\r\nstatus: active\r\nlabels: a, b\r\n---\r\n# P\r\n",
+	"bom+crlf":         "\ufeff---\r\nstatus: active\r\nlabels: a, b\r\n---\r\n# P\r\n",
+	"cr only":          "---\rstatus: active\rlabels: a, b\r---\r# P\r",
+	"delim whitespace": "--- \nstatus: active\nlabels: a, b\n---\t\n# P\n",
+	"tab indented":     "---\n\tstatus: active\n\tlabels: a, b\n---\n# P\n",
+	"space indented":   "---\n  status: active\n  labels:\n    - a\n    - b\n---\n# P\n",
+	"tab after colon":  "---\nstatus:\tactive\nlabels:\ta, b\n---\n# P\n",
+	"quoted":           "---\nstatus: \"active\"\nlabels: 'a, b'\n---\n# P\n",
+	"comments":         "---\n# meta\nstatus: active # triaged\nlabels: a, b\n---\n# P\n",
+	"colon in value":   "---\ntitle: \"Plan: phase 2\"\nstatus: active\nlabels: a, b\n---\n# P\n",
+}
+
+func TestFrontmatterCorpus(t *testing.T) {
+	for name, content := range frontmatterCorpus {
+		fields, body := ParseFrontmatter(content)
+		if fields["status"] != "active" || fields["labels"] != "a, b" {
+			t.Errorf("%s: fields = %v", name, fields)
+		}
+		if body != "# P\n" {
+			t.Errorf("%s: body = %q", name, body)
+		}
+	}
+	if fields, _ := ParseFrontmatter(frontmatterCorpus["colon in value"]); fields["title"] != "Plan: phase 2" {
+		t.Errorf("quoted value with colon = %q", fields["title"])
+	}
+}
+
+func TestSetFrontmatterPreservesEncoding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	for _, name := range []string{"bom", "crlf", "bom+crlf", "tab indented"} {
+		writeFile(t, path, frontmatterCorpus[name])
+		if err := SetFrontmatter(path, map[string]string{"status": "done", "created": "2026-01-02"}); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(path)
+		got := string(data)
+		if strings.HasPrefix(frontmatterCorpus[name], utf8BOM) != strings.HasPrefix(got, utf8BOM) {
+			t.Errorf("%s: BOM not preserved: %q", name, got)
+		}
+		if strings.Contains(frontmatterCorpus[name], "\r\n") && strings.Count(got, "\n") != strings.Count(got, "\r\n") {
+			t.Errorf("%s: CRLF not preserved: %q", name, got)
+		}
+		if name == "tab indented" && !strings.Contains(got, "\n\tstatus: done\n\tlabels: a, b\n\tcreated: 2026-01-02\n") {
+			t.Errorf("%s: indentation not preserved: %q", name, got)
+		}
+		fields, body := ParseFrontmatter(got)
+		if fields["status"] != "done" || fields["labels"] != "a, b" || body != "# P\n" {
+			t.Errorf("%s: round-trip fields = %v body = %q", name, fields, body)
+		}
+	}
+}
+
+// FuzzFrontmatter checks that parsing never panics, that an empty edit leaves
+// frontmatter untouched, and that a status written by EditFrontmatter reads
+// back unchanged.
+func FuzzFrontmatter(f *testing.F) {
+	for _, content := range frontmatterCorpus {
+		f.Add(content)
+	}
+	f.Add("+++\nstatus = \"active\"\n[t]\nx = 1\n+++\n")
+	f.Add("---\n---\n")
+	f.Add("---\nno closing delimiter")
+	f.Fuzz(func(t *testing.T, content string) {
+		ParseFrontmatter(content)
+		front, body, delim := SplitFrontmatter(content)
+		if delim == "" {
+			return
+		}
+		if got := EditFrontmatter(front, nil, delim); strings.Join(got, "\n") != strings.Join(front, "\n") {
+			t.Errorf("empty edit changed frontmatter:\n%q\n%q", front, got)
+		}
+		edited := JoinFrontmatter(EditFrontmatter(front, map[string]string{"status": "active"}, delim), body, delim)
+		if fields, _ := ParseFrontmatter(edited); fields["status"] != "active" {
+			t.Errorf("status not read back from %q: %v", edited, fields)
+		}
+	})
+}
+
+func TestEditFrontmatterKeepsInlineComment(t *testing.T) {
+	tests := []struct {
+		name, line, want string
+	}{
+		{"plain", "title: Old # triaged", "title: New # triaged"},
+		{"double-quoted hash", `title: "Fix #12"`, "title: New"},
+		{"single-quoted hash", "title: 'Fix #12'", "title: New"},
+		{"single-quoted then comment", "title: 'Fix #12' # keep", "title: New # keep"},
+		{"escaped single quote", "title: 'it'' #s' # keep", "title: New # keep"},
+		{"escaped double quote", `title: "say \" #hi" # keep`, "title: New # keep"},
+	}
+	for _, tt := range tests {
+		got := EditFrontmatter([]string{tt.line}, map[string]string{"title": "New"}, YAMLDelim)
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package plans
+
+import (
+	"strings"
+	"time"
+)
+
+// ─── Status History ──────────────────────────────────────────────────────────
+//
+// Status changes are recorded in a history: frontmatter list, oldest first,
+// one "<time> <from> -> <to>" entry per change:
+//
+//	history:
+//	  - 2026-03-01T10:00:00Z new -> active
+//	  - 2026-03-05T16:30:00Z active -> done
+//
+// The unset status is written as "new".
+
+// StatusChange is one entry of a plan's history: list.
+type StatusChange struct {
+	At       time.Time
+	From, To string // "" is new
+}
+
+// String formats c as a history: entry.
+func (c StatusChange) String() string {
+	return c.At.UTC().Format(time.RFC3339) + " " + historyStatus(c.From) + " -> " + historyStatus(c.To)
+}
+
+func historyStatus(s string) string {
+	if s == "" {
+		return "new"
+	}
+	return s
+}
+
+// ParseHistory reads a history: value as ParseFrontmatter flattens it
+// (entries joined by ", "). Entries that don't parse are skipped.
+func ParseHistory(v string) []StatusChange {
+	var out []StatusChange
+	for _, entry := range strings.Split(v, ",") {
+		f := strings.Fields(entry)
+		if len(f) != 4 || f[2] != "->" {
+			continue
+		}
+		at, ok := ParseTime(f[0])
+		if !ok {
+			continue
+		}
+		c := StatusChange{At: at, From: f[1], To: f[3]}
+		if c.From == "new" {
+			c.From = ""
+		}
+		if c.To == "new" {
+			c.To = ""
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// FormatHistory renders changes as a history: value for SetFrontmatter.
+func FormatHistory(changes []StatusChange) string {
+	entries := make([]string, len(changes))
+	for i, c := range changes {
+		entries[i] = c.String()
+	}
+	return strings.Join(entries, ", ")
+}
+
+// StatusUpdates returns the frontmatter updates that set status on a plan
+// with the given fields. If the status changes, an entry stamped at is
+// appended to its history; existing entries, including hand-written ones
+// ParseHistory skips, are kept as they are.
+func StatusUpdates(fields map[string]string, status string, at time.Time) map[string]string {
+	updates := map[string]string{"status": status}
+	if fields["status"] == status {
+		return updates
+	}
+	entry := StatusChange{At: at, From: fields["status"], To: status}.String()
+	if history := fields["history"]; history != "" {
+		entry = history + ", " + entry
+	}
+	updates["history"] = entry
+	return updates
+}
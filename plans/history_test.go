@@ -0,0 +1,78 @@
+package plans
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetStatusRecordsHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "---\nstatus: reviewed\ncreated: 2026-01-02\n---\n# P\n")
+	for _, status := range []string{"active", "active", "done"} {
+		if err := SetStatus(path, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+	content := readFile(t, path)
+	fm, _ := ParseFrontmatter(content)
+	history := ParseHistory(fm["history"])
+	if len(history) != 2 {
+		t.Fatalf("history = %+v\n%s", history, content)
+	}
+	if history[0].From != "reviewed" || history[0].To != "active" || history[1].From != "active" || history[1].To != "done" {
+		t.Errorf("history = %+v", history)
+	}
+	if !strings.Contains(content, "history:\n  - ") || !strings.Contains(content, " active -> done\n") {
+		t.Errorf("history should be a block list:\n%s", content)
+	}
+}
+
+func TestStatusUpdates(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	got := StatusUpdates(map[string]string{}, "active", at)
+	if got["status"] != "active" || got["history"] != "2026-03-01T10:00:00Z new -> active" {
+		t.Errorf("updates = %v", got)
+	}
+	if got := StatusUpdates(map[string]string{"status": "active"}, "active", at); len(got) != 1 {
+		t.Errorf("unchanged status should not touch history: %v", got)
+	}
+	// Malformed entries are dropped; "new" reads back as "".
+	h := ParseHistory("garbage, 2026-03-01T10:00:00Z active -> new")
+	if len(h) != 1 || h[0].From != "active" || h[0].To != "" || !h[0].At.Equal(at) {
+		t.Errorf("ParseHistory = %+v", h)
+	}
+}
+
+func TestSetStatusKeepsUnparsedHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "---\nstatus: reviewed\nhistory:\n  - kicked off at standup\n  - \"note: paired with sam\"\n  - 2026-03-01T10:00:00Z new -> reviewed\n---\n# P\n")
+	if err := SetStatus(path, "active"); err != nil {
+		t.Fatal(err)
+	}
+	content := readFile(t, path)
+	want := "history:\n  - kicked off at standup\n  - \"note: paired with sam\"\n  - 2026-03-01T10:00:00Z new -> reviewed\n  - "
+	if !strings.Contains(content, want) || !strings.Contains(content, " reviewed -> active\n") {
+		t.Errorf("existing entries should be kept and the change appended:\n%s", content)
+	}
+	fm, _ := ParseFrontmatter(content)
+	if h := ParseHistory(fm["history"]); len(h) != 2 || h[1].To != "active" {
+		t.Errorf("history = %+v", h)
+	}
+}
+
+func TestSetStatusHistoryTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "+++\nstatus = \"active\"\ncreated = 2026-01-02\n+++\n# P\n")
+	if err := SetStatus(path, "done"); err != nil {
+		t.Fatal(err)
+	}
+	fm, _ := ParseFrontmatter(readFile(t, path))
+	if h := ParseHistory(fm["history"]); len(h) != 1 || h[0].To != "done" {
+		t.Errorf("history = %q", fm["history"])
+	}
+}
@@ -0,0 +1,59 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ─── Notes ───────────────────────────────────────────────────────────────────
+
+// NotesDir is where private notes live, relative to a plan's directory. Notes
+// are kept out of the plan file so they never reach an agent that reads it,
+// and scanning skips hidden directories (see SubDirs), so they never list as
+// plans.
+const NotesDir = ".planc/notes"
+
+// NotesPath returns the sidecar notes file for the plan at path.
+func NotesPath(path string) string {
+	return filepath.Join(filepath.Dir(path), NotesDir, filepath.Base(path))
+}
+
+// ReadNotes returns the private notes for the plan at path, or "" if it has
+// none.
+func ReadNotes(path string) (string, error) {
+	data, err := os.ReadFile(NotesPath(path))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// WriteNotes replaces the private notes for the plan at path. Blank notes
+// remove the sidecar file.
+func WriteNotes(path, notes string) error {
+	file := NotesPath(path)
+	if strings.TrimSpace(notes) == "" {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(strings.TrimRight(notes, "\n")+"\n"), 0644)
+}
+
+// moveNotes carries a plan's notes along when it is renamed or moved. A plan
+// without notes is not an error.
+func moveNotes(src, dst string) error {
+	from, to := NotesPath(src), NotesPath(dst)
+	if _, err := os.Stat(from); err != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	return moveFile(from, to, false)
+}
@@ -0,0 +1,50 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNotesFollowThePlan(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "a.md")
+	writeFile(t, src, "# A\n")
+
+	if notes, err := ReadNotes(src); err != nil || notes != "" {
+		t.Fatalf("ReadNotes without notes = %q, %v", notes, err)
+	}
+	if err := WriteNotes(src, "ask Sam about quotas"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(dir, NotesDir, "a.md")); got != "ask Sam about quotas\n" {
+		t.Errorf("sidecar = %q", got)
+	}
+	if got := readFile(t, src); got != "# A\n" {
+		t.Errorf("plan body changed: %q", got)
+	}
+
+	renamed, err := Rename(src, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := Archive(renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notes, _ := ReadNotes(archived); notes != "ask Sam about quotas\n" {
+		t.Errorf("notes after rename and archive = %q", notes)
+	}
+
+	if err := WriteNotes(archived, "  \n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(NotesPath(archived)); !os.IsNotExist(err) {
+		t.Errorf("blank notes should remove the sidecar: %v", err)
+	}
+
+	plans, err := Scan(dir)
+	if err != nil || len(plans) != 0 {
+		t.Errorf("notes must not scan as plans: %+v, %v", plans, err)
+	}
+}
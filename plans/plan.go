@@ -0,0 +1,347 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ─── Plan ────────────────────────────────────────────────────────────────────
+
+// Plan is the metadata of one plan file, as read by [Read] and [Scan].
+type Plan struct {
+	Dir          string            // directory containing the plan file
+	File         string            // base filename
+	Fields       map[string]string // all frontmatter fields, flattened to strings
+	Status       string            // frontmatter status:, or "" (unset); legacy "pending" reads as "reviewed"
+	Project      string            // frontmatter project:, or "" (deprecated; use Labels)
+	Labels       []string          // frontmatter labels:, or migrated from Project
+	Title        string            // frontmatter title:, else first # heading, else the filename
+	Untitled     bool              // Title fell back to the filename
+	Created      time.Time         // frontmatter created: (or updated:), else file birth time
+	Modified     time.Time         // file modification time
+	Size         int               // file size in bytes
+	Tokens       int               // approximate LLM token count of the whole file (see EstimateTokens)
+	Words        int               // words in the body (see CountWords)
+	Comments     int               // comment blockquotes in the body
+	OpenComments int               // comments not yet marked [resolved]
+	Tasks        int               // task items ("- [ ]" and "- [x]") in the body
+	TasksDone    int               // checked task items
+}
+
+// Path returns the plan's full file path.
+func (p Plan) Path() string {
+	return filepath.Join(p.Dir, p.File)
+}
+
+// EstimateTokens approximates how many LLM tokens text will consume. It uses
+// the common ~4 characters per token rule, floored by a word-based estimate
+// so dense prose with short words isn't undercounted.
+func EstimateTokens(text string) int {
+	byChars := (utf8.RuneCountInString(text) + 3) / 4
+	byWords := len(strings.Fields(text)) * 4 / 3
+	return max(byChars, byWords)
+}
+
+// CountWords counts the words in body text: whitespace-separated runs with
+// at least one letter or digit, so markdown syntax such as "#", "-", "|",
+// and "```" isn't counted.
+func CountWords(body string) int {
+	n := 0
+	for _, f := range strings.Fields(body) {
+		if strings.IndexFunc(f, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Heading returns the text of the first # heading in body text.
+func Heading(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(line[2:])
+		}
+	}
+	return ""
+}
+
+// Title picks a plan's display title: the frontmatter title: override, else
+// the first # heading, else the filename. untitled reports the last case. A
+// blank title: falls through to the heading.
+func Title(fm map[string]string, body, file string) (title string, untitled bool) {
+	if t := strings.TrimSpace(fm["title"]); t != "" {
+		return t, false
+	}
+	if t := Heading(body); t != "" {
+		return t, false
+	}
+	return strings.TrimSuffix(file, filepath.Ext(file)), true
+}
+
+// Created returns a plan's creation time. Frontmatter created: (then
+// updated:) wins over the filesystem birth time, which resets when files are
+// copied, cloned, or synced and is unavailable on many Linux filesystems.
+func Created(fm map[string]string, path string, modTime time.Time) time.Time {
+	for _, k := range []string{"created", "updated"} {
+		if t, ok := ParseTime(fm[k]); ok {
+			return t
+		}
+	}
+	return fileCreatedTime(path, modTime)
+}
+
+// Read parses the plan file at path. It also returns the body (everything
+// after the frontmatter) for callers that derive more from it.
+func Read(path string) (Plan, string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Plan{}, "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Plan{}, "", err
+	}
+	fm, body := ParseFrontmatter(string(data))
+	file := filepath.Base(path)
+	title, untitled := Title(fm, body, file)
+	labels := ParseLabels(fm["labels"])
+	project := fm["project"]
+	// Backward compat: migrate project → labels
+	if len(labels) == 0 && project != "" {
+		labels = []string{project}
+	}
+	// Backward compat: migrate pending → reviewed
+	status := fm["status"]
+	if status == "pending" {
+		status = "reviewed"
+	}
+	comments, open := CountComments(body)
+	tasks, tasksDone := CountTasks(body)
+	return Plan{
+		Dir:          filepath.Dir(path),
+		File:         file,
+		Fields:       fm,
+		Status:       status,
+		Project:      project,
+		Labels:       labels,
+		Title:        title,
+		Untitled:     untitled,
+		Created:      Created(fm, path, info.ModTime()),
+		Modified:     info.ModTime(),
+		Size:         len(data),
+		Tokens:       EstimateTokens(string(data)),
+		Words:        CountWords(body),
+		Comments:     comments,
+		OpenComments: open,
+		Tasks:        tasks,
+		TasksDone:    tasksDone,
+	}, body, nil
+}
+
+// DefaultFilePatterns are the plan file patterns used when none are given.
+var DefaultFilePatterns = []string{"*.md"}
+
+// MatchFile reports whether the base name of path is a plan file under
+// patterns: globs such as "*.plan.md" or "PLAN-*.md", or bare extensions
+// such as ".markdown". Empty patterns mean [DefaultFilePatterns].
+func MatchFile(path string, patterns []string) bool {
+	if len(patterns) == 0 {
+		patterns = DefaultFilePatterns
+	}
+	name := filepath.Base(path)
+	for _, pat := range patterns {
+		if strings.HasPrefix(pat, ".") && !strings.ContainsAny(pat, "*?[") {
+			pat = "*" + pat
+		}
+		if ok, _ := filepath.Match(pat, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan reads every .md file directly in dir, newest first. Unreadable files
+// are skipped; subdirectories (including the archive) are not descended into.
+func Scan(dir string) ([]Plan, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var plans []Plan
+	for _, e := range entries {
+		if e.IsDir() || !MatchFile(e.Name(), nil) {
+			continue
+		}
+		p, _, err := Read(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		plans = append(plans, p)
+	}
+	sortNewestFirst(plans)
+	return plans, nil
+}
+
+// ScanAll scans agentDir and every directory matched by projectGlob (see
+// [ProjectDirs]). Plans are deduplicated by path and sorted newest first. A
+// missing agentDir is not an error.
+func ScanAll(agentDir, projectGlob string) ([]Plan, error) {
+	plans, err := Scan(agentDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, p := range plans {
+		seen[p.Path()] = true
+	}
+	for _, dir := range ProjectDirs(projectGlob) {
+		dirPlans, err := Scan(dir)
+		if err != nil {
+			continue
+		}
+		for _, p := range dirPlans {
+			if !seen[p.Path()] {
+				seen[p.Path()] = true
+				plans = append(plans, p)
+			}
+		}
+	}
+	sortNewestFirst(plans)
+	return plans, nil
+}
+
+func sortNewestFirst(plans []Plan) {
+	sort.SliceStable(plans, func(i, j int) bool {
+		return plans[i].Created.After(plans[j].Created)
+	})
+}
+
+// ─── Project Directories ─────────────────────────────────────────────────────
+
+// skipDirs lists directory names that are typically very large and
+// will never contain user plan files. Skipping them during glob
+// resolution avoids walking hundreds of thousands of entries
+// (e.g. node_modules trees) that make startup unacceptably slow.
+var skipDirs = map[string]bool{
+	"node_modules":  true,
+	".git":          true,
+	".hg":           true,
+	".svn":          true,
+	".venv":         true,
+	"venv":          true,
+	"__pycache__":   true,
+	".cache":        true,
+	".next":         true,
+	".nuxt":         true,
+	".output":       true,
+	".angular":      true,
+	".gradle":       true,
+	".cargo":        true,
+	".npm":          true,
+	".pnpm":         true,
+	".tox":          true,
+	".mypy_cache":   true,
+	".pytest_cache": true,
+	".generated":    true,
+	"target":        true,
+	"dist":          true,
+	"build":         true,
+	"coverage":      true,
+	".turbo":        true,
+	".parcel-cache": true,
+	".docusaurus":   true,
+}
+
+// ExpandHome expands a leading "~/" to the user's home directory.
+func ExpandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return path
+		}
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+// ProjectDirs expands a glob pattern (supporting ** and a leading ~/) and
+// returns matching directories. Uses filepath.WalkDir from the static prefix
+// of the pattern, skipping known heavy directories for performance.
+func ProjectDirs(glob string) []string {
+	if glob == "" {
+		return nil
+	}
+	glob = ExpandHome(glob)
+
+	base := globBase(glob)
+	if _, err := os.Stat(base); err != nil {
+		return nil
+	}
+
+	var dirs []string
+	filepath.WalkDir(base, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != base && skipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		matched, _ := doublestar.PathMatch(glob, path)
+		if matched {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	return dirs
+}
+
+// SubDirs returns the directories below dir, at any depth, that may hold
+// plans, in walk order. Directories in skipDirs, hidden ones (such as the
+// .planc notes sidecar), and the ArchiveDir are left out with everything
+// under them.
+func SubDirs(dir string) []string {
+	var dirs []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		if !d.IsDir() || path == dir {
+			return nil
+		}
+		if name := d.Name(); skipDirs[name] || strings.HasPrefix(name, ".") || name == ArchiveDir {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	return dirs
+}
+
+// globBase returns the longest directory prefix of a glob pattern
+// that contains no wildcard characters (* ? [ {).
+func globBase(pattern string) string {
+	for i, c := range pattern {
+		if c == '*' || c == '?' || c == '[' || c == '{' {
+			dir := pattern[:i]
+			if j := strings.LastIndex(dir, string(filepath.Separator)); j >= 0 {
+				return pattern[:j]
+			}
+			return "."
+		}
+	}
+	return pattern
+}
@@ -0,0 +1,186 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile is a test helper that writes content to a file and fails the test on error.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writeFile(%s): %v", path, err)
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	writeFile(t, path, "---\nstatus: pending\nproject: api\ncreated: 2026-01-02\nowner: sam\n---\n# Auth\n\n> **[comment]:** why?\n\n> **[resolved]:** done\n")
+
+	p, body, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Dir != dir || p.File != "auth.md" || p.Path() != path {
+		t.Errorf("location = %q, %q", p.Dir, p.File)
+	}
+	if p.Status != "reviewed" {
+		t.Errorf("Status = %q, want pending migrated to reviewed", p.Status)
+	}
+	if !slices.Equal(p.Labels, []string{"api"}) {
+		t.Errorf("Labels = %v, want project migrated to [api]", p.Labels)
+	}
+	if p.Title != "Auth" || p.Untitled {
+		t.Errorf("Title = %q, untitled = %v", p.Title, p.Untitled)
+	}
+	if p.Fields["owner"] != "sam" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if want := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local); !p.Created.Equal(want) {
+		t.Errorf("Created = %v, want %v", p.Created, want)
+	}
+	if p.Comments != 2 || p.OpenComments != 1 {
+		t.Errorf("comments = %d/%d open, want 2/1", p.Comments, p.OpenComments)
+	}
+	if body[:6] != "# Auth" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestCountTasks(t *testing.T) {
+	body := "# Plan\n\n- [x] Design\n- [X] Build\n  * [ ] Nested\n+ [ ] Ship\n- [ ]\n- [] not a task\n\n```\n- [ ] in a fence\n```\n"
+	if total, done := CountTasks(body); total != 4 || done != 2 {
+		t.Errorf("CountTasks = %d/%d done, want 4/2", total, done)
+	}
+	checked := SetTaskDone(body, 4, true)
+	if lines := strings.Split(checked, "\n"); lines[4] != "  * [x] Nested" {
+		t.Errorf("checked line = %q", lines[4])
+	}
+	if SetTaskDone(checked, 2, false) != strings.Replace(checked, "[x] Design", "[ ] Design", 1) {
+		t.Error("unchecking changed more than the box")
+	}
+	if SetTaskDone(body, 0, true) != body {
+		t.Error("a heading line was changed")
+	}
+}
+
+func TestScanAllDeduplicatesAndSkipsArchive(t *testing.T) {
+	base := t.TempDir()
+	agent := filepath.Join(base, "agent")
+	proj := filepath.Join(base, "proj", "plans")
+	for _, d := range []string{agent, proj, filepath.Join(agent, ArchiveDir)} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(agent, "old.md"), "---\ncreated: 2026-01-01\n---\n# Old\n")
+	writeFile(t, filepath.Join(agent, ArchiveDir, "gone.md"), "# Gone\n")
+	writeFile(t, filepath.Join(proj, "new.md"), "---\ncreated: 2026-02-01\n---\n# New\n")
+
+	// The glob also matches the agent dir, which must not be listed twice, and
+	// Scan doesn't descend into the agent dir's archive.
+	plans, err := ScanAll(agent, filepath.Join(base, "{agent,proj/plans}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for _, p := range plans {
+		files = append(files, p.File)
+	}
+	if !slices.Equal(files, []string{"new.md", "old.md"}) {
+		t.Errorf("files = %v, want [new.md old.md]", files)
+	}
+
+	if _, err := ScanAll(filepath.Join(base, "missing"), ""); err != nil {
+		t.Errorf("missing agent dir: %v", err)
+	}
+}
+
+func TestGlobBase(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"/home/jake/code/**/plans", "/home/jake/code"},
+		{"/home/jake/code/*/plans", "/home/jake/code"},
+		{"/home/jake/code/plans", "/home/jake/code/plans"},
+		{"**/plans", "."},
+		{"/a/b/c", "/a/b/c"},
+		{"/a/b[0-9]/c", "/a"},
+	}
+	for _, tt := range tests {
+		got := globBase(tt.pattern)
+		if got != tt.want {
+			t.Errorf("globBase(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestSubDirs(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"api/v2", "web", "node_modules/x", ".planc", ArchiveDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(dir, "web", "notes.md"), "# Notes\n")
+	var got []string
+	for _, d := range SubDirs(dir) {
+		rel, _ := filepath.Rel(dir, d)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	if want := []string{"api", "api/v2", "web"}; !slices.Equal(got, want) {
+		t.Errorf("SubDirs = %v, want %v", got, want)
+	}
+}
+
+func TestMatchFile(t *testing.T) {
+	patterns := []string{"*.plan.md", ".markdown", "PLAN-*.md"}
+	for name, want := range map[string]bool{
+		"/x/auth.plan.md": true,
+		"notes.markdown":  true,
+		"PLAN-auth.md":    true,
+		"README.md":       false,
+		"auth.plan.txt":   false,
+	} {
+		if got := MatchFile(name, patterns); got != want {
+			t.Errorf("MatchFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if !MatchFile("README.md", nil) || MatchFile("notes.txt", nil) {
+		t.Error("nil patterns should match *.md")
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	body := "# Auth plan\n\n- [ ] Add the token check\n\n| a | b |\n|---|---|\n\n```go\nx := 1\n```\n"
+	// Auth plan Add the token check a b go x 1
+	if got := CountWords(body); got != 11 {
+		t.Errorf("CountWords = %d, want 11", got)
+	}
+}
+
+func TestTitle(t *testing.T) {
+	body := "# Implementation Plan\n\nSteps.\n"
+	for _, tt := range []struct {
+		fm       map[string]string
+		body     string
+		want     string
+		untitled bool
+	}{
+		{map[string]string{"title": "Rate limits"}, body, "Rate limits", false},
+		{map[string]string{"title": "  "}, body, "Implementation Plan", false},
+		{nil, body, "Implementation Plan", false},
+		{nil, "Steps.\n", "rate-limits", true},
+	} {
+		got, untitled := Title(tt.fm, tt.body, "rate-limits.md")
+		if got != tt.want || untitled != tt.untitled {
+			t.Errorf("Title(%v) = %q, %v; want %q, %v", tt.fm, got, untitled, tt.want, tt.untitled)
+		}
+	}
+}
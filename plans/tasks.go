@@ -0,0 +1,55 @@
+package plans
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ─── Tasks ───────────────────────────────────────────────────────────────────
+
+// TaskPattern matches a markdown task item ("- [ ] text" or "- [x] text").
+// The first group is the box's content: a space while the task is open.
+var TaskPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+\S`)
+
+// CountTasks returns how many task items the body has and how many of them
+// are checked. Fenced code is skipped.
+func CountTasks(body string) (total, done int) {
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := TaskPattern.FindStringSubmatch(line); m != nil {
+			total++
+			if m[1] != " " {
+				done++
+			}
+		}
+	}
+	return total, done
+}
+
+// SetTaskDone checks or unchecks the task item on the given body line,
+// keeping its bullet, indentation, and text. Other lines are returned
+// unchanged.
+func SetTaskDone(rawBody string, taskLine int, done bool) string {
+	lines := strings.Split(rawBody, "\n")
+	if taskLine < 0 || taskLine >= len(lines) {
+		return rawBody
+	}
+	loc := TaskPattern.FindStringSubmatchIndex(lines[taskLine])
+	if loc == nil {
+		return rawBody
+	}
+	box := " "
+	if done {
+		box = "x"
+	}
+	line := lines[taskLine]
+	lines[taskLine] = line[:loc[2]] + box + line[loc[3]:]
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,119 @@
+package plans
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─── Trash ───────────────────────────────────────────────────────────────────
+
+// TrashDir is where deleted plans go, relative to the plan's directory. It is
+// hidden, so scans and SubDirs never list trashed plans.
+const TrashDir = ".planc-trash"
+
+// trashStamp prefixes trashed file names with the time they were deleted, so
+// plans deleted under the same name don't collide. Plans of the same name
+// deleted within the same second get a counter after the stamp:
+// 20260304-100000.2-auth.md.
+const trashStamp = "20060102-150405"
+
+// TrashedPlan is a plan file in a TrashDir.
+type TrashedPlan struct {
+	Path    string    // the file in the trash
+	Dir     string    // the directory it was deleted from
+	File    string    // its file name before it was deleted
+	Deleted time.Time // when it was deleted
+	seq     int       // counter among plans of the same name deleted within a second
+}
+
+// Trash moves the plan at path into the TrashDir next to it, with its private
+// notes, and returns the trashed file's path.
+func Trash(path string, now time.Time) (string, error) {
+	trash, file := filepath.Join(filepath.Dir(path), TrashDir), filepath.Base(path)
+	stamp := now.Format(trashStamp)
+	dst := filepath.Join(trash, stamp+"-"+file)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(dst); err != nil {
+			break
+		}
+		dst = filepath.Join(trash, fmt.Sprintf("%s.%d-%s", stamp, n, file))
+	}
+	if err := relocate(path, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// Trashed lists the plans in dir's TrashDir, most recently deleted first. A
+// directory without a trash has none.
+func Trashed(dir string) ([]TrashedPlan, error) {
+	trash := filepath.Join(dir, TrashDir)
+	entries, err := os.ReadDir(trash)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []TrashedPlan
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || len(name) <= len(trashStamp)+1 {
+			continue
+		}
+		deleted, err := time.ParseInLocation(trashStamp, name[:len(trashStamp)], time.Local)
+		if err != nil {
+			continue
+		}
+		rest, seq := name[len(trashStamp):], 1
+		if counter, ok := strings.CutPrefix(rest, "."); ok {
+			i := strings.IndexByte(counter, '-')
+			if seq, err = strconv.Atoi(counter[:max(i, 0)]); err != nil {
+				continue
+			}
+			rest = counter[i:]
+		}
+		if len(rest) < 2 || rest[0] != '-' {
+			continue
+		}
+		out = append(out, TrashedPlan{
+			Path:    filepath.Join(trash, name),
+			Dir:     dir,
+			File:    rest[1:],
+			Deleted: deleted,
+			seq:     seq,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b TrashedPlan) int {
+		return cmp.Or(b.Deleted.Compare(a.Deleted), cmp.Compare(b.seq, a.seq))
+	})
+	return out, nil
+}
+
+// Restore moves a trashed plan, with its notes, back to the directory it was
+// deleted from under its old name, refusing to overwrite a plan that has
+// taken the name since. It returns the restored path.
+func Restore(t TrashedPlan) (string, error) {
+	dst := filepath.Join(t.Dir, t.File)
+	if err := relocate(t.Path, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// Purge permanently deletes a trashed plan and its notes.
+func Purge(t TrashedPlan) error {
+	if err := Delete(t.Path); err != nil {
+		return err
+	}
+	if err := os.Remove(NotesPath(t.Path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package plans
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTrashRestorePurge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	writeFile(t, path, "# Auth\n")
+	if err := WriteNotes(path, "keep this"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	trashed, err := Trash(path, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, TrashDir, "20260304-100000-auth.md"); trashed != want {
+		t.Errorf("trashed to %s, want %s", trashed, want)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("plan still in place")
+	}
+	if plans, _ := Scan(dir); len(plans) != 0 {
+		t.Errorf("scan should skip the trash, got %v", plans)
+	}
+
+	items, err := Trashed(dir)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Trashed = %v, %v", items, err)
+	}
+	if it := items[0]; it.File != "auth.md" || it.Dir != dir || !it.Deleted.Equal(now) {
+		t.Errorf("item = %+v", it)
+	}
+
+	writeFile(t, path, "# New auth\n")
+	if _, err := Restore(items[0]); err == nil {
+		t.Error("restore should refuse to overwrite a plan that took the name")
+	}
+	os.Remove(path)
+	restored, err := Restore(items[0])
+	if err != nil || restored != path || readFile(t, path) != "# Auth\n" {
+		t.Fatalf("Restore = %s, %v", restored, err)
+	}
+	if notes, _ := ReadNotes(path); notes != "keep this\n" {
+		t.Errorf("notes not restored: %q", notes)
+	}
+
+	if _, err := Trash(path, now); err != nil {
+		t.Fatal(err)
+	}
+	items, _ = Trashed(dir)
+	if err := Purge(items[0]); err != nil {
+		t.Fatal(err)
+	}
+	if items, _ := Trashed(dir); len(items) != 0 {
+		t.Errorf("purged plan still listed: %v", items)
+	}
+	if _, err := os.Stat(NotesPath(items[0].Path)); !os.IsNotExist(err) {
+		t.Error("purge should remove the plan's notes")
+	}
+}
+
+func TestTrashSameNameWithinASecond(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	for _, body := range []string{"# First\n", "# Second\n", "# Third\n"} {
+		writeFile(t, path, body)
+		if _, err := Trash(path, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := Trashed(dir)
+	if err != nil || len(items) != 3 {
+		t.Fatalf("Trashed = %v, %v", items, err)
+	}
+	for i, want := range []string{"# Third\n", "# Second\n", "# First\n"} {
+		if it := items[i]; it.File != "auth.md" || !it.Deleted.Equal(now) || readFile(t, it.Path) != want {
+			t.Errorf("item %d = %+v, want the plan with %q", i, it, want)
+		}
+	}
+	if filepath.Base(items[0].Path) != "20260304-100000.3-auth.md" {
+		t.Errorf("third copy trashed as %s", items[0].Path)
+	}
+}
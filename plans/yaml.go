@@ -0,0 +1,92 @@
+package plans
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ─── YAML ────────────────────────────────────────────────────────────────────
+//
+// YAML frontmatter is read with a real YAML parser so block sequences,
+// quoted and multi-line strings, block scalars (| and >), anchors, and nested
+// mappings all come through. Agents don't always write valid YAML, though
+// (an unquoted "title: Fix: login" is an error), so frontmatter the parser
+// rejects falls back to the lenient line-based reader. Writes don't go
+// through here: EditFrontmatter still rewrites only the lines of changed keys.
+
+// parseYAML flattens the top-level mapping in front to strings: scalars as
+// their value, sequences as "a, b", and nested mappings as dotted keys
+// ("github.pr"). ok is false if front isn't a valid YAML mapping.
+func parseYAML(front []string) (fields map[string]string, ok bool) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(front, "\n")), &doc); err != nil {
+		return nil, false
+	}
+	fields = make(map[string]string)
+	if len(doc.Content) == 0 {
+		return fields, true // empty, or only comments
+	}
+	root := yamlResolve(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	flattenYAML(fields, "", root)
+	return fields, true
+}
+
+// flattenYAML adds the pairs of mapping n to fields, with keys under prefix.
+// Empty values are dropped, as in the line-based reader.
+func flattenYAML(fields map[string]string, prefix string, n *yaml.Node) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], yamlResolve(n.Content[i+1])
+		switch {
+		case k.Tag == "!!merge" && v.Kind == yaml.MappingNode: // <<: *defaults
+			flattenYAML(fields, prefix, v)
+		case v.Kind == yaml.MappingNode:
+			flattenYAML(fields, prefix+k.Value+".", v)
+		default:
+			if s := yamlString(v); s != "" {
+				fields[prefix+k.Value] = s
+			}
+		}
+	}
+}
+
+// yamlString flattens a value: sequences become "a, b" and mappings inside
+// them "k: v, k2: v2". Nulls are empty.
+func yamlString(n *yaml.Node) string {
+	n = yamlResolve(n)
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Tag == "!!null" {
+			return ""
+		}
+		return strings.TrimSpace(n.Value)
+	case yaml.SequenceNode:
+		var items []string
+		for _, c := range n.Content {
+			if s := yamlString(c); s != "" {
+				items = append(items, s)
+			}
+		}
+		return strings.Join(items, ", ")
+	case yaml.MappingNode:
+		var pairs []string
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if s := yamlString(n.Content[i+1]); s != "" {
+				pairs = append(pairs, n.Content[i].Value+": "+s)
+			}
+		}
+		return strings.Join(pairs, ", ")
+	}
+	return ""
+}
+
+// yamlResolve follows aliases (*name) to the node they refer to.
+func yamlResolve(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
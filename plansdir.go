@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Plans Directory Health ──────────────────────────────────────────────────
+//
+// The agent plans directory can disappear mid-session: a network drive is
+// unmounted, an external disk ejected, the folder deleted. Every read and
+// write then fails, so instead of surfacing each failure planc stats the
+// directory after the first error or watcher removal event, shows a
+// persistent banner while it is missing, and keeps the last-known list on
+// screen. It checks again every few seconds (r checks now); once the
+// directory is back the list is rescanned and the directory re-watched.
+
+const plansDirRetryInterval = 5 * time.Second
+
+type plansDirState struct {
+	err     error // why the directory is unavailable; nil when it's fine
+	retryID int   // invalidates pending retry ticks
+}
+
+// statPlansDir returns an error if dir is missing or not a directory.
+func statPlansDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+func checkPlansDir(dir string) tea.Cmd {
+	return func() tea.Msg {
+		return plansDirMsg{err: statPlansDir(dir)}
+	}
+}
+
+// setPlansDirErr records the result of a directory check. While the
+// directory is unavailable it schedules the next check; when it comes back
+// it re-watches the directory and reloads the plans.
+func (m *model) setPlansDirErr(err error) tea.Cmd {
+	if err != nil {
+		if m.plansDir.err == nil {
+			logger.Error("plans dir unavailable", "dir", m.dir, "err", err)
+		}
+		m.plansDir.err = err
+		m.plansDir.retryID++
+		id := m.plansDir.retryID
+		return tea.Tick(plansDirRetryInterval, func(time.Time) tea.Msg {
+			return plansDirRetryMsg{id: id}
+		})
+	}
+	if m.plansDir.err == nil {
+		return nil
+	}
+	m.plansDir.err = nil
+	logger.Info("plans dir available", "dir", m.dir)
+	if m.watcher != nil {
+		if err := m.watcher.Add(m.dir); err != nil {
+			logger.Error("watch failed", "dir", m.dir, "err", err)
+		}
+	}
+	cmds := []tea.Cmd{m.setNotification(tr("Plans directory is available again"), statusTimeout)}
+	if !m.demo.active {
+		dir, glob := m.dir, m.cfg.ProjectPlanGlob
+		cmds = append(cmds, func() tea.Msg { return reloadAllPlans(dir, glob) })
+	}
+	return tea.Batch(cmds...)
+}
+
+// plansDirBanner is the footer text shown while the directory is unavailable.
+func (m model) plansDirBanner() string {
+	reason := m.plansDir.err
+	var pathErr *fs.PathError
+	if errors.As(reason, &pathErr) {
+		reason = pathErr.Err
+	}
+	return fmt.Sprintf("⚠ Plans directory unavailable: %s (%v) · r retry", contractHome(m.dir), reason)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPlansDirUnavailableShowsBannerAndRecovers(t *testing.T) {
+	m := testModel()
+	m.dir = filepath.Join(t.TempDir(), "plans")
+	before := len(m.list.Items())
+
+	// The first error triggers a check of the directory.
+	m2, cmd := m.Update(errMsg{errors.New("open x.md: no such file or directory")})
+	m = m2.(model)
+	if cmd == nil {
+		t.Fatal("errMsg should check the plans directory")
+	}
+	m2, _ = m.Update(checkPlansDir(m.dir)())
+	m = m2.(model)
+	if m.plansDir.err == nil {
+		t.Fatal("missing directory should be recorded")
+	}
+	if view := m.View(); !strings.Contains(view, "Plans directory unavailable") {
+		t.Error("banner should be shown while the directory is missing")
+	}
+
+	// Further errors are absorbed by the banner, and the list is kept.
+	m.notification = ""
+	m2, cmd = m.Update(errMsg{errors.New("again")})
+	m = m2.(model)
+	if cmd != nil || m.notification != "" {
+		t.Error("errors while the directory is missing should not notify")
+	}
+	m2, _ = m.Update(fileChangedMsg{})
+	m = m2.(model)
+	if len(m.list.Items()) != before {
+		t.Errorf("list changed from %d to %d items while directory missing", before, len(m.list.Items()))
+	}
+
+	// A stale retry tick is ignored; r checks immediately.
+	if _, cmd = m.Update(plansDirRetryMsg{id: m.plansDir.retryID - 1}); cmd != nil {
+		t.Error("stale retry should be ignored")
+	}
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	m2, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = m2.(model)
+	if cmd == nil {
+		t.Fatal("r should check the directory")
+	}
+	m2, cmd = m.Update(cmd())
+	m = m2.(model)
+	if m.plansDir.err != nil || cmd == nil {
+		t.Error("directory should be available again and plans reloaded")
+	}
+	if view := m.View(); strings.Contains(view, "Plans directory unavailable") {
+		t.Error("banner should clear once the directory is back")
+	}
+}
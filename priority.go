@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Priority ────────────────────────────────────────────────────────────────
+//
+// The priority: frontmatter key is high, medium, or low. The list shows it as
+// a colored badge, the "priority" sort key orders plans by it (see
+// comparePlans), and p sets it from a modal like the status modal.
+
+// priorityOptions are the choices in the priority modal, in rank order.
+var priorityOptions = []struct {
+	key      string
+	priority string
+}{
+	{"1", "high"},
+	{"2", "medium"},
+	{"3", "low"},
+	{"0", ""},
+}
+
+// priorityRank orders priorities for sorting. Plans without a priority sort
+// with medium ones.
+var priorityRank = map[string]int{"high": 0, "medium": 1, "": 1, "low": 2}
+
+// parsePriority returns the priority a frontmatter value names, or "" if it
+// isn't one.
+func parsePriority(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if _, ok := priorityRank[s]; !ok {
+		return ""
+	}
+	return s
+}
+
+// priorityBadge renders p's priority for the list row, or "".
+func priorityBadge(p plan) string {
+	switch p.priority {
+	case "high":
+		return lipgloss.NewStyle().Bold(true).Foreground(colorRed).Render("high")
+	case "medium":
+		return lipgloss.NewStyle().Foreground(colorYellow).Render("med")
+	case "low":
+		return dateStyle.Render("low")
+	}
+	return ""
+}
+
+// ─── Priority Modal ──────────────────────────────────────────────────────────
+
+func (m *model) openPriorityModal(current string) {
+	m.settingPriority = true
+	m.priorityCursor = len(priorityOptions) - 1
+	for i, opt := range priorityOptions {
+		if opt.priority == current {
+			m.priorityCursor = i
+		}
+	}
+}
+
+// applyPriority sets priority on the selected plans, or the current one.
+func (m model) applyPriority(priority string) tea.Cmd {
+	files := m.selectedFiles()
+	if len(files) == 0 {
+		item, ok := m.list.SelectedItem().(plan)
+		if !ok {
+			return nil
+		}
+		files = []string{item.path()}
+	}
+	return m.store.batchSetField(files, "priority", priority)
+}
+
+func (m model) handlePriorityModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.settingPriority = false
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.settingPriority = false
+		return m, m.applyPriority(priorityOptions[m.priorityCursor].priority), true
+	case msg.String() == "j" || msg.String() == "down":
+		if m.priorityCursor < len(priorityOptions)-1 {
+			m.priorityCursor++
+		}
+		return m, nil, true
+	case msg.String() == "k" || msg.String() == "up":
+		if m.priorityCursor > 0 {
+			m.priorityCursor--
+		}
+		return m, nil, true
+	}
+	for _, opt := range priorityOptions {
+		if msg.String() == opt.key {
+			m.settingPriority = false
+			return m, m.applyPriority(opt.priority), true
+		}
+	}
+	return m, nil, true
+}
+
+func (m model) renderPriorityModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	var context string
+	if len(m.selected) > 0 {
+		context = fmt.Sprintf("%d plans selected", len(m.selected))
+	} else if item, ok := m.list.SelectedItem().(plan); ok {
+		context = item.file
+	}
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Set Priority")) + "\n")
+	b.WriteString(dimStyle.Render(context) + "\n\n")
+	for i, opt := range priorityOptions {
+		label := opt.priority
+		if label == "" {
+			label = tr("none")
+		}
+		if i == m.priorityCursor {
+			b.WriteString(fmt.Sprintf("%s%s  %s\n", accentStyle.Render("> "), accentStyle.Render(opt.key), accentStyle.Render(label)))
+		} else {
+			b.WriteString(fmt.Sprintf("  %s  %s\n", opt.key, label))
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("j/k navigate · 0-3 select · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPriorityModal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+	writeFile(t, path, "---\nstatus: active\npriority: low\n---\n# Plan\n")
+	plans, _ := scanPlans(dir)
+	if plans[0].priority != "low" {
+		t.Fatalf("priority = %q, want low", plans[0].priority)
+	}
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	if out := ansi.Strip(m.View()); !strings.Contains(out, " low ") {
+		t.Errorf("list row should show the priority badge:\n%s", out)
+	}
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = m2.(model)
+	if !m.settingPriority || priorityOptions[m.priorityCursor].priority != "low" {
+		t.Fatalf("p should open the priority modal on the current priority, cursor %d", m.priorityCursor)
+	}
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	m = m2.(model)
+	if m.settingPriority || cmd == nil {
+		t.Fatal("1 should close the modal and set the priority")
+	}
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	data, _ := os.ReadFile(path)
+	if fm, _ := parseFrontmatter(string(data)); fm["priority"] != "high" {
+		t.Errorf("file priority = %q, want high", fm["priority"])
+	}
+	if p := m.list.Items()[0].(plan); p.priority != "high" {
+		t.Errorf("list priority = %q, want high", p.priority)
+	}
+}
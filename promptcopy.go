@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Copy as Prompt ──────────────────────────────────────────────────────────
+//
+// P copies the highlighted plan wrapped in a prompt, for web-based agents
+// that `c` can't launch. The prompt_template config field is the template;
+// its placeholders are {title}, {file}, {status}, {labels}, {plan} (the body
+// without frontmatter or comment blockquotes), and {comments} (the open
+// review comments as a list, empty when there are none).
+
+const defaultPromptTemplate = `Please review and implement the following plan.
+
+Plan: {title} ({file})
+
+{plan}
+
+{comments}`
+
+// promptComments lists a body's open comments, each with the heading it
+// follows, under an intro line. Empty when there are none.
+func promptComments(body string) string {
+	var lines []string
+	heading := ""
+	for _, e := range extractToc(body) {
+		switch {
+		case !e.isComment:
+			heading = e.text
+		case !e.resolved:
+			if heading != "" {
+				lines = append(lines, fmt.Sprintf("- %s (on %q)", e.text, heading))
+			} else {
+				lines = append(lines, "- "+e.text)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "Address these open review comments:\n" + strings.Join(lines, "\n")
+}
+
+// stripComments removes comment blockquotes outside fenced code, along with
+// the blank line that separated them.
+func stripComments(body string) string {
+	var out []string
+	inFence, dropped := false, false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence && commentRegex.MatchString(trimmed) {
+			dropped = true
+			continue
+		}
+		if trimmed == "" && dropped && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+			continue
+		}
+		dropped = dropped && trimmed == ""
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// planPrompt fills tmpl (or the default template) for the plan.
+func planPrompt(tmpl string, p plan, content string) string {
+	if tmpl == "" {
+		tmpl = defaultPromptTemplate
+	}
+	_, body := parseFrontmatter(content)
+	r := strings.NewReplacer(
+		"{title}", p.title,
+		"{file}", p.path(),
+		"{status}", exportStatus(p.status),
+		"{labels}", labelsString(p.labels),
+		"{plan}", strings.TrimSpace(stripComments(body)),
+		"{comments}", promptComments(body),
+	)
+	return strings.TrimSpace(r.Replace(tmpl)) + "\n"
+}
+
+// copyPlanPrompt copies the plan's prompt to the clipboard.
+func copyPlanPrompt(tmpl string, p plan) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(p.path())
+		if err != nil {
+			return errMsg{fmt.Errorf("copy: %w", err)}
+		}
+		if err := clipboard.WriteAll(planPrompt(tmpl, p, string(data))); err != nil {
+			return errMsg{fmt.Errorf("clipboard: %w", err)}
+		}
+		return planCopiedMsg{path: p.path()}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanPrompt(t *testing.T) {
+	p := plan{file: "cache.md", dir: "/plans", title: "Cache index", status: "reviewed", labels: []string{"perf"}}
+	content := "---\nstatus: reviewed\n---\n# Cache index\n\n## Design\n\n> **[comment]:** What about eviction?\n> **[resolved]:** Done\n\nUse an LRU.\n\n```\n> **[comment]:** in code\n```\n"
+
+	got := planPrompt("{title} [{status}; {labels}] {file}\n---\n{plan}\n---\n{comments}", p, content)
+	want := "Cache index [reviewed; perf] /plans/cache.md\n---\n" +
+		"# Cache index\n\n## Design\n\nUse an LRU.\n\n```\n> **[comment]:** in code\n```\n---\n" +
+		"Address these open review comments:\n- What about eviction? (on \"Design\")\n"
+	if got != want {
+		t.Errorf("prompt:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The default template drops the comments section when there are none.
+	got = planPrompt("", p, "# Cache index\n\nUse an LRU.\n")
+	if !strings.HasPrefix(got, "Please review and implement") || !strings.HasSuffix(got, "Use an LRU.\n") {
+		t.Errorf("default prompt:\n%s", got)
+	}
+}
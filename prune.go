@@ -0,0 +1,274 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── planc prune ─────────────────────────────────────────────────────────────
+//
+// `planc prune` is the janitor for the state planc leaves behind outside the
+// plans themselves. It removes, and reports the space reclaimed from:
+//
+//   - embeddings cached for plans that no longer exist (or the whole cache
+//     once semantic search is turned off)
+//   - update-check state about a release that is already installed, and temp
+//     files from interrupted update-check writes
+//   - crash reports, `planc -` session directories, private notes whose
+//     plan was deleted, and plans in the trash, once they're older than
+//     --days (default 30)
+//
+// --dry-run lists what would go. New kinds of state should get a finder here.
+
+const pruneDays = 30
+
+// scratchDirName matches the temp directories readStdinPlan creates.
+var scratchDirName = regexp.MustCompile(`^planc-[0-9]+$`)
+
+// pruneDirs are where planc keeps its state.
+type pruneDirs struct {
+	config string // update-check.json and crash reports, next to config.json
+	cache  string // embeddings.json
+	temp   string // planc - sessions
+}
+
+func defaultPruneDirs() pruneDirs {
+	dirs := pruneDirs{config: crashDir(), temp: os.TempDir()}
+	if dir, err := os.UserCacheDir(); err == nil {
+		dirs.cache = filepath.Join(dir, "planc")
+	}
+	return dirs
+}
+
+// pruneItem is one piece of state to remove or rewrite.
+type pruneItem struct {
+	what  string
+	path  string
+	bytes int64 // reclaimed
+	apply func() error
+}
+
+// removeItem returns an item that deletes path, a file or a directory.
+func removeItem(what, path string) pruneItem {
+	return pruneItem{what: what, path: path, bytes: diskUsage(path), apply: func() error { return os.RemoveAll(path) }}
+}
+
+// diskUsage returns the size of the file at path or of the files under it.
+func diskUsage(path string) int64 {
+	var n int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				n += info.Size()
+			}
+		}
+		return nil
+	})
+	return n
+}
+
+// olderFiles returns the paths matching pattern last modified before cutoff.
+func olderFiles(pattern string, cutoff time.Time) []string {
+	matches, _ := filepath.Glob(pattern)
+	var out []string
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && info.ModTime().Before(cutoff) {
+			out = append(out, path)
+		}
+	}
+	return out
+}
+
+// findPrunable lists what planc prune would do. version is the running
+// version, and all the scanned plans.
+func findPrunable(cfg config, dirs pruneDirs, all []plan, version string, maxAge time.Duration, now time.Time) []pruneItem {
+	var items []pruneItem
+	cutoff := now.Add(-maxAge)
+
+	if dirs.cache != "" {
+		items = append(items, pruneEmbeddings(cfg, filepath.Join(dirs.cache, "embeddings.json"), all)...)
+	}
+	if dirs.config != "" {
+		items = append(items, pruneUpdateState(filepath.Join(dirs.config, "update-check.json"), version)...)
+		// A write in progress is seconds old.
+		for _, path := range olderFiles(filepath.Join(dirs.config, ".update-check-*.tmp"), now.Add(-time.Hour)) {
+			items = append(items, removeItem("update-check temp file", path))
+		}
+		for _, path := range olderFiles(filepath.Join(dirs.config, "crash-*.txt"), cutoff) {
+			items = append(items, removeItem("crash report", path))
+		}
+	}
+	if dirs.temp != "" {
+		for _, path := range olderFiles(filepath.Join(dirs.temp, "planc-*"), cutoff) {
+			if info, err := os.Stat(path); err == nil && info.IsDir() && scratchDirName.MatchString(filepath.Base(path)) {
+				items = append(items, removeItem("planc - session", path))
+			}
+		}
+	}
+	items = append(items, pruneOrphanNotes(cfg.PlansDir, all, cutoff)...)
+	items = append(items, pruneTrash(cfg.PlansDir, all, cutoff)...)
+	return items
+}
+
+// pruneEmbeddings drops cached vectors for plans that are gone.
+func pruneEmbeddings(cfg config, path string, all []plan) []pruneItem {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if cfg.EmbeddingURL == "" {
+		return []pruneItem{removeItem("embeddings cache (semantic search is off)", path)}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var vectors map[string]embeddingCacheEntry
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return []pruneItem{removeItem("unreadable embeddings cache", path)}
+	}
+	exists := make(map[string]bool, len(all))
+	for _, p := range all {
+		exists[p.path()] = true
+	}
+	stale := 0
+	for file := range vectors {
+		if !exists[file] {
+			delete(vectors, file)
+			stale++
+		}
+	}
+	if stale == 0 {
+		return nil
+	}
+	pruned, err := json.Marshal(vectors)
+	if err != nil {
+		return nil
+	}
+	return []pruneItem{{
+		what:  fmt.Sprintf("%d embeddings of deleted plans", stale),
+		path:  path,
+		bytes: info.Size() - int64(len(pruned)),
+		apply: func() error { return os.WriteFile(path, pruned, 0644) },
+	}}
+}
+
+// pruneUpdateState forgets an available update once it's installed.
+func pruneUpdateState(path, version string) []pruneItem {
+	st, err := loadUpdateState(path)
+	if err != nil || st.LatestVersion == "" {
+		return nil
+	}
+	if cmp, ok := compareVersions(st.LatestVersion, version); !ok || cmp > 0 {
+		return nil
+	}
+	latest := st.LatestVersion
+	st.LatestVersion, st.ReleaseURL = "", ""
+	return []pruneItem{{
+		what:  "update-check state for " + latest + " (installed)",
+		path:  path,
+		apply: func() error { return saveUpdateState(path, st) },
+	}}
+}
+
+// planDirSet returns the agent plans directory and every directory holding a
+// plan.
+func planDirSet(agentDir string, all []plan) map[string]bool {
+	dirs := map[string]bool{agentDir: agentDir != ""}
+	for _, p := range all {
+		dirs[p.dir] = true
+	}
+	return dirs
+}
+
+// pruneOrphanNotes finds private notes whose plan no longer exists.
+func pruneOrphanNotes(agentDir string, all []plan, cutoff time.Time) []pruneItem {
+	var items []pruneItem
+	for dir, ok := range planDirSet(agentDir, all) {
+		if !ok {
+			continue
+		}
+		for _, path := range olderFiles(filepath.Join(dir, plans.NotesDir, "*.md"), cutoff) {
+			if _, err := os.Stat(filepath.Join(dir, filepath.Base(path))); os.IsNotExist(err) {
+				items = append(items, removeItem("notes of a deleted plan", path))
+			}
+		}
+	}
+	return items
+}
+
+// pruneTrash finds plans deleted to the trash before cutoff.
+func pruneTrash(agentDir string, all []plan, cutoff time.Time) []pruneItem {
+	var items []pruneItem
+	for dir, ok := range planDirSet(agentDir, all) {
+		if !ok {
+			continue
+		}
+		trashed, _ := plans.Trashed(dir)
+		for _, t := range trashed {
+			if t.Deleted.Before(cutoff) {
+				items = append(items, pruneItem{what: "trashed plan", path: t.Path, bytes: diskUsage(t.Path), apply: func() error { return plans.Purge(t) }})
+			}
+		}
+	}
+	return items
+}
+
+// writePruneReport prints each item and the total reclaimed.
+func writePruneReport(w io.Writer, items []pruneItem, dryRun bool) {
+	verb, summary := "removed", "Reclaimed"
+	if dryRun {
+		verb, summary = "would remove", "Would reclaim"
+	}
+	var total int64
+	for _, it := range items {
+		fmt.Fprintf(w, "%s %s: %s (%s)\n", verb, it.what, contractHome(it.path), formatSize(int(max(it.bytes, 0))))
+		total += max(it.bytes, 0)
+	}
+	fmt.Fprintf(w, "%s %s\n", summary, formatSize(int(total)))
+}
+
+func runPrune(cfg config, args []string) int {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	days := fs.Int("days", pruneDays, "remove crash reports, planc - sessions, orphaned notes, and trashed plans older than this")
+	dryRun := fs.Bool("dry-run", false, "list what would be removed")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc prune [--days N] [--dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *days < 0 {
+		return cliError("prune: --days must not be negative")
+	}
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("prune: %v", err)
+	}
+	maxAge := time.Duration(*days) * 24 * time.Hour
+	items := findPrunable(cfg, defaultPruneDirs(), all, getVersion(), maxAge, time.Now())
+	code := 0
+	if !*dryRun {
+		var done []pruneItem
+		for _, it := range items {
+			if err := it.apply(); err != nil {
+				code = cliError("prune: %v", err)
+				continue
+			}
+			done = append(done, it)
+		}
+		items = done
+	}
+	writePruneReport(os.Stdout, items, *dryRun)
+	return code
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// age backdates path by days.
+func age(t *testing.T, path string, days int) {
+	t.Helper()
+	old := time.Now().AddDate(0, 0, -days)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindPrunable(t *testing.T) {
+	root := t.TempDir()
+	dirs := pruneDirs{config: filepath.Join(root, "config"), cache: filepath.Join(root, "cache"), temp: filepath.Join(root, "tmp")}
+	plansDir := filepath.Join(root, "plans")
+	for _, d := range []string{dirs.config, dirs.cache, dirs.temp, plansDir, filepath.Join(plansDir, plans.NotesDir)} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := newDefaultConfig()
+	cfg.PlansDir = plansDir
+	cfg.EmbeddingURL = "http://localhost:11434/api/embeddings"
+
+	writeFile(t, filepath.Join(plansDir, "kept.md"), "# Kept\n")
+	all, err := scanAllPlans(plansDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := all[0].path()
+
+	// Notes: one for a live plan, one orphaned, one orphaned but recent.
+	for _, name := range []string{"kept.md", "gone.md", "just-gone.md"} {
+		writeFile(t, filepath.Join(plansDir, plans.NotesDir, name), "notes\n")
+	}
+	age(t, filepath.Join(plansDir, plans.NotesDir, "kept.md"), 60)
+	age(t, filepath.Join(plansDir, plans.NotesDir, "gone.md"), 60)
+
+	vectors := map[string]embeddingCacheEntry{kept: {Vector: []float64{1}}, filepath.Join(plansDir, "gone.md"): {Vector: []float64{2}}}
+	data, _ := json.Marshal(vectors)
+	writeFile(t, filepath.Join(dirs.cache, "embeddings.json"), string(data))
+
+	writeFile(t, filepath.Join(dirs.config, "update-check.json"), `{"checked_at": "2026-03-01T00:00:00Z", "latest_version": "v1.2.0", "release_url": "https://example.com"}`)
+	writeFile(t, filepath.Join(dirs.config, "crash-20260101-000000.txt"), "panic")
+	age(t, filepath.Join(dirs.config, "crash-20260101-000000.txt"), 60)
+	writeFile(t, filepath.Join(dirs.config, "crash-20260301-000000.txt"), "panic")
+
+	session := filepath.Join(dirs.temp, "planc-12345")
+	if err := os.Mkdir(session, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(session, "plan.md"), "# Piped\n")
+	age(t, session, 60)
+	other := filepath.Join(dirs.temp, "planc-export")
+	if err := os.Mkdir(other, 0755); err != nil {
+		t.Fatal(err)
+	}
+	age(t, other, 60)
+
+	// Trash: one plan deleted long ago, one just now.
+	writeFile(t, filepath.Join(plansDir, "old.md"), "# Old\n")
+	writeFile(t, filepath.Join(plansDir, "new.md"), "# New\n")
+	oldTrash, err := plans.Trash(filepath.Join(plansDir, "old.md"), time.Now().AddDate(0, 0, -60))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plans.Trash(filepath.Join(plansDir, "new.md"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	items := findPrunable(cfg, dirs, all, "v1.2.0", 30*24*time.Hour, time.Now())
+	var got []string
+	for _, it := range items {
+		got = append(got, it.what+" "+filepath.Base(it.path))
+	}
+	want := []string{
+		"1 embeddings of deleted plans embeddings.json",
+		"update-check state for v1.2.0 (installed) update-check.json",
+		"crash report crash-20260101-000000.txt",
+		"planc - session planc-12345",
+		"notes of a deleted plan gone.md",
+		"trashed plan " + filepath.Base(oldTrash),
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("items:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for _, it := range items {
+		if err := it.apply(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ = os.ReadFile(filepath.Join(dirs.cache, "embeddings.json"))
+	var left map[string]embeddingCacheEntry
+	if err := json.Unmarshal(data, &left); err != nil || len(left) != 1 {
+		t.Errorf("embeddings after prune = %s", data)
+	}
+	if st, _ := loadUpdateState(filepath.Join(dirs.config, "update-check.json")); st.LatestVersion != "" || st.CheckedAt.IsZero() {
+		t.Errorf("update state after prune = %+v", st)
+	}
+	for _, path := range []string{session, filepath.Join(plansDir, plans.NotesDir, "gone.md"), oldTrash} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s survived", path)
+		}
+	}
+	if again := findPrunable(cfg, dirs, all, "v1.2.0", 30*24*time.Hour, time.Now()); len(again) != 0 {
+		t.Errorf("second prune found %d items", len(again))
+	}
+
+	var b bytes.Buffer
+	writePruneReport(&b, items[2:3], true)
+	if !strings.HasPrefix(b.String(), "would remove crash report: ") || !strings.Contains(b.String(), "Would reclaim 5 B") {
+		t.Errorf("report = %q", b.String())
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Related Plans ───────────────────────────────────────────────────────────
+//
+// Plans link to each other by [[wikilink]] or .md filename (see bodyLinks),
+// e.g. when an agent supersedes an old plan with a new one. The preview
+// footer names the selected plan's links (→) and backlinks (←), and b opens
+// a panel listing both; enter jumps to one.
+
+// relatedPlan is one row of the related plans panel.
+type relatedPlan struct {
+	plan     plan
+	backlink bool // p links to the selected plan, rather than the reverse
+}
+
+type relatedState struct {
+	active bool
+	items  []relatedPlan
+	cursor int
+}
+
+// relatedPlans returns the plans p links to, then the plans that link to p,
+// each in all's order. A plan that does both is listed once, as a link.
+func relatedPlans(p plan, all []plan) []relatedPlan {
+	links := make(map[string]bool, len(p.links))
+	for _, l := range p.links {
+		links[l] = true
+	}
+	backlinks := make(map[string]bool, len(p.backlinks))
+	for _, path := range p.backlinks {
+		backlinks[path] = true
+	}
+	var out, back []relatedPlan
+	for _, o := range all {
+		switch {
+		case o.path() == p.path():
+		case links[planRefName(o.file)]:
+			out = append(out, relatedPlan{plan: o})
+		case backlinks[o.path()]:
+			back = append(back, relatedPlan{plan: o, backlink: true})
+		}
+	}
+	return append(out, back...)
+}
+
+// relatedFooter summarizes the selected plan's links for the bottom of the
+// preview pane, or "" if it has none.
+func (m model) relatedFooter(width int) string {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok || (len(item.links) == 0 && len(item.backlinks) == 0) {
+		return ""
+	}
+	var out, back []string
+	for _, r := range relatedPlans(item, *m.planSource()) {
+		if r.backlink {
+			back = append(back, r.plan.title)
+		} else {
+			out = append(out, r.plan.title)
+		}
+	}
+	if len(out) == 0 && len(back) == 0 {
+		return ""
+	}
+	var parts []string
+	if len(out) > 0 {
+		parts = append(parts, "→ "+strings.Join(out, ", "))
+	}
+	if len(back) > 0 {
+		parts = append(parts, "← "+strings.Join(back, ", "))
+	}
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	hint := lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render("b") + dimStyle.Render(tr(" related"))
+	text := truncateForWidth(strings.Join(parts, "  "), width-lipgloss.Width(hint)-3)
+	return " " + dimStyle.Render(text) + "  " + hint
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+func (m *model) openRelated() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	items := relatedPlans(item, *m.planSource())
+	if len(items) == 0 {
+		return m.setNotification(tr("No linked plans"), statusTimeout)
+	}
+	m.related = relatedState{active: true, items: items}
+	return nil
+}
+
+func (m model) handleRelatedKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Related), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.related.active = false
+	case msg.Type == tea.KeyEnter:
+		m.related.active = false
+		if m.related.cursor < len(m.related.items) {
+			cmd := m.jumpToPlan(m.related.items[m.related.cursor].plan.path())
+			return m, cmd, true
+		}
+	case msg.String() == "j" || msg.String() == "down":
+		if m.related.cursor < len(m.related.items)-1 {
+			m.related.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.related.cursor > 0 {
+			m.related.cursor--
+		}
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderRelatedModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := min(m.width-4, 80)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Related Plans")) + "\n")
+	if item, ok := m.list.SelectedItem().(plan); ok {
+		b.WriteString(dimStyle.Render(truncateForWidth(item.title, contentW)) + "\n")
+	}
+	section := ""
+	for i, r := range m.related.items {
+		heading := tr("Links to")
+		if r.backlink {
+			heading = tr("Referenced by")
+		}
+		if heading != section {
+			section = heading
+			b.WriteString("\n" + dimStyle.Render(heading) + "\n")
+		}
+		title := truncateForWidth(r.plan.title, contentW-4)
+		if i == m.related.cursor {
+			b.WriteString(accentStyle.Render("> "+title) + "\n")
+		} else {
+			b.WriteString("  " + title + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("j/k navigate · enter open · esc close")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
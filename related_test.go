@@ -0,0 +1,53 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRelatedPlans(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "auth-v1.md"), "---\nstatus: active\ncreated: 2026-01-01\n---\n# Auth v1\n")
+	writeFile(t, filepath.Join(dir, "auth-v2.md"), "---\nstatus: active\ncreated: 2026-02-01\n---\n# Auth v2\n\nSupersedes [[auth-v1]]; see also rollout.md.\n")
+	writeFile(t, filepath.Join(dir, "rollout.md"), "---\nstatus: active\ncreated: 2026-01-15\n---\n# Rollout\n\nFollows [Auth v2](auth-v2.md).\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v2 plan
+	for _, p := range all {
+		if p.file == "auth-v2.md" {
+			v2 = p
+		}
+	}
+	var got []string
+	for _, r := range relatedPlans(v2, all) {
+		got = append(got, r.plan.file+map[bool]string{true: " ←", false: " →"}[r.backlink])
+	}
+	// rollout.md is both a link and a backlink; it's listed once, as a link.
+	if strings.Join(got, ", ") != "rollout.md →, auth-v1.md →" {
+		t.Errorf("related = %v", got)
+	}
+
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	m.selectFile(filepath.Join(dir, "auth-v1.md"))
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "← Auth v2") {
+		t.Errorf("preview footer should show the backlink:\n%s", out)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = m2.(model)
+	if !m.related.active || len(m.related.items) != 1 {
+		t.Fatalf("b should open the related panel: %+v", m.related)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.related.active || m.selectedFile() != filepath.Join(dir, "auth-v2.md") {
+		t.Errorf("enter should jump to the linked plan, selected %s", m.selectedFile())
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Startup Reminders ───────────────────────────────────────────────────────
+//
+// At launch planc lists plans that need attention: unfinished plans past
+// their due: date, and active plans nobody has touched in remind_after_days
+// (default 14; -1 turns reminders off). enter jumps to a plan, esc dismisses.
+// Nothing is shown when the list is empty.
+
+const defaultRemindAfterDays = 14
+
+// reminder is one plan the startup reminders list.
+type reminder struct {
+	plan   plan
+	reason string
+}
+
+// remindAfter returns how long an active plan may go unmodified before it's
+// listed, or 0 if reminders are off.
+func remindAfter(cfg config) time.Duration {
+	days := cfg.RemindAfterDays
+	switch {
+	case days < 0:
+		return 0
+	case days == 0:
+		days = defaultRemindAfterDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// findReminders lists overdue plans (oldest deadline first), then stale
+// active plans (longest untouched first). A plan is listed once.
+func findReminders(plans []plan, dues []dueEvent, staleAfter time.Duration, now time.Time) []reminder {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var out []reminder
+	listed := make(map[string]bool)
+	slices.SortStableFunc(dues, func(a, b dueEvent) int { return a.due.Compare(b.due) })
+	for _, d := range dues {
+		if d.due.Before(today) {
+			out = append(out, reminder{plan: d.plan, reason: trf("overdue since %s", d.due.Format("Jan 2"))})
+			listed[d.plan.path()] = true
+		}
+	}
+	for _, p := range stalePlans(plans, staleAfter, now) {
+		if listed[p.path()] {
+			continue
+		}
+		days := int(now.Sub(p.modified).Hours() / 24)
+		out = append(out, reminder{plan: p, reason: trf("active, untouched for %d days", days)})
+	}
+	return out
+}
+
+func loadReminders(plans []plan, staleAfter time.Duration, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return remindersMsg{items: findReminders(plans, dueEvents(plans), staleAfter, now)}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+type remindersState struct {
+	active bool
+	items  []reminder
+	cursor int
+}
+
+func (m model) handleRemindersKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.reminders.active = false
+	case msg.Type == tea.KeyEnter:
+		if m.reminders.cursor < len(m.reminders.items) {
+			m.reminders.active = false
+			m.revealPlan(m.reminders.items[m.reminders.cursor].plan.path())
+			return m, m.renderWindow(), true
+		}
+	case msg.String() == "j" || msg.String() == "down":
+		if m.reminders.cursor < len(m.reminders.items)-1 {
+			m.reminders.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.reminders.cursor > 0 {
+			m.reminders.cursor--
+		}
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderRemindersModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := min(m.width-4, 90)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Plans needing attention")) + "\n")
+
+	items := m.reminders.items
+	maxVisible := max(m.height-12, 3)
+	scrollOff := 0
+	if len(items) > maxVisible {
+		scrollOff = min(max(m.reminders.cursor-maxVisible/2, 0), len(items)-maxVisible)
+	}
+	end := min(scrollOff+maxVisible, len(items))
+	if scrollOff > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    ↑ %d more", scrollOff)) + "\n")
+	}
+	for i, r := range items[scrollOff:end] {
+		textW := contentW - 2 - lipgloss.Width(r.reason) - 2
+		text := truncateForWidth(r.plan.title, textW)
+		if scrollOff+i == m.reminders.cursor {
+			b.WriteString(accentStyle.Render("> "+text) + "  " + dimStyle.Render(r.reason) + "\n")
+		} else {
+			b.WriteString("  " + text + "  " + dimStyle.Render(r.reason) + "\n")
+		}
+	}
+	if end < len(items) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    ↓ %d more", len(items)-end)) + "\n")
+	}
+
+	b.WriteString("\n" + dimStyle.Render(tr("j/k navigate · enter show plan · esc dismiss")))
+
+	overlay := helpBoxStyle.Width(modalW - 2).Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFindReminders(t *testing.T) {
+	now := time.Date(2025, 6, 20, 10, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	fresh := plan{file: "fresh.md", title: "Fresh", status: "active", modified: now.Add(-2 * day)}
+	stale := plan{file: "stale.md", title: "Stale", status: "active", modified: now.Add(-20 * day)}
+	staler := plan{file: "staler.md", title: "Staler", status: "active", modified: now.Add(-40 * day)}
+	reviewed := plan{file: "reviewed.md", title: "Reviewed", status: "reviewed", modified: now.Add(-40 * day)}
+	overdue := plan{file: "overdue.md", title: "Overdue", status: "reviewed", modified: now}
+	dues := []dueEvent{
+		{plan: staler, due: now.Add(-1 * day)},
+		{plan: overdue, due: now.Add(-3 * day)},
+		{plan: fresh, due: time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)}, // due today isn't overdue
+	}
+
+	got := findReminders([]plan{fresh, stale, staler, reviewed, overdue}, dues, 14*day, now)
+	var files, reasons []string
+	for _, r := range got {
+		files = append(files, r.plan.file)
+		reasons = append(reasons, r.reason)
+	}
+	if strings.Join(files, " ") != "overdue.md staler.md stale.md" {
+		t.Errorf("reminders = %v", files)
+	}
+	if reasons[0] != "overdue since Jun 17" || reasons[2] != "active, untouched for 20 days" {
+		t.Errorf("reasons = %q", reasons)
+	}
+}
+
+func TestRemindAfter(t *testing.T) {
+	for days, want := range map[int]time.Duration{0: 14 * 24 * time.Hour, 3: 3 * 24 * time.Hour, -1: 0} {
+		if got := remindAfter(config{RemindAfterDays: days}); got != want {
+			t.Errorf("remindAfter(%d) = %v, want %v", days, got, want)
+		}
+	}
+}
+
+func TestRemindersModalJumpsToPlan(t *testing.T) {
+	m := testModel()
+	target := m.list.Items()[2].(plan)
+	m2, _ := m.Update(remindersMsg{items: []reminder{
+		{plan: m.list.Items()[0].(plan), reason: "overdue since Jun 1"},
+		{plan: target, reason: "active, untouched for 30 days"},
+	}})
+	m = m2.(model)
+	if !m.reminders.active {
+		t.Fatal("reminders should open the modal")
+	}
+	if view := m.View(); !strings.Contains(view, "Plans needing attention") || !strings.Contains(view, "untouched for 30 days") {
+		t.Error("modal should list the reminders")
+	}
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("j")}, {Type: tea.KeyEnter}} {
+		m2, _ = m.Update(k)
+		m = m2.(model)
+	}
+	if m.reminders.active {
+		t.Error("enter should dismiss the reminders")
+	}
+	if p, ok := m.list.SelectedItem().(plan); !ok || p.path() != target.path() {
+		t.Errorf("enter should select %s", target.path())
+	}
+
+	m2, _ = m.Update(remindersMsg{})
+	if m2.(model).reminders.active {
+		t.Error("no reminders should show nothing")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// ─── Rich Text Copy ──────────────────────────────────────────────────────────
+//
+// Y renders the highlighted plan to HTML and puts it on the clipboard as rich
+// text, so pasting into Google Docs or Slack keeps headings, lists, and code
+// blocks. The clipboard library only handles plain text, so each platform
+// shells out to its own tool: textutil + pbcopy on macOS (as RTF),
+// Set-Clipboard -AsHtml on Windows, wl-copy or xclip on Linux.
+
+// planHTML renders a plan's markdown body (without frontmatter) as an HTML
+// fragment.
+func planHTML(content string) (string, error) {
+	_, body := parseFrontmatter(content)
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(body), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// richCopyCommand is a pipeline that puts HTML on stdin onto the clipboard.
+type richCopyCommand [][]string
+
+// richCopyCommands returns the candidate pipelines for goos, best first.
+// wayland selects wl-copy over xclip on Linux.
+func richCopyCommands(goos string, wayland bool) []richCopyCommand {
+	switch goos {
+	case "darwin":
+		// pbcopy stores RTF input as rich text; textutil converts the HTML.
+		return []richCopyCommand{{
+			{"textutil", "-stdin", "-format", "html", "-convert", "rtf", "-stdout"},
+			{"pbcopy"},
+		}}
+	case "windows":
+		return []richCopyCommand{{{"powershell", "-NoProfile", "-Command",
+			"[Console]::InputEncoding = [Text.Encoding]::UTF8; Set-Clipboard -AsHtml -Value ([Console]::In.ReadToEnd())"}}}
+	}
+	wl := richCopyCommand{{"wl-copy", "--type", "text/html"}}
+	x := richCopyCommand{{"xclip", "-selection", "clipboard", "-t", "text/html"}}
+	if wayland {
+		return []richCopyCommand{wl, x}
+	}
+	return []richCopyCommand{x, wl}
+}
+
+// available reports whether every program in the pipeline is on PATH.
+func (c richCopyCommand) available() bool {
+	for _, args := range c {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// run feeds input through the pipeline.
+func (c richCopyCommand) run(input []byte) error {
+	for _, args := range c {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = bytes.NewReader(input)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		logger.Info("launch", "args", cmd.Args)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		input = stdout.Bytes()
+	}
+	return nil
+}
+
+// copyRichText puts an HTML fragment on the clipboard as rich text.
+func copyRichText(fragment string) error {
+	doc := "<!DOCTYPE html><html><head><meta charset=\"utf-8\"></head><body>\n" + fragment + "</body></html>\n"
+	for _, c := range richCopyCommands(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "") {
+		if c.available() {
+			return c.run([]byte(doc))
+		}
+	}
+	if runtime.GOOS == "linux" {
+		return errors.New("rich text copy needs wl-copy or xclip on PATH")
+	}
+	return fmt.Errorf("rich text copy isn't supported on %s", runtime.GOOS)
+}
+
+// copyPlanRichText renders the plan at path and copies it as rich text.
+func copyPlanRichText(path string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errMsg{fmt.Errorf("copy: %w", err)}
+		}
+		fragment, err := planHTML(string(data))
+		if err != nil {
+			return errMsg{fmt.Errorf("copy: %w", err)}
+		}
+		if err := copyRichText(fragment); err != nil {
+			return errMsg{fmt.Errorf("copy: %w", err)}
+		}
+		return planCopiedMsg{path: path}
+	}
+}
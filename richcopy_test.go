@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPlanHTML(t *testing.T) {
+	content := "---\nstatus: active\n---\n# Plan\n\n## Steps\n\n- one\n- **two**\n\n```go\nx := 1\n```\n"
+	got, err := planHTML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<h1>Plan</h1>", "<h2>Steps</h2>", "<li><strong>two</strong></li>", `<pre><code class="language-go">x := 1`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "status") {
+		t.Errorf("frontmatter leaked into HTML:\n%s", got)
+	}
+}
+
+func TestRichCopyCommands(t *testing.T) {
+	first := func(goos string, wayland bool) string {
+		return richCopyCommands(goos, wayland)[0][0][0]
+	}
+	if got := first("darwin", false); got != "textutil" {
+		t.Errorf("darwin: %s", got)
+	}
+	if mac := richCopyCommands("darwin", false)[0]; !slices.Equal(mac[1], []string{"pbcopy"}) {
+		t.Errorf("darwin pipeline should end in pbcopy: %v", mac)
+	}
+	if got := first("windows", false); got != "powershell" {
+		t.Errorf("windows: %s", got)
+	}
+	if got := first("linux", true); got != "wl-copy" {
+		t.Errorf("wayland: %s", got)
+	}
+	if got := first("linux", false); got != "xclip" {
+		t.Errorf("x11: %s", got)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRecursiveScan(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "top.md"), "---\nstatus: active\n---\n# Top plan\n")
+	writeFile(t, filepath.Join(dir, "api", "auth.md"), "---\nstatus: active\n---\n# Auth plan\n")
+
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("without recursive_scan, got %d plans", len(all))
+	}
+
+	recursiveScan.Store(true)
+	defer recursiveScan.Store(false)
+	all, err = scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("with recursive_scan, got %d plans", len(all))
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	out := ansi.Strip(m2.(model).View())
+	if !strings.Contains(out, "api/Auth plan") {
+		t.Errorf("subdirectory plan should show its subpath before the title:\n%s", out)
+	}
+	if strings.Contains(out, "api/Top plan") {
+		t.Errorf("top-level plan should have no subpath:\n%s", out)
+	}
+}
+
+func TestPlanFilePatterns(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "auth.plan.md"), "# Auth\n")
+	writeFile(t, filepath.Join(dir, "notes.markdown"), "no heading\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "# Readme\n")
+
+	setPlanFiles([]string{"*.plan.md", ".markdown"})
+	defer setPlanFiles(nil)
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, p := range all {
+		titles = append(titles, p.title)
+	}
+	slices.Sort(titles)
+	if want := []string{"Auth", "notes"}; !slices.Equal(titles, want) {
+		t.Errorf("titles = %v, want %v", titles, want)
+	}
+	if !isPlanFile(filepath.Join(dir, "auth.plan.md")) || isPlanFile(filepath.Join(dir, "README.md")) {
+		t.Error("the watchers should filter with the same patterns")
+	}
+}
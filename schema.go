@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Frontmatter Validation ──────────────────────────────────────────────────
+//
+// Known frontmatter fields are checked on scan. Plans with problems get a ⚠
+// badge in the list; the info modal (i) lists each problem with its proposed
+// fix, and r applies the fixes through setFrontmatter.
+
+// knownStatuses returns the status values planc understands: the vocabulary
+// past its first status, which is written by leaving status: out, and
+// "pending", a legacy alias for "reviewed".
+func knownStatuses() []string {
+	return append(statusNames()[1:], "pending")
+}
+
+// fieldProblem is a single validation failure and the value that repairs it.
+type fieldProblem struct {
+	key     string
+	message string
+	fix     string // replacement value; "" removes the key
+}
+
+// validateFrontmatter checks the known fields in fm and returns any problems,
+// ordered by key.
+func validateFrontmatter(fm map[string]string) []fieldProblem {
+	var problems []fieldProblem
+
+	if s := fm["status"]; s != "" {
+		norm := strings.ToLower(strings.TrimSpace(s))
+		known := false
+		for _, k := range knownStatuses() {
+			if norm == k {
+				known = true
+				break
+			}
+		}
+		switch {
+		case !known:
+			problems = append(problems, fieldProblem{"status", fmt.Sprintf("unknown status %q", s), ""})
+		case norm != s:
+			problems = append(problems, fieldProblem{"status", fmt.Sprintf("status %q is not lowercase", s), norm})
+		}
+	}
+
+	if s := fm["priority"]; s != "" {
+		switch norm := parsePriority(s); {
+		case norm == "":
+			problems = append(problems, fieldProblem{"priority", fmt.Sprintf("unknown priority %q", s), ""})
+		case norm != s:
+			problems = append(problems, fieldProblem{"priority", fmt.Sprintf("priority %q is not lowercase", s), norm})
+		}
+	}
+
+	if s := fm["estimate"]; s != "" {
+		if _, ok := parseEstimate(s); !ok {
+			problems = append(problems, fieldProblem{"estimate", fmt.Sprintf("unknown estimate %q", s), ""})
+		}
+	}
+
+	if raw := fm["labels"]; raw != "" {
+		seen := make(map[string]bool)
+		var dups []string
+		for _, l := range parseLabels(raw) {
+			if seen[l] {
+				dups = append(dups, l)
+			}
+			seen[l] = true
+		}
+		if len(dups) > 0 {
+			var unique []string
+			for l := range seen {
+				unique = append(unique, l)
+			}
+			sort.Strings(unique)
+			problems = append(problems, fieldProblem{"labels",
+				"duplicate labels: " + strings.Join(dups, ", "), labelsString(unique)})
+		}
+	}
+
+	for _, k := range []string{"created", "updated", "due"} {
+		if v := fm[k]; v != "" {
+			if _, ok := parseFrontmatterTime(v); !ok {
+				problems = append(problems, fieldProblem{k, fmt.Sprintf("malformed date %q", v), ""})
+			}
+		}
+	}
+	return problems
+}
+
+// repairPlan applies the fixes for a plan's frontmatter problems and rescans.
+func repairPlan(agentDir, projectGlob string, p plan) tea.Cmd {
+	return func() tea.Msg {
+		updates := make(map[string]string, len(p.problems))
+		for _, pr := range p.problems {
+			updates[pr.key] = pr.fix
+		}
+		if err := setFrontmatter(p.path(), updates); err != nil {
+			return errMsg{fmt.Errorf("repair: %w", err)}
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return batchDoneMsg{
+			plans:   plans,
+			files:   []string{p.path()},
+			message: repairMessage(len(p.problems)),
+		}
+	}
+}
+
+func repairMessage(n int) string {
+	if n == 1 {
+		return "Repaired 1 field"
+	}
+	return fmt.Sprintf("Repaired %d fields", n)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateFrontmatter(t *testing.T) {
+	problems := validateFrontmatter(map[string]string{
+		"status":  "Active",
+		"labels":  "api, API, infra",
+		"created": "last tuesday",
+		"updated": "2026-01-02",
+	})
+	got := map[string]fieldProblem{}
+	for _, p := range problems {
+		got[p.key] = p
+	}
+	if len(problems) != 3 {
+		t.Fatalf("got %d problems, want 3: %+v", len(problems), problems)
+	}
+	if got["status"].fix != "active" {
+		t.Errorf("status fix = %q, want active", got["status"].fix)
+	}
+	if got["labels"].fix != "api, infra" {
+		t.Errorf("labels fix = %q", got["labels"].fix)
+	}
+	if _, ok := got["created"]; !ok || got["created"].fix != "" {
+		t.Errorf("created should be flagged for removal: %+v", got["created"])
+	}
+
+	if p := validateFrontmatter(map[string]string{"status": "bogus"}); len(p) != 1 || p[0].fix != "" {
+		t.Errorf("unknown status should be flagged for removal: %+v", p)
+	}
+	if p := validateFrontmatter(map[string]string{"priority": "High"}); len(p) != 1 || p[0].fix != "high" {
+		t.Errorf("priority should be lowercased: %+v", p)
+	}
+	if p := validateFrontmatter(map[string]string{"priority": "urgent"}); len(p) != 1 || p[0].fix != "" {
+		t.Errorf("unknown priority should be flagged for removal: %+v", p)
+	}
+	if p := validateFrontmatter(map[string]string{"status": "pending", "labels": "a, b", "priority": "low"}); len(p) != 0 {
+		t.Errorf("valid frontmatter flagged: %+v", p)
+	}
+}
+
+func TestRepairPlan(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p.md")
+	writeFile(t, path, "---\nstatus: DONE\nlabels: x, x\ncreated: 2026-01-02\n---\n# P\n")
+	plans, err := scanPlans(dir)
+	if err != nil || len(plans) != 1 {
+		t.Fatal(err)
+	}
+	if len(plans[0].problems) != 2 {
+		t.Fatalf("problems = %+v", plans[0].problems)
+	}
+	msg := repairPlan(dir, "", plans[0])().(batchDoneMsg)
+	if msg.message != "Repaired 2 fields" {
+		t.Errorf("message = %q", msg.message)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "---\nstatus: done\nlabels: x\ncreated: 2026-01-02\n---\n# P\n"; string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+	if len(msg.plans) != 1 || len(msg.plans[0].problems) != 0 || !strings.EqualFold(msg.plans[0].status, "done") {
+		t.Errorf("rescanned plan = %+v", msg.plans)
+	}
+}
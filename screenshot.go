@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Screenshot Export ───────────────────────────────────────────────────────
+//
+// The S key captures the current rendered frame and writes it twice: once as
+// a raw .ans file (cat it in any terminal) and once as a standalone .html page
+// with the ANSI colors translated to inline styles.
+
+// screenshotDir returns the directory screenshots are written to: the current
+// working directory if available, otherwise the OS temp directory.
+func screenshotDir() string {
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return os.TempDir()
+}
+
+// saveScreenshot writes frame as .ans and .html files in dir, named by timestamp.
+func saveScreenshot(frame, dir string, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		base := filepath.Join(dir, "planc-"+now.Format("20060102-150405"))
+		if err := os.WriteFile(base+".ans", []byte(frame), 0644); err != nil {
+			return errMsg{fmt.Errorf("screenshot: %w", err)}
+		}
+		if err := os.WriteFile(base+".html", []byte(ansiToHTML(frame)), 0644); err != nil {
+			return errMsg{fmt.Errorf("screenshot: %w", err)}
+		}
+		return screenshotSavedMsg{path: base + ".html"}
+	}
+}
+
+// sgrState tracks the active SGR attributes while converting ANSI to HTML.
+type sgrState struct {
+	fg, bg                              string // CSS colors, "" for default
+	bold, faint, italic, underline, rev bool
+}
+
+func (s sgrState) css() string {
+	fg, bg := s.fg, s.bg
+	if s.rev {
+		fg, bg = bg, fg
+		if fg == "" {
+			fg = "#1e1e1e"
+		}
+		if bg == "" {
+			bg = "#d4d4d4"
+		}
+	}
+	var parts []string
+	if fg != "" {
+		parts = append(parts, "color:"+fg)
+	}
+	if bg != "" {
+		parts = append(parts, "background:"+bg)
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.faint {
+		parts = append(parts, "opacity:0.6")
+	}
+	if s.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	if s.underline {
+		parts = append(parts, "text-decoration:underline")
+	}
+	return strings.Join(parts, ";")
+}
+
+// ansi16 holds the standard and bright xterm palette entries.
+var ansi16 = []string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+// ansi256 converts an xterm 256-color index to a CSS hex color.
+func ansi256(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		n -= 16
+		levels := []int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[(n/6)%6], levels[n%6])
+	default:
+		v := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", v, v, v)
+	}
+}
+
+// applySGR updates s from the parameters of a single CSI ... m sequence.
+func (s *sgrState) applySGR(params string) {
+	if params == "" {
+		*s = sgrState{}
+		return
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		c, _ := strconv.Atoi(codes[i])
+		switch {
+		case c == 0:
+			*s = sgrState{}
+		case c == 1:
+			s.bold = true
+		case c == 2:
+			s.faint = true
+		case c == 3:
+			s.italic = true
+		case c == 4:
+			s.underline = true
+		case c == 7:
+			s.rev = true
+		case c == 22:
+			s.bold, s.faint = false, false
+		case c == 23:
+			s.italic = false
+		case c == 24:
+			s.underline = false
+		case c == 27:
+			s.rev = false
+		case c >= 30 && c <= 37:
+			s.fg = ansi16[c-30]
+		case c >= 90 && c <= 97:
+			s.fg = ansi16[c-90+8]
+		case c == 39:
+			s.fg = ""
+		case c >= 40 && c <= 47:
+			s.bg = ansi16[c-40]
+		case c >= 100 && c <= 107:
+			s.bg = ansi16[c-100+8]
+		case c == 49:
+			s.bg = ""
+		case c == 38 || c == 48:
+			var color string
+			if i+2 < len(codes) && codes[i+1] == "5" {
+				n, _ := strconv.Atoi(codes[i+2])
+				color = ansi256(n)
+				i += 2
+			} else if i+4 < len(codes) && codes[i+1] == "2" {
+				r, _ := strconv.Atoi(codes[i+2])
+				g, _ := strconv.Atoi(codes[i+3])
+				b, _ := strconv.Atoi(codes[i+4])
+				color = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+				i += 4
+			}
+			if c == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// ansiToHTML converts ANSI-styled terminal output into a standalone HTML page.
+// SGR sequences become inline-styled spans; other escape sequences are dropped.
+func ansiToHTML(frame string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>planc</title></head>\n")
+	b.WriteString("<body style=\"background:#1e1e1e;color:#d4d4d4;margin:0;padding:1em\">\n")
+	b.WriteString("<pre style=\"font-family:Menlo,Consolas,'DejaVu Sans Mono',monospace;font-size:13px;line-height:1.2\">")
+
+	var st sgrState
+	open := false
+	var text strings.Builder
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		b.WriteString(html.EscapeString(text.String()))
+		text.Reset()
+	}
+	setStyle := func() {
+		flush()
+		if open {
+			b.WriteString("</span>")
+			open = false
+		}
+		if css := st.css(); css != "" {
+			b.WriteString(`<span style="` + css + `">`)
+			open = true
+		}
+	}
+
+	for i := 0; i < len(frame); i++ {
+		if frame[i] != 0x1b {
+			text.WriteByte(frame[i])
+			continue
+		}
+		if i+1 >= len(frame) {
+			break
+		}
+		switch frame[i+1] {
+		case '[':
+			// CSI: parameters then a final byte in 0x40–0x7e.
+			j := i + 2
+			for j < len(frame) && (frame[j] < 0x40 || frame[j] > 0x7e) {
+				j++
+			}
+			if j < len(frame) && frame[j] == 'm' {
+				st.applySGR(frame[i+2 : j])
+				setStyle()
+			}
+			i = j
+		case ']':
+			// OSC: terminated by BEL or ST (ESC \).
+			j := i + 2
+			for j < len(frame) && frame[j] != 0x07 && !(frame[j] == 0x1b && j+1 < len(frame) && frame[j+1] == '\\') {
+				j++
+			}
+			if j < len(frame) && frame[j] == 0x1b {
+				j++
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	flush()
+	if open {
+		b.WriteString("</span>")
+	}
+	b.WriteString("</pre>\n</body></html>\n")
+	return b.String()
+}
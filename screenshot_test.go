@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnsiToHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"plain text escaped", "a <b> & c", []string{"a &lt;b&gt; &amp; c"}},
+		{"bold basic color", "\x1b[1;32mok\x1b[0m", []string{`<span style="color:#0dbc79;font-weight:bold">ok</span>`}},
+		{"256 color", "\x1b[38;5;204mlabel\x1b[m", []string{`color:#ff5f87`, ">label</span>"}},
+		{"truecolor background", "\x1b[48;2;1;2;3mx\x1b[49m", []string{`background:#010203`}},
+		{"non-SGR sequences dropped", "\x1b[2Kline\x1b]0;title\x07", []string{">line</pre>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ansiToHTML(tt.input)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("ansiToHTML(%q) missing %q\ngot: %s", tt.input, w, got)
+				}
+			}
+			if strings.Contains(got, "\x1b") {
+				t.Errorf("output still contains escape bytes: %q", got)
+			}
+		})
+	}
+}
+
+func TestSaveScreenshot(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	msg := saveScreenshot("\x1b[35mPlanc\x1b[0m", dir, now)()
+	saved, ok := msg.(screenshotSavedMsg)
+	if !ok {
+		t.Fatalf("expected screenshotSavedMsg, got %T", msg)
+	}
+	if want := filepath.Join(dir, "planc-20260301-093000.html"); saved.path != want {
+		t.Errorf("path = %q, want %q", saved.path, want)
+	}
+	ans, err := os.ReadFile(filepath.Join(dir, "planc-20260301-093000.ans"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(ans) != "\x1b[35mPlanc\x1b[0m" {
+		t.Errorf(".ans content = %q", ans)
+	}
+}
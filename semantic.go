@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Semantic Search ─────────────────────────────────────────────────────────
+//
+// Optional embedding index over plan bodies. When `embedding_url` is set, each
+// plan is embedded once (cached on disk by path + mtime) and the list search
+// appends semantically similar plans after the usual fuzzy matches. The
+// endpoint may speak either the Ollama (/api/embeddings) or OpenAI
+// (/v1/embeddings) request/response shape.
+//
+// The filter itself never waits on the endpoint: it ranks with the query
+// vectors already cached, and the model fetches a term's vector once typing
+// pauses (debounceQuery), then re-runs the filter. Until then the fuzzy
+// matches show alone.
+
+const (
+	embedMaxChars      = 8000 // body prefix sent for embedding, in bytes
+	semanticMinQuery   = 3    // shorter queries use fuzzy matching only
+	semanticMinScore   = 0.45 // cosine similarity cutoff for semantic matches
+	semanticMaxResults = 20
+	semanticMaxQueries = 100                    // query vectors kept, oldest dropped first
+	semanticDebounce   = 300 * time.Millisecond // typing pause before a query is embedded
+)
+
+type embeddingCacheEntry struct {
+	Modified int64     `json:"modified"` // plan mtime (unix nanos) the vector was computed from
+	Vector   []float64 `json:"vector"`
+}
+
+// semanticIndex holds plan embeddings keyed by full path, plus a cache of
+// query embeddings. Shared by pointer between the model and the list filter,
+// which runs off the Update loop, so access is guarded by mu.
+type semanticIndex struct {
+	url, model string
+	cachePath  string
+	client     *http.Client
+
+	mu         sync.Mutex
+	vectors    map[string]embeddingCacheEntry
+	queries    map[string][]float64
+	queryOrder []string // keys of queries, oldest first
+}
+
+func newSemanticIndex(cfg config) *semanticIndex {
+	if cfg.EmbeddingURL == "" {
+		return nil
+	}
+	idx := &semanticIndex{
+		url:     cfg.EmbeddingURL,
+		model:   cfg.EmbeddingModel,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		vectors: make(map[string]embeddingCacheEntry),
+		queries: make(map[string][]float64),
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		idx.cachePath = filepath.Join(dir, "planc", "embeddings.json")
+		if data, err := os.ReadFile(idx.cachePath); err == nil {
+			_ = json.Unmarshal(data, &idx.vectors)
+		}
+	}
+	return idx
+}
+
+// embed requests an embedding vector for text from the configured endpoint.
+func (s *semanticIndex) embed(text string) ([]float64, error) {
+	payload, err := json.Marshal(map[string]string{
+		"model":  s.model,
+		"prompt": text, // Ollama
+		"input":  text, // OpenAI-compatible
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", s.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key := os.Getenv("PLANC_EMBEDDING_KEY"); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedding request: %s", resp.Status)
+	}
+	var out struct {
+		Embedding []float64 `json:"embedding"`
+		Data      []struct {
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("embedding response: %w", err)
+	}
+	if len(out.Embedding) > 0 {
+		return out.Embedding, nil
+	}
+	if len(out.Data) > 0 && len(out.Data[0].Embedding) > 0 {
+		return out.Data[0].Embedding, nil
+	}
+	return nil, fmt.Errorf("embedding response: no vector")
+}
+
+// build embeds any plans whose cached vector is missing or stale, drops
+// entries for plans that no longer exist, and persists the cache.
+func (s *semanticIndex) build(plans []plan) tea.Cmd {
+	return func() tea.Msg {
+		s.mu.Lock()
+		live := make(map[string]bool, len(plans))
+		var stale []plan
+		for _, p := range plans {
+			live[p.path()] = true
+			if e, ok := s.vectors[p.path()]; !ok || e.Modified != p.modified.UnixNano() {
+				stale = append(stale, p)
+			}
+		}
+		for path := range s.vectors {
+			if !live[path] {
+				delete(s.vectors, path)
+			}
+		}
+		s.mu.Unlock()
+
+		var firstErr error
+		for _, p := range stale {
+			data, err := os.ReadFile(p.path())
+			if err != nil {
+				continue
+			}
+			_, body := parseFrontmatter(string(data))
+			body = truncateUTF8(body, embedMaxChars)
+			vec, err := s.embed(p.title + "\n\n" + body)
+			if err != nil {
+				// Endpoint is likely down; don't hammer it for every plan.
+				firstErr = err
+				break
+			}
+			s.mu.Lock()
+			s.vectors[p.path()] = embeddingCacheEntry{Modified: p.modified.UnixNano(), Vector: vec}
+			s.mu.Unlock()
+		}
+
+		s.mu.Lock()
+		count := len(s.vectors)
+		data, err := json.Marshal(s.vectors)
+		s.mu.Unlock()
+		if err == nil && s.cachePath != "" {
+			if os.MkdirAll(filepath.Dir(s.cachePath), 0755) == nil {
+				_ = os.WriteFile(s.cachePath, data, 0644)
+			}
+		}
+		return semanticIndexedMsg{count: count, err: firstErr}
+	}
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// cachedQuery returns the embedding fetched for a search term, or nil.
+func (s *semanticIndex) cachedQuery(term string) []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queries[term]
+}
+
+// debounceQuery returns a command that asks for term's embedding after a
+// typing pause, or nil if the filter doesn't need one.
+func (s *semanticIndex) debounceQuery(term string) tea.Cmd {
+	if len(strings.TrimSpace(term)) < semanticMinQuery || s.cachedQuery(term) != nil {
+		return nil
+	}
+	return tea.Tick(semanticDebounce, func(time.Time) tea.Msg { return semanticQueryMsg{term: term} })
+}
+
+// fetchQuery embeds term off the Update loop and caches the vector, dropping
+// the oldest query past semanticMaxQueries.
+func (s *semanticIndex) fetchQuery(term string) tea.Cmd {
+	return func() tea.Msg {
+		vec, err := s.embed(term)
+		if err != nil {
+			return semanticQueryMsg{term: term, done: true, err: err}
+		}
+		s.mu.Lock()
+		if _, ok := s.queries[term]; !ok {
+			s.queryOrder = append(s.queryOrder, term)
+		}
+		s.queries[term] = vec
+		for len(s.queryOrder) > semanticMaxQueries {
+			delete(s.queries, s.queryOrder[0])
+			s.queryOrder = s.queryOrder[1:]
+		}
+		s.mu.Unlock()
+		return semanticQueryMsg{term: term, done: true}
+	}
+}
+
+// filterFor returns the list.FilterFunc for a list showing items: fuzzy
+// matches first (unchanged behavior), then plans whose embeddings are close
+// to the query, most similar first.
+func (s *semanticIndex) filterFor(items []list.Item) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		return s.filter(term, targets, items)
+	}
+}
+
+// filter ranks targets, the FilterValues of items in the same order.
+func (s *semanticIndex) filter(term string, targets []string, items []list.Item) []list.Rank {
+	ranks := list.DefaultFilter(term, targets)
+	if len(strings.TrimSpace(term)) < semanticMinQuery {
+		return ranks
+	}
+	q := s.cachedQuery(term)
+	if q == nil {
+		return ranks
+	}
+	matched := make(map[int]bool, len(ranks))
+	for _, r := range ranks {
+		matched[r.Index] = true
+	}
+	type scored struct {
+		index int
+		score float64
+	}
+	var extra []scored
+	s.mu.Lock()
+	for i := range targets {
+		if matched[i] || i >= len(items) {
+			continue
+		}
+		p, ok := items[i].(plan)
+		if !ok {
+			continue
+		}
+		e, ok := s.vectors[p.path()]
+		if !ok {
+			continue
+		}
+		if sc := cosineSimilarity(q, e.Vector); sc >= semanticMinScore {
+			extra = append(extra, scored{i, sc})
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(extra, func(i, j int) bool { return extra[i].score > extra[j].score })
+	if len(extra) > semanticMaxResults {
+		extra = extra[:semanticMaxResults]
+	}
+	for _, e := range extra {
+		ranks = append(ranks, list.Rank{Index: e.index})
+	}
+	return ranks
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0 if
+// they differ in length or either is zero.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeEmbedServer embeds text as a 2-d vector: [mentions databases, mentions UI].
+func fakeEmbedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input string `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		text := strings.ToLower(req.Input)
+		vec := []float64{0.01, 0.01}
+		for _, w := range []string{"database", "schema", "migration", "postgres"} {
+			if strings.Contains(text, w) {
+				vec[0]++
+			}
+		}
+		for _, w := range []string{"button", "layout", "css"} {
+			if strings.Contains(text, w) {
+				vec[1]++
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"embedding": vec}}})
+	}))
+}
+
+func TestSemanticFilter(t *testing.T) {
+	srv := fakeEmbedServer(t)
+	defer srv.Close()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "db.md"), "# Storage rework\n\nMove the schema to Postgres.\n")
+	writeFile(t, filepath.Join(dir, "ui.md"), "# Settings page\n\nNew button layout and CSS.\n")
+	plans, err := scanPlans(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx := newSemanticIndex(config{EmbeddingURL: srv.URL})
+	msg := idx.build(plans)().(semanticIndexedMsg)
+	if msg.err != nil || msg.count != 2 {
+		t.Fatalf("build = %+v, want 2 vectors", msg)
+	}
+
+	targets := make([]string, len(plans))
+	for i, p := range plans {
+		targets[i] = p.FilterValue()
+	}
+	filter := idx.filterFor(plansToItems(plans))
+	const term = "database migration approach"
+	if ranks := filter(term, targets); len(ranks) != 0 {
+		t.Errorf("before the query is fetched only fuzzy matches should show, got %+v", ranks)
+	}
+	if msg := idx.fetchQuery(term)().(semanticQueryMsg); !msg.done || msg.err != nil {
+		t.Fatalf("fetchQuery = %+v", msg)
+	}
+	ranks := filter(term, targets)
+	if len(ranks) != 1 || plans[ranks[0].Index].file != "db.md" {
+		t.Fatalf("semantic ranks = %+v, want only db.md", ranks)
+	}
+
+	// Short queries stay fuzzy-only.
+	if ranks := filter("db", targets); len(ranks) != 1 || plans[ranks[0].Index].file != "db.md" {
+		t.Errorf("fuzzy ranks = %+v, want db.md by filename", ranks)
+	}
+
+	// A second build reuses the on-disk cache without re-embedding.
+	srv.Close()
+	idx2 := newSemanticIndex(config{EmbeddingURL: srv.URL})
+	if msg := idx2.build(plans)().(semanticIndexedMsg); msg.err != nil || msg.count != 2 {
+		t.Errorf("cached build = %+v, want 2 vectors and no error", msg)
+	}
+}
+
+func TestSemanticFilterSameFileName(t *testing.T) {
+	srv := fakeEmbedServer(t)
+	defer srv.Close()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	a, b := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(a, "plan.md"), "# Storage rework\n\nMove the schema to Postgres.\n")
+	writeFile(t, filepath.Join(b, "plan.md"), "# Settings page\n\nNew button layout and CSS.\n")
+	pa, _ := scanPlans(a)
+	pb, _ := scanPlans(b)
+	plans := append(pa, pb...)
+
+	idx := newSemanticIndex(config{EmbeddingURL: srv.URL})
+	if msg := idx.build(plans)().(semanticIndexedMsg); msg.count != 2 {
+		t.Fatalf("build = %+v, want a vector per path", msg)
+	}
+	targets := make([]string, len(plans))
+	for i, p := range plans {
+		targets[i] = p.FilterValue()
+	}
+	idx.fetchQuery("button layout styling")()
+	ranks := idx.filterFor(plansToItems(plans))("button layout styling", targets)
+	if len(ranks) != 1 || plans[ranks[0].Index].dir != b {
+		t.Errorf("ranks = %+v, want only the plan in %s", ranks, b)
+	}
+}
+
+func TestSemanticQueryCacheBounded(t *testing.T) {
+	srv := fakeEmbedServer(t)
+	defer srv.Close()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	idx := newSemanticIndex(config{EmbeddingURL: srv.URL})
+	for i := range semanticMaxQueries + 5 {
+		idx.fetchQuery(fmt.Sprintf("query %d", i))()
+	}
+	if len(idx.queries) != semanticMaxQueries || len(idx.queryOrder) != semanticMaxQueries {
+		t.Errorf("cache holds %d queries, want %d", len(idx.queries), semanticMaxQueries)
+	}
+	if idx.cachedQuery("query 0") != nil || idx.cachedQuery(fmt.Sprintf("query %d", semanticMaxQueries+4)) == nil {
+		t.Error("the oldest queries should be dropped first")
+	}
+	if idx.debounceQuery(fmt.Sprintf("query %d", semanticMaxQueries)) != nil || idx.debounceQuery("db") != nil {
+		t.Error("cached and short terms shouldn't be fetched")
+	}
+}
+
+func TestSemanticQueryMsgFollowsSearch(t *testing.T) {
+	srv := fakeEmbedServer(t)
+	defer srv.Close()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	m := testModel()
+	m.semantic = newSemanticIndex(config{EmbeddingURL: srv.URL})
+	m.setListItems(m.visiblePlans())
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m = m2.(model)
+	for _, r := range "auth" {
+		m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = m2.(model)
+	}
+	if _, cmd := m.Update(semanticQueryMsg{term: "aut"}); cmd != nil {
+		t.Error("a term the search moved on from shouldn't be fetched")
+	}
+	_, cmd := m.Update(semanticQueryMsg{term: "auth"})
+	if cmd == nil {
+		t.Fatal("the current term should be fetched")
+	}
+	msg, ok := cmd().(semanticQueryMsg)
+	if !ok || !msg.done || m.semantic.cachedQuery("auth") == nil {
+		t.Fatalf("fetch = %+v", msg)
+	}
+	if _, cmd := m.Update(msg); cmd == nil {
+		t.Error("a fetched query should re-run the filter")
+	}
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	s := "ab€" // € is 3 bytes
+	for n, want := range map[int]string{2: "ab", 3: "ab", 4: "ab", 5: "ab€", 10: "ab€"} {
+		if got := truncateUTF8(s, n); got != want {
+			t.Errorf("truncateUTF8(%q, %d) = %q, want %q", s, n, got, want)
+		}
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if got := cosineSimilarity([]float64{1, 0}, []float64{1, 0}); got < 0.999 {
+		t.Errorf("identical = %v, want 1", got)
+	}
+	if got := cosineSimilarity([]float64{1, 0}, []float64{0, 1}); got != 0 {
+		t.Errorf("orthogonal = %v, want 0", got)
+	}
+	if got := cosineSimilarity([]float64{1}, []float64{1, 0}); got != 0 {
+		t.Errorf("length mismatch = %v, want 0", got)
+	}
+}
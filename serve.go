@@ -0,0 +1,325 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── planc serve ─────────────────────────────────────────────────────────────
+//
+// `planc serve --port 7777` serves plans over HTTP on localhost so editors,
+// dashboards, and launchers can read and update them:
+//
+//	GET   /plans                   list, ?status=active,reviewed&label=api
+//	GET   /plans/{file}            one plan with its comments and body
+//	PATCH /plans/{file}            {"status": "done", "labels": ["api"]}
+//	POST  /plans/{file}/comments   {"text": "...", "heading": "Rollout"}
+//	GET   /feed                    Atom feed of changes, ?n=50 (see feed.go)
+//
+// Responses are JSON in the shapes of `planc list --json` and `planc export
+// --format json`, except the feed; errors are {"error": "..."}. Writes go through diskStore,
+// as in the TUI, and are audited. Plans are rescanned on every request.
+//
+// There is no authentication, so the server only listens on 127.0.0.1. To
+// keep web pages from reaching it through the browser, requests must name a
+// loopback Host (against DNS rebinding) and writes must be sent as
+// application/json, which browsers won't do cross-origin without a CORS
+// preflight the server never approves.
+
+const servePort = 7777
+
+type planServer struct {
+	cfg       config
+	store     planStore
+	auditPath string // "" skips the audit log
+	now       func() time.Time
+}
+
+func newPlanServer(cfg config, auditPath string) *planServer {
+	return &planServer{
+		cfg:       cfg,
+		store:     diskStore{agentDir: cfg.PlansDir, projectGlob: cfg.ProjectPlanGlob},
+		auditPath: auditPath,
+		now:       time.Now,
+	}
+}
+
+func (s *planServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /plans", s.listPlans)
+	mux.HandleFunc("GET /plans/{file}", s.getPlan)
+	mux.HandleFunc("PATCH /plans/{file}", s.updatePlan)
+	mux.HandleFunc("POST /plans/{file}/comments", s.addComment)
+	mux.HandleFunc("GET /feed", s.feed)
+	return localOnly(mux)
+}
+
+// localOnly rejects requests that may come from a web page (see above).
+func localOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			writeServeError(w, httpError{http.StatusForbidden, fmt.Errorf("host %q is not localhost", r.Host)})
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/json" {
+				writeServeError(w, httpError{http.StatusUnsupportedMediaType, errors.New("send the request body as application/json")})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// httpError is an error with the HTTP status to report it with.
+type httpError struct {
+	code int
+	err  error
+}
+
+func (e httpError) Error() string { return e.err.Error() }
+
+func writeServeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeServeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var he httpError
+	if errors.As(err, &he) {
+		code = he.code
+	}
+	writeServeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// scan returns every plan, and the one named file if file isn't "".
+func (s *planServer) scan(file string) ([]plan, plan, error) {
+	all, err := scanAllPlans(s.cfg.PlansDir, s.cfg.ProjectPlanGlob)
+	if err != nil || file == "" {
+		return all, plan{}, err
+	}
+	path, err := openPlanPath(all, file)
+	if err != nil {
+		return all, plan{}, httpError{http.StatusNotFound, err}
+	}
+	for _, p := range all {
+		if p.path() == path {
+			return all, p, nil
+		}
+	}
+	return all, plan{}, httpError{http.StatusNotFound, fmt.Errorf("no plan %q", file)}
+}
+
+// apply runs a planStore command and audits the change it reports.
+func (s *planServer) apply(cmd tea.Cmd, before []plan) error {
+	msg := cmd()
+	if e, ok := msg.(errMsg); ok {
+		return e.err
+	}
+	if src, ok := msg.(eventSource); ok {
+		for _, ev := range src.planEvents(before) {
+			s.record(ev)
+		}
+	}
+	return nil
+}
+
+func (s *planServer) record(ev planEvent) {
+	if s.auditPath == "" {
+		return
+	}
+	ev.at = s.now()
+	auditLog{s.auditPath}.record(ev)
+}
+
+// document returns the plan named file as it is on disk now.
+func (s *planServer) document(file string) (planDocument, error) {
+	_, p, err := s.scan(file)
+	if err != nil {
+		return planDocument{}, err
+	}
+	data, err := os.ReadFile(p.path())
+	if err != nil {
+		return planDocument{}, err
+	}
+	_, body := parseFrontmatter(string(data))
+	return newPlanDocument(p, body), nil
+}
+
+func (s *planServer) listPlans(w http.ResponseWriter, r *http.Request) {
+	var statuses []string
+	for _, st := range strings.Split(r.URL.Query().Get("status"), ",") {
+		if st = strings.TrimSpace(st); st != "" {
+			statuses = append(statuses, st)
+		}
+	}
+	all, _, err := s.scan("")
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	matched := matchPlans(all, statuses, r.URL.Query().Get("label"))
+	sortPlans(matched)
+	entries := make([]listEntry, len(matched))
+	for i, p := range matched {
+		entries[i] = newListEntry(p)
+	}
+	writeServeJSON(w, http.StatusOK, entries)
+}
+
+func (s *planServer) getPlan(w http.ResponseWriter, r *http.Request) {
+	doc, err := s.document(r.PathValue("file"))
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	writeServeJSON(w, http.StatusOK, doc)
+}
+
+func (s *planServer) updatePlan(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		Status *string   `json:"status"`
+		Labels *[]string `json:"labels"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeServeError(w, httpError{http.StatusBadRequest, err})
+		return
+	}
+	if in.Status == nil && in.Labels == nil {
+		writeServeError(w, httpError{http.StatusBadRequest, errors.New("nothing to change; give status or labels")})
+		return
+	}
+	var status string
+	if in.Status != nil {
+		st, ok := statusByName(*in.Status)
+		if !ok {
+			writeServeError(w, httpError{http.StatusBadRequest, fmt.Errorf("unknown status %q (%s)", *in.Status, statusChoices())})
+			return
+		}
+		status = st
+	}
+	all, p, err := s.scan(r.PathValue("file"))
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	if in.Status != nil && status != p.status {
+		if err := s.apply(s.store.setStatus(p, status), all); err != nil {
+			writeServeError(w, err)
+			return
+		}
+	}
+	if in.Labels != nil {
+		labels := parseLabels(strings.Join(*in.Labels, ","))
+		if labelsString(labels) != labelsString(p.labels) {
+			if err := s.apply(s.store.setLabels(p, labels), all); err != nil {
+				writeServeError(w, err)
+				return
+			}
+		}
+	}
+	s.getPlan(w, r)
+}
+
+func (s *planServer) addComment(w http.ResponseWriter, r *http.Request) {
+	var in struct{ Text, Heading string }
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeServeError(w, httpError{http.StatusBadRequest, err})
+		return
+	}
+	_, p, err := s.scan(r.PathValue("file"))
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	ev, _, err := addPlanComment(p, in.Heading, in.Text)
+	if err != nil {
+		writeServeError(w, httpError{http.StatusBadRequest, err})
+		return
+	}
+	s.record(ev)
+	doc, err := s.document(p.path())
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	writeServeJSON(w, http.StatusCreated, doc)
+}
+
+func runServe(cfg config, args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	port := fs.Int("port", servePort, "port to listen on (localhost only)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc serve [--port N]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	auditPath, err := auditLogPath()
+	if err != nil {
+		auditPath = ""
+	}
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(*port))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return cliError("serve: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Serving plans on http://%s/plans\n", ln.Addr())
+	if err := http.Serve(ln, newPlanServer(cfg, auditPath).handler()); err != nil {
+		return cliError("serve: %v", err)
+	}
+	return 0
+}
+
+// feed serves the Atom feed of the audit log, as `planc feed` prints it.
+func (s *planServer) feed(w http.ResponseWriter, r *http.Request) {
+	limit := feedLimit
+	if v := r.URL.Query().Get("n"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeServeError(w, httpError{http.StatusBadRequest, fmt.Errorf("n must be a positive number, got %q", v)})
+			return
+		}
+		limit = n
+	}
+	var entries []auditEntry
+	if s.auditPath != "" {
+		var err error
+		if entries, err = readAuditLog(s.auditPath); err != nil {
+			writeServeError(w, err)
+			return
+		}
+	}
+	all, _, err := s.scan("")
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	data, err := buildFeed(feedEntries(entries, limit), feedTitles(all), s.now())
+	if err != nil {
+		writeServeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write(data)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanServer(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	cfg.ProjectPlanGlob = ""
+	writeFile(t, filepath.Join(cfg.PlansDir, "auth.md"), "---\nstatus: active\nlabels: api\n---\n# Auth\n\n## Rollout\n\nShip it.\n")
+	writeFile(t, filepath.Join(cfg.PlansDir, "docs.md"), "---\nstatus: done\n---\n# Docs\n")
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	s := newPlanServer(cfg, auditPath)
+	s.now = func() time.Time { return time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC) }
+	h := s.handler()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, "http://localhost:7777"+target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("GET", "/plans?status=active", "")
+	var entries []listEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil || len(entries) != 1 || entries[0].Title != "Auth" {
+		t.Fatalf("GET /plans = %d %s", rec.Code, rec.Body)
+	}
+
+	if rec := do("GET", "/plans/nope.md", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown plan: %d %s", rec.Code, rec.Body)
+	}
+
+	rec = do("PATCH", "/plans/auth.md", `{"status": "done", "labels": ["Infra", "api"]}`)
+	var doc planDocument
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("PATCH = %d %s", rec.Code, rec.Body)
+	}
+	if doc.Status != "done" || strings.Join(doc.Labels, ",") != "api,infra" {
+		t.Errorf("after PATCH: status %q, labels %v", doc.Status, doc.Labels)
+	}
+	if rec := do("PATCH", "/plans/auth.md", `{"status": "shipped"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad status: %d", rec.Code)
+	}
+
+	rec = do("POST", "/plans/auth.md/comments", `{"text": "Needs a flag", "heading": "rollout"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST comment = %d %s", rec.Code, rec.Body)
+	}
+	data, _ := os.ReadFile(filepath.Join(cfg.PlansDir, "auth.md"))
+	if !strings.Contains(string(data), "Needs a flag") {
+		t.Errorf("comment not written:\n%s", data)
+	}
+
+	entriesLogged, err := readAuditLog(auditPath)
+	if err != nil || len(entriesLogged) != 3 {
+		t.Errorf("audit log = %+v, %v", entriesLogged, err)
+	}
+
+	rec = do("GET", "/feed", "")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/atom+xml") {
+		t.Fatalf("GET /feed = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "<title>Auth → done</title>") || strings.Count(body, "<entry>") != 1 {
+		t.Errorf("feed should have the status change:\n%s", body)
+	}
+	if rec := do("GET", "/feed?n=0", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad n: %d", rec.Code)
+	}
+}
+
+func TestPlanServerRejectsBrowserRequests(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	cfg.ProjectPlanGlob = ""
+	writeFile(t, filepath.Join(cfg.PlansDir, "auth.md"), "# Auth\n")
+	h := newPlanServer(cfg, "").handler()
+
+	req := httptest.NewRequest("GET", "/plans", nil)
+	req.Host = "evil.example:7777"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("foreign Host: %d", rec.Code)
+	}
+
+	req = httptest.NewRequest("POST", "http://127.0.0.1:7777/plans/auth.md/comments", strings.NewReader(`{"text": "hi"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain write: %d", rec.Code)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── planc set ───────────────────────────────────────────────────────────────
+//
+// `planc set PLAN... --status done --add-label infra` changes plans' status
+// and labels without the TUI, for shell scripts and git hooks. A PLAN is a
+// path or a plan's file name (with or without .md). All plans are written
+// or, if any write fails, none are. Changes go to the audit log like edits
+// made in the TUI.
+
+// labelsFlag collects repeated, comma-separated label flags.
+type labelsFlag []string
+
+func (l *labelsFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *labelsFlag) Set(s string) error {
+	*l = append(*l, parseLabels(s)...)
+	return nil
+}
+
+// resolvePlanPaths turns PLAN arguments into file paths: an existing file
+// is used as is, anything else must name exactly one scanned plan.
+func resolvePlanPaths(all []plan, args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+			abs, err := filepath.Abs(arg)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, abs)
+			continue
+		}
+		name := strings.TrimSuffix(arg, ".md") + ".md"
+		var matches []string
+		for _, p := range all {
+			if p.file == arg || p.file == name {
+				matches = append(matches, p.path())
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no plan %q", arg)
+		case 1:
+			paths = append(paths, matches[0])
+		default:
+			return nil, fmt.Errorf("%q matches %d plans; give a path", arg, len(matches))
+		}
+	}
+	return paths, nil
+}
+
+// setPlans applies a status (if setStatus) and label changes to paths in one
+// batch and returns the resulting events.
+func setPlans(paths []string, setStatus bool, status string, add, remove []string) ([]planEvent, error) {
+	var events []planEvent
+	now := time.Now()
+	err := plans.BatchSetFrontmatter(paths, func(path string, fields map[string]string) map[string]string {
+		updates := make(map[string]string)
+		if setStatus {
+			updates = plans.StatusUpdates(fields, status, now)
+			if fields["status"] != status {
+				events = append(events, planEvent{kind: eventStatusChanged, path: path, from: fields["status"], to: status})
+			}
+		}
+		if len(add) > 0 || len(remove) > 0 {
+			lu := plans.LabelUpdates(fields, add, remove)
+			for k, v := range lu {
+				updates[k] = v
+			}
+			from := fields["labels"]
+			if from == "" {
+				from = fields["project"]
+			}
+			// Compare as written: labels are stored sorted.
+			from = labelsString(parseLabels(from))
+			if to := labelsString(parseLabels(lu["labels"])); from != to {
+				events = append(events, planEvent{kind: eventLabelsChanged, path: path, from: from, to: to})
+			}
+		}
+		return updates
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// reportSet prints each change and records it in the audit log at auditPath
+// ("" skips the log).
+func reportSet(w io.Writer, events []planEvent, auditPath string, now time.Time) {
+	for _, ev := range events {
+		ev.at = now
+		fmt.Fprintf(w, "%s  %s\n", contractHome(ev.path), newAuditEntry(ev).describe())
+		if auditPath != "" {
+			auditLog{auditPath}.record(ev)
+		}
+	}
+}
+
+func runSet(cfg config, args []string) int {
+	fs := flag.NewFlagSet("set", flag.ContinueOnError)
+	status := fs.String("status", "", "set the status: "+statusList())
+	var add, remove labelsFlag
+	fs.Var(&add, "add-label", "add a label (repeatable, or comma-separated)")
+	fs.Var(&remove, "remove-label", "remove a label (repeatable, or comma-separated)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc set PLAN... [--status S] [--add-label L]... [--remove-label L]...")
+		fs.PrintDefaults()
+	}
+	// Flags may come before or after the plans.
+	var names []string
+	for rest := args; ; {
+		if err := fs.Parse(rest); err != nil {
+			return 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		rest = fs.Args()[1:]
+	}
+	if len(names) == 0 {
+		fs.Usage()
+		return 2
+	}
+	setStatus := *status != ""
+	st, ok := statusByName(*status)
+	if setStatus && !ok {
+		return cliError("set: unknown status %q (%s)", *status, statusChoices())
+	}
+	if !setStatus && len(add) == 0 && len(remove) == 0 {
+		return cliError("set: nothing to change; give --status, --add-label, or --remove-label")
+	}
+
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("set: %v", err)
+	}
+	paths, err := resolvePlanPaths(all, names)
+	if err != nil {
+		return cliError("set: %v", err)
+	}
+	events, err := setPlans(paths, setStatus, st, add, remove)
+	if err != nil {
+		var be *plans.BatchError
+		if errors.As(err, &be) && len(be.Unrestored) == 0 {
+			return cliError("set: %v (no plans changed)", err)
+		}
+		return cliError("set: %v", err)
+	}
+	auditPath, err := auditLogPath()
+	if err != nil {
+		auditPath = ""
+	}
+	reportSet(os.Stdout, events, auditPath, time.Now())
+	return 0
+}
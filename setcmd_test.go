@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolvePlanPaths(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "# A\n")
+	for _, project := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(dir, project, "plans"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(dir, "api", "plans", "b.md"), "# B\n")
+	writeFile(t, filepath.Join(dir, "web", "plans", "b.md"), "# B\n")
+	all, err := scanAllPlans(dir, filepath.Join(dir, "*", "plans"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := resolvePlanPaths(all, []string{"a", filepath.Join(dir, "web", "plans", "b.md")})
+	if err != nil || len(paths) != 2 || paths[0] != filepath.Join(dir, "a.md") {
+		t.Errorf("paths = %v, %v", paths, err)
+	}
+	if _, err := resolvePlanPaths(all, []string{"b.md"}); err == nil || !strings.Contains(err.Error(), "matches 2 plans") {
+		t.Errorf("an ambiguous name should fail: %v", err)
+	}
+	if _, err := resolvePlanPaths(all, []string{"missing"}); err == nil {
+		t.Error("an unknown plan should fail")
+	}
+}
+
+func TestSetPlans(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.md")
+	b := filepath.Join(dir, "b.md")
+	writeFile(t, a, "---\nstatus: reviewed\nlabels: api\n---\n# A\n")
+	writeFile(t, b, "---\nproject: legacy\n---\n# B\n")
+
+	read := func(path string) string {
+		data, _ := os.ReadFile(path)
+		return string(data)
+	}
+
+	events, err := setPlans([]string{a, b}, true, "done", []string{"infra"}, []string{"api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fm, _ := parseFrontmatter(read(a))
+	if fm["status"] != "done" || fm["labels"] != "infra" {
+		t.Errorf("a = %v", fm)
+	}
+	fm, _ = parseFrontmatter(read(b))
+	if fm["status"] != "done" || fm["labels"] != "infra, legacy" || fm["project"] != "" {
+		t.Errorf("b = %v", fm)
+	}
+	if len(events) != 4 {
+		t.Fatalf("events = %+v", events)
+	}
+
+	var out bytes.Buffer
+	audit := filepath.Join(t.TempDir(), auditFileName)
+	reportSet(&out, events, audit, time.Now())
+	if !strings.Contains(out.String(), "status: reviewed → done") || !strings.Contains(out.String(), "labels: legacy → infra, legacy") {
+		t.Errorf("output = %q", out.String())
+	}
+	entries, err := readAuditLog(audit)
+	if err != nil || len(entries) != 4 {
+		t.Errorf("audit entries = %d, %v", len(entries), err)
+	}
+
+	// Setting what's already there is not a change.
+	events, _ = setPlans([]string{a}, true, "done", nil, nil)
+	if len(events) != 0 {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestRunSet(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.PlansDir = t.TempDir()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	path := filepath.Join(cfg.PlansDir, "deploy.md")
+	writeFile(t, path, "# Deploy\n")
+	read := func() string {
+		data, _ := os.ReadFile(path)
+		return string(data)
+	}
+
+	if code := runSet(cfg, []string{"deploy", "--status", "active", "--add-label", "ops,infra"}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	fm, _ := parseFrontmatter(read())
+	if fm["status"] != "active" || fm["labels"] != "infra, ops" {
+		t.Errorf("plan = %v", fm)
+	}
+	if code := runSet(cfg, []string{"deploy"}); code == 0 {
+		t.Error("no change should fail")
+	}
+	if code := runSet(cfg, []string{"--status", "shipped", "deploy"}); code == 0 {
+		t.Error("an unknown status should fail")
+	}
+	if code := runSet(cfg, []string{"--status", "new", "deploy"}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	if strings.Contains(read(), "status:") {
+		t.Errorf("new should remove the status: %q", read())
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Set Field ───────────────────────────────────────────────────────────────
+//
+// The : prompt writes any frontmatter key to the current plan, or to every
+// selected plan in select mode: `sprint=12`, `set epic=auth`. An empty value
+// (`estimate=`) removes the key.
+
+var fieldKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// parseSetCommand parses "key=value" with an optional leading "set ".
+func parseSetCommand(s string) (k, v string, err error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "set "); ok {
+		s = strings.TrimSpace(rest)
+	}
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("expected key=value")
+	}
+	k = strings.TrimSpace(k)
+	if !fieldKeyRegex.MatchString(k) {
+		return "", "", fmt.Errorf("invalid key %q", k)
+	}
+	return k, strings.TrimSpace(v), nil
+}
+
+// batchSetField writes key=value into each file's frontmatter and rescans.
+func batchSetField(agentDir, projectGlob string, paths []string, k, v string) tea.Cmd {
+	return func() tea.Msg {
+		var events []planEvent
+		err := plans.BatchSetFrontmatter(paths, func(path string, fields map[string]string) map[string]string {
+			if fields[k] != v {
+				events = append(events, fieldEvent(path, k, fields[k], v))
+			}
+			return map[string]string{k: v}
+		})
+		if err != nil {
+			return batchFailed(agentDir, projectGlob, paths, err)
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return batchDoneMsg{
+			plans:   plans,
+			files:   paths,
+			message: setFieldMessage(len(paths), k, v),
+			events:  events,
+		}
+	}
+}
+
+func setFieldMessage(n int, k, v string) string {
+	target := "1 plan"
+	if n != 1 {
+		target = fmt.Sprintf("%d plans", n)
+	}
+	msg := fmt.Sprintf("%s: %s=%s", target, k, v)
+	if v == "" {
+		msg = fmt.Sprintf("%s: removed %s", target, k)
+	}
+	return msg
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// openFieldModal opens the set-field prompt for the given plan paths.
+func (m *model) openFieldModal(files []string) tea.Cmd {
+	if len(files) == 0 {
+		return nil
+	}
+	m.settingField = true
+	m.fieldFiles = files
+	m.fieldErr = ""
+	m.fieldInput.SetValue("")
+	m.fieldInput.Focus()
+	return textinput.Blink
+}
+
+func (m model) handleFieldModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.settingField = false
+		m.fieldInput.Blur()
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		k, v, err := parseSetCommand(m.fieldInput.Value())
+		if err != nil {
+			m.fieldErr = err.Error()
+			return m, nil, true
+		}
+		m.settingField = false
+		m.fieldInput.Blur()
+		return m, m.store.batchSetField(m.fieldFiles, k, v), true
+	}
+	m.fieldErr = ""
+	var cmd tea.Cmd
+	m.fieldInput, cmd = m.fieldInput.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) renderFieldModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	warnStyle := lipgloss.NewStyle().Foreground(colorYellow)
+
+	var b strings.Builder
+	title := tr("Set field")
+	if len(m.fieldFiles) > 1 {
+		title = trf("Set field (%d plans)", len(m.fieldFiles))
+	}
+	b.WriteString(helpTitleStyle.Render(title) + "\n")
+	b.WriteString("set " + m.fieldInput.View() + "\n")
+	if m.fieldErr != "" {
+		b.WriteString(warnStyle.Render(m.fieldErr) + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("key=value · key= removes · enter apply · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
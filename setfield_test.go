@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseSetCommand(t *testing.T) {
+	tests := []struct {
+		in, k, v string
+		wantErr  bool
+	}{
+		{"sprint=12", "sprint", "12", false},
+		{"set epic = auth rewrite", "epic", "auth rewrite", false},
+		{"estimate=", "estimate", "", false},
+		{"no equals", "", "", true},
+		{"bad key=1", "", "", true},
+		{"=x", "", "", true},
+	}
+	for _, tt := range tests {
+		k, v, err := parseSetCommand(tt.in)
+		if (err != nil) != tt.wantErr || k != tt.k || v != tt.v {
+			t.Errorf("parseSetCommand(%q) = %q, %q, %v", tt.in, k, v, err)
+		}
+	}
+}
+
+func TestBatchSetField(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.md")
+	b := filepath.Join(dir, "b.md")
+	writeFile(t, a, "# A\n")
+	writeFile(t, b, "---\nsprint: 11\n---\n# B\n")
+
+	msg := batchSetField(dir, "", []string{a, b}, "sprint", "12")().(batchDoneMsg)
+	if msg.message != "2 plans: sprint=12" {
+		t.Errorf("message = %q", msg.message)
+	}
+	for _, p := range []string{a, b} {
+		data, _ := os.ReadFile(p)
+		if !strings.Contains(string(data), "sprint: 12\n") {
+			t.Errorf("%s = %q", filepath.Base(p), data)
+		}
+	}
+
+	batchSetField(dir, "", []string{b}, "sprint", "")()
+	data, _ := os.ReadFile(b)
+	if strings.Contains(string(data), "sprint") {
+		t.Errorf("empty value should remove the key, got %q", data)
+	}
+}
+
+func TestFieldModalRejectsInvalidInput(t *testing.T) {
+	m := testModel()
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	m = m2.(model)
+	if !m.settingField || len(m.fieldFiles) != 1 {
+		t.Fatal(": should open the set-field prompt for the current plan")
+	}
+	m.fieldInput.SetValue("nonsense")
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if !m.settingField || m.fieldErr == "" {
+		t.Error("invalid input should keep the prompt open with an error")
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m2.(model).settingField {
+		t.Error("esc should close the prompt")
+	}
+}
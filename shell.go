@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// ─── Shell ───────────────────────────────────────────────────────────────────
+//
+// The editor and coding agent commands run through a shell so aliases and
+// rc files apply. The shell config field picks it: cmd, powershell, pwsh, or
+// any POSIX shell; the default is cmd on Windows and $SHELL (run with -ic)
+// elsewhere. Each shell gets its own quoting, so paths with spaces and
+// quotes reach the command as one argument.
+
+// commandShell holds the shell config field.
+var commandShell atomic.Value // string
+
+// defaultShell returns the shell used when none is configured.
+func defaultShell(goos string) string {
+	if goos == "windows" {
+		return "cmd"
+	}
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "sh"
+}
+
+// commandBase returns a command's file name without directories or a .exe
+// extension, whichever platform's separators it uses.
+func commandBase(cmd string) string {
+	base := cmd[strings.LastIndexAny(cmd, `/\`)+1:]
+	if strings.HasSuffix(strings.ToLower(base), ".exe") {
+		base = base[:len(base)-len(".exe")]
+	}
+	return base
+}
+
+// shellQuote quotes s as a POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "'\"'\"'") + "'"
+}
+
+// cmdQuote quotes s for cmd.exe and the program's own argument parsing
+// (Microsoft C runtime rules). Backslashes before a quote are doubled, and a
+// quote is written as "", which the runtime reads as a literal quote and
+// which keeps cmd.exe's quote tracking balanced, so & | < > ^ stay literal.
+func cmdQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	slashes := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			slashes++
+		case '"':
+			b.WriteString(strings.Repeat(`\`, 2*slashes) + `""`)
+			slashes = 0
+		default:
+			b.WriteString(strings.Repeat(`\`, slashes))
+			b.WriteByte(c)
+			slashes = 0
+		}
+	}
+	b.WriteString(strings.Repeat(`\`, 2*slashes) + `"`)
+	return b.String()
+}
+
+// psQuoter doubles the characters PowerShell treats as single quotes.
+var psQuoter = strings.NewReplacer("'", "''", "‘", "‘‘", "’", "’’", "‚", "‚‚", "‛", "‛‛")
+
+// psQuote quotes s as a PowerShell verbatim string.
+func psQuote(s string) string {
+	return "'" + psQuoter.Replace(s) + "'"
+}
+
+// shellInvocation returns the argv that runs args through shell. For cmd it
+// also returns the raw Windows command line: cmd.exe doesn't parse its
+// arguments the way Go quotes them, so the line has to be passed verbatim.
+func shellInvocation(shell string, args []string) (argv []string, cmdLine string) {
+	quoteAll := func(quote func(string) string) []string {
+		out := make([]string, len(args))
+		for i, a := range args {
+			out[i] = quote(a)
+		}
+		return out
+	}
+	switch strings.ToLower(commandBase(shell)) {
+	case "cmd":
+		// /S strips exactly the outer quotes, whatever the command starts with.
+		inner := `"` + strings.Join(quoteAll(cmdQuote), " ") + `"`
+		return []string{shell, "/S", "/C", inner}, cmdQuote(shell) + " /S /C " + inner
+	case "powershell", "pwsh":
+		script := "& " + strings.Join(quoteAll(psQuote), " ") + "; exit $LASTEXITCODE"
+		return []string{shell, "-NoLogo", "-Command", script}, ""
+	default:
+		return []string{shell, "-ic", strings.Join(quoteAll(shellQuote), " ")}, ""
+	}
+}
+
+// shellCommand builds an exec.Cmd that runs args through the user's shell.
+func shellCommand(args ...string) *exec.Cmd {
+	logger.Info("launch", "args", args)
+	shell, _ := commandShell.Load().(string)
+	if shell == "" {
+		shell = defaultShell(runtime.GOOS)
+	}
+	argv, cmdLine := shellInvocation(shell, args)
+	c := exec.Command(argv[0], argv[1:]...)
+	if cmdLine != "" {
+		setCmdLine(c, cmdLine)
+	}
+	return c
+}
@@ -0,0 +1,9 @@
+//go:build !unix && !windows
+
+package main
+
+import "os/exec"
+
+func setCmdLine(c *exec.Cmd, line string) {}
+
+func detach(c *exec.Cmd) {}
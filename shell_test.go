@@ -0,0 +1,142 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// parseWindowsArgs splits a command line the way the Microsoft C runtime
+// does for a program's argv.
+func parseWindowsArgs(line string) []string {
+	var args []string
+	var cur strings.Builder
+	inQuotes, inArg := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\':
+			n := 0
+			for i < len(line) && line[i] == '\\' {
+				n++
+				i++
+			}
+			if i < len(line) && line[i] == '"' {
+				cur.WriteString(strings.Repeat(`\`, n/2))
+				if n%2 == 1 {
+					cur.WriteByte('"')
+				} else {
+					i-- // the quote is a delimiter
+				}
+			} else {
+				cur.WriteString(strings.Repeat(`\`, n))
+				i--
+			}
+			inArg = true
+		case c == '"':
+			if inQuotes && i+1 < len(line) && line[i+1] == '"' {
+				cur.WriteByte('"')
+				i++
+			} else {
+				inQuotes = !inQuotes
+			}
+			inArg = true
+		case (c == ' ' || c == '\t') && !inQuotes:
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args
+}
+
+// cmdUnquoted returns the text cmd.exe sees outside double quotes, where
+// & | < > ^ would be interpreted.
+func cmdUnquoted(s string) string {
+	var b strings.Builder
+	in := false
+	for _, r := range s {
+		if r == '"' {
+			in = !in
+			continue
+		}
+		if !in {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func TestShellInvocationCmd(t *testing.T) {
+	editor := expandCommand([]string{`C:\Program Files\Microsoft VS Code\bin\code.cmd`, "--wait"},
+		`C:\Users\Jo Smith\plans\a & b.md`, "")
+	agent := expandCommand([]string{"claude"}, `C:\plans\trailing\`, `Read "this" plan: `)
+
+	for _, args := range [][]string{editor, agent} {
+		argv, line := shellInvocation("cmd", args)
+		if !slices.Equal(argv[:3], []string{"cmd", "/S", "/C"}) {
+			t.Fatalf("argv = %q", argv)
+		}
+		if !strings.HasPrefix(line, `"cmd" /S /C "`) || !strings.HasSuffix(line, `"`) {
+			t.Fatalf("line = %s", line)
+		}
+		// cmd /S drops the outer quotes and runs the rest.
+		inner := strings.TrimSuffix(strings.TrimPrefix(argv[3], `"`), `"`)
+		if strings.ContainsAny(cmdUnquoted(inner), "&|<>^") {
+			t.Errorf("metacharacters outside quotes: %s", inner)
+		}
+		if got := parseWindowsArgs(inner); !slices.Equal(got, args) {
+			t.Errorf("program would see %q, want %q", got, args)
+		}
+	}
+}
+
+func TestShellInvocationPowerShell(t *testing.T) {
+	args := []string{`C:\Program Files\nvim\nvim.exe`, `C:\plans\it's ‘quoted’.md`}
+	for _, shell := range []string{"pwsh", `C:\Windows\System32\WindowsPowerShell\v1.0\powershell.exe`} {
+		argv, line := shellInvocation(shell, args)
+		if line != "" {
+			t.Errorf("%s needs no raw command line", shell)
+		}
+		want := `& 'C:\Program Files\nvim\nvim.exe' 'C:\plans\it''s ‘‘quoted’’.md'; exit $LASTEXITCODE`
+		if !slices.Equal(argv, []string{shell, "-NoLogo", "-Command", want}) {
+			t.Errorf("argv = %q", argv)
+		}
+	}
+}
+
+func TestShellInvocationPOSIX(t *testing.T) {
+	argv, line := shellInvocation("/bin/zsh", []string{"vim", "/tmp/it's a plan.md"})
+	want := []string{"/bin/zsh", "-ic", `'vim' '/tmp/it'"'"'s a plan.md'`}
+	if !slices.Equal(argv, want) || line != "" {
+		t.Errorf("argv = %q, line = %q", argv, line)
+	}
+}
+
+func TestCommandBase(t *testing.T) {
+	tests := map[string]string{
+		`C:\Program Files\Neovim\bin\nvim.exe`: "nvim",
+		`C:\tools\Code.EXE`:                    "Code",
+		"/usr/bin/vim":                         "vim",
+		"code":                                 "code",
+	}
+	for in, want := range tests {
+		if got := commandBase(in); got != want {
+			t.Errorf("commandBase(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if !isTerminalEditor([]string{`C:\Program Files\Neovim\bin\NVIM.exe`}) {
+		t.Error("nvim.exe should be a terminal editor")
+	}
+	if got := commandLabel([]string{`C:\Users\me\.local\bin\claude.exe`}); got != "claude" {
+		t.Errorf("commandLabel = %q", got)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setCmdLine is only needed for cmd.exe; elsewhere c.Args is passed as is.
+func setCmdLine(c *exec.Cmd, line string) {}
+
+// detach starts c in a new session without a controlling terminal, so an
+// interactive shell doesn't fight planc for it and ctrl+c doesn't reach it.
+func detach(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setCmdLine makes c start with line as its command line instead of the one
+// Go would build from c.Args.
+func setCmdLine(c *exec.Cmd, line string) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.CmdLine = line
+}
+
+// detach starts c in its own process group, so ctrl+c in planc's console
+// doesn't reach it.
+func detach(c *exec.Cmd) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
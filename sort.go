@@ -0,0 +1,200 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Sort Order ──────────────────────────────────────────────────────────────
+//
+// Plans are ordered by zero or more group keys (status, priority, label, title) and then
+// by date, newest first. The date is created or modified time per
+// sortByModified (M); the group keys come from sort_by or the O menu.
+
+// sortKeyNames are the accepted group keys, in the order the menu offers them.
+var sortKeyNames = []string{"status", "priority", "label", "title"}
+
+// sortKeys holds the active group keys. Set from config and the sort menu.
+var sortKeys atomic.Pointer[[]string]
+
+// parseSortKeys reads a comma-separated sort_by value, dropping unknown and
+// repeated keys, so a typo falls back to date order rather than failing.
+func parseSortKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Split(s, ",") {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "labels" {
+			k = "label"
+		}
+		if !slices.Contains(sortKeyNames, k) || slices.Contains(keys, k) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func setSortKeys(keys []string) {
+	sortKeys.Store(&keys)
+}
+
+func currentSortKeys() []string {
+	if keys := sortKeys.Load(); keys != nil {
+		return *keys
+	}
+	return nil
+}
+
+// comparePlans reports the order of a and b under keys: negative if a sorts
+// first, positive if b does, 0 if keys can't tell them apart.
+func comparePlans(a, b plan, keys []string) int {
+	for _, k := range keys {
+		switch k {
+		case "status":
+			if d := statusRank(a.status) - statusRank(b.status); d != 0 {
+				return d
+			}
+		case "priority":
+			if d := priorityRank[a.priority] - priorityRank[b.priority]; d != 0 {
+				return d
+			}
+		case "label":
+			// Unlabeled plans go last.
+			la, lb := firstLabel(a), firstLabel(b)
+			if la != lb {
+				if la == "" || lb == "" {
+					return len(lb) - len(la)
+				}
+				return strings.Compare(la, lb)
+			}
+		case "title":
+			if c := strings.Compare(strings.ToLower(a.title), strings.ToLower(b.title)); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func firstLabel(p plan) string {
+	if len(p.labels) == 0 {
+		return ""
+	}
+	return p.labels[0]
+}
+
+func sortPlans(plans []plan) {
+	keys := currentSortKeys()
+	sort.SliceStable(plans, func(i, j int) bool {
+		if c := comparePlans(plans[i], plans[j], keys); c != 0 {
+			return c < 0
+		}
+		return planDate(plans[i]).After(planDate(plans[j]))
+	})
+}
+
+// sortDescription names the current order for the title bar and menu,
+// e.g. "status › modified".
+func sortDescription(keys []string) string {
+	date := "created"
+	if sortByModified.Load() {
+		date = "modified"
+	}
+	return strings.Join(append(append([]string{}, keys...), date), " › ")
+}
+
+// sortPresets are the orders offered by the sort menu.
+var sortPresets = [][]string{
+	nil,
+	{"status"},
+	{"status", "priority"},
+	{"label"},
+	{"status", "label"},
+	{"label", "status"},
+	{"title"},
+}
+
+// ─── Sort Menu ───────────────────────────────────────────────────────────────
+
+func (m *model) openSortModal() {
+	m.settingSort = true
+	m.sortCursor = 0
+	cur := strings.Join(currentSortKeys(), ",")
+	for i, p := range sortPresets {
+		if strings.Join(p, ",") == cur {
+			m.sortCursor = i
+		}
+	}
+}
+
+// applySort switches to keys, re-sorts, and saves the choice to config.
+func (m *model) applySort(keys []string) {
+	setSortKeys(keys)
+	if !m.demo.active {
+		m.cfg.SortBy = strings.Join(keys, ",")
+		if path, err := configPath(); err == nil {
+			saveConfig(path, m.cfg)
+		}
+	}
+	m.resortPlans()
+}
+
+func (m model) handleSortModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.settingSort = false
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.settingSort = false
+		m.applySort(sortPresets[m.sortCursor])
+		cmd := m.setNotification("Sort: "+sortDescription(currentSortKeys()), statusTimeout)
+		return m, cmd, true
+	case key.Matches(msg, m.keys.ToggleDate):
+		// Flip the date key without leaving the menu.
+		m.toggleDateMode()
+		return m, nil, true
+	case msg.String() == "j" || msg.String() == "down":
+		if m.sortCursor < len(sortPresets)-1 {
+			m.sortCursor++
+		}
+		return m, nil, true
+	case msg.String() == "k" || msg.String() == "up":
+		if m.sortCursor > 0 {
+			m.sortCursor--
+		}
+		return m, nil, true
+	}
+	return m, nil, true
+}
+
+func (m model) renderSortModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Sort")) + "\n")
+	b.WriteString(dimStyle.Render("then newest first") + "\n\n")
+	for i, p := range sortPresets {
+		label := sortDescription(p)
+		if i == m.sortCursor {
+			b.WriteString(accentStyle.Render("> "+label) + "\n")
+		} else {
+			b.WriteString("  " + label + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("j/k navigate · enter select · M created/modified · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
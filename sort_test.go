@@ -0,0 +1,96 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"status", []string{"status"}},
+		{" Status , labels ", []string{"status", "label"}},
+		{"status,bogus,status,title", []string{"status", "title"}},
+	}
+	for _, tt := range tests {
+		if got := parseSortKeys(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("parseSortKeys(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSortPlansCompoundKeys(t *testing.T) {
+	t.Cleanup(func() { setSortKeys(nil) })
+	now := time.Now()
+	day := 24 * time.Hour
+	plans := []plan{
+		{file: "done.md", status: "done", labels: []string{"api"}, created: now},
+		{file: "active-old.md", status: "active", labels: []string{"web"}, created: now.Add(-5 * day)},
+		{file: "reviewed.md", status: "reviewed", created: now.Add(-1 * day)},
+		{file: "active-new.md", status: "active", labels: []string{"api"}, created: now.Add(-2 * day)},
+	}
+	files := func() []string {
+		var out []string
+		for _, p := range plans {
+			out = append(out, p.file)
+		}
+		return out
+	}
+
+	setSortKeys([]string{"status"})
+	sortPlans(plans)
+	if want := []string{"active-new.md", "active-old.md", "reviewed.md", "done.md"}; !slices.Equal(files(), want) {
+		t.Errorf("status order = %v, want %v", files(), want)
+	}
+
+	setSortKeys([]string{"label"})
+	sortPlans(plans)
+	if want := []string{"done.md", "active-new.md", "active-old.md", "reviewed.md"}; !slices.Equal(files(), want) {
+		t.Errorf("label order = %v, want %v (unlabeled last)", files(), want)
+	}
+
+	for i := range plans {
+		plans[i].priority = map[string]string{"active-old.md": "high", "reviewed.md": "low"}[plans[i].file]
+	}
+	setSortKeys([]string{"status", "priority"})
+	sortPlans(plans)
+	if want := []string{"active-old.md", "active-new.md", "reviewed.md", "done.md"}; !slices.Equal(files(), want) {
+		t.Errorf("status, priority order = %v, want %v", files(), want)
+	}
+
+	setSortKeys(nil)
+	sortPlans(plans)
+	if want := []string{"done.md", "reviewed.md", "active-new.md", "active-old.md"}; !slices.Equal(files(), want) {
+		t.Errorf("date order = %v, want %v", files(), want)
+	}
+}
+
+func TestSortMenuAppliesPreset(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Cleanup(func() { setSortKeys(nil) })
+	m := testModel()
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	m = m2.(model)
+	if !m.settingSort {
+		t.Fatal("O should open the sort menu")
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = m2.(model)
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.settingSort {
+		t.Error("enter should close the sort menu")
+	}
+	if got := currentSortKeys(); !slices.Equal(got, []string{"status"}) || m.cfg.SortBy != "status" {
+		t.Errorf("sort keys = %v, config = %q", got, m.cfg.SortBy)
+	}
+	if p := m.list.Items()[len(m.list.Items())-1].(plan); p.status != "reviewed" {
+		t.Errorf("last visible plan status = %q, want reviewed", p.status)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// ─── planc stats ─────────────────────────────────────────────────────────────
+//
+// `planc stats` reports on all plans: counts by status and by label, plans
+// created and completed per week, and the average time from created to
+// done, as text tables or --json for dashboards. A plan's completion time is
+// its last change to done in the audit log or, for history that predates
+// the log, its modification time.
+
+const statsWeeks = 8
+
+// labelStat is how many plans carry a label and how many of those are done.
+type labelStat struct {
+	Label string `json:"label"`
+	Plans int    `json:"plans"`
+	Done  int    `json:"done"`
+}
+
+// weekStat counts plans created and completed in the week starting Start.
+type weekStat struct {
+	Start     time.Time `json:"start"`
+	Created   int       `json:"created"`
+	Completed int       `json:"completed"`
+}
+
+type planStats struct {
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"by_status"`
+	ByLabel  []labelStat    `json:"by_label"`
+	Weeks    []weekStat     `json:"weeks"`
+	// AvgDaysToDone averages created-to-done over the DoneMeasured done
+	// plans with both times.
+	AvgDaysToDone float64 `json:"avg_days_to_done"`
+	DoneMeasured  int     `json:"done_measured"`
+}
+
+// weekStart returns midnight on the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// completionTimes returns when each done plan was completed.
+func completionTimes(plans []plan, audit []auditEntry) map[string]time.Time {
+	doneAt := make(map[string]time.Time)
+	for _, e := range audit {
+		if eventKind(e.Event) == eventStatusChanged && isDone(e.To) && e.Time.After(doneAt[e.File]) {
+			doneAt[e.File] = e.Time
+		}
+	}
+	out := make(map[string]time.Time)
+	for _, p := range plans {
+		if !isDone(p.status) {
+			continue
+		}
+		if t, ok := doneAt[p.path()]; ok {
+			out[p.path()] = t
+		} else if !p.modified.IsZero() {
+			out[p.path()] = p.modified
+		}
+	}
+	return out
+}
+
+// buildStats computes the report, with weeks weeks ending with now's.
+func buildStats(plans []plan, audit []auditEntry, weeks int, now time.Time) planStats {
+	s := planStats{Total: len(plans), ByStatus: map[string]int{}}
+	labels := make(map[string]*labelStat)
+	doneAt := completionTimes(plans, audit)
+
+	first := weekStart(now).AddDate(0, 0, -7*(weeks-1))
+	s.Weeks = make([]weekStat, weeks)
+	for i := range s.Weeks {
+		s.Weeks[i].Start = first.AddDate(0, 0, 7*i)
+	}
+	week := func(t time.Time) int {
+		if t.Before(first) {
+			return -1
+		}
+		return min(int(weekStart(t).Sub(first).Hours()/24/7+0.5), weeks-1)
+	}
+
+	var totalDays float64
+	for _, p := range plans {
+		s.ByStatus[exportStatus(p.status)]++
+		for _, l := range p.labels {
+			if labels[l] == nil {
+				labels[l] = &labelStat{Label: l}
+			}
+			labels[l].Plans++
+			if isDone(p.status) {
+				labels[l].Done++
+			}
+		}
+		if i := week(p.created); !p.created.IsZero() && i >= 0 {
+			s.Weeks[i].Created++
+		}
+		done, ok := doneAt[p.path()]
+		if !ok {
+			continue
+		}
+		if i := week(done); i >= 0 {
+			s.Weeks[i].Completed++
+		}
+		if !p.created.IsZero() && !done.Before(p.created) {
+			totalDays += done.Sub(p.created).Hours() / 24
+			s.DoneMeasured++
+		}
+	}
+	if s.DoneMeasured > 0 {
+		s.AvgDaysToDone = totalDays / float64(s.DoneMeasured)
+	}
+
+	s.ByLabel = []labelStat{}
+	for _, l := range labels {
+		s.ByLabel = append(s.ByLabel, *l)
+	}
+	slices.SortFunc(s.ByLabel, func(a, b labelStat) int {
+		return cmp.Or(cmp.Compare(b.Plans, a.Plans), cmp.Compare(a.Label, b.Label))
+	})
+	return s
+}
+
+// writeText prints the report as aligned tables.
+func (s planStats) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Status\tPlans\n")
+	for _, st := range statusNames() {
+		fmt.Fprintf(tw, "%s\t%d\n", st, s.ByStatus[st])
+	}
+	fmt.Fprintf(tw, "total\t%d\n", s.Total)
+
+	if len(s.ByLabel) > 0 {
+		fmt.Fprintf(tw, "\nLabel\tPlans\tDone\n")
+		for _, l := range s.ByLabel {
+			fmt.Fprintf(tw, "%s\t%d\t%d\n", l.Label, l.Plans, l.Done)
+		}
+	}
+
+	fmt.Fprintf(tw, "\nWeek of\tCreated\tCompleted\n")
+	for _, wk := range s.Weeks {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", wk.Start.Format("2006-01-02"), wk.Created, wk.Completed)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if s.DoneMeasured > 0 {
+		_, err := fmt.Fprintf(w, "\nAverage time to done: %.1f days (%d plans)\n", s.AvgDaysToDone, s.DoneMeasured)
+		return err
+	}
+	return nil
+}
+
+func runStats(cfg config, args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print JSON instead of tables")
+	weeks := fs.Int("weeks", statsWeeks, "how many weeks of created and completed counts to show")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc stats [--json] [--weeks N]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *weeks < 1 {
+		return cliError("stats: --weeks must be at least 1")
+	}
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("stats: %v", err)
+	}
+	var audit []auditEntry
+	if path, err := auditLogPath(); err == nil {
+		audit, _ = readAuditLog(path)
+	}
+	s := buildStats(plans, audit, *weeks, time.Now())
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(s); err != nil {
+			return cliError("stats: %v", err)
+		}
+		return 0
+	}
+	if err := s.writeText(os.Stdout); err != nil {
+		return cliError("stats: %v", err)
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	for _, day := range []int{16, 18, 22} { // Monday, Wednesday, Sunday
+		got := weekStart(time.Date(2026, 3, day, 15, 4, 0, 0, time.UTC))
+		if want := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("weekStart(Mar %d) = %v", day, got)
+		}
+	}
+}
+
+func TestBuildStats(t *testing.T) {
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC) // a Wednesday
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	plans := []plan{
+		{dir: "/p", file: "a.md", status: "done", labels: []string{"api"}, created: day(2), modified: day(17)},
+		{dir: "/p", file: "b.md", status: "done", labels: []string{"api", "ui"}, created: day(10), modified: day(12)},
+		{dir: "/p", file: "c.md", status: "active", labels: []string{"ui"}, created: day(16), modified: day(16)},
+		{dir: "/p", file: "d.md", created: day(17), modified: day(17)},
+	}
+	// a.md was completed on the 6th; it was edited later.
+	audit := []auditEntry{
+		{Time: day(6), Event: string(eventStatusChanged), File: "/p/a.md", From: "active", To: "done"},
+	}
+
+	s := buildStats(plans, audit, 3, now)
+	if s.Total != 4 || s.ByStatus["done"] != 2 || s.ByStatus["new"] != 1 || s.ByStatus["reviewed"] != 0 {
+		t.Errorf("by status = %v", s.ByStatus)
+	}
+	if len(s.ByLabel) != 2 || s.ByLabel[0] != (labelStat{"api", 2, 2}) || s.ByLabel[1] != (labelStat{"ui", 2, 1}) {
+		t.Errorf("by label = %+v", s.ByLabel)
+	}
+	// Weeks of Mar 2, 9, and 16.
+	want := []weekStat{
+		{Start: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Created: 1, Completed: 1},
+		{Start: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Created: 1, Completed: 1},
+		{Start: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), Created: 2, Completed: 0},
+	}
+	for i, w := range want {
+		if !s.Weeks[i].Start.Equal(w.Start) || s.Weeks[i].Created != w.Created || s.Weeks[i].Completed != w.Completed {
+			t.Errorf("week %d = %+v, want %+v", i, s.Weeks[i], w)
+		}
+	}
+	// a.md took 4 days, b.md 2 (from its modification time).
+	if s.DoneMeasured != 2 || s.AvgDaysToDone != 3 {
+		t.Errorf("avg = %v over %d", s.AvgDaysToDone, s.DoneMeasured)
+	}
+
+	var b bytes.Buffer
+	if err := s.writeText(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"done      2", "api    2      2", "2026-03-16  2        0", "Average time to done: 3.0 days (2 plans)"} {
+		if !strings.Contains(b.String(), line) {
+			t.Errorf("text lacks %q:\n%s", line, b.String())
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Statuses ────────────────────────────────────────────────────────────────
+//
+// The status vocabulary comes from the statuses config field, in cycle
+// order, so a team can model its own workflow:
+//
+//	"statuses": [
+//	  {"name": "backlog"},
+//	  {"name": "scoped", "icon": "○", "color": "3"},
+//	  {"name": "in-review", "icon": "◐", "color": "12"},
+//	  {"name": "shipped", "icon": "✓", "done": true}
+//	]
+//
+// The first status is what plans without a status: key show as, and picking
+// it clears the key. Statuses marked done count as finished: they leave the
+// active tab, stop blocking other plans, and are what Z archives; without a
+// done mark the last status is the done one. ~ steps through the list,
+// wrapping past the first status, and 0-9 pick a status by position.
+// Without the field planc uses new, reviewed, active, and done.
+
+// statusDef is one entry of the statuses config field.
+type statusDef struct {
+	Name  string `json:"name"`
+	Icon  string `json:"icon,omitempty"`  // glyph (default: the glyphs entry of that name, else by position)
+	Color string `json:"color,omitempty"` // ANSI number or hex color (default: by name, else by position)
+	Done  bool   `json:"done,omitempty"`  // counts as finished
+}
+
+// statusOption is a status as the UI offers it.
+type statusOption struct {
+	key    string // number key that sets it
+	label  string // name shown and accepted by --status
+	status string // frontmatter value; "" for the first status
+	icon   string // "" falls back to the glyph set
+	color  string // "" falls back to the built-in styles
+	done   bool
+}
+
+// maxStatuses is how many statuses the number keys 0-9 can reach.
+const maxStatuses = 10
+
+func defaultStatuses() []statusDef {
+	return []statusDef{{Name: "new"}, {Name: "reviewed"}, {Name: "active"}, {Name: "done", Done: true}}
+}
+
+var statusVocab atomic.Pointer[[]statusOption]
+
+// setStatuses installs the status vocabulary from config. Names are
+// lowercased with runs of whitespace turned into hyphens ("In review" is
+// in-review), since history: entries are split on whitespace; blank and
+// repeated names and those past the tenth are ignored,
+// and fewer than two statuses leave the defaults in place. Call it before
+// setGlyphs, which pads to the widest status icon.
+func setStatuses(defs []statusDef) {
+	var opts []statusOption
+	for _, d := range defs {
+		name := strings.ToLower(strings.Join(strings.Fields(d.Name), "-"))
+		dup := func(o statusOption) bool { return o.label == name }
+		if name == "" || len(opts) == maxStatuses || slices.ContainsFunc(opts, dup) {
+			continue
+		}
+		opts = append(opts, statusOption{label: name, status: name, icon: d.Icon, color: d.Color, done: d.Done})
+	}
+	if len(opts) < 2 {
+		opts = opts[:0]
+		for _, d := range defaultStatuses() {
+			opts = append(opts, statusOption{label: d.Name, status: d.Name, done: d.Done})
+		}
+	}
+	opts[0].status = ""
+	opts[0].done = false
+	anyDone := false
+	for i := range opts {
+		opts[i].key = string(rune('0' + i))
+		anyDone = anyDone || opts[i].done
+	}
+	if !anyDone {
+		opts[len(opts)-1].done = true
+	}
+	statusVocab.Store(&opts)
+}
+
+// statusOptions returns the statuses in cycle order.
+func statusOptions() []statusOption {
+	if opts := statusVocab.Load(); opts != nil {
+		return *opts
+	}
+	setStatuses(nil)
+	return *statusVocab.Load()
+}
+
+// statusIndex returns s's position in the vocabulary, or -1. The first
+// status matches both "" and its name.
+func statusIndex(s string) int {
+	for i, opt := range statusOptions() {
+		if opt.status == s || opt.label == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// statusByName returns the status value for a status's name (the first
+// status is "").
+func statusByName(name string) (string, bool) {
+	for _, opt := range statusOptions() {
+		if opt.label == name {
+			return opt.status, true
+		}
+	}
+	return "", false
+}
+
+// statusForKey returns the status a number key sets.
+func statusForKey(k string) (string, bool) {
+	for _, opt := range statusOptions() {
+		if opt.key == k {
+			return opt.status, true
+		}
+	}
+	return "", false
+}
+
+func statusCursorForStatus(s string) int {
+	return max(statusIndex(s), 0)
+}
+
+// nextStatus returns the status ~ moves s to: the next one in the list,
+// wrapping from the last to the second.
+func nextStatus(s string) string {
+	opts := statusOptions()
+	i := statusIndex(s) + 1
+	if i <= 0 || i >= len(opts) {
+		i = 1
+	}
+	return opts[i].status
+}
+
+// isDone reports whether s is a finished status.
+func isDone(s string) bool {
+	i := statusIndex(s)
+	return i >= 0 && statusOptions()[i].done
+}
+
+// isInProgress reports whether s is a status between the first and the
+// finished ones: reviewed or active by default.
+func isInProgress(s string) bool {
+	return statusIndex(s) > 0 && !isDone(s)
+}
+
+// isActive reports whether s is the in-progress status furthest along, the
+// one work happens in: active by default.
+func isActive(s string) bool {
+	names := inProgressNames()
+	return len(names) > 0 && statusLabel(s) == names[0]
+}
+
+// inProgressNames returns the in-progress status names, furthest along
+// first: "active", "reviewed" by default.
+func inProgressNames() []string {
+	var names []string
+	for _, opt := range slices.Backward(statusOptions()) {
+		if isInProgress(opt.status) {
+			names = append(names, opt.label)
+		}
+	}
+	return names
+}
+
+// statusRank orders statuses for sorting: work in progress first, furthest
+// along first, then the first status, then finished ones. Unknown statuses
+// sort with the furthest along.
+func statusRank(s string) int {
+	opts := statusOptions()
+	i := statusIndex(s)
+	if i < 0 {
+		return 0
+	}
+	if opts[i].done {
+		return len(opts)
+	}
+	return len(opts) - 1 - i
+}
+
+// statusNames returns the status names in cycle order.
+func statusNames() []string {
+	opts := statusOptions()
+	names := make([]string, len(opts))
+	for i, opt := range opts {
+		names[i] = opt.label
+	}
+	return names
+}
+
+// statusList joins the status names for help text: "new, reviewed,
+// active, or done".
+func statusList() string {
+	names := statusNames()
+	last := len(names) - 1
+	if last == 1 {
+		return names[0] + " or " + names[1]
+	}
+	return strings.Join(names[:last], ", ") + ", or " + names[last]
+}
+
+// statusChoices is the hint unknown-status errors end with.
+func statusChoices() string {
+	return "want " + statusList()
+}
+
+// setStatusBinding binds a number key to each status: 0-3 by default.
+func setStatusBinding() key.Binding {
+	opts := statusOptions()
+	keys := make([]string, len(opts))
+	for i, opt := range opts {
+		keys[i] = opt.key
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp("0-"+keys[len(keys)-1], tr("set status")))
+}
+
+// statusIconFor returns the unpadded glyph for a status: its configured
+// icon, the glyph of the same name, or one by position.
+func statusIconFor(s string, g glyphSet) string {
+	i := statusIndex(s)
+	if i >= 0 {
+		if icon := statusOptions()[i].icon; icon != "" {
+			return icon
+		}
+	}
+	switch name := statusLabel(s); {
+	case name == "active":
+		return g.active
+	case name == "reviewed":
+		return g.reviewed
+	case name == "done" || i >= 0 && statusOptions()[i].done:
+		return g.done
+	case i > 0:
+		return g.reviewed
+	}
+	return g.new
+}
+
+// statusStyle returns the color a status is drawn in: its configured color,
+// the built-in style of the same name, or one by position.
+func statusStyle(s string) lipgloss.Style {
+	i := statusIndex(s)
+	if i >= 0 {
+		if c := statusOptions()[i].color; c != "" {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color(c))
+		}
+	}
+	switch name := statusLabel(s); {
+	case name == "active":
+		return activeStyle
+	case name == "reviewed":
+		return reviewedStyle
+	case name == "done" || i >= 0 && statusOptions()[i].done:
+		return doneStyle
+	case i > 0:
+		return reviewedStyle
+	}
+	return unsetStyle
+}
+
+// statusLabel returns the name a status is shown by: the first status's
+// name for "", else s itself.
+func statusLabel(s string) string {
+	if i := statusIndex(s); i >= 0 {
+		return statusOptions()[i].label
+	}
+	return s
+}
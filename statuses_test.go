@@ -0,0 +1,203 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/jakebf/planc/plans"
+)
+
+// useStatuses installs defs for the test and restores the defaults after.
+func useStatuses(t *testing.T, defs []statusDef) {
+	t.Helper()
+	t.Cleanup(func() {
+		setStatuses(nil)
+		setGlyphs(nil)
+	})
+	setStatuses(defs)
+	setGlyphs(nil)
+}
+
+func TestDefaultStatuses(t *testing.T) {
+	for from, want := range map[string]string{"": "reviewed", "reviewed": "active", "active": "done", "done": "reviewed", "bogus": "reviewed"} {
+		if got := nextStatus(from); got != want {
+			t.Errorf("nextStatus(%q) = %q, want %q", from, got, want)
+		}
+	}
+	order := []string{"done", "", "reviewed", "active"}
+	slices.SortFunc(order, func(a, b string) int { return statusRank(a) - statusRank(b) })
+	if want := []string{"active", "reviewed", "", "done"}; !slices.Equal(order, want) {
+		t.Errorf("rank order = %q, want %q", order, want)
+	}
+	if !isDone("done") || isDone("active") || isDone("") {
+		t.Error("only done should be done")
+	}
+	if got := statusChoices(); got != "want new, reviewed, active, or done" {
+		t.Errorf("statusChoices = %q", got)
+	}
+	if got := statusLabel(""); got != "new" {
+		t.Errorf("statusLabel(\"\") = %q", got)
+	}
+}
+
+func TestCustomStatuses(t *testing.T) {
+	useStatuses(t, []statusDef{
+		{Name: "Backlog"},
+		{Name: "scoped", Icon: "S>"},
+		{Name: " "},
+		{Name: "in-review"},
+		{Name: "scoped"},
+		{Name: "shipped"},
+	})
+
+	if got := statusNames(); !slices.Equal(got, []string{"backlog", "scoped", "in-review", "shipped"}) {
+		t.Fatalf("names = %q (blank and repeated names should be dropped)", got)
+	}
+	if st, ok := statusByName("backlog"); !ok || st != "" {
+		t.Errorf("the first status should clear status:, got %q, %v", st, ok)
+	}
+	if _, ok := statusByName("active"); ok {
+		t.Error("built-in statuses shouldn't be accepted")
+	}
+	if got := nextStatus("shipped"); got != "scoped" {
+		t.Errorf("~ from the last status = %q, want scoped", got)
+	}
+	if !isDone("shipped") || isDone("in-review") {
+		t.Error("without a done mark the last status is done")
+	}
+	if st, ok := statusForKey("2"); !ok || st != "in-review" {
+		t.Errorf("key 2 = %q, %v", st, ok)
+	}
+	if got := setStatusBinding().Help().Key; got != "0-3" {
+		t.Errorf("key range = %q", got)
+	}
+	if got := knownStatuses(); !slices.Equal(got, []string{"scoped", "in-review", "shipped", "pending"}) {
+		t.Errorf("knownStatuses = %q", got)
+	}
+	if got := statusIcon("scoped"); got != "S>" {
+		t.Errorf("configured icon = %q", got)
+	}
+	if got := statusIcon("shipped"); got != "✓ " {
+		t.Errorf("done statuses fall back to the done glyph, padded: %q", got)
+	}
+	if got := statusChoices(); got != "want backlog, scoped, in-review, or shipped" {
+		t.Errorf("statusChoices = %q", got)
+	}
+}
+
+func TestStatusNamesWithSpaces(t *testing.T) {
+	useStatuses(t, []statusDef{{Name: "to do"}, {Name: " In  review "}, {Name: "in-review"}, {Name: "shipped"}})
+	if got := statusNames(); !slices.Equal(got, []string{"to-do", "in-review", "shipped"}) {
+		t.Fatalf("names = %q", got)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+	writeFile(t, path, "# Plan\n")
+	for _, s := range []string{"in-review", "shipped"} {
+		if err := plans.SetStatus(path, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	fields, _ := parseFrontmatter(string(data))
+	if got := plans.ParseHistory(fields["history"]); len(got) != 2 || got[0].To != "in-review" {
+		t.Errorf("history should keep both changes, got %+v", got)
+	}
+}
+
+func TestCustomStatusesDoneMark(t *testing.T) {
+	useStatuses(t, []statusDef{{Name: "todo"}, {Name: "doing"}, {Name: "shipped", Done: true}, {Name: "dropped", Done: true}})
+	if !isDone("shipped") || !isDone("dropped") || isDone("doing") {
+		t.Error("statuses marked done should be done, and only those")
+	}
+	plans := []plan{{file: "a.md", status: "dropped"}, {file: "b.md", status: "doing"}, {file: "c.md", status: "shipped"}}
+	if got := filterPlans(plans, false, nil, "", time.Time{}); len(got) != 1 || got[0].file != "b.md" {
+		t.Errorf("the active tab should hide every done status, got %v", got)
+	}
+}
+
+func TestTooFewStatusesKeepsDefaults(t *testing.T) {
+	useStatuses(t, []statusDef{{Name: "only"}})
+	if got := statusNames(); !slices.Equal(got, []string{"new", "reviewed", "active", "done"}) {
+		t.Errorf("names = %q", got)
+	}
+}
+
+func TestCustomStatusesInList(t *testing.T) {
+	useStatuses(t, []statusDef{{Name: "backlog"}, {Name: "scoped"}, {Name: "in-review", Icon: "R>"}, {Name: "shipped"}})
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "plan-a.md"), "---\nstatus: in-review\n---\n# Plan A\n")
+
+	plans, _ := scanPlans(dir)
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "R> Plan A") {
+		t.Errorf("row should use the configured icon:\n%s", view)
+	}
+
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = m2.(model)
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "3  ✓   shipped") || !strings.Contains(view, "0-3 select") {
+		t.Errorf("status modal should list the configured statuses:\n%s", view)
+	}
+
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	m = m2.(model)
+	if cmd == nil {
+		t.Fatal("3 should set the status")
+	}
+	cmd()
+	data, _ := os.ReadFile(filepath.Join(dir, "plan-a.md"))
+	if fields, _ := parseFrontmatter(string(data)); fields["status"] != "shipped" {
+		t.Errorf("status = %q, want shipped", fields["status"])
+	}
+}
+
+func TestCustomStatusesInReports(t *testing.T) {
+	useStatuses(t, []statusDef{{Name: "backlog"}, {Name: "scoped"}, {Name: "in-review"}, {Name: "shipped"}})
+	if !isInProgress("scoped") || !isInProgress("in-review") || isInProgress("") || isInProgress("shipped") {
+		t.Error("in progress should be the statuses between the first and the done ones")
+	}
+	if !isActive("in-review") || isActive("scoped") {
+		t.Error("active should be the in-progress status furthest along")
+	}
+
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -30)
+	all := []plan{
+		{file: "a.md", status: "in-review", modified: old},
+		{file: "b.md", status: "scoped", modified: old},
+		{file: "c.md", modified: old},
+	}
+	if got := stalePlans(all, 14*24*time.Hour, now); len(got) != 1 || got[0].file != "a.md" {
+		t.Errorf("stale = %v", got)
+	}
+	if got := summaryLine(all, 1); got != "plans: 1 in-review · 1 scoped · 1 stale" {
+		t.Errorf("summary = %q", got)
+	}
+	if d := buildDigest(all, nil, now.AddDate(0, 0, -7), now); len(d.stalled) != 2 {
+		t.Errorf("digest stalled = %v", d.stalled)
+	}
+	history := []plans.StatusChange{{At: old, To: "scoped"}, {At: old.AddDate(0, 0, 1), From: "scoped", To: "in-review"}, {At: old.AddDate(0, 0, 4), From: "in-review", To: "shipped"}}
+	if got, ok := cycleTime(history); !ok || got != 3*24*time.Hour {
+		t.Errorf("cycleTime = %v, %v", got, ok)
+	}
+}
+
+func TestCustomStatusesInMCPSchema(t *testing.T) {
+	useStatuses(t, []statusDef{{Name: "backlog"}, {Name: "scoped"}, {Name: "shipped"}})
+	for _, tool := range mcpTools() {
+		if tool.Name == "set_status" && tool.Description != "Set a plan's status: backlog, scoped, or shipped." {
+			t.Errorf("set_status description = %q", tool.Description)
+		}
+	}
+}
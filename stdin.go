@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ─── planc - ─────────────────────────────────────────────────────────────────
+//
+// `cat plan.md | planc -` opens piped markdown in a one-off session, e.g. to
+// review a plan generated in CI. The text is written to a new temp
+// directory, which is the session's plans directory (project plans are left
+// out); keys still come from the terminal. Comments, status changes, and
+// other edits go to that file, whose path is printed on exit. Choices that
+// are normally saved to the config, such as the sort order, only last for
+// the session, so the config keeps the real plans directory.
+
+// readStdinPlan writes the markdown read from r to a new temp directory and
+// returns its path. The file is named after the plan's title.
+func readStdinPlan(r io.Reader) (string, error) {
+	if f, ok := r.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return "", errors.New("planc - reads a plan from a pipe, e.g. cat plan.md | planc -")
+		}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", errors.New("no plan on stdin")
+	}
+	dir, err := os.MkdirTemp("", "planc-")
+	if err != nil {
+		return "", err
+	}
+	fm, body := parseFrontmatter(string(data))
+	title, _ := planTitle(fm, body, "stdin.md")
+	path := filepath.Join(dir, planSlug(title)+".md")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadStdinPlan(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	md := "---\nstatus: reviewed\n---\n# Deploy to staging\n\nSteps.\n"
+	path, err := readStdinPlan(strings.NewReader(md))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "deploy-to-staging.md" {
+		t.Errorf("path = %s", path)
+	}
+	if data, _ := os.ReadFile(path); string(data) != md {
+		t.Errorf("content = %q", data)
+	}
+	all, err := scanAllPlans(filepath.Dir(path), "")
+	if err != nil || len(all) != 1 || all[0].status != "reviewed" {
+		t.Errorf("plans = %+v, %v", all, err)
+	}
+
+	if _, err := readStdinPlan(strings.NewReader(" \n")); err == nil {
+		t.Error("empty input should fail")
+	}
+}
+
+func TestScratchSessionSkipsConfigSaves(t *testing.T) {
+	scratchSession.Store(true)
+	defer scratchSession.Store(false)
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := saveConfig(path, newDefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("config was written: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── planc summary ───────────────────────────────────────────────────────────
+//
+// `planc summary` prints a compact overview without starting the TUI: counts
+// by status, the most used labels, the newest plans, and stale active plans
+// (untouched for remind_after_days). It's meant for shell startup files;
+// --line prints a single line for tmux or other status bars. Colors are
+// dropped automatically when output isn't a terminal.
+
+const (
+	summaryTopLabels = 5
+	summaryNewest    = 3
+)
+
+// labelCount is a label and how many unfinished plans carry it.
+type labelCount struct {
+	label string
+	count int
+}
+
+// topLabels returns the n labels most used by unfinished plans, most used
+// first, ties alphabetical.
+func topLabels(plans []plan, n int) []labelCount {
+	counts := make(map[string]int)
+	for _, p := range plans {
+		if isDone(p.status) {
+			continue
+		}
+		for _, l := range p.labels {
+			counts[l]++
+		}
+	}
+	var out []labelCount
+	for l, c := range counts {
+		out = append(out, labelCount{l, c})
+	}
+	slices.SortFunc(out, func(a, b labelCount) int {
+		return cmp.Or(cmp.Compare(b.count, a.count), cmp.Compare(a.label, b.label))
+	})
+	return out[:min(n, len(out))]
+}
+
+// stalePlans returns active plans (see isActive) untouched for at least after, longest
+// untouched first.
+func stalePlans(plans []plan, after time.Duration, now time.Time) []plan {
+	var out []plan
+	for _, p := range plans {
+		if isActive(p.status) && now.Sub(p.modified) >= after {
+			out = append(out, p)
+		}
+	}
+	slices.SortFunc(out, func(a, b plan) int { return a.modified.Compare(b.modified) })
+	return out
+}
+
+// summaryLine is the one-line overview for status bars.
+func summaryLine(plans []plan, stale int) string {
+	counts := statusCounts(plans)
+	var parts []string
+	for _, name := range inProgressNames() {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[name], name))
+	}
+	if stale > 0 {
+		parts = append(parts, fmt.Sprintf("%d stale", stale))
+	}
+	return "plans: " + strings.Join(parts, " · ")
+}
+
+// buildSummary renders the multi-line overview.
+func buildSummary(plans []plan, after time.Duration, now time.Time) string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	headStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	var b strings.Builder
+	b.WriteString(renderStatusCounts(statusCounts(plans)) + "\n")
+
+	if labels := topLabels(plans, summaryTopLabels); len(labels) > 0 {
+		var parts []string
+		for _, l := range labels {
+			parts = append(parts, labelColor(l.label).Render(l.label)+dimStyle.Render(fmt.Sprintf(" %d", l.count)))
+		}
+		b.WriteString(dimStyle.Render("Labels: ") + strings.Join(parts, dimStyle.Render(" · ")) + "\n")
+	}
+
+	newest := slices.Clone(plans)
+	slices.SortFunc(newest, func(a, b plan) int { return b.created.Compare(a.created) })
+	newest = newest[:min(summaryNewest, len(newest))]
+	if len(newest) > 0 {
+		b.WriteString("\n" + headStyle.Render("Newest") + "\n")
+		for _, p := range newest {
+			b.WriteString("  " + truncateForWidth(p.title, 60) + dimStyle.Render("  "+p.created.Format("2006-01-02")) + "\n")
+		}
+	}
+
+	if after > 0 {
+		if stale := stalePlans(plans, after, now); len(stale) > 0 {
+			b.WriteString("\n" + headStyle.Render("Stale") + "\n")
+			for _, p := range stale {
+				days := int(now.Sub(p.modified).Hours() / 24)
+				b.WriteString("  " + truncateForWidth(p.title, 60) + dimStyle.Render(fmt.Sprintf("  untouched %dd", days)) + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+func runSummary(cfg config, args []string) int {
+	fs := flag.NewFlagSet("summary", flag.ContinueOnError)
+	line := fs.Bool("line", false, "print a single line (for tmux or other status bars)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc summary [--line]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	plans, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("summary: %v", err)
+	}
+	now := time.Now()
+	after := remindAfter(cfg)
+	if *line {
+		stale := 0
+		if after > 0 {
+			stale = len(stalePlans(plans, after, now))
+		}
+		fmt.Println(summaryLine(plans, stale))
+		return 0
+	}
+	fmt.Print(buildSummary(plans, after, now))
+	return 0
+}
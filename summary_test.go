@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildSummary(t *testing.T) {
+	now := time.Date(2025, 6, 20, 10, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	ps := []plan{
+		{file: "a.md", title: "Alpha", status: "active", labels: []string{"api", "web"}, created: now.Add(-30 * day), modified: now.Add(-20 * day)},
+		{file: "b.md", title: "Beta", status: "active", labels: []string{"api"}, created: now.Add(-2 * day), modified: now.Add(-1 * day)},
+		{file: "c.md", title: "Gamma", status: "reviewed", labels: []string{"web"}, created: now.Add(-1 * day), modified: now},
+		{file: "d.md", title: "Delta", status: "done", labels: []string{"ops", "ops2"}, created: now.Add(-3 * day), modified: now},
+		{file: "e.md", title: "Epsilon", created: now.Add(-40 * day), modified: now.Add(-40 * day)},
+	}
+
+	labels := topLabels(ps, 5)
+	if len(labels) != 2 || labels[0] != (labelCount{"api", 2}) || labels[1] != (labelCount{"web", 2}) {
+		t.Errorf("topLabels = %v (done plans' labels shouldn't count)", labels)
+	}
+
+	out := buildSummary(ps, 14*day, now)
+	for _, want := range []string{"2 active", "1 reviewed", "api 2", "Newest\n  Gamma", "Stale\n  Alpha  untouched 20d"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Epsilon  untouched") {
+		t.Error("only active plans are stale")
+	}
+
+	if got := summaryLine(ps, 1); got != "plans: 2 active · 1 reviewed · 1 stale" {
+		t.Errorf("summaryLine = %q", got)
+	}
+}
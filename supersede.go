@@ -0,0 +1,270 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Superseded Plans ────────────────────────────────────────────────────────
+//
+// A plan replaced by a newer one carries superseded_by: naming it, and the
+// newer plan carries supersedes: naming the old one. Either key alone is
+// enough to link the two. Superseded plans are grayed out in the list, the
+// preview shows a banner, and < / > walk back and forward along the chain.
+// V marks the current plan as superseding another, writing both keys.
+
+// planByRef returns the plan in all that ref (a filename, with or without
+// .md) names, other than self.
+func planByRef(all []plan, ref string, self plan) (plan, bool) {
+	name := planRefName(ref)
+	for _, p := range all {
+		if name != "" && planRefName(p.file) == name && p.path() != self.path() {
+			return p, true
+		}
+	}
+	return plan{}, false
+}
+
+// newerPlan returns the plan that supersedes p.
+func newerPlan(p plan, all []plan) (plan, bool) {
+	if p.supersededBy != "" {
+		return planByRef(all, p.supersededBy, p)
+	}
+	name := planRefName(p.file)
+	for _, o := range all {
+		if o.supersedes != "" && planRefName(o.supersedes) == name && o.path() != p.path() {
+			return o, true
+		}
+	}
+	return plan{}, false
+}
+
+// olderPlan returns the plan p supersedes.
+func olderPlan(p plan, all []plan) (plan, bool) {
+	if p.supersedes != "" {
+		return planByRef(all, p.supersedes, p)
+	}
+	name := planRefName(p.file)
+	for _, o := range all {
+		if o.supersededBy != "" && planRefName(o.supersededBy) == name && o.path() != p.path() {
+			return o, true
+		}
+	}
+	return plan{}, false
+}
+
+// supersedePlan records that newer supersedes older in both plans'
+// frontmatter and rescans.
+func supersedePlan(agentDir, projectGlob string, newer, older plan) tea.Cmd {
+	updates := map[string][2]string{ // path → key, value
+		newer.path(): {"supersedes", older.file},
+		older.path(): {"superseded_by", newer.file},
+	}
+	paths := []string{newer.path(), older.path()}
+	return setSupersedeFields(agentDir, projectGlob, updates, paths, supersedeMessage(newer, older))
+}
+
+// setSupersedeFields writes one supersede key per path (updates maps path →
+// key, value) and rescans.
+func setSupersedeFields(agentDir, projectGlob string, updates map[string][2]string, paths []string, message string) tea.Cmd {
+	return func() tea.Msg {
+		var events []planEvent
+		err := plans.BatchSetFrontmatter(paths, func(path string, fields map[string]string) map[string]string {
+			k, v := updates[path][0], updates[path][1]
+			if fields[k] != v {
+				events = append(events, fieldEvent(path, k, fields[k], v))
+			}
+			return map[string]string{k: v}
+		})
+		if err != nil {
+			return batchFailed(agentDir, projectGlob, paths, err)
+		}
+		all, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return batchDoneMsg{plans: all, files: paths, message: message, events: events}
+	}
+}
+
+func supersedeMessage(newer, older plan) string {
+	return newer.title + " supersedes " + older.title
+}
+
+func (s demoStore) supersedePlan(newer, older plan) tea.Cmd {
+	plans := *s.plans
+	return func() tea.Msg {
+		updated := slices.Clone(plans)
+		for i, p := range updated {
+			switch p.path() {
+			case newer.path():
+				updated[i].supersedes = older.file
+			case older.path():
+				updated[i].supersededBy = newer.file
+			}
+		}
+		return batchDoneMsg{plans: updated, files: []string{newer.path(), older.path()}, message: supersedeMessage(newer, older)}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// walkChain selects the plan that supersedes the selected one (forward) or
+// that it supersedes.
+func (m *model) walkChain(forward bool) tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	next, found := olderPlan(item, *m.planSource())
+	if forward {
+		next, found = newerPlan(item, *m.planSource())
+	}
+	if !found {
+		if forward {
+			return m.setNotification(tr("No newer version of this plan"), statusTimeout)
+		}
+		return m.setNotification(tr("No older version of this plan"), statusTimeout)
+	}
+	return m.jumpToPlan(next.path())
+}
+
+// supersedeBanner describes the selected plan's place in its chain for the
+// top of the preview pane, or "" if it has none.
+func (m model) supersedeBanner(width int) string {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return ""
+	}
+	all := *m.planSource()
+	var parts []string
+	hintStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+	if newer, ok := newerPlan(item, all); ok {
+		parts = append(parts, lipgloss.NewStyle().Foreground(colorYellow).Render(
+			truncateForWidth(tr("Superseded by ")+newer.title, width/2))+" "+hintStyle.Render(">"))
+	}
+	if older, ok := olderPlan(item, all); ok {
+		parts = append(parts, lipgloss.NewStyle().Foreground(colorDim).Render(
+			truncateForWidth(tr("Supersedes ")+older.title, width/2))+" "+hintStyle.Render("<"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " " + strings.Join(parts, "  ")
+}
+
+type supersedeState struct {
+	active bool
+	input  textinput.Model
+	cursor int
+}
+
+// openSupersede starts picking the plan the selected one supersedes.
+func (m *model) openSupersede() tea.Cmd {
+	if _, ok := m.list.SelectedItem().(plan); !ok {
+		return nil
+	}
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = tr("filter")
+	ti.CharLimit = 100
+	ti.Width = 40
+	m.supersede = supersedeState{active: true, input: ti}
+	return m.supersede.input.Focus()
+}
+
+// supersedeCandidates lists the plans matching the picker's filter, in list
+// order, leaving out the selected plan.
+func (m model) supersedeCandidates() []plan {
+	item, _ := m.list.SelectedItem().(plan)
+	q := strings.ToLower(strings.TrimSpace(m.supersede.input.Value()))
+	var out []plan
+	for _, p := range *m.planSource() {
+		if p.path() == item.path() {
+			continue
+		}
+		if q == "" || strings.Contains(strings.ToLower(p.title), q) || strings.Contains(strings.ToLower(p.file), q) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m model) handleSupersedeKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.supersede.active = false
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.supersede.active = false
+		item, ok := m.list.SelectedItem().(plan)
+		candidates := m.supersedeCandidates()
+		if !ok || m.supersede.cursor >= len(candidates) {
+			return m, nil, true
+		}
+		return m, m.store.supersedePlan(item, candidates[m.supersede.cursor]), true
+	case msg.Type == tea.KeyDown:
+		if m.supersede.cursor < len(m.supersedeCandidates())-1 {
+			m.supersede.cursor++
+		}
+		return m, nil, true
+	case msg.Type == tea.KeyUp:
+		if m.supersede.cursor > 0 {
+			m.supersede.cursor--
+		}
+		return m, nil, true
+	}
+	var cmd tea.Cmd
+	m.supersede.input, cmd = m.supersede.input.Update(msg)
+	m.supersede.cursor = 0
+	return m, cmd, true
+}
+
+func (m model) renderSupersedeModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := min(m.width-4, 80)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Supersedes")) + "\n")
+	if item, ok := m.list.SelectedItem().(plan); ok {
+		b.WriteString(dimStyle.Render(trf("Which plan does %s replace?", truncateForWidth(item.title, contentW-30))) + "\n")
+	}
+	b.WriteString("> " + m.supersede.input.View() + "\n\n")
+
+	candidates := m.supersedeCandidates()
+	maxVisible := max(m.height-14, 3)
+	start := 0
+	if m.supersede.cursor >= maxVisible {
+		start = m.supersede.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(candidates))
+	if len(candidates) == 0 {
+		b.WriteString(dimStyle.Render(tr("No matching plans")) + "\n")
+	}
+	for i := start; i < end; i++ {
+		title := truncateForWidth(candidates[i].title, contentW-4)
+		if i == m.supersede.cursor {
+			b.WriteString(accentStyle.Render("> "+title) + "\n")
+		} else {
+			b.WriteString("  " + title + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("type to filter · ↑/↓ choose · enter mark superseded · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
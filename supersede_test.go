@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSupersedeChain(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "auth-v1.md"), "---\nstatus: done\ncreated: 2026-01-01\nsuperseded_by: auth-v2.md\n---\n# Auth v1\n")
+	// Only the newer plan names the older one; the chain still links.
+	writeFile(t, filepath.Join(dir, "auth-v2.md"), "---\nstatus: done\ncreated: 2026-02-01\n---\n# Auth v2\n")
+	writeFile(t, filepath.Join(dir, "auth-v3.md"), "---\nstatus: active\ncreated: 2026-03-01\nsupersedes: auth-v2\n---\n# Auth v3\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byFile := make(map[string]plan)
+	for _, p := range all {
+		byFile[p.file] = p
+	}
+	tests := []struct {
+		file         string
+		newer, older string
+	}{
+		{"auth-v1.md", "auth-v2.md", ""},
+		{"auth-v2.md", "auth-v3.md", "auth-v1.md"},
+		{"auth-v3.md", "", "auth-v2.md"},
+	}
+	for _, tt := range tests {
+		newer, _ := newerPlan(byFile[tt.file], all)
+		older, _ := olderPlan(byFile[tt.file], all)
+		if newer.file != tt.newer || older.file != tt.older {
+			t.Errorf("%s: newer=%q older=%q, want %q %q", tt.file, newer.file, older.file, tt.newer, tt.older)
+		}
+	}
+}
+
+func TestSupersedeNavigation(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "auth-v1.md"), "---\nstatus: active\ncreated: 2026-01-01\nsuperseded_by: auth-v2.md\n---\n# Auth v1\n")
+	writeFile(t, filepath.Join(dir, "auth-v2.md"), "---\nstatus: active\ncreated: 2026-02-01\nsupersedes: auth-v1.md\n---\n# Auth v2\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	m.selectFile(filepath.Join(dir, "auth-v1.md"))
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "Superseded by Auth v2 >") {
+		t.Errorf("preview should show the superseded banner:\n%s", out)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	m = m2.(model)
+	if m.selectedFile() != filepath.Join(dir, "auth-v2.md") {
+		t.Fatalf("> should jump to the newer plan, selected %s", m.selectedFile())
+	}
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "Supersedes Auth v1 <") {
+		t.Errorf("preview should show the supersedes banner:\n%s", out)
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("<")})
+	m = m2.(model)
+	if m.selectedFile() != filepath.Join(dir, "auth-v1.md") {
+		t.Errorf("< should jump back to the older plan, selected %s", m.selectedFile())
+	}
+}
+
+func TestSupersedePicker(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "auth-v1.md"), "---\nstatus: active\ncreated: 2026-01-01\n---\n# Auth v1\n")
+	writeFile(t, filepath.Join(dir, "auth-v2.md"), "---\nstatus: active\ncreated: 2026-02-01\n---\n# Auth v2\n")
+	writeFile(t, filepath.Join(dir, "rollout.md"), "---\nstatus: active\ncreated: 2026-01-15\n---\n# Rollout\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	m.selectFile(filepath.Join(dir, "auth-v2.md"))
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	m = m2.(model)
+	if !m.supersede.active {
+		t.Fatal("V should open the supersede picker")
+	}
+	m2, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v1")})
+	m = m2.(model)
+	if c := m.supersedeCandidates(); len(c) != 1 || c[0].file != "auth-v1.md" {
+		t.Fatalf("candidates = %v", c)
+	}
+	m2, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = m2.(model)
+	if m.supersede.active || cmd == nil {
+		t.Fatal("enter should close the picker and write the plans")
+	}
+	msg, ok := cmd().(batchDoneMsg)
+	if !ok {
+		t.Fatalf("got %T, want batchDoneMsg", msg)
+	}
+	if len(msg.events) != 2 {
+		t.Errorf("events = %+v", msg.events)
+	}
+	for file, want := range map[string]string{"auth-v2.md": "supersedes: auth-v1.md", "auth-v1.md": "superseded_by: auth-v2.md"} {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s missing %q:\n%s", file, want, data)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Task Mode ───────────────────────────────────────────────────────────────
+//
+// In the preview pane, x starts task mode on the selected plan: n/N (or j/k)
+// move between its "- [ ]" task items, scrolling the preview to each, and
+// space checks or unchecks the current one in the file. Like comment edits,
+// only the body is rewritten; frontmatter is left as it was, except that the
+// first write to a plan without a created: date adds one (see
+// plans.WriteBody).
+
+type taskItem struct {
+	rawLine int // line number in the body (after frontmatter)
+	text    string
+	done    bool
+}
+
+type taskModeState struct {
+	active bool
+	path   string // the plan's path
+	items  []taskItem
+	cursor int
+}
+
+// extractTasks returns every task item in body, checked or not. Task items
+// inside fenced code blocks are ignored.
+func extractTasks(body string) []taskItem {
+	var items []taskItem
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		loc := plans.TaskPattern.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+		text := strings.TrimSpace(line[loc[3]+1:])
+		items = append(items, taskItem{rawLine: i, text: text, done: line[loc[2]:loc[3]] != " "})
+	}
+	return items
+}
+
+// taskRenderLine returns the line of the rendered preview showing items[n],
+// or -1. Tasks are matched in order by their first few words, the same way
+// computeRenderLines finds headings.
+func taskRenderLine(rendered string, items []taskItem, n int) int {
+	lines := strings.Split(ansi.Strip(rendered), "\n")
+	pos := 0
+	for i := 0; i <= n && i < len(items); i++ {
+		words := headingWords(items[i].text)
+		if len(words) > 3 {
+			words = words[:3]
+		}
+		found := -1
+		for j := pos; j < len(lines); j++ {
+			if containsWordsInOrder(lines[j], words) {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			return -1
+		}
+		if i == n {
+			return found
+		}
+		pos = found + 1
+	}
+	return -1
+}
+
+// readTaskBody returns the body of the plan at path, from demo content when
+// content is non-nil.
+func readTaskBody(path string, content map[string]string) (string, error) {
+	if content != nil {
+		return content[filepath.Base(path)], nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	_, body := parseFrontmatter(string(data))
+	return body, nil
+}
+
+// loadTasks reads the plan's body for task mode.
+func loadTasks(path string, content map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		body, err := readTaskBody(path, content)
+		if err != nil {
+			return errMsg{err}
+		}
+		return tasksLoadedMsg{path: path, body: body}
+	}
+}
+
+// toggleTask flips it in the plan at path and writes the body back. The body
+// is re-read first; if the task has moved since it was loaded (an agent
+// edited the plan), it is found again by its text.
+func toggleTask(path string, it taskItem, content map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		body, err := readTaskBody(path, content)
+		if err != nil {
+			return errMsg{err}
+		}
+		line := -1
+		for _, t := range extractTasks(body) {
+			if t.text == it.text && (line < 0 || t.rawLine == it.rawLine) {
+				line = t.rawLine
+			}
+		}
+		if line < 0 {
+			return errMsg{fmt.Errorf("task not found: %s", it.text)}
+		}
+		newBody := plans.SetTaskDone(body, line, !it.done)
+		if content != nil {
+			content[filepath.Base(path)] = newBody
+		} else if err := writeCommentBody(path, newBody); err != nil {
+			return errMsg{err}
+		}
+		kind := eventTaskChecked
+		if it.done {
+			kind = eventTaskUnchecked
+		}
+		return tasksSavedMsg{path: path, body: newBody, event: planEvent{kind: kind, path: path, to: it.text}}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+func (m model) demoContent() map[string]string {
+	if m.demo.active {
+		return m.demo.content
+	}
+	return nil
+}
+
+// openTaskMode starts task mode on the selected plan once its body is read.
+func (m *model) openTaskMode() tea.Cmd {
+	p, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	if p.tasks == 0 {
+		return m.setNotification(tr("No tasks in this plan"), statusTimeout)
+	}
+	return loadTasks(p.path(), m.demoContent())
+}
+
+// setTasks replaces the task items from body, keeping the cursor in range.
+func (m *model) setTasks(path, body string) {
+	m.taskMode.path = path
+	m.taskMode.items = extractTasks(body)
+	if m.taskMode.cursor >= len(m.taskMode.items) {
+		m.taskMode.cursor = len(m.taskMode.items) - 1
+	}
+	if m.taskMode.cursor < 0 {
+		m.taskMode.cursor = 0
+	}
+}
+
+// syncTasks updates the task counts on the plan matching planPath in both
+// allPlans and the visible list, like syncComments.
+func (m *model) syncTasks(planPath, body string) {
+	total, done := countTasks(body)
+	plans := m.planSource()
+	for i, p := range *plans {
+		if p.path() == planPath {
+			(*plans)[i].tasks, (*plans)[i].tasksDone = total, done
+			break
+		}
+	}
+	for i, item := range m.list.Items() {
+		if p, ok := item.(plan); ok && p.path() == planPath {
+			p.tasks, p.tasksDone = total, done
+			m.list.SetItem(i, p)
+			break
+		}
+	}
+}
+
+// scrollToTask scrolls the preview so the current task is near the top.
+func (m *model) scrollToTask() {
+	line := taskRenderLine(m.previewCache[m.taskMode.path], m.taskMode.items, m.taskMode.cursor)
+	if line < 0 {
+		return
+	}
+	m.viewport.SetYOffset(max(line-2, 0))
+}
+
+func (m model) handleTaskKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Tasks), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.taskMode.active = false
+		return m, nil, true
+	case msg.String() == " " || msg.Type == tea.KeyEnter:
+		if m.taskMode.cursor < len(m.taskMode.items) {
+			return m, toggleTask(m.taskMode.path, m.taskMode.items[m.taskMode.cursor], m.demoContent()), true
+		}
+	case msg.String() == "n" || msg.String() == "j" || msg.String() == "down":
+		if m.taskMode.cursor < len(m.taskMode.items)-1 {
+			m.taskMode.cursor++
+			m.scrollToTask()
+		}
+	case msg.String() == "N" || msg.String() == "k" || msg.String() == "up":
+		if m.taskMode.cursor > 0 {
+			m.taskMode.cursor--
+			m.scrollToTask()
+		}
+	}
+	return m, nil, true
+}
+
+// handleTasksSaved refreshes task mode, the plan's progress in the list, and
+// its preview after a task was toggled.
+func (m *model) handleTasksSaved(msg tasksSavedMsg) tea.Cmd {
+	if m.taskMode.active && m.taskMode.path == msg.path {
+		m.setTasks(msg.path, msg.body)
+	}
+	m.syncTasks(msg.path, msg.body)
+	m.refreshing[msg.path] = true
+	if m.demo.active {
+		return renderMarkdown(msg.path, msg.body, m.glamourStyle, m.previewW())
+	}
+	for _, p := range *m.planSource() {
+		if p.path() == msg.path {
+			return renderPlan(p, m.glamourStyle, m.previewW())
+		}
+	}
+	return nil
+}
+
+// taskStatusBar shows the current task and task mode's keys.
+func (m model) taskStatusBar(hint, dim func(...string) string) string {
+	sep := dim(" | ")
+	s := " "
+	if m.taskMode.cursor < len(m.taskMode.items) {
+		it := m.taskMode.items[m.taskMode.cursor]
+		box := "[ ] "
+		if it.done {
+			box = "[x] "
+		}
+		s += truncateForWidth(box+it.text, m.width/2) + dim(fmt.Sprintf(" (%d/%d)", m.taskMode.cursor+1, len(m.taskMode.items))) + sep
+	}
+	return s +
+		hint("space") + dim(tr(" check")) + sep +
+		hint("n/N") + dim(tr(" next/prev")) + sep +
+		hint("esc") + dim(tr(" done"))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestExtractTasks(t *testing.T) {
+	body := "# Plan\n\n- [ ] Write `parser`, then test\n  * [X] Nested\n```\n- [ ] not a task\n```\n- [] nor this\n"
+	items := extractTasks(body)
+	if len(items) != 2 || items[0].text != "Write `parser`, then test" || items[0].done || items[0].rawLine != 2 ||
+		items[1].text != "Nested" || !items[1].done {
+		t.Fatalf("items = %+v", items)
+	}
+	rendered := "\x1b[1mPlan\x1b[0m\n\n  [ ] Write parser, then test\n    [✓] Nested\n"
+	if got := taskRenderLine(rendered, items, 1); got != 3 {
+		t.Errorf("render line = %d, want 3", got)
+	}
+}
+
+func TestTaskModeToggle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+	writeFile(t, path, "---\nstatus: active\ncreated: 2026-01-02\n---\n# Plan\n\n- [x] First\n- [ ] Second\n")
+	plans, _ := scanPlans(dir)
+	m := newModel(plans, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+	update := func(msg tea.Msg) tea.Cmd {
+		m2, cmd := m.Update(msg)
+		m = m2.(model)
+		return cmd
+	}
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	update(tea.KeyMsg{Type: tea.KeyTab})
+	update(update(key("x"))())
+	if !m.taskMode.active || len(m.taskMode.items) != 2 {
+		t.Fatalf("task mode = %+v", m.taskMode)
+	}
+	update(key("n"))
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "[ ] Second (2/2)") {
+		t.Errorf("status bar should show the current task:\n%s", out)
+	}
+	msg := update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})()
+	if saved, ok := msg.(tasksSavedMsg); !ok || saved.event.kind != eventTaskChecked || saved.event.to != "Second" {
+		t.Fatalf("toggle msg = %#v", msg)
+	}
+	update(msg)
+	data, _ := os.ReadFile(path)
+	if want := "---\nstatus: active\ncreated: 2026-01-02\n---\n# Plan\n\n- [x] First\n- [x] Second\n"; string(data) != want {
+		t.Errorf("file = %q\nwant %q", data, want)
+	}
+	if p := m.list.Items()[0].(plan); p.tasks != 2 || p.tasksDone != 2 || !m.taskMode.items[1].done {
+		t.Errorf("tasks not synced: %d/%d, items %+v", p.tasksDone, p.tasks, m.taskMode.items)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.taskMode.active || m.focused != previewPane {
+		t.Errorf("esc should leave task mode and stay in the preview")
+	}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Plan Templates ──────────────────────────────────────────────────────────
+//
+// The templates directory (templates/ next to config.json, or templates_dir)
+// holds one markdown file per template. Frontmatter in a template becomes the
+// new plan's frontmatter; {{title}}, {{date}}, and {{labels}} (or {{label}})
+// are filled in, and any other {{name}} placeholder is asked for when the plan
+// is created. Labels entered for {{labels}} are always added to the plan's
+// labels:. N and `planc new` offer a picker when templates exist, and
+// `planc new --list-templates` lists them.
+
+const templatesDirName = "templates"
+
+// planTemplate is one template file.
+type planTemplate struct {
+	name   string            // filename without .md
+	fields map[string]string // frontmatter, unexpanded
+	body   string            // unexpanded
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z][\w-]*)\s*\}\}`)
+
+// placeholderName returns the variable a placeholder's name refers to;
+// {{label}} is another spelling of {{labels}}.
+func placeholderName(name string) string {
+	if name == "label" {
+		return "labels"
+	}
+	return name
+}
+
+// templatesDir returns the configured templates directory.
+func templatesDir(cfg config) (string, error) {
+	if cfg.TemplatesDir != "" {
+		return expandHome(cfg.TemplatesDir), nil
+	}
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), templatesDirName), nil
+}
+
+// loadTemplates reads every .md template in dir, sorted by name. A missing
+// directory has no templates.
+func loadTemplates(dir string) ([]planTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []planTemplate
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		fields, body := parseFrontmatter(string(data))
+		out = append(out, planTemplate{name: strings.TrimSuffix(e.Name(), ".md"), fields: fields, body: body})
+	}
+	return out, nil
+}
+
+// variables returns the values the template needs from the user: title and
+// labels, then its own placeholders in order of first appearance.
+func (t planTemplate) variables() []string {
+	vars := []string{"title", "labels"}
+	add := func(s string) {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+			if name := placeholderName(m[1]); name != "date" && !slices.Contains(vars, name) {
+				vars = append(vars, name)
+			}
+		}
+	}
+	keys := make([]string, 0, len(t.fields))
+	for k := range t.fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		add(t.fields[k])
+	}
+	add(t.body)
+	return vars
+}
+
+// render fills the template's placeholders. {{date}} is today; unknown
+// placeholders become empty.
+func (t planTemplate) render(vars map[string]string, now time.Time) (fields map[string]string, body string) {
+	fill := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(p string) string {
+			name := placeholderName(placeholderPattern.FindStringSubmatch(p)[1])
+			if name == "date" {
+				return now.Format("2006-01-02")
+			}
+			return vars[name]
+		})
+	}
+	fields = make(map[string]string, len(t.fields)+1)
+	for k, v := range t.fields {
+		fields[k] = fill(v)
+	}
+	if labels := parseLabels(vars["labels"]); len(labels) > 0 {
+		fields["labels"] = labelsString(applyLabelChanges(parseLabels(fields["labels"]), labels, nil))
+	}
+	body = fill(t.body)
+	if strings.TrimSpace(body) == "" {
+		body = expandTemplate(defaultPlanBody, vars["title"], now)
+	}
+	return fields, body
+}
+
+// createFromTemplate writes a plan from t in dir and returns its path.
+func createFromTemplate(dir string, t planTemplate, vars map[string]string, now time.Time) (string, error) {
+	fields, body := t.render(vars, now)
+	return writeNewPlan(dir, vars["title"], fields, body, now)
+}
+
+func createPlanFromTemplate(agentDir, projectGlob, dir string, t planTemplate, vars map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := createFromTemplate(dir, t, vars, time.Now())
+		if err != nil {
+			return errMsg{fmt.Errorf("new plan: %w", err)}
+		}
+		plans, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return planFileMsg{plans: plans, path: path, message: "Created: " + filepath.Base(path),
+			event: planEvent{kind: eventPlanCreated, path: path, to: vars["title"]}}
+	}
+}
+
+// ─── planc new ───────────────────────────────────────────────────────────────
+
+// varsFlag collects repeated --var name=value flags.
+type varsFlag map[string]string
+
+func (v varsFlag) String() string { return "" }
+
+func (v varsFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("want name=value, got %q", s)
+	}
+	v[name] = value
+	return nil
+}
+
+// promptLine asks for one line on out and reads it from in.
+func promptLine(in *bufio.Scanner, out io.Writer, label string) string {
+	fmt.Fprintf(out, "%s: ", label)
+	if !in.Scan() {
+		return ""
+	}
+	return strings.TrimSpace(in.Text())
+}
+
+// pickTemplate lists the templates and reads a choice; 0 or empty is a
+// blank plan (nil).
+func pickTemplate(in *bufio.Scanner, out io.Writer, templates []planTemplate) (*planTemplate, error) {
+	fmt.Fprintln(out, "  0) blank plan")
+	for i, t := range templates {
+		fmt.Fprintf(out, "  %d) %s\n", i+1, t.name)
+	}
+	choice := promptLine(in, out, "Template [0]")
+	if choice == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(choice)
+	if err != nil || n < 0 || n > len(templates) {
+		return nil, fmt.Errorf("no template %q", choice)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return &templates[n-1], nil
+}
+
+func runNew(cfg config, args []string) int {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	name := fs.String("template", "", "template name (default: ask when templates exist)")
+	dir := fs.String("dir", "", "directory for the new plan (default: the agent plans directory)")
+	status := fs.String("status", "", "initial status: "+statusList())
+	list := fs.Bool("list-templates", false, "list the templates and their variables, then exit")
+	randomName := fs.Bool("random-name", false, "name the file like Claude Code plans (e.g. humming-marinating-narwhal) instead of after the title")
+	vars := varsFlag{}
+	fs.Var(vars, "var", "fill a template variable, name=value (repeatable)")
+	var labels []string
+	fs.Func("label", "add a label (repeatable, or comma-separated)", func(s string) error {
+		labels = append(labels, parseLabels(s)...)
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc new [--label L]... [--status S] [--template NAME] [--var name=value]... [--dir DIR] [--random-name] [TITLE]")
+		fmt.Fprintln(fs.Output(), "       planc new --list-templates")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		vars["title"] = strings.Join(fs.Args(), " ")
+	}
+	fields := map[string]string{}
+	if *status != "" {
+		st, ok := statusByName(*status)
+		if !ok {
+			return cliError("new: unknown status %q (%s)", *status, statusChoices())
+		}
+		if st != "" {
+			fields["status"] = st
+		}
+	}
+	if _, ok := vars["labels"]; !ok && len(labels) > 0 {
+		vars["labels"] = labelsString(labels) // also answers a template's {{labels}}
+	}
+	tdir, err := templatesDir(cfg)
+	if err != nil {
+		return cliError("new: %v", err)
+	}
+	templates, err := loadTemplates(tdir)
+	if err != nil {
+		return cliError("new: %v", err)
+	}
+	if *list {
+		if len(templates) == 0 {
+			fmt.Fprintf(os.Stderr, "no templates in %s\n", contractHome(tdir))
+		}
+		writeTemplateList(os.Stdout, templates)
+		return 0
+	}
+
+	in := bufio.NewScanner(os.Stdin)
+	var tmpl *planTemplate
+	switch {
+	case *name != "":
+		i := slices.IndexFunc(templates, func(t planTemplate) bool { return t.name == *name })
+		if i < 0 {
+			return cliError("new: no template %q in %s", *name, contractHome(tdir))
+		}
+		tmpl = &templates[i]
+	case len(templates) > 0:
+		if tmpl, err = pickTemplate(in, os.Stderr, templates); err != nil {
+			return cliError("new: %v", err)
+		}
+	}
+
+	needed := []string{"title"}
+	if tmpl != nil {
+		needed = tmpl.variables()
+	}
+	for _, v := range needed {
+		if _, ok := vars[v]; !ok {
+			vars[v] = promptLine(in, os.Stderr, strings.ToUpper(v[:1])+v[1:])
+		}
+	}
+	if strings.TrimSpace(vars["title"]) == "" {
+		return cliError("new: a title is required")
+	}
+
+	target := cfg.PlansDir
+	if *dir != "" {
+		target = expandHome(*dir)
+	}
+	now := time.Now()
+	stem := planSlug(vars["title"])
+	if *randomName {
+		stem = randomPlanName(rand.New(rand.NewPCG(uint64(now.UnixNano()), 0)))
+	}
+	var body string
+	if tmpl != nil {
+		var tfields map[string]string
+		tfields, body = tmpl.render(vars, now)
+		for k, v := range fields {
+			tfields[k] = v
+		}
+		fields = tfields
+	} else if labels := parseLabels(vars["labels"]); len(labels) > 0 {
+		fields["labels"] = labelsString(labels)
+	}
+	path, err := writeNewPlanAs(target, stem, vars["title"], fields, body, now)
+	if err != nil {
+		return cliError("new: %v", err)
+	}
+	fmt.Println(contractHome(path))
+	return 0
+}
+
+// writeTemplateList prints each template's name and variables, tab-separated.
+func writeTemplateList(w io.Writer, templates []planTemplate) {
+	for _, t := range templates {
+		fmt.Fprintf(w, "%s\t%s\n", t.name, strings.Join(t.variables(), ", "))
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// templateFlow is the template half of the N modal: picking a template, then
+// asking for its variables one at a time in titleInput.
+type templateFlow struct {
+	templates []planTemplate
+	picking   bool
+	cursor    int // 0 is a blank plan, i is templates[i-1]
+	tmpl      *planTemplate
+	vars      []string // variables to ask for
+	step      int      // index into vars
+	values    map[string]string
+}
+
+// startTemplateFlow loads the templates for N. Without any, N asks for a
+// title as before.
+func (m *model) startTemplateFlow() {
+	m.newPlanFlow = templateFlow{}
+	if m.demo.active {
+		return
+	}
+	dir, err := templatesDir(m.cfg)
+	if err != nil {
+		return
+	}
+	templates, err := loadTemplates(dir)
+	if err != nil || len(templates) == 0 {
+		return
+	}
+	m.newPlanFlow = templateFlow{templates: templates, picking: true}
+}
+
+// currentVar is the variable titleInput is asking for.
+func (f templateFlow) currentVar() string {
+	if f.tmpl == nil {
+		return "title"
+	}
+	return f.vars[f.step]
+}
+
+func (m model) handleTemplatePicker(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	f := &m.newPlanFlow
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.creatingPlan = false
+		m.titleInput.Blur()
+	case msg.String() == "j" || msg.String() == "down":
+		if f.cursor < len(f.templates) {
+			f.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if f.cursor > 0 {
+			f.cursor--
+		}
+	case msg.Type == tea.KeyEnter:
+		f.picking = false
+		if f.cursor > 0 {
+			f.tmpl = &f.templates[f.cursor-1]
+			f.vars = f.tmpl.variables()
+			f.values = make(map[string]string)
+		}
+		m.titleInput.SetValue("")
+		m.titleInput.Focus()
+		return m, textinput.Blink, true
+	}
+	return m, nil, true
+}
+
+// templateInputDone records the answer for the current variable and moves
+// on, returning the create command after the last one.
+func (m *model) templateInputDone(value string) tea.Cmd {
+	f := &m.newPlanFlow
+	f.values[f.vars[f.step]] = value
+	f.step++
+	if f.step < len(f.vars) {
+		m.titleInput.SetValue("")
+		return nil
+	}
+	m.creatingPlan = false
+	m.titleInput.Blur()
+	return createPlanFromTemplate(m.dir, m.cfg.ProjectPlanGlob, m.newPlanDirPath, *f.tmpl, f.values)
+}
+
+func (m model) renderTemplatePicker() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+	f := m.newPlanFlow
+
+	var b strings.Builder
+	names := append([]string{tr("Blank plan")}, make([]string, len(f.templates))...)
+	for i, t := range f.templates {
+		names[i+1] = t.name
+	}
+	for i, name := range names {
+		if i == f.cursor {
+			b.WriteString(accentStyle.Render("> "+name) + "\n")
+		} else {
+			b.WriteString("  " + name + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render(tr("j/k choose · enter next · esc cancel")))
+	return b.String()
+}
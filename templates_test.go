@@ -0,0 +1,206 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const bugTemplate = "---\nlabels: bug\nseverity: \"{{severity}}\"\n---\n# {{title}}\n\nReported {{date}} in {{component}}. Labels: {{labels}}\n"
+
+func TestTemplateRender(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "bug.md", bugTemplate)
+	writeTemplate(t, dir, "notes.txt", "ignored")
+	templates, err := loadTemplates(dir)
+	if err != nil || len(templates) != 1 || templates[0].name != "bug" {
+		t.Fatalf("loadTemplates = %+v, %v", templates, err)
+	}
+	tmpl := templates[0]
+	if got := tmpl.variables(); !slices.Equal(got, []string{"title", "labels", "severity", "component"}) {
+		t.Errorf("variables = %v", got)
+	}
+
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	vars := map[string]string{"title": "Login loops", "labels": "auth, bug", "severity": "high", "component": "web"}
+	path, err := createFromTemplate(t.TempDir(), tmpl, vars, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	fm, body := parseFrontmatter(string(data))
+	if fm["labels"] != "auth, bug" || fm["severity"] != "high" || fm["created"] == "" {
+		t.Errorf("frontmatter = %v", fm)
+	}
+	if body != "# Login loops\n\nReported 2026-03-04 in web. Labels: auth, bug\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	if ts, err := loadTemplates(filepath.Join(dir, "missing")); err != nil || ts != nil {
+		t.Errorf("missing dir = %v, %v", ts, err)
+	}
+}
+
+func TestTemplateLabelAliasAndList(t *testing.T) {
+	tmpl := planTemplate{name: "rfc", body: "# RFC: {{title}}\n\nArea: {{label}}. Owner: {{owner}}. Also {{labels}}.\n"}
+	if got := tmpl.variables(); !slices.Equal(got, []string{"title", "labels", "owner"}) {
+		t.Errorf("variables = %v", got)
+	}
+	_, body := tmpl.render(map[string]string{"title": "Caching", "labels": "infra", "owner": "sam"}, time.Now())
+	if body != "# RFC: Caching\n\nArea: infra. Owner: sam. Also infra.\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	var b strings.Builder
+	writeTemplateList(&b, []planTemplate{tmpl, {name: "blank"}})
+	if b.String() != "rfc\ttitle, labels, owner\nblank\ttitle, labels\n" {
+		t.Errorf("list = %q", b.String())
+	}
+}
+
+func TestNewPlanModalTemplatePicker(t *testing.T) {
+	tdir := t.TempDir()
+	writeTemplate(t, tdir, "bug.md", bugTemplate)
+	dir := t.TempDir()
+	cfg := newDefaultConfig()
+	cfg.TemplatesDir = tdir
+	m := newModel(nil, dir, cfg, nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = m2.(model)
+
+	keys := func(ks ...tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+		for _, k := range ks {
+			var m2 tea.Model
+			m2, cmd = m.Update(k)
+			m = m2.(model)
+		}
+		return cmd
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	keys(runes("N"))
+	if !m.newPlanFlow.picking || !strings.Contains(m.View(), "Blank plan") {
+		t.Fatal("N should offer the template picker")
+	}
+	keys(runes("j"), enter)
+	if m.newPlanFlow.tmpl == nil || m.newPlanFlow.currentVar() != "title" {
+		t.Fatal("enter should choose the bug template and ask for a title")
+	}
+	keys(enter)
+	if m.newPlanFlow.step != 0 {
+		t.Error("an empty title should not advance")
+	}
+	keys(runes("Crash"), enter, enter, runes("low"), enter, runes("api"))
+	if !strings.Contains(m.View(), "Component: api") {
+		t.Error("modal should ask for the template's own variables")
+	}
+	cmd := keys(enter)
+	if m.creatingPlan || cmd == nil {
+		t.Fatal("the last variable should create the plan")
+	}
+	msg, ok := cmd().(planFileMsg)
+	if !ok {
+		t.Fatal("expected planFileMsg")
+	}
+	data, _ := os.ReadFile(msg.path)
+	if !strings.Contains(string(data), "severity: low") || !strings.Contains(string(data), "in api.") {
+		t.Errorf("plan = %q", data)
+	}
+}
+
+func TestRunNewWithTemplate(t *testing.T) {
+	tdir := t.TempDir()
+	writeTemplate(t, tdir, "bug.md", bugTemplate)
+	cfg := newDefaultConfig()
+	cfg.TemplatesDir = tdir
+	cfg.PlansDir = t.TempDir()
+
+	code := runNew(cfg, []string{"--template", "bug", "--var", "labels=", "--var", "severity=high", "--var", "component=cli", "Bad", "flag"})
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.PlansDir, "bad-flag.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "# Bad flag\n") || !strings.Contains(string(data), "in cli.") {
+		t.Errorf("plan = %q", data)
+	}
+	if code := runNew(cfg, []string{"--template", "nope", "X"}); code == 0 {
+		t.Error("an unknown template should fail")
+	}
+}
+
+func TestRunNewLabelsAndStatus(t *testing.T) {
+	cfg := newDefaultConfig()
+	cfg.TemplatesDir = t.TempDir() // no templates, so no picker
+	cfg.PlansDir = t.TempDir()
+
+	if code := runNew(cfg, []string{"--label", "api", "--label", "ui, auth", "--status", "active", "Rate limits"}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.PlansDir, "rate-limits.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fm, body := parseFrontmatter(string(data))
+	if fm["status"] != "active" || fm["labels"] != "api, auth, ui" || !strings.HasPrefix(body, "# Rate limits") {
+		t.Errorf("plan = %q", data)
+	}
+
+	if code := runNew(cfg, []string{"--status", "new", "--random-name", "Untracked"}); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	entries, _ := os.ReadDir(cfg.PlansDir)
+	var random string
+	for _, e := range entries {
+		if e.Name() != "rate-limits.md" {
+			random = e.Name()
+		}
+	}
+	if strings.Count(random, "-") != 2 || strings.Contains(random, "untracked") {
+		t.Errorf("--random-name file = %q", random)
+	}
+	if data, _ := os.ReadFile(filepath.Join(cfg.PlansDir, random)); strings.Contains(string(data), "status:") {
+		t.Errorf("new should write no status: %q", data)
+	}
+
+	if code := runNew(cfg, []string{"--status", "started", "X"}); code == 0 {
+		t.Error("an unknown status should fail")
+	}
+}
+
+func TestRunNewTemplateWithFlags(t *testing.T) {
+	tdir := t.TempDir()
+	writeTemplate(t, tdir, "bug.md", bugTemplate)
+	cfg := newDefaultConfig()
+	cfg.TemplatesDir = tdir
+	cfg.PlansDir = t.TempDir()
+
+	code := runNew(cfg, []string{"--template", "bug", "--label", "cli", "--status", "reviewed", "--var", "severity=low", "--var", "component=api", "Crash"})
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	data, _ := os.ReadFile(filepath.Join(cfg.PlansDir, "crash.md"))
+	fm, _ := parseFrontmatter(string(data))
+	if fm["status"] != "reviewed" || !strings.Contains(fm["labels"], "cli") || fm["severity"] != "low" {
+		t.Errorf("plan = %q", data)
+	}
+}
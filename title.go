@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Title Modal ─────────────────────────────────────────────────────────────
+//
+// R sets a frontmatter title: override, shown in the list and preview header
+// in place of the plan's (often long) first heading. Clearing the input
+// removes the override.
+
+func (m *model) openTitleModal() tea.Cmd {
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return nil
+	}
+	m.settingTitle = true
+	if item.untitled {
+		m.titleInput.SetValue("")
+	} else {
+		m.titleInput.SetValue(item.title)
+	}
+	m.titleInput.CursorEnd()
+	m.titleInput.Focus()
+	return textinput.Blink
+}
+
+func (m model) handleTitleModal(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case msg.Type == tea.KeyEsc:
+		m.settingTitle = false
+		m.titleInput.Blur()
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.settingTitle = false
+		m.titleInput.Blur()
+		item, ok := m.list.SelectedItem().(plan)
+		if !ok {
+			return m, nil, true
+		}
+		title := strings.TrimSpace(m.titleInput.Value())
+		if title == item.title && !item.untitled {
+			return m, nil, true
+		}
+		return m, m.store.setTitle(item, title), true
+	}
+	var cmd tea.Cmd
+	m.titleInput, cmd = m.titleInput.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) renderTitleModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Title")) + "\n")
+	if item, ok := m.list.SelectedItem().(plan); ok {
+		b.WriteString(dimStyle.Render(item.file) + "\n")
+	}
+	b.WriteString("\n" + m.titleInput.View() + "\n\n")
+	b.WriteString(dimStyle.Render(tr("enter save · empty to use the first heading · esc cancel")))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Action Items ────────────────────────────────────────────────────────────
+//
+// The todo view (t) collects unchecked "- [ ]" task items from every active
+// plan into one list. Selecting an item opens the plan in comment mode with
+// the ToC cursor on the section containing the task.
+
+var todoRegex = regexp.MustCompile(`^\s*[-*+]\s+\[ \]\s+(.+)$`)
+
+// countTasks returns the number of task items in body and how many are
+// checked (see plans.CountTasks).
+func countTasks(body string) (total, done int) {
+	return plans.CountTasks(body)
+}
+
+// taskProgress renders p's checked and total task items, e.g. "4/9", or ""
+// for a plan without tasks.
+func taskProgress(p plan) string {
+	if p.tasks == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", p.tasksDone, p.tasks)
+}
+
+type todoItem struct {
+	planPath  string
+	planTitle string
+	heading   string // nearest heading above the task, or ""
+	text      string
+	rawLine   int // line number in the body (after frontmatter)
+}
+
+type todoState struct {
+	active  bool
+	loading bool
+	items   []todoItem
+	cursor  int
+}
+
+// extractTodos returns the unchecked task items in body, each tagged with the
+// nearest preceding heading. Task items inside fenced code blocks are ignored.
+func extractTodos(body string) []todoItem {
+	var items []todoItem
+	heading := ""
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			if h := strings.TrimSpace(strings.TrimLeft(trimmed, "#")); h != "" {
+				heading = h
+			}
+			continue
+		}
+		if m := todoRegex.FindStringSubmatch(line); m != nil {
+			items = append(items, todoItem{heading: heading, text: strings.TrimSpace(m[1]), rawLine: i})
+		}
+	}
+	return items
+}
+
+// loadTodos reads every active plan (see isActive) and collects its open task items, in plan
+// list order. content, when non-nil, supplies bodies by filename (demo mode).
+func loadTodos(plans []plan, content map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		var items []todoItem
+		for _, p := range plans {
+			if !isActive(p.status) {
+				continue
+			}
+			var body string
+			if content != nil {
+				body = content[p.file]
+			} else {
+				data, err := os.ReadFile(p.path())
+				if err != nil {
+					continue
+				}
+				_, body = parseFrontmatter(string(data))
+			}
+			for _, it := range extractTodos(body) {
+				it.planPath = p.path()
+				it.planTitle = p.title
+				items = append(items, it)
+			}
+		}
+		return todosLoadedMsg{items: items}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+func (m *model) openTodos() tea.Cmd {
+	m.todo = todoState{active: true, loading: true}
+	var content map[string]string
+	if m.demo.active {
+		content = m.demo.content
+	}
+	return loadTodos(*m.planSource(), content)
+}
+
+// jumpToTodo opens the item's plan in comment mode, positioned at its section.
+func (m *model) jumpToTodo(it todoItem) tea.Cmd {
+	m.todo.active = false
+	return m.openCommentAt(it.planPath, it.rawLine)
+}
+
+// openCommentAt opens the plan at path in comment mode with the ToC cursor
+// on the section containing rawLine.
+func (m *model) openCommentAt(path string, rawLine int) tea.Cmd {
+	m.revealPlan(path)
+	m.prevIndex = m.list.Index()
+	m.comment.active = true
+	m.comment.planFile = path
+	m.comment.cursor = 0
+	m.comment.jumpLine = rawLine
+	m.comment.editing = false
+	m.focused = listPane
+	m.applyLayout()
+	return m.cmdLoadComment(path)
+}
+
+// tocIndexForLine returns the index of the last ToC entry at or above rawLine.
+func tocIndexForLine(toc []tocEntry, rawLine int) int {
+	idx := 0
+	for i, e := range toc {
+		if e.rawLine > rawLine {
+			break
+		}
+		idx = i
+	}
+	return idx
+}
+
+func (m model) handleTodoKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Todo), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.todo.active = false
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		if m.todo.cursor < len(m.todo.items) {
+			cmd := m.jumpToTodo(m.todo.items[m.todo.cursor])
+			return m, cmd, true
+		}
+	case msg.String() == "j" || msg.String() == "down":
+		if m.todo.cursor < len(m.todo.items)-1 {
+			m.todo.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if m.todo.cursor > 0 {
+			m.todo.cursor--
+		}
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderTodoModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+	planStyle := lipgloss.NewStyle().Bold(true)
+
+	modalW := m.width - 4
+	if modalW > 100 {
+		modalW = 100
+	}
+	contentW := modalW - 8 // helpBoxStyle borders + padding
+	if contentW < 20 {
+		contentW = 20
+	}
+
+	var b strings.Builder
+	title := tr("Action Items")
+	if !m.todo.loading {
+		title = trf("Action Items (%d)", len(m.todo.items))
+	}
+	b.WriteString(helpTitleStyle.Render(title) + "\n")
+
+	switch {
+	case m.todo.loading:
+		b.WriteString(dimStyle.Render("Loading...") + "\n")
+	case len(m.todo.items) == 0:
+		b.WriteString(dimStyle.Render("No open tasks in active plans.") + "\n")
+	default:
+		// Build rows: a plan header before each plan's first task.
+		type row struct {
+			text string
+			task int // index into items, -1 for headers
+		}
+		var rows []row
+		cursorRow := 0
+		lastPlan := ""
+		for i, it := range m.todo.items {
+			if it.planPath != lastPlan {
+				lastPlan = it.planPath
+				rows = append(rows, row{planStyle.Render(truncateForWidth(it.planTitle, contentW)), -1})
+			}
+			if i == m.todo.cursor {
+				cursorRow = len(rows)
+			}
+			rows = append(rows, row{it.text, i})
+		}
+
+		maxVisible := m.height - 12
+		if maxVisible < 3 {
+			maxVisible = 3
+		}
+		scrollOff := 0
+		if len(rows) > maxVisible {
+			scrollOff = cursorRow - maxVisible/2
+			if scrollOff < 0 {
+				scrollOff = 0
+			}
+			if scrollOff > len(rows)-maxVisible {
+				scrollOff = len(rows) - maxVisible
+			}
+		}
+		end := scrollOff + maxVisible
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if scrollOff > 0 {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    ↑ %d more", scrollOff)) + "\n")
+		}
+		for _, r := range rows[scrollOff:end] {
+			if r.task < 0 {
+				b.WriteString(r.text + "\n")
+				continue
+			}
+			it := m.todo.items[r.task]
+			suffix := ""
+			if it.heading != "" {
+				suffix = " · " + it.heading
+			}
+			textW := contentW - 6
+			text := truncateForWidth(r.text, textW)
+			suffix = truncateForWidth(suffix, textW-lipgloss.Width(text))
+			if r.task == m.todo.cursor {
+				b.WriteString(accentStyle.Render("> ☐ "+text) + dimStyle.Render(suffix) + "\n")
+			} else {
+				b.WriteString("  ☐ " + text + dimStyle.Render(suffix) + "\n")
+			}
+		}
+		if end < len(rows) {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    ↓ %d more", len(rows)-end)) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + dimStyle.Render(tr("j/k navigate · enter open plan · esc close")))
+
+	overlay := helpBoxStyle.Width(modalW - 2).Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
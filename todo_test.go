@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestExtractTodos(t *testing.T) {
+	body := "# Plan\n\n## Setup\n- [ ] install deps\n- [x] done already\n\n## Rollout\n  * [ ] ship it\n```\n- [ ] not a task\n```\n"
+	got := extractTodos(body)
+	if len(got) != 2 {
+		t.Fatalf("got %d items, want 2: %+v", len(got), got)
+	}
+	if got[0].text != "install deps" || got[0].heading != "Setup" || got[0].rawLine != 3 {
+		t.Errorf("item 0 = %+v", got[0])
+	}
+	if got[1].text != "ship it" || got[1].heading != "Rollout" {
+		t.Errorf("item 1 = %+v", got[1])
+	}
+}
+
+func TestTocIndexForLine(t *testing.T) {
+	toc := []tocEntry{{rawLine: 0}, {rawLine: 2}, {rawLine: 6}}
+	tests := []struct {
+		line, want int
+	}{
+		{0, 0},
+		{3, 1},
+		{6, 2},
+		{20, 2},
+	}
+	for _, tt := range tests {
+		if got := tocIndexForLine(toc, tt.line); got != tt.want {
+			t.Errorf("tocIndexForLine(%d) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jakebf/planc/plans"
+)
+
+// ─── Trash ───────────────────────────────────────────────────────────────────
+//
+// # doesn't delete a plan outright: it moves the file into a .planc-trash/
+// directory next to it (see plans.Trash), stamped with the time. X lists the
+// trashed plans of every plan directory, newest first; enter restores one
+// under its old name, p purges one for good, and P empties the trash, both
+// after a y/n confirmation. `planc prune` purges plans trashed more than
+// --days ago.
+
+type trashState struct {
+	active  bool
+	items   []plans.TrashedPlan
+	cursor  int
+	confirm string // "p" or "P" while a purge awaits y
+}
+
+// trashLoadedMsg carries the trashed plans of every plan directory.
+type trashLoadedMsg struct {
+	items []plans.TrashedPlan
+}
+
+// trashPurgedMsg reports permanently deleted plans.
+type trashPurgedMsg struct {
+	n int
+}
+
+// loadTrash lists the trashed plans in dirs, most recently deleted first.
+func loadTrash(dirs []string) tea.Cmd {
+	return func() tea.Msg {
+		var items []plans.TrashedPlan
+		for _, dir := range dirs {
+			trashed, err := plans.Trashed(dir)
+			if err != nil {
+				return errMsg{fmt.Errorf("trash: %w", err)}
+			}
+			items = append(items, trashed...)
+		}
+		slices.SortStableFunc(items, func(a, b plans.TrashedPlan) int { return b.Deleted.Compare(a.Deleted) })
+		return trashLoadedMsg{items: items}
+	}
+}
+
+// restoreTrashed moves t back to where it was deleted from, rescans, and
+// selects it.
+func restoreTrashed(agentDir, projectGlob string, t plans.TrashedPlan) tea.Cmd {
+	return func() tea.Msg {
+		path, err := plans.Restore(t)
+		if err != nil {
+			return errMsg{fmt.Errorf("restore: %w", err)}
+		}
+		all, err := scanAllPlans(agentDir, projectGlob)
+		if err != nil {
+			return errMsg{err}
+		}
+		return planFileMsg{plans: all, path: path, message: "Restored: " + filepath.Base(path),
+			event: planEvent{kind: eventPlanRestored, path: path, from: t.Path, to: path}}
+	}
+}
+
+// purgeTrashed permanently deletes items, stopping at the first error.
+func purgeTrashed(items []plans.TrashedPlan) tea.Cmd {
+	return func() tea.Msg {
+		for i, t := range items {
+			if err := plans.Purge(t); err != nil {
+				logger.Error("purge failed", "path", t.Path, "err", err)
+				return errMsg{fmt.Errorf("purge: %w (%d of %d purged)", err, i, len(items))}
+			}
+		}
+		return trashPurgedMsg{n: len(items)}
+	}
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+func (m *model) openTrash() tea.Cmd {
+	return loadTrash(m.planDirs())
+}
+
+// showTrash opens the trash view on freshly loaded items.
+func (m *model) showTrash(msg trashLoadedMsg) tea.Cmd {
+	if len(msg.items) == 0 {
+		return m.setNotification(tr("Trash is empty"), statusTimeout)
+	}
+	m.trash = trashState{active: true, items: msg.items}
+	return nil
+}
+
+func (m model) handleTrashKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	t := &m.trash
+	if key.Matches(msg, m.keys.ForceQuit) {
+		return m, tea.Quit, true
+	}
+	if t.confirm != "" {
+		confirm := t.confirm
+		t.confirm = ""
+		if msg.String() != "y" {
+			return m, nil, true
+		}
+		doomed := t.items
+		if confirm == "p" {
+			doomed = t.items[t.cursor : t.cursor+1]
+		}
+		doomed = slices.Clone(doomed)
+		t.items = slices.DeleteFunc(t.items, func(it plans.TrashedPlan) bool { return slices.Contains(doomed, it) })
+		t.cursor = min(t.cursor, max(len(t.items)-1, 0))
+		t.active = len(t.items) > 0
+		return m, purgeTrashed(doomed), true
+	}
+	switch {
+	case key.Matches(msg, m.keys.Trash), key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		t.active = false
+	case msg.String() == "j" || msg.String() == "down":
+		if t.cursor < len(t.items)-1 {
+			t.cursor++
+		}
+	case msg.String() == "k" || msg.String() == "up":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case msg.Type == tea.KeyEnter || msg.String() == "r":
+		item := t.items[t.cursor]
+		t.items = slices.Delete(t.items, t.cursor, t.cursor+1)
+		t.cursor = min(t.cursor, max(len(t.items)-1, 0))
+		t.active = len(t.items) > 0
+		return m, restoreTrashed(m.dir, m.cfg.ProjectPlanGlob, item), true
+	case msg.String() == "p" || msg.String() == "P":
+		t.confirm = msg.String()
+	}
+	return m, nil, true
+}
+
+// ─── View ────────────────────────────────────────────────────────────────────
+
+func (m model) renderTrashModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+
+	modalW := min(m.width-4, 90)
+	contentW := max(modalW-8, 20) // helpBoxStyle borders + padding
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(tr("Trash")) + "\n")
+	b.WriteString(dimStyle.Render(trf("%d deleted plans", len(m.trash.items))) + "\n\n")
+
+	maxVisible := max(m.height-12, 3)
+	start := 0
+	if m.trash.cursor >= maxVisible {
+		start = m.trash.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.trash.items))
+	for i := start; i < end; i++ {
+		it := m.trash.items[i]
+		meta := " · " + it.Deleted.Format("2006-01-02 15:04")
+		if src := sourceName(it.Dir, m.dir); src != "" {
+			meta = " · " + src + meta
+		}
+		name := truncateForWidth(it.File, contentW-lipgloss.Width(meta)-2)
+		if i == m.trash.cursor {
+			b.WriteString(accentStyle.Render("> "+name) + dimStyle.Render(meta) + "\n")
+		} else {
+			b.WriteString("  " + name + dimStyle.Render(meta) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	switch m.trash.confirm {
+	case "p":
+		b.WriteString(accentStyle.Render(trf("Permanently delete %s? (y/n)", m.trash.items[m.trash.cursor].File)))
+	case "P":
+		b.WriteString(accentStyle.Render(trf("Permanently delete %d plans? (y/n)", len(m.trash.items))))
+	default:
+		b.WriteString(dimStyle.Render(tr("j/k navigate · enter restore · p purge · P empty trash · esc close")))
+	}
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
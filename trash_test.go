@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/jakebf/planc/plans"
+)
+
+func TestTrashDeleteAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	writeFile(t, path, "---\nstatus: active\n---\n# Auth\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(all, dir, newDefaultConfig(), nil)
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m2, _ = m2.Update(m.store.deletePlan(all[0])())
+	m = m2.(model)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("deleted plan still in place")
+	}
+	if trashed, _ := plans.Trashed(dir); len(trashed) != 1 || trashed[0].File != "auth.md" {
+		t.Fatalf("trash = %v", trashed)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	m2, _ = m.Update(cmd())
+	m = m2.(model)
+	if !m.trash.active {
+		t.Fatal("X should open the trash")
+	}
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "auth.md") {
+		t.Errorf("trash should list the plan:\n%s", out)
+	}
+	m2, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m2.(model).trash.active {
+		t.Error("restoring the last plan should close the trash")
+	}
+	if msg, ok := cmd().(planFileMsg); !ok || msg.path != path {
+		t.Fatalf("restore msg = %#v", msg)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("plan not restored: %v", err)
+	}
+}
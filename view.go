@@ -20,6 +20,7 @@ var (
 	colorGreen   = lipgloss.Color("10") // active status, welcome checkmark
 	colorYellow  = lipgloss.Color("11") // reviewed status, update notices
 	colorMagenta = lipgloss.Color("13") // selection highlight, status bar messages
+	colorRed     = lipgloss.Color("9")  // overdue plans, high priority
 )
 
 // ─── Styles ──────────────────────────────────────────────────────────────────
@@ -122,6 +123,11 @@ func (m *model) refreshReleaseNotesView() {
 	m.releaseNotes.viewport.GotoTop()
 }
 
+// planSizeInfo describes a plan's approximate token count and file size.
+func planSizeInfo(p plan) string {
+	return formatTokens(p.tokens) + " tokens · " + formatSize(p.size)
+}
+
 // renderFooter combines left-aligned help hints with a right-aligned notification.
 // If width is too narrow, the notification is truncated first.
 func renderFooter(help, notification string, width int) string {
@@ -194,6 +200,7 @@ func (m model) View() string {
 			Render(msg)
 		leftContent = lipgloss.Place(listW-2, innerH, lipgloss.Center, lipgloss.Center, hint)
 	} else {
+		m.restoreTitle() // keep tab counts current after plan changes
 		leftContent = m.list.View()
 	}
 	previewTitle := ""
@@ -215,7 +222,48 @@ func (m model) View() string {
 			previewTitle = paneTitleStyle.Render(item.file)
 		}
 	}
+	if item, ok := m.list.SelectedItem().(plan); ok && item.tokens > 0 && previewTitle != "" {
+		// Reading time, task progress, and token/size estimate,
+		// right-aligned; dropped when the title leaves no room.
+		infoText := planSizeInfo(item)
+		if progress := taskProgress(item); progress != "" {
+			infoText = progress + " tasks · " + infoText
+		}
+		if item.words > 0 {
+			infoText = readingTime(item.words) + " read · " + infoText
+		}
+		info := lipgloss.NewStyle().Foreground(colorDim).Render(infoText + " ")
+		gap := previewW - 2 - lipgloss.Width(previewTitle) - lipgloss.Width(info)
+		if gap >= 2 {
+			previewTitle += strings.Repeat(" ", gap) + info
+		}
+	}
 	rightContent := previewTitle + "\n" + m.viewport.View()
+	if !m.comment.active {
+		// The metadata block and the supersede and blocked banners take the
+		// viewport's first lines and the related footer its last.
+		vp := m.viewport
+		var header, banner, footer string
+		if item, ok := m.list.SelectedItem().(plan); ok && m.cfg.ShowMetadata {
+			if header = metadataHeader(item.fields, previewW-2); header != "" {
+				vp.Height -= strings.Count(header, "\n") + 1
+				header += "\n"
+			}
+		}
+		if banner = m.supersedeBanner(previewW - 2); banner != "" {
+			vp.Height--
+			banner += "\n"
+		}
+		if blocked := m.blockedBanner(previewW - 2); blocked != "" {
+			vp.Height--
+			banner += blocked + "\n"
+		}
+		if footer = m.relatedFooter(previewW - 2); footer != "" {
+			vp.Height--
+			footer = "\n" + footer
+		}
+		rightContent = previewTitle + "\n" + header + banner + vp.View() + footer
+	}
 
 	panes := lipgloss.JoinHorizontal(lipgloss.Top,
 		leftStyle.Render(leftContent),
@@ -223,7 +271,9 @@ func (m model) View() string {
 	)
 
 	var statusBar string
-	if m.comment.active {
+	if m.plansDir.err != nil && !m.demo.active && !m.comment.editing {
+		statusBar = " " + updateTextStyle.Render(truncateForWidth(m.plansDirBanner(), m.width-1))
+	} else if m.comment.active {
 		hintStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
 		dimStyle := lipgloss.NewStyle().Foreground(colorDim)
 		sep := dimStyle.Render(" | ")
@@ -234,18 +284,27 @@ func (m model) View() string {
 				hintStyle.Render("j/k") + dimStyle.Render(" scroll") + sep +
 				hintStyle.Render("tab") + dimStyle.Render(" ToC") + sep +
 				hintStyle.Render("n/p") + dimStyle.Render(" files") + sep +
-				hintStyle.Render("esc") + dimStyle.Render(" back")
+				hintStyle.Render("esc") + dimStyle.Render(tr(" back"))
 		} else {
 			statusBar = " " +
 				hintStyle.Render("enter") + dimStyle.Render(" comment") + sep
 			if len(m.comment.toc) > 0 && m.comment.cursor < len(m.comment.toc) && m.comment.toc[m.comment.cursor].isComment {
 				statusBar += hintStyle.Render("d") + dimStyle.Render(" delete comment") + sep
+				if m.comment.toc[m.comment.cursor].resolved {
+					statusBar += hintStyle.Render("r") + dimStyle.Render(" reopen") + sep
+				} else {
+					statusBar += hintStyle.Render("r") + dimStyle.Render(" resolve") + sep
+				}
 			}
 			statusBar +=
 				hintStyle.Render("s/l") + dimStyle.Render(" status/labels") + sep +
 				hintStyle.Render("n/p") + dimStyle.Render(" files") + sep +
-				hintStyle.Render("esc") + dimStyle.Render(" back")
+				hintStyle.Render("esc") + dimStyle.Render(tr(" back"))
 		}
+	} else if m.taskMode.active {
+		hintStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+		dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+		statusBar = m.taskStatusBar(hintStyle.Render, dimStyle.Render)
 	} else if len(m.selected) > 0 {
 		count := len(m.selected)
 		hintStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
@@ -255,21 +314,22 @@ func (m model) View() string {
 			hintStyle.Render("l") + dimStyle.Render(" labels") + dimStyle.Render(" | ") +
 			hintStyle.Render("C") + dimStyle.Render(" copy path") + dimStyle.Render(" | ") +
 			hintStyle.Render("a") + dimStyle.Render(" all") + dimStyle.Render(" | ") +
-			hintStyle.Render("esc") + dimStyle.Render(" clear")
+			hintStyle.Render("esc") + dimStyle.Render(tr(" clear"))
 	} else if m.updateAvailable != nil {
 		notice := fmt.Sprintf("Update %s available · go install github.com/jakebf/planc@latest", m.updateAvailable.version)
 		statusBar = " " + updateTextStyle.Render(truncateForWidth(notice, m.width-1))
 	} else {
 		statusBar = " " + m.help.ShortHelpView(m.keys.ShortHelp())
 	}
+	statusBar = m.macroIndicator() + statusBar
 	statusBar = renderFooter(statusBar, m.notification, m.width)
 	base := panes + "\n" + statusBar
 
 	if m.releaseNotes.on {
 		modalW, _, contentW, _ := m.releaseNotesDims()
-		header := helpTitleStyle.Render("What's New in " + m.releaseNotes.version)
+		header := helpTitleStyle.Render(trf("What's New in %s", m.releaseNotes.version))
 		footer := lipgloss.NewStyle().Foreground(colorDim).
-			Render("enter/esc dismiss  ·  j/k or space/B scroll")
+			Render(tr("enter/esc dismiss  ·  j/k or space/B scroll"))
 		body := lipgloss.NewStyle().MaxWidth(contentW).Render(
 			header + "\n" + m.releaseNotes.viewport.View() + "\n" + footer,
 		)
@@ -284,12 +344,101 @@ func (m model) View() string {
 		base = m.renderLabelModal()
 	}
 
+	if m.settingTitle {
+		base = m.renderTitleModal()
+	}
+
+	if m.renaming {
+		base = m.renderRenameModal()
+	}
+
+	if m.forking {
+		base = m.renderForkModal()
+	}
+
+	if m.settingField {
+		base = m.renderFieldModal()
+	}
+
+	if m.settingDue {
+		base = m.renderDueModal()
+	}
+
+	if m.creatingPlan {
+		base = m.renderNewPlanModal()
+	}
+
 	if m.settingStatus {
 		base = m.renderStatusModal(base)
 	}
 
+	if m.settingSort {
+		base = m.renderSortModal()
+	}
+
+	if m.settingPriority {
+		base = m.renderPriorityModal()
+	}
+
+	if m.showInfo {
+		base = m.renderInfoModal()
+	}
+
+	if m.todo.active {
+		base = m.renderTodoModal()
+	}
+
+	if m.related.active {
+		base = m.renderRelatedModal()
+	}
+
+	if m.supersede.active {
+		base = m.renderSupersedeModal()
+	}
+
+	if m.move.active {
+		base = m.renderMoveModal()
+	}
+
+	if m.dedupe.active {
+		base = m.renderDedupeModal()
+	}
+
+	if m.trash.active {
+		base = m.renderTrashModal()
+	}
+
+	if m.metadata.active {
+		base = m.renderMetadataModal()
+	}
+
+	if m.showHistory {
+		base = m.renderHistoryModal()
+	}
+
+	if m.activity.active {
+		base = m.renderActivityModal()
+	}
+
+	if m.notes.active {
+		base = m.renderNotesModal()
+	}
+
+	if m.reminders.active {
+		base = m.renderRemindersModal()
+	}
+
+	if m.agentOutput.active {
+		base = m.renderAgentOutputModal()
+	}
+
+	if m.macro.prompting {
+		base = m.renderMacroPrompt()
+	}
+
 	if m.help.ShowAll {
-		content := helpTitleStyle.Render("Keybindings") + "\n" + m.help.FullHelpView(m.keys.FullHelp())
+		content := helpTitleStyle.Render(tr("Keybindings")) + "\n" + m.help.FullHelpView(m.keys.FullHelp())
+		content += "\n\n" + renderStatusCounts(statusCounts(*m.planSource()))
 
 		// Keep the help modal comfortably narrow on wide terminals while still
 		// fitting on small screens.
@@ -318,6 +467,18 @@ func (m model) View() string {
 	return base
 }
 
+// renderStatusCounts renders per-status plan counts as one line of icons,
+// in status modal order.
+func renderStatusCounts(counts map[string]int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	var parts []string
+	for _, opt := range statusOptions() {
+		icon := styledStatusIcon(opt.status)
+		parts = append(parts, fmt.Sprintf("%s %d %s", icon, counts[opt.status], opt.label))
+	}
+	return dimStyle.Render("Plans: ") + strings.Join(parts, dimStyle.Render(" · "))
+}
+
 func (m model) renderStatusModal(_ string) string {
 	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
 	accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
@@ -331,22 +492,12 @@ func (m model) renderStatusModal(_ string) string {
 	}
 
 	var b strings.Builder
-	b.WriteString(helpTitleStyle.Render("Set Status") + "\n")
+	b.WriteString(helpTitleStyle.Render(tr("Set Status")) + "\n")
 	b.WriteString(dimStyle.Render(context) + "\n\n")
 
-	for i, opt := range statusOptions {
+	for i, opt := range statusOptions() {
 		isCursor := i == m.statusModalCursor
-		var icon string
-		switch opt.status {
-		case "active":
-			icon = activeStyle.Render(opt.icon)
-		case "reviewed":
-			icon = reviewedStyle.Render(opt.icon)
-		case "done":
-			icon = doneStyle.Render(opt.icon)
-		default:
-			icon = unsetStyle.Render(opt.icon)
-		}
+		icon := styledStatusIcon(opt.status)
 		cursor := "  "
 		if isCursor {
 			cursor = accentStyle.Render("> ")
@@ -358,7 +509,101 @@ func (m model) renderStatusModal(_ string) string {
 		}
 	}
 
-	b.WriteString("\n" + dimStyle.Render("j/k navigate · 0-3 select · esc cancel"))
+	b.WriteString("\n" + dimStyle.Render(trf("j/k navigate · %s select · esc cancel", m.keys.SetStatus.Help().Key)))
+
+	overlay := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(colorBlack),
+	)
+}
+
+func (m model) renderInfoModal() string {
+	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	okStyle := lipgloss.NewStyle().Bold(true).Foreground(colorGreen)
+	warnStyle := lipgloss.NewStyle().Bold(true).Foreground(colorYellow)
+
+	item, ok := m.list.SelectedItem().(plan)
+	if !ok {
+		return ""
+	}
+	status := statusLabel(item.status)
+	labels := dimStyle.Render("(none)")
+	if len(item.labels) > 0 {
+		var styled []string
+		for _, l := range item.labels {
+			styled = append(styled, labelColor(l).Render(l))
+		}
+		labels = strings.Join(styled, " ")
+	}
+
+	maxW := m.width - 16
+	if maxW > 72 {
+		maxW = 72
+	}
+	row := func(k, v string) string {
+		return dimStyle.Render(fmt.Sprintf("%-9s", k)) + " " + v + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render(truncateForWidth(item.title, maxW)) + "\n")
+	b.WriteString(row("File", truncateForWidth(contractHome(item.path()), maxW-10)))
+	b.WriteString(row("Status", statusIcon(item.status)+" "+status))
+	b.WriteString(row("Labels", labels))
+	b.WriteString(row("Created", item.created.Format("2006-01-02 15:04")))
+	if !item.modified.IsZero() {
+		b.WriteString(row("Modified", item.modified.Format("2006-01-02 15:04")))
+	}
+	if item.tokens > 0 {
+		b.WriteString(row("Size", planSizeInfo(item)))
+	}
+	if item.words > 0 {
+		b.WriteString(row("Length", formatWords(item.words)+" · "+readingTime(item.words)+" read"))
+	}
+
+	if len(m.cfg.LintSections) > 0 {
+		missing := make(map[string]bool)
+		for _, s := range lintPlan(item, m.cfg.LintSections) {
+			missing[s] = true
+		}
+		b.WriteString("\n" + dimStyle.Render("Checklist") + "\n")
+		for _, s := range m.cfg.LintSections {
+			if missing[s] {
+				b.WriteString("  " + warnStyle.Render("✗") + " " + s + "\n")
+			} else {
+				b.WriteString("  " + okStyle.Render("✓") + " " + s + "\n")
+			}
+		}
+	}
+
+	hint := "esc/i close"
+	if len(item.problems) > 0 {
+		b.WriteString("\n" + dimStyle.Render("Problems") + "\n")
+		for _, pr := range item.problems {
+			fix := "remove " + pr.key
+			if pr.fix != "" {
+				fix = pr.key + ": " + pr.fix
+			}
+			b.WriteString("  " + warnStyle.Render("⚠") + " " + truncateForWidth(pr.message, maxW-4) + "\n")
+			b.WriteString("    " + dimStyle.Render("→ "+truncateForWidth(fix, maxW-6)) + "\n")
+		}
+		hint = "r repair · " + hint
+	}
+	if refs := m.infoBacklinks(); len(refs) > 0 {
+		accentStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+		b.WriteString("\n" + dimStyle.Render("Referenced by") + "\n")
+		for i, r := range refs {
+			title := truncateForWidth(r.title, maxW-4)
+			if i == m.infoCursor {
+				b.WriteString(accentStyle.Render("> "+title) + "\n")
+			} else {
+				b.WriteString("  " + title + "\n")
+			}
+		}
+		hint = "j/k navigate · enter open · " + hint
+	}
+
+	b.WriteString("\n" + dimStyle.Render(hint))
 
 	overlay := helpBoxStyle.Render(b.String())
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
@@ -431,6 +676,9 @@ func (m model) renderLabelModal() string {
 			} else if toggled {
 				icon = "✓"
 				iconStyle = checkStyle
+			} else if m.labelSuggested[l] {
+				icon = "+"
+				iconStyle = mixedStyle
 			}
 
 			// Flash effect: alternate icon visibility
@@ -449,10 +697,14 @@ func (m model) renderLabelModal() string {
 				cursor = accentStyle.Render("> ")
 			}
 
+			suffix := ""
+			if m.labelSuggested[l] && !toggled {
+				suffix = dimStyle.Render(" suggested")
+			}
 			if isCursor || isFlashing {
-				b.WriteString(cursor + accentStyle.Render(icon) + " " + accentStyle.Render(l) + "\n")
+				b.WriteString(cursor + accentStyle.Render(icon) + " " + accentStyle.Render(l) + suffix + "\n")
 			} else {
-				b.WriteString(cursor + iconStyle.Render(icon) + " " + labelColor(l).Render(l) + "\n")
+				b.WriteString(cursor + iconStyle.Render(icon) + " " + labelColor(l).Render(l) + suffix + "\n")
 			}
 		}
 
@@ -474,6 +726,9 @@ func (m model) renderLabelModal() string {
 		b.WriteString("filter: " + m.labelInput.View() + "\n")
 	}
 	b.WriteString(dimStyle.Render("type to filter/add · enter toggle+close · space multi-select"))
+	if len(m.labelSuggested) > 0 {
+		b.WriteString("\n" + dimStyle.Render("tab accept suggestions"))
+	}
 
 	overlay := helpBoxStyle.Render(b.String())
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay,
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ─── planc watch ─────────────────────────────────────────────────────────────
+//
+// `planc watch` runs headless, watching the plans directories like the TUI
+// does, and prints a line for each plan that is created, modified, deleted,
+// or changes status, until it is interrupted. With --json each line is a JSON
+// object carrying the frontmatter before and after the change, for desktop
+// notifications and other automation:
+//
+//	{"time":"…","event":"status_changed","file":"/…/auth.md","title":"Auth",
+//	 "before":{"status":"reviewed"},"after":{"status":"done"}}
+//
+// Unlike the event bus in events.go, which reports changes made in planc,
+// these are inferred from the files, so they include an agent's writes.
+
+// Watch event names.
+const (
+	watchCreated       = "created"
+	watchModified      = "modified"
+	watchDeleted       = "deleted"
+	watchStatusChanged = "status_changed"
+)
+
+// watchEvent is one line of planc watch --json.
+type watchEvent struct {
+	Time   time.Time         `json:"time"`
+	Event  string            `json:"event"`
+	File   string            `json:"file"`
+	Title  string            `json:"title,omitempty"`
+	Before map[string]string `json:"before,omitempty"` // frontmatter; absent for created
+	After  map[string]string `json:"after,omitempty"`  // frontmatter; absent for deleted
+}
+
+// watchedPlan is what planc watch remembers about a plan file.
+type watchedPlan struct {
+	content string
+	fm      map[string]string
+	title   string
+}
+
+// readWatchedPlan reads the plan at path; ok is false if it doesn't exist.
+func readWatchedPlan(path string) (watchedPlan, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return watchedPlan{}, false
+	}
+	fm, body := parseFrontmatter(string(data))
+	title, _ := planTitle(fm, body, filepath.Base(path))
+	return watchedPlan{content: string(data), fm: fm, title: title}, true
+}
+
+// diffWatchedPlan returns the event for path going from before to after,
+// where a nil plan means the file doesn't exist, or false if nothing changed.
+func diffWatchedPlan(path string, before, after *watchedPlan, now time.Time) (watchEvent, bool) {
+	ev := watchEvent{Time: now, File: path}
+	switch {
+	case before == nil && after == nil:
+		return ev, false
+	case before == nil:
+		ev.Event = watchCreated
+	case after == nil:
+		ev.Event = watchDeleted
+	case before.content == after.content:
+		return ev, false
+	case before.fm["status"] != after.fm["status"]:
+		ev.Event = watchStatusChanged
+	default:
+		ev.Event = watchModified
+	}
+	if before != nil {
+		ev.Before, ev.Title = nonNilMap(before.fm), before.title
+	}
+	if after != nil {
+		ev.After, ev.Title = nonNilMap(after.fm), after.title
+	}
+	return ev, true
+}
+
+// nonNilMap returns m, or an empty map so a plan without frontmatter is
+// written as {} rather than left out.
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// planWatcher turns file system events into watchEvents.
+type planWatcher struct {
+	known map[string]watchedPlan // by path
+	now   func() time.Time
+}
+
+func newPlanWatcher(all []plan) *planWatcher {
+	w := &planWatcher{known: make(map[string]watchedPlan, len(all)), now: time.Now}
+	for _, p := range all {
+		if wp, ok := readWatchedPlan(p.path()); ok {
+			w.known[p.path()] = wp
+		}
+	}
+	return w
+}
+
+// update re-reads the changed files and returns their events, in path order.
+func (w *planWatcher) update(changed []string) []watchEvent {
+	var events []watchEvent
+	for _, path := range slices.Sorted(slices.Values(changed)) {
+		var before, after *watchedPlan
+		if wp, ok := w.known[path]; ok {
+			before = &wp
+		}
+		if wp, ok := readWatchedPlan(path); ok {
+			after = &wp
+			w.known[path] = wp
+		} else {
+			delete(w.known, path)
+		}
+		if ev, ok := diffWatchedPlan(path, before, after, w.now()); ok {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+// run reads watcher events until the watcher is closed, passing the changes
+// from each burst of writes to emit.
+func (w *planWatcher) run(watcher *fsnotify.Watcher, emit func(watchEvent) error) error {
+	for {
+		select {
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !isPlanFile(ev.Name) {
+				continue
+			}
+			changed := map[string]bool{ev.Name: true}
+			// Editors and agents write in bursts; report the result once.
+			time.Sleep(100 * time.Millisecond)
+		drain:
+			for {
+				select {
+				case extra, ok := <-watcher.Events:
+					if !ok {
+						break drain
+					}
+					if isPlanFile(extra.Name) {
+						changed[extra.Name] = true
+					}
+				default:
+					break drain
+				}
+			}
+			for _, e := range w.update(slices.Collect(maps.Keys(changed))) {
+				if err := emit(e); err != nil {
+					return err
+				}
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error("watch error", "err", err)
+		}
+	}
+}
+
+// writeWatchLine prints ev as a tab-separated line: time, event, file, and
+// for status changes the old and new status.
+func writeWatchLine(w io.Writer, ev watchEvent) error {
+	line := ev.Time.Format(time.RFC3339) + "\t" + ev.Event + "\t" + contractHome(ev.File)
+	if ev.Event == watchStatusChanged {
+		line += "\t" + exportStatus(ev.Before["status"]) + " → " + exportStatus(ev.After["status"])
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+func runWatch(cfg config, args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print newline-delimited JSON events with the frontmatter before and after")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: planc watch [--json]")
+		fmt.Fprintln(fs.Output(), "Prints plan changes (created, modified, deleted, status_changed) until interrupted.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	all, err := scanAllPlans(cfg.PlansDir, cfg.ProjectPlanGlob)
+	if err != nil {
+		return cliError("watch: %v", err)
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return cliError("watch: %v", err)
+	}
+	defer watcher.Close()
+	dirs := append([]string{cfg.PlansDir}, agentSubDirs(cfg.PlansDir)...)
+	for _, dir := range append(dirs, resolveProjectDirs(cfg.ProjectPlanGlob)...) {
+		if err := watcher.Add(dir); err != nil {
+			return cliError("watch: %v", err)
+		}
+	}
+
+	emit := func(ev watchEvent) error { return writeWatchLine(os.Stdout, ev) }
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		emit = func(ev watchEvent) error { return enc.Encode(ev) }
+	}
+	if err := newPlanWatcher(all).run(watcher, emit); err != nil {
+		return cliError("watch: %v", err)
+	}
+	return 0
+}
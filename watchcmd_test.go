@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestPlanWatcherUpdate(t *testing.T) {
+	dir := t.TempDir()
+	auth := filepath.Join(dir, "auth.md")
+	writeFile(t, auth, "---\nstatus: reviewed\n---\n# Auth\n")
+	all, err := scanAllPlans(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := newPlanWatcher(all)
+
+	writeFile(t, auth, "---\nstatus: reviewed\n---\n# Auth\n\nMore.\n")
+	writeFile(t, filepath.Join(dir, "new.md"), "# New\n")
+	events := w.update([]string{filepath.Join(dir, "new.md"), auth})
+	if len(events) != 2 || events[0].Event != watchModified || events[1].Event != watchCreated || events[1].Before != nil || events[1].Title != "New" {
+		t.Fatalf("events = %+v", events)
+	}
+
+	writeFile(t, auth, "---\nstatus: done\n---\n# Auth\n\nMore.\n")
+	events = w.update([]string{auth})
+	if len(events) != 1 || events[0].Event != watchStatusChanged || events[0].Before["status"] != "reviewed" || events[0].After["status"] != "done" {
+		t.Fatalf("status events = %+v", events)
+	}
+	if events := w.update([]string{auth}); len(events) != 0 {
+		t.Errorf("unchanged file reported: %+v", events)
+	}
+
+	os.Remove(auth)
+	events = w.update([]string{auth})
+	if len(events) != 1 || events[0].Event != watchDeleted || events[0].After != nil || events[0].Before["status"] != "done" {
+		t.Fatalf("delete events = %+v", events)
+	}
+
+	var b bytes.Buffer
+	writeWatchLine(&b, watchEvent{Time: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), Event: watchStatusChanged, File: "/p/auth.md",
+		Before: map[string]string{}, After: map[string]string{"status": "active"}})
+	if got := b.String(); got != "2026-03-01T09:00:00Z\tstatus_changed\t/p/auth.md\tnew → active\n" {
+		t.Errorf("line = %q", got)
+	}
+}
+
+func TestPlanWatcherRun(t *testing.T) {
+	dir := t.TempDir()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Skip("fsnotify unavailable:", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	events := make(chan watchEvent, 8)
+	go newPlanWatcher(nil).run(watcher, func(ev watchEvent) error {
+		events <- ev
+		return nil
+	})
+	writeFile(t, filepath.Join(dir, "plan.md"), "---\nstatus: new\n---\n# Plan\n")
+	select {
+	case ev := <-events:
+		data, _ := json.Marshal(ev)
+		if ev.Event != watchCreated || !strings.Contains(string(data), `"after":{"status":"new"}`) {
+			t.Errorf("event = %s", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for a new plan")
+	}
+}